@@ -0,0 +1,179 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package abi provides helpers to decode ABI-encoded event and call data, for handlers that
+// deliver typed records.
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/attestantio/go-execution-client/types"
+	"golang.org/x/crypto/sha3"
+)
+
+// WordLength is the length of an ABI-encoded word.
+const WordLength = 32
+
+// Keccak256 returns the Keccak-256 hash of the data.
+func Keccak256(data []byte) [32]byte {
+	var res [32]byte
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(data)
+	copy(res[:], hash.Sum(nil))
+
+	return res
+}
+
+// EventTopic returns the topic of the event with the given signature, for example
+// "Transfer(address,address,uint256)".
+func EventTopic(signature string) types.Hash {
+	return types.Hash(Keccak256([]byte(signature)))
+}
+
+// Selector returns the selector of the function with the given signature, for example
+// "transfer(address,uint256)".
+func Selector(signature string) [4]byte {
+	hash := Keccak256([]byte(signature))
+
+	return [4]byte(hash[:4])
+}
+
+// Word returns the word at the given index of the data.
+func Word(data []byte, index int) ([]byte, error) {
+	if index < 0 || len(data) < (index+1)*WordLength {
+		return nil, fmt.Errorf("data does not contain word %d", index)
+	}
+
+	return data[index*WordLength : (index+1)*WordLength], nil
+}
+
+// Uint returns the word at the given index of the data as an unsigned integer.
+func Uint(data []byte, index int) (*big.Int, error) {
+	word, err := Word(data, index)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(word), nil
+}
+
+// Int returns the word at the given index of the data as a two's complement signed integer.
+func Int(data []byte, index int) (*big.Int, error) {
+	word, err := Word(data, index)
+	if err != nil {
+		return nil, err
+	}
+
+	return signed(word), nil
+}
+
+// Bool returns the word at the given index of the data as a boolean.
+func Bool(data []byte, index int) (bool, error) {
+	word, err := Word(data, index)
+	if err != nil {
+		return false, err
+	}
+
+	return new(big.Int).SetBytes(word).Sign() != 0, nil
+}
+
+// Address returns the word at the given index of the data as an address.
+func Address(data []byte, index int) (types.Address, error) {
+	word, err := Word(data, index)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	return types.Address(word[WordLength-types.AddressLength:]), nil
+}
+
+// Bytes returns the dynamic bytes whose offset is held in the word at the given index of the data.
+func Bytes(data []byte, index int) ([]byte, error) {
+	offset, err := Uint(data, index)
+	if err != nil {
+		return nil, err
+	}
+	if !offset.IsInt64() || offset.Int64() > int64(len(data)) {
+		return nil, errors.New("bytes offset out of range")
+	}
+	start := int(offset.Int64())
+	if len(data) < start+WordLength {
+		return nil, errors.New("bytes length beyond data")
+	}
+	length := new(big.Int).SetBytes(data[start : start+WordLength])
+	if !length.IsInt64() || length.Int64() > int64(len(data)-start-WordLength) {
+		return nil, errors.New("bytes extend beyond data")
+	}
+	start += WordLength
+
+	return append([]byte{}, data[start:start+int(length.Int64())]...), nil
+}
+
+// String returns the dynamic string whose offset is held in the word at the given index of the data.
+func String(data []byte, index int) (string, error) {
+	value, err := Bytes(data, index)
+	if err != nil {
+		return "", err
+	}
+
+	return string(value), nil
+}
+
+// Array returns the number of elements and the encoding of the elements of the dynamic array
+// whose offset is held in the word at the given index of the data.  The elements can be decoded
+// with the other helpers, using the element index as the word index.
+func Array(data []byte, index int) (int, []byte, error) {
+	offset, err := Uint(data, index)
+	if err != nil {
+		return 0, nil, err
+	}
+	if !offset.IsInt64() || offset.Int64() > int64(len(data)-WordLength) {
+		return 0, nil, errors.New("array offset out of range")
+	}
+	start := int(offset.Int64())
+	length := new(big.Int).SetBytes(data[start : start+WordLength])
+	elements := data[start+WordLength:]
+	if !length.IsInt64() || length.Int64() > int64(len(elements)/WordLength) {
+		return 0, nil, errors.New("array extends beyond data")
+	}
+
+	return int(length.Int64()), elements, nil
+}
+
+// TopicAddress returns the address held in an event topic.
+func TopicAddress(topic types.Hash) types.Address {
+	return types.Address(topic[WordLength-types.AddressLength:])
+}
+
+// TopicUint returns the unsigned integer held in an event topic.
+func TopicUint(topic types.Hash) *big.Int {
+	return new(big.Int).SetBytes(topic[:])
+}
+
+// TopicInt returns the two's complement signed integer held in an event topic.
+func TopicInt(topic types.Hash) *big.Int {
+	return signed(topic[:])
+}
+
+// signed returns the two's complement signed integer held in a word.
+func signed(word []byte) *big.Int {
+	value := new(big.Int).SetBytes(word)
+	if len(word) > 0 && word[0]&0x80 != 0 {
+		value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(len(word)*8)))
+	}
+
+	return value
+}
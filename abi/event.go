@@ -0,0 +1,378 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package abi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// Event is an event defined in an ABI.
+type Event struct {
+	// Name is the name of the event, for example "Transfer".
+	Name string
+	// Signature is the canonical signature of the event, for example "Transfer(address,address,uint256)".
+	Signature string
+	// Topic is the topic of the event, being the hash of its signature.
+	Topic types.Hash
+	// Inputs are the arguments of the event.
+	Inputs []*Argument
+	// Anonymous is true if the event does not emit its topic.
+	Anonymous bool
+}
+
+// Argument is an argument of an event.
+type Argument struct {
+	// Name is the name of the argument.  Unnamed arguments are named by position, for example "arg2".
+	Name string
+	// Type is the canonical type of the argument, for example "uint256".
+	Type string
+	// Indexed is true if the argument is held in a topic rather than in the data of the event.
+	Indexed bool
+
+	argType *argType
+}
+
+// abiEntryJSON is the JSON representation of an entry in an ABI.
+type abiEntryJSON struct {
+	Type      string          `json:"type"`
+	Name      string          `json:"name"`
+	Anonymous bool            `json:"anonymous"`
+	Inputs    []*abiInputJSON `json:"inputs"`
+}
+
+// abiInputJSON is the JSON representation of an input of an entry in an ABI.
+type abiInputJSON struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed"`
+}
+
+// ParseEvents parses the events from a JSON ABI.
+// Events with arguments of types that cannot be decoded, such as tuples, are omitted.
+func ParseEvents(data []byte) ([]*Event, error) {
+	var entries []*abiEntryJSON
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, errors.Join(errors.New("invalid ABI"), err)
+	}
+
+	events := make([]*Event, 0)
+	for _, entry := range entries {
+		if entry.Type != "event" {
+			continue
+		}
+		if entry.Name == "" {
+			return nil, errors.New("event without name in ABI")
+		}
+		event, err := newEvent(entry)
+		if err != nil {
+			// The event cannot be decoded.
+			continue
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// newEvent creates an event from its ABI entry.
+func newEvent(entry *abiEntryJSON) (*Event, error) {
+	event := &Event{
+		Name:      entry.Name,
+		Inputs:    make([]*Argument, len(entry.Inputs)),
+		Anonymous: entry.Anonymous,
+	}
+	argTypes := make([]string, len(entry.Inputs))
+	for i, input := range entry.Inputs {
+		argType, err := parseType(input.Type)
+		if err != nil {
+			return nil, err
+		}
+		name := input.Name
+		if name == "" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		event.Inputs[i] = &Argument{
+			Name:    name,
+			Type:    argType.String(),
+			Indexed: input.Indexed,
+			argType: argType,
+		}
+		argTypes[i] = argType.String()
+	}
+	event.Signature = fmt.Sprintf("%s(%s)", entry.Name, strings.Join(argTypes, ","))
+	event.Topic = EventTopic(event.Signature)
+
+	return event, nil
+}
+
+// indexedInputs returns the number of indexed inputs of the event.
+func (e *Event) indexedInputs() int {
+	indexed := 0
+	for _, input := range e.Inputs {
+		if input.Indexed {
+			indexed++
+		}
+	}
+
+	return indexed
+}
+
+// Decode decodes the topics and data of an emitted event into its arguments by name.
+// Values are types.Address for addresses, bool for booleans, *big.Int for integers, []byte for
+// fixed and dynamic bytes, string for strings and []any for arrays.  Indexed arguments of dynamic
+// types are only available as the hash of their value, so are types.Hash.
+func (e *Event) Decode(topics []types.Hash, data []byte) (map[string]any, error) {
+	if !e.Anonymous {
+		if len(topics) == 0 || topics[0] != e.Topic {
+			return nil, errors.New("event topic does not match")
+		}
+		topics = topics[1:]
+	}
+	if len(topics) != e.indexedInputs() {
+		return nil, fmt.Errorf("event has %d indexed topics but %d expected", len(topics), e.indexedInputs())
+	}
+
+	args := make(map[string]any, len(e.Inputs))
+	topic := 0
+	word := 0
+	for _, input := range e.Inputs {
+		if input.Indexed {
+			args[input.Name] = input.argType.decodeTopic(topics[topic])
+			topic++
+
+			continue
+		}
+		value, err := input.argType.decode(data, word)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to decode argument %s", input.Name), err)
+		}
+		args[input.Name] = value
+		word += input.argType.headWords()
+	}
+
+	return args, nil
+}
+
+// kind is the kind of an ABI type.
+type kind int
+
+const (
+	kindAddress kind = iota
+	kindBool
+	kindUint
+	kindInt
+	kindFixedBytes
+	kindBytes
+	kindString
+	kindArray
+)
+
+// argType is an ABI type.
+type argType struct {
+	kind kind
+	// size is the size in bits of integers, or in bytes of fixed bytes.
+	size int
+	// elem is the element type of arrays.
+	elem *argType
+	// length is the length of fixed arrays, or -1 for dynamic arrays.
+	length int
+}
+
+// parseType parses an ABI type.  Arrays are limited to single-dimension arrays of elementary types.
+func parseType(name string) (*argType, error) {
+	if strings.HasSuffix(name, "]") {
+		open := strings.LastIndex(name, "[")
+		if open == -1 {
+			return nil, fmt.Errorf("invalid type %q", name)
+		}
+		elem, err := parseType(name[:open])
+		if err != nil {
+			return nil, err
+		}
+		if elem.kind == kindArray || elem.dynamic() {
+			return nil, fmt.Errorf("unsupported type %q", name)
+		}
+		length := -1
+		if lengthStr := name[open+1 : len(name)-1]; lengthStr != "" {
+			length, err = strconv.Atoi(lengthStr)
+			if err != nil || length <= 0 {
+				return nil, fmt.Errorf("invalid array length in type %q", name)
+			}
+		}
+
+		return &argType{kind: kindArray, elem: elem, length: length}, nil
+	}
+
+	switch {
+	case name == "address":
+		return &argType{kind: kindAddress}, nil
+	case name == "bool":
+		return &argType{kind: kindBool}, nil
+	case name == "string":
+		return &argType{kind: kindString}, nil
+	case name == "bytes":
+		return &argType{kind: kindBytes}, nil
+	case strings.HasPrefix(name, "uint"):
+		size, err := typeSize(name, "uint", 256, 8, 256)
+		if err != nil {
+			return nil, err
+		}
+
+		return &argType{kind: kindUint, size: size}, nil
+	case strings.HasPrefix(name, "int"):
+		size, err := typeSize(name, "int", 256, 8, 256)
+		if err != nil {
+			return nil, err
+		}
+
+		return &argType{kind: kindInt, size: size}, nil
+	case strings.HasPrefix(name, "bytes"):
+		size, err := typeSize(name, "bytes", 0, 1, 32)
+		if err != nil || size == 0 {
+			return nil, fmt.Errorf("invalid type %q", name)
+		}
+
+		return &argType{kind: kindFixedBytes, size: size}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %q", name)
+	}
+}
+
+// typeSize returns the size that follows the prefix of a type name, or the default if there is none.
+func typeSize(name string, prefix string, defaultSize int, multiple int, maxSize int) (int, error) {
+	sizeStr := strings.TrimPrefix(name, prefix)
+	if sizeStr == "" {
+		return defaultSize, nil
+	}
+	size, err := strconv.Atoi(sizeStr)
+	if err != nil || size <= 0 || size > maxSize || size%multiple != 0 {
+		return 0, fmt.Errorf("invalid type %q", name)
+	}
+
+	return size, nil
+}
+
+// String returns the canonical name of the type.
+func (t *argType) String() string {
+	switch t.kind {
+	case kindAddress:
+		return "address"
+	case kindBool:
+		return "bool"
+	case kindUint:
+		return fmt.Sprintf("uint%d", t.size)
+	case kindInt:
+		return fmt.Sprintf("int%d", t.size)
+	case kindFixedBytes:
+		return fmt.Sprintf("bytes%d", t.size)
+	case kindBytes:
+		return "bytes"
+	case kindString:
+		return "string"
+	case kindArray:
+		if t.length == -1 {
+			return t.elem.String() + "[]"
+		}
+
+		return fmt.Sprintf("%s[%d]", t.elem.String(), t.length)
+	default:
+		return "unknown"
+	}
+}
+
+// dynamic returns true if the type is encoded out of line.
+func (t *argType) dynamic() bool {
+	return t.kind == kindBytes || t.kind == kindString || (t.kind == kindArray && t.length == -1)
+}
+
+// headWords returns the number of words that the type occupies in the head of the encoding.
+func (t *argType) headWords() int {
+	if t.kind == kindArray && t.length != -1 {
+		return t.length
+	}
+
+	return 1
+}
+
+// decode decodes a value of the type held at the given word index of the data.
+func (t *argType) decode(data []byte, index int) (any, error) {
+	switch t.kind {
+	case kindBytes:
+		return Bytes(data, index)
+	case kindString:
+		return String(data, index)
+	case kindArray:
+		length := t.length
+		elements := data[min(len(data), index*WordLength):]
+		if length == -1 {
+			var err error
+			length, elements, err = Array(data, index)
+			if err != nil {
+				return nil, err
+			}
+		}
+		values := make([]any, length)
+		for i := range values {
+			word, err := Word(elements, i)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = t.elem.decodeWord(word)
+		}
+
+		return values, nil
+	default:
+		word, err := Word(data, index)
+		if err != nil {
+			return nil, err
+		}
+
+		return t.decodeWord(word), nil
+	}
+}
+
+// decodeTopic decodes a value of the type held in a topic.
+func (t *argType) decodeTopic(topic types.Hash) any {
+	if t.dynamic() || t.kind == kindArray {
+		// The topic holds the hash of the value.
+		return topic
+	}
+
+	return t.decodeWord(topic[:])
+}
+
+// decodeWord decodes a value of an elementary static type held in a word.
+func (t *argType) decodeWord(word []byte) any {
+	switch t.kind {
+	case kindAddress:
+		return types.Address(word[WordLength-types.AddressLength:])
+	case kindBool:
+		return word[WordLength-1] != 0
+	case kindUint:
+		return new(big.Int).SetBytes(word)
+	case kindInt:
+		return signed(word)
+	case kindFixedBytes:
+		return append([]byte{}, word[:t.size]...)
+	default:
+		return nil
+	}
+}
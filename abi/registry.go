@@ -0,0 +1,137 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package abi
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// Registry holds events from ABIs, to decode emitted events by their topic.
+// It is safe for concurrent use.
+type Registry struct {
+	mu     sync.RWMutex
+	events map[types.Hash][]*Event
+}
+
+// NewRegistry creates a registry holding the events from the given JSON ABIs.
+func NewRegistry(abis ...[]byte) (*Registry, error) {
+	r := &Registry{
+		events: make(map[types.Hash][]*Event),
+	}
+	for _, abi := range abis {
+		if err := r.Add(abi); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Add adds the events from a JSON ABI to the registry.
+// Anonymous events are not added, as they cannot be identified by their topic.
+func (r *Registry) Add(abi []byte) error {
+	events, err := ParseEvents(abi)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, event := range events {
+		if event.Anonymous || r.contains(event) {
+			continue
+		}
+		r.events[event.Topic] = append(r.events[event.Topic], event)
+	}
+
+	return nil
+}
+
+// contains returns true if the registry already holds an event with the same signature and indexed arguments.
+// This assumes that the lock is held.
+func (r *Registry) contains(event *Event) bool {
+	for _, existing := range r.events[event.Topic] {
+		same := true
+		for i := range existing.Inputs {
+			if existing.Inputs[i].Indexed != event.Inputs[i].Indexed {
+				same = false
+
+				break
+			}
+		}
+		if same {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Decode decodes an emitted event, returning its definition and arguments.
+// Events with the same signature but different indexed arguments, such as the ERC-20 and ERC-721
+// Transfer events, are distinguished by their number of topics.
+func (r *Registry) Decode(topics []types.Hash, data []byte) (*Event, map[string]any, error) {
+	if len(topics) == 0 {
+		return nil, nil, errors.New("event has no topics")
+	}
+
+	r.mu.RLock()
+	events := r.events[topics[0]]
+	r.mu.RUnlock()
+	if len(events) == 0 {
+		return nil, nil, errors.New("event not in registry")
+	}
+
+	var errs error
+	for _, event := range events {
+		if event.indexedInputs() != len(topics)-1 {
+			continue
+		}
+		args, err := event.Decode(topics, data)
+		if err == nil {
+			return event, args, nil
+		}
+		errs = errors.Join(errs, err)
+	}
+	if errs == nil {
+		return nil, nil, errors.New("event topics do not match any registered event")
+	}
+
+	return nil, nil, errs
+}
+
+// Merge returns a registry holding the events of this registry and those of the given JSON ABIs.
+func (r *Registry) Merge(abis ...[]byte) (*Registry, error) {
+	merged, err := NewRegistry(abis...)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	merged.mu.Lock()
+	defer merged.mu.Unlock()
+	for _, events := range r.events {
+		for _, event := range events {
+			if !merged.contains(event) {
+				merged.events[event.Topic] = append(merged.events[event.Topic], event)
+			}
+		}
+	}
+
+	return merged, nil
+}
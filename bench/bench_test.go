@@ -0,0 +1,300 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	"github.com/wealdtech/go-eth-listener/handlers"
+	"github.com/wealdtech/go-eth-listener/services/listener/ethclient"
+	"github.com/wealdtech/go-eth-listener/services/listener/ethclient/ethclienttest"
+)
+
+// source is the address that emits events on the simulated chain.
+var source = types.Address{0x01}
+
+func TestMain(m *testing.M) {
+	// Logging would distort the results.
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+// BenchmarkBlocks benchmarks polling blocks with varying numbers of block triggers.
+func BenchmarkBlocks(b *testing.B) {
+	for _, triggers := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("triggers=%d", triggers), blocksBenchmark(500, triggers))
+	}
+}
+
+// BenchmarkEvents benchmarks polling events with varying numbers of event triggers.
+func BenchmarkEvents(b *testing.B) {
+	for _, triggers := range []int{1, 4} {
+		b.Run(fmt.Sprintf("triggers=%d", triggers), eventsBenchmark(100, 10, triggers, time.Millisecond))
+	}
+}
+
+// BenchmarkMatching benchmarks matching events with varying numbers of event triggers.
+func BenchmarkMatching(b *testing.B) {
+	for _, triggers := range []int{8, 32} {
+		b.Run(fmt.Sprintf("triggers=%d", triggers), matchingBenchmark(100, 50, triggers))
+	}
+}
+
+// BenchmarkMetadata benchmarks committing metadata with different strategies.
+func BenchmarkMetadata(b *testing.B) {
+	b.Run("sequential", metadataBenchmark(250, 8))
+	b.Run("isolated", metadataBenchmark(250, 8, ethclient.WithTriggerIsolation(true)))
+	b.Run("encrypted", metadataBenchmark(250, 8, ethclient.WithMetadataCipher(benchCipher())))
+}
+
+// blocksBenchmark benchmarks polling the given number of blocks with the given number of block triggers.
+func blocksBenchmark(blocks uint32, triggers int) func(b *testing.B) {
+	return func(b *testing.B) {
+		provider := newProvider(ethclienttest.NewChain(blocks-1), 0)
+		handler := &handler{}
+		elapsed := pollBenchmark(b, provider, ethclient.WithBlockTriggers(blockTriggers(triggers, handler)))
+		// Each block is handled once by each trigger.
+		b.ReportMetric(float64(handler.blocks.Load())/float64(triggers)/elapsed.Seconds(), "blocks/s")
+	}
+}
+
+// eventsBenchmark benchmarks polling the given number of blocks, each with the given number of
+// events, for the given number of event triggers with the given latency for each request.
+// The listener fetches a limited number of blocks of events in a poll, so the number of blocks
+// should not exceed that.
+func eventsBenchmark(blocks uint32, eventsPerBlock int, triggers int, latency time.Duration) func(b *testing.B) {
+	return func(b *testing.B) {
+		chain := ethclienttest.NewChain(blocks - 1)
+		for height := range blocks {
+			for range eventsPerBlock {
+				if _, err := chain.AddEvent(height, source, nil, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+		provider := newProvider(chain, latency)
+		handler := &handler{}
+		eventTriggers := make([]*handlers.EventTrigger, triggers)
+		for i := range eventTriggers {
+			eventTriggers[i] = &handlers.EventTrigger{
+				Name:    fmt.Sprintf("events-%d", i),
+				Source:  &source,
+				Handler: handler,
+			}
+		}
+		elapsed := pollBenchmark(b, provider, ethclient.WithEventTriggers(eventTriggers))
+		b.ReportMetric(float64(handler.events.Load())/elapsed.Seconds(), "events/s")
+		b.ReportMetric(float64(provider.eventsRequests.Load())/float64(b.N), "getlogs/op")
+	}
+}
+
+// matchingBenchmark benchmarks matching events against the given number of event triggers that
+// select events by topic sets, which cannot be expressed in the events filter, so that each
+// trigger receives and checks every event in the given number of blocks.
+func matchingBenchmark(blocks uint32, eventsPerBlock int, triggers int) func(b *testing.B) {
+	return func(b *testing.B) {
+		chain := ethclienttest.NewChain(blocks - 1)
+		for height := range blocks {
+			for i := range eventsPerBlock {
+				address := types.Address{byte(i % triggers)}
+				topics := []types.Hash{{byte(i % 4)}, {byte(i)}}
+				if _, err := chain.AddEvent(height, address, topics, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+		provider := newProvider(chain, 0)
+		handler := &handler{}
+		eventTriggers := make([]*handlers.EventTrigger, triggers)
+		for i := range eventTriggers {
+			eventTriggers[i] = &handlers.EventTrigger{
+				Name:      fmt.Sprintf("matching-%d", i),
+				TopicSets: []handlers.TopicSet{newSet(types.Hash{0}), newSet(types.Hash{byte(i)})},
+				Handler:   handler,
+			}
+		}
+		elapsed := pollBenchmark(b, provider, ethclient.WithEventTriggers(eventTriggers))
+		b.ReportMetric(float64(int(blocks)*eventsPerBlock*triggers*b.N)/elapsed.Seconds(), "checks/s")
+	}
+}
+
+// metadataBenchmark benchmarks the commit of metadata when polling the given number of blocks
+// with the given number of block triggers.
+func metadataBenchmark(blocks uint32, triggers int, params ...ethclient.Parameter) func(b *testing.B) {
+	return func(b *testing.B) {
+		provider := newProvider(ethclienttest.NewChain(blocks-1), 0)
+		handler := &handler{}
+		params := append([]ethclient.Parameter{ethclient.WithBlockTriggers(blockTriggers(triggers, handler))}, params...)
+		elapsed := pollBenchmark(b, provider, params...)
+		// Each block is handled once by each trigger.
+		b.ReportMetric(float64(handler.blocks.Load())/float64(triggers)/elapsed.Seconds(), "blocks/s")
+	}
+}
+
+// pollBenchmark times a single poll of a new listener for each iteration, returning the total time.
+// Creation of the listener is not timed.
+func pollBenchmark(b *testing.B, provider *provider, params ...ethclient.Parameter) time.Duration {
+	b.Helper()
+	ctx := context.Background()
+
+	var elapsed time.Duration
+	b.StopTimer()
+	for range b.N {
+		listener, stop, err := newListener(ctx, provider, params...)
+		if err != nil {
+			b.Fatal(err)
+		}
+		started := time.Now()
+		b.StartTimer()
+		err = listener.PollOnce(ctx)
+		b.StopTimer()
+		elapsed += time.Since(started)
+		stop()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	return elapsed
+}
+
+// newListener creates a listener over the provider with a temporary metadata database, returning
+// a function that stops the listener and removes its database.
+func newListener(ctx context.Context, provider *provider, params ...ethclient.Parameter) (*ethclient.Service, func(), error) {
+	dir, err := os.MkdirTemp("", "eth-listener-bench")
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("failed to create metadata database directory"), err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	listener, err := ethclient.New(ctx, append([]ethclient.Parameter{
+		ethclient.WithLogLevel(zerolog.Disabled),
+		ethclient.WithProvider(provider),
+		ethclient.WithMetadataDBPath(dir),
+		ethclient.WithTimeout(time.Minute),
+		ethclient.WithInterval(0),
+	}, params...)...)
+	if err != nil {
+		cancel()
+		_ = os.RemoveAll(dir)
+
+		return nil, nil, err
+	}
+
+	return listener, func() {
+		_ = listener.Close()
+		cancel()
+		_ = os.RemoveAll(dir)
+	}, nil
+}
+
+// blockTriggers returns the given number of block triggers using the handler.
+func blockTriggers(count int, handler *handler) []*handlers.BlockTrigger {
+	triggers := make([]*handlers.BlockTrigger, count)
+	for i := range triggers {
+		triggers[i] = &handlers.BlockTrigger{
+			Name:    fmt.Sprintf("blocks-%d", i),
+			Handler: handler,
+		}
+	}
+
+	return triggers
+}
+
+// benchCipher returns a cipher with a fixed key, for benchmarking encrypted metadata.
+func benchCipher() ethclient.ValueCipher {
+	cipher, err := ethclient.NewAESGCMCipher(make([]byte, 32))
+	if err != nil {
+		panic(err)
+	}
+
+	return cipher
+}
+
+// set is a set of addresses and topics.
+type set struct {
+	addresses map[types.Address]struct{}
+	topics    map[types.Hash]struct{}
+}
+
+// newSet creates a set with the given addresses and topics.
+func newSet(members ...any) *set {
+	s := &set{
+		addresses: make(map[types.Address]struct{}),
+		topics:    make(map[types.Hash]struct{}),
+	}
+	for _, member := range members {
+		switch member := member.(type) {
+		case types.Address:
+			s.addresses[member] = struct{}{}
+		case types.Hash:
+			s.topics[member] = struct{}{}
+		}
+	}
+
+	return s
+}
+
+// Contains returns true if the address is a member of the set.
+func (s *set) Contains(address types.Address) bool {
+	_, exists := s.addresses[address]
+
+	return exists
+}
+
+// Addresses returns the addresses that are members of the set.
+func (s *set) Addresses() []types.Address {
+	res := make([]types.Address, 0, len(s.addresses))
+	for address := range s.addresses {
+		res = append(res, address)
+	}
+
+	return res
+}
+
+// ContainsTopic returns true if the topic is a member of the set.
+func (s *set) ContainsTopic(topic types.Hash) bool {
+	_, exists := s.topics[topic]
+
+	return exists
+}
+
+// handler is a handler that only counts items, so that benchmarks measure the listener alone.
+type handler struct {
+	blocks atomic.Int64
+	events atomic.Int64
+}
+
+// HandleBlock handles a block.
+func (h *handler) HandleBlock(_ context.Context, _ *spec.Block, _ *handlers.BlockTrigger) error {
+	h.blocks.Add(1)
+
+	return nil
+}
+
+// HandleEvent handles an event.
+func (h *handler) HandleEvent(_ context.Context, _ *spec.BerlinTransactionEvent, _ *handlers.EventTrigger) error {
+	h.events.Add(1)
+
+	return nil
+}
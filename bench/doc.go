@@ -0,0 +1,18 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bench provides reproducible benchmarks of the listener's poll pipeline, run over a
+// simulated chain so that results are not affected by Ethereum clients.  The benchmarks are run
+// with "go test -bench . ./bench", and their output can be passed to eth-listener-bench, which
+// compares results against a baseline to catch regressions.
+package bench
@@ -0,0 +1,74 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/attestantio/go-execution-client/api"
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/wealdtech/go-eth-listener/services/listener/ethclient/ethclienttest"
+)
+
+// provider provides data from a simulated chain with a fixed latency per request, counting
+// requests made.
+type provider struct {
+	chain          *ethclienttest.Chain
+	latency        time.Duration
+	blockRequests  atomic.Int64
+	eventsRequests atomic.Int64
+}
+
+func newProvider(chain *ethclienttest.Chain, latency time.Duration) *provider {
+	return &provider{
+		chain:   chain,
+		latency: latency,
+	}
+}
+
+// ChainID returns the chain ID.
+func (p *provider) ChainID(ctx context.Context) (uint64, error) {
+	return p.chain.ChainID(ctx)
+}
+
+// ChainHeight returns the height of the chain.
+func (p *provider) ChainHeight(ctx context.Context) (uint32, error) {
+	p.wait()
+
+	return p.chain.ChainHeight(ctx)
+}
+
+// Block returns the block with the given ID.
+func (p *provider) Block(ctx context.Context, blockID string) (*spec.Block, error) {
+	p.blockRequests.Add(1)
+	p.wait()
+
+	return p.chain.Block(ctx, blockID)
+}
+
+// Events returns the events matching the filter.
+func (p *provider) Events(ctx context.Context, filter *api.EventsFilter) ([]*spec.BerlinTransactionEvent, error) {
+	p.eventsRequests.Add(1)
+	p.wait()
+
+	return p.chain.Events(ctx, filter)
+}
+
+func (p *provider) wait() {
+	if p.latency > 0 {
+		time.Sleep(p.latency)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main provides eth-listener-bench, a tool to compare the results of the listener's poll
+// pipeline benchmarks against a baseline, failing if performance has regressed.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const usage = `Usage: eth-listener-bench [flags] [file]
+
+Compares the results of benchmarks of the listener's poll pipeline, as output by
+"go test -bench . ./bench", against a baseline.  Results are read from the file if
+supplied, otherwise from standard input.
+
+Flags:
+`
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("eth-listener-bench", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flags.PrintDefaults()
+	}
+	filter := flags.String("run", ".", "regular expression selecting the benchmarks to compare")
+	baseline := flags.String("baseline", "", "file of results against which to compare")
+	threshold := flags.Float64("threshold", 10, "percentage increase in time per operation over the baseline treated as a regression")
+	save := flags.String("save", "", "file to which to save results, for use as a baseline")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+
+		return errors.New("too many arguments")
+	}
+
+	matcher, err := regexp.Compile(*filter)
+	if err != nil {
+		return errors.Join(errors.New("invalid run expression"), err)
+	}
+
+	input := io.Reader(os.Stdin)
+	if flags.NArg() == 1 {
+		file, err := os.Open(flags.Arg(0))
+		if err != nil {
+			return errors.Join(errors.New("failed to open results"), err)
+		}
+		defer file.Close()
+		input = file
+	}
+	benchmarks, err := parseResults(input)
+	if err != nil {
+		return err
+	}
+	if len(benchmarks) == 0 {
+		return errors.New("no benchmark results found")
+	}
+
+	var baselineResults map[string]float64
+	if *baseline != "" {
+		baselineResults, err = loadResults(*baseline)
+		if err != nil {
+			return err
+		}
+	}
+
+	names := make([]string, 0, len(benchmarks))
+	for name := range benchmarks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	results := make(map[string]float64)
+	regressions := make([]string, 0)
+	for _, name := range names {
+		if !matcher.MatchString(name) {
+			continue
+		}
+		nsPerOp := benchmarks[name]
+		results[name] = nsPerOp
+		fmt.Printf("%-24s\t%.0f ns/op\n", name, nsPerOp)
+
+		if previous, exists := baselineResults[name]; exists && previous > 0 {
+			change := (nsPerOp - previous) * 100 / previous
+			if change > *threshold {
+				regressions = append(regressions, fmt.Sprintf("%s: %.0f ns/op against baseline %.0f ns/op (+%.1f%%)", name, nsPerOp, previous, change))
+			}
+		}
+	}
+
+	if *save != "" {
+		if err := saveResults(*save, results); err != nil {
+			return err
+		}
+	}
+
+	if len(regressions) > 0 {
+		for _, regression := range regressions {
+			fmt.Fprintf(os.Stderr, "Regression: %s\n", regression)
+		}
+
+		return fmt.Errorf("%d benchmarks regressed", len(regressions))
+	}
+
+	return nil
+}
+
+// benchmarkProcs matches the suffix that go test adds to benchmark names for GOMAXPROCS.
+var benchmarkProcs = regexp.MustCompile(`-[0-9]+$`)
+
+// parseResults parses the output of go test benchmarks, returning nanoseconds per operation keyed
+// by benchmark name.  If a benchmark is run more than once its mean is returned.
+func parseResults(input io.Reader) (map[string]float64, error) {
+	totals := make(map[string]float64)
+	counts := make(map[string]int)
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			// Not a result line.
+			continue
+		}
+		name := benchmarkProcs.ReplaceAllString(strings.TrimPrefix(fields[0], "Benchmark"), "")
+		for i := 2; i+1 < len(fields); i += 2 {
+			if fields[i+1] != "ns/op" {
+				continue
+			}
+			nsPerOp, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid result for benchmark %s", name)
+			}
+			totals[name] += nsPerOp
+			counts[name]++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Join(errors.New("failed to read results"), err)
+	}
+
+	results := make(map[string]float64, len(totals))
+	for name, total := range totals {
+		results[name] = total / float64(counts[name])
+	}
+
+	return results, nil
+}
+
+// loadResults loads results, in nanoseconds per operation keyed by benchmark name, from a file.
+func loadResults(path string) (map[string]float64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to read baseline"), err)
+	}
+	results := make(map[string]float64)
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, errors.Join(errors.New("failed to parse baseline"), err)
+	}
+
+	return results, nil
+}
+
+// saveResults saves results, in nanoseconds per operation keyed by benchmark name, to a file.
+func saveResults(path string, results map[string]float64) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return errors.Join(errors.New("failed to marshal results"), err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return errors.Join(errors.New("failed to write results"), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main provides eth-listener-meta, a tool to dump, diff and edit the progress of
+// triggers in a listener's metadata database, and to show its audit log.  The listener must
+// be stopped whilst the tool is in use.  Edits are recorded in the audit log.
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/wealdtech/go-eth-listener/services/listener/ethclient"
+)
+
+const usage = `Usage: eth-listener-meta [-key hex] [-actor name] <command> [arguments]
+
+Commands:
+  dump <db>                                  write trigger progress as JSON to stdout
+  diff <db> <db>                             show differences in trigger progress
+  load <db> <file>                           replace trigger progress with JSON from a file
+  set <db> block <trigger> <latest>          set the latest block for a block trigger
+  set <db> transaction <trigger> <latest>    set the latest block for a transaction trigger
+  set <db> event <trigger> <next> [index]    set the next block and event index for an event trigger
+  delete <db> <block|transaction|event> <trigger>
+                                             remove the progress for a trigger
+  audit <db>                                 write the audit log as JSON to stdout
+`
+
+func main() {
+	if err := run(context.Background(), os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("eth-listener-meta", flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	key := flags.String("key", "", "hex-encoded AES key, if the metadata database is encrypted")
+	actor := flags.String("actor", os.Getenv("USER"), "name recorded in the audit log for edits")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	args = flags.Args()
+	if len(args) < 2 {
+		flags.Usage()
+
+		return errors.New("command and database required")
+	}
+
+	var cipher ethclient.ValueCipher
+	if *key != "" {
+		keyBytes, err := hex.DecodeString(strings.TrimPrefix(*key, "0x"))
+		if err != nil {
+			return errors.Join(errors.New("invalid key"), err)
+		}
+		cipher, err = ethclient.NewAESGCMCipher(keyBytes)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx = ethclient.ContextWithActor(ctx, *actor)
+
+	switch args[0] {
+	case "dump":
+		return dump(ctx, args[1:], cipher)
+	case "diff":
+		return diff(ctx, args[1:], cipher)
+	case "load":
+		return load(ctx, args[1:], cipher)
+	case "set":
+		return set(ctx, args[1:], cipher)
+	case "delete":
+		return remove(ctx, args[1:], cipher)
+	case "audit":
+		return audit(ctx, args[1:], cipher)
+	default:
+		flags.Usage()
+
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+func dump(ctx context.Context, args []string, cipher ethclient.ValueCipher) error {
+	progress, err := ethclient.ReadProgress(ctx, args[0], cipher)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(progress)
+}
+
+func audit(ctx context.Context, args []string, cipher ethclient.ValueCipher) error {
+	entries, err := ethclient.ReadAuditLog(ctx, args[0], cipher)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(entries)
+}
+
+func diff(ctx context.Context, args []string, cipher ethclient.ValueCipher) error {
+	if len(args) != 2 {
+		return errors.New("two databases required")
+	}
+	a, err := ethclient.ReadProgress(ctx, args[0], cipher)
+	if err != nil {
+		return err
+	}
+	b, err := ethclient.ReadProgress(ctx, args[1], cipher)
+	if err != nil {
+		return err
+	}
+
+	for _, difference := range ethclient.DiffProgress(a, b) {
+		fmt.Println(difference)
+	}
+
+	return nil
+}
+
+func load(ctx context.Context, args []string, cipher ethclient.ValueCipher) error {
+	if len(args) != 2 {
+		return errors.New("database and file required")
+	}
+	data, err := os.ReadFile(args[1])
+	if err != nil {
+		return errors.Join(errors.New("failed to read progress"), err)
+	}
+	progress := &ethclient.Progress{}
+	if err := json.Unmarshal(data, progress); err != nil {
+		return errors.Join(errors.New("failed to parse progress"), err)
+	}
+
+	return ethclient.WriteProgress(ctx, args[0], progress, cipher)
+}
+
+func set(ctx context.Context, args []string, cipher ethclient.ValueCipher) error {
+	if len(args) < 3 {
+		return errors.New("database, trigger type and progress required")
+	}
+
+	return edit(ctx, args[0], cipher, func(progress *ethclient.Progress) error {
+		switch {
+		case args[1] == "block" && len(args) == 4:
+			latest, err := parseInt[int64](args[3])
+			if err != nil {
+				return err
+			}
+			progress.Blocks[args[2]] = latest
+		case args[1] == "transaction" && len(args) == 4:
+			latest, err := parseInt[int64](args[3])
+			if err != nil {
+				return err
+			}
+			progress.Transactions[args[2]] = latest
+		case args[1] == "event" && (len(args) == 4 || len(args) == 5):
+			next, err := parseInt[int64](args[3])
+			if err != nil || next < 0 {
+				return errors.New("invalid next block")
+			}
+			index := int32(-1)
+			if len(args) == 5 {
+				index, err = parseInt[int32](args[4])
+				if err != nil {
+					return err
+				}
+			}
+			progress.Events[args[2]] = &ethclient.EventProgress{
+				NextBlock:        uint64(next),
+				LatestEventIndex: index,
+			}
+		default:
+			return errors.New("invalid arguments to set")
+		}
+
+		return nil
+	})
+}
+
+func remove(ctx context.Context, args []string, cipher ethclient.ValueCipher) error {
+	if len(args) != 3 {
+		return errors.New("database, trigger type and trigger required")
+	}
+
+	return edit(ctx, args[0], cipher, func(progress *ethclient.Progress) error {
+		switch args[1] {
+		case "block":
+			delete(progress.Blocks, args[2])
+		case "transaction":
+			delete(progress.Transactions, args[2])
+		case "event":
+			delete(progress.Events, args[2])
+		default:
+			return fmt.Errorf("unknown trigger type %q", args[1])
+		}
+
+		return nil
+	})
+}
+
+// edit reads the progress from a database, applies a change to it and writes it back.
+func edit(ctx context.Context, path string, cipher ethclient.ValueCipher, change func(*ethclient.Progress) error) error {
+	progress, err := ethclient.ReadProgress(ctx, path, cipher)
+	if err != nil {
+		return err
+	}
+	if err := change(progress); err != nil {
+		return err
+	}
+
+	return ethclient.WriteProgress(ctx, path, progress, cipher)
+}
+
+func parseInt[T int32 | int64](input string) (T, error) {
+	var value T
+	if _, err := fmt.Sscan(input, &value); err != nil {
+		return 0, fmt.Errorf("invalid number %q", input)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,52 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package format provides text templates for human-readable messages about blocks, transactions
+// and events, with helper functions for common on-chain values.
+//
+// The following helpers are available to templates:
+//
+//   - address: the checksummed form of an address
+//   - shortAddress: an abbreviated form of an address, for example 0x1234…cdef
+//   - shortHash: an abbreviated form of a hash
+//   - amount: a token amount with the given number of decimals, for example {{amount .Value 6}}
+//   - ether: an amount of Wei in Ether
+//   - topicAddress: the address held in an event topic
+//   - dataWord: the unsigned integer held in the given 32-byte word of event data
+//   - explorerAddress, explorerTx, explorerBlock: links to the block explorer
+package format
+
+import (
+	"errors"
+	"text/template"
+)
+
+// Parse parses a template, making the helper functions available to it.
+func Parse(name string, text string, params ...Parameter) (*template.Template, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	funcs := helpers(parameters.explorerURL)
+	for k, v := range parameters.funcs {
+		funcs[k] = v
+	}
+
+	tmpl, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errors.Join(errors.New("invalid template"), err)
+	}
+
+	return tmpl, nil
+}
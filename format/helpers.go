@@ -0,0 +1,149 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"text/template"
+
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// wordSize is the size of a word in ABI-encoded data.
+const wordSize = 32
+
+// helpers returns the helper functions for templates.
+func helpers(explorerURL string) template.FuncMap {
+	return template.FuncMap{
+		"address":      formatAddress,
+		"shortAddress": shortAddress,
+		"shortHash":    shortHash,
+		"amount":       Amount,
+		"ether": func(value any) (string, error) {
+			return Amount(value, 18)
+		},
+		"topicAddress": TopicAddress,
+		"dataWord":     DataWord,
+		"explorerAddress": func(address any) (string, error) {
+			formatted, err := formatAddress(address)
+			if err != nil {
+				return "", err
+			}
+
+			return fmt.Sprintf("%s/address/%s", explorerURL, formatted), nil
+		},
+		"explorerTx": func(hash types.Hash) string {
+			return fmt.Sprintf("%s/tx/%#x", explorerURL, hash)
+		},
+		"explorerBlock": func(number uint32) string {
+			return fmt.Sprintf("%s/block/%d", explorerURL, number)
+		},
+	}
+}
+
+// formatAddress formats an address, or pointer to an address, in checksummed form.
+func formatAddress(address any) (string, error) {
+	switch v := address.(type) {
+	case types.Address:
+		return v.String(), nil
+	case *types.Address:
+		if v == nil {
+			return "", nil
+		}
+
+		return v.String(), nil
+	default:
+		return "", fmt.Errorf("cannot format %T as an address", address)
+	}
+}
+
+func shortAddress(address any) (string, error) {
+	formatted, err := formatAddress(address)
+	if err != nil || len(formatted) < 10 {
+		return formatted, err
+	}
+
+	return fmt.Sprintf("%s…%s", formatted[:6], formatted[len(formatted)-4:]), nil
+}
+
+func shortHash(hash types.Hash) string {
+	formatted := hash.String()
+
+	return fmt.Sprintf("%s…%s", formatted[:10], formatted[len(formatted)-8:])
+}
+
+// Amount formats an integer value as a decimal with the given number of decimals,
+// removing trailing zeros.  The value can be a *big.Int or any integer type.
+func Amount(value any, decimals int) (string, error) {
+	var val *big.Int
+	switch v := value.(type) {
+	case *big.Int:
+		val = v
+	case uint64:
+		val = new(big.Int).SetUint64(v)
+	case uint32:
+		val = new(big.Int).SetUint64(uint64(v))
+	case int64:
+		val = big.NewInt(v)
+	case int:
+		val = big.NewInt(int64(v))
+	default:
+		return "", fmt.Errorf("cannot format %T as an amount", value)
+	}
+	if val == nil {
+		return "0", nil
+	}
+	if decimals < 0 {
+		return "", errors.New("decimals cannot be negative")
+	}
+
+	negative := val.Sign() < 0
+	digits := new(big.Int).Abs(val).String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	whole := digits[:len(digits)-decimals]
+	fraction := strings.TrimRight(digits[len(digits)-decimals:], "0")
+
+	res := whole
+	if fraction != "" {
+		res = fmt.Sprintf("%s.%s", whole, fraction)
+	}
+	if negative {
+		res = "-" + res
+	}
+
+	return res, nil
+}
+
+// TopicAddress returns the address held in an event topic.
+func TopicAddress(topic types.Hash) types.Address {
+	var address types.Address
+	copy(address[:], topic[len(topic)-types.AddressLength:])
+
+	return address
+}
+
+// DataWord returns the unsigned integer held in the given 32-byte word of event data.
+func DataWord(data []byte, index int) (*big.Int, error) {
+	start := index * wordSize
+	if index < 0 || start+wordSize > len(data) {
+		return nil, fmt.Errorf("data does not contain word %d", index)
+	}
+
+	return new(big.Int).SetBytes(data[start : start+wordSize]), nil
+}
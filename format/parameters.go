@@ -0,0 +1,71 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"errors"
+	"strings"
+)
+
+type parameters struct {
+	explorerURL string
+	funcs       map[string]any
+}
+
+// Parameter is the interface for formatter parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithExplorerURL sets the base URL of the block explorer used for links.
+func WithExplorerURL(url string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.explorerURL = url
+	})
+}
+
+// WithFuncs adds functions to those available to templates, overriding any helpers of the same name.
+func WithFuncs(funcs map[string]any) Parameter {
+	return parameterFunc(func(p *parameters) {
+		for k, v := range funcs {
+			p.funcs[k] = v
+		}
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		explorerURL: "https://etherscan.io",
+		funcs:       make(map[string]any),
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.explorerURL == "" {
+		return nil, errors.New("no explorer URL specified")
+	}
+	parameters.explorerURL = strings.TrimSuffix(parameters.explorerURL, "/")
+
+	return &parameters, nil
+}
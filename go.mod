@@ -9,6 +9,8 @@ require (
 	github.com/cockroachdb/pebble v1.1.2
 	github.com/prometheus/client_golang v1.20.5
 	github.com/rs/zerolog v1.33.0
+	golang.org/x/crypto v0.31.0
+	google.golang.org/protobuf v1.36.0
 )
 
 require (
@@ -38,9 +40,7 @@ require (
 	github.com/prometheus/procfs v0.15.1 // indirect
 	github.com/rogpeppe/go-internal v1.13.1 // indirect
 	github.com/ybbus/jsonrpc/v2 v2.1.7 // indirect
-	golang.org/x/crypto v0.31.0 // indirect
 	golang.org/x/exp v0.0.0-20241217172543-b2144cdd0a67 // indirect
 	golang.org/x/sys v0.28.0 // indirect
 	golang.org/x/text v0.21.0 // indirect
-	google.golang.org/protobuf v1.36.0 // indirect
 )
@@ -0,0 +1,35 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// AddressLabel is a human-readable annotation for an address.
+type AddressLabel struct {
+	// Label is the name of the address, for example "Uniswap V3: Router".
+	// It is empty if the address has no known name.
+	Label string
+	// URL is the URL of the address in a block explorer.
+	URL string
+}
+
+// AddressLabeler defines the methods that need to be implemented to label addresses.
+type AddressLabeler interface {
+	// LabelAddress returns the label for an address.
+	LabelAddress(ctx context.Context, address types.Address) (*AddressLabel, error)
+}
@@ -0,0 +1,42 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// AddressSet defines the methods that need to be implemented to provide a set of addresses.
+// Address sets allow a single trigger to match against a large or frequently-changing list of addresses.
+type AddressSet interface {
+	// Contains returns true if the address is a member of the set.
+	Contains(address types.Address) bool
+
+	// Addresses returns the addresses that are members of the set.
+	Addresses() []types.Address
+}
+
+// AddressSetResolver defines the methods that need to be implemented to resolve the members of an address set.
+type AddressSetResolver interface {
+	// ResolveAddresses resolves the addresses that are members of the set.
+	ResolveAddresses(ctx context.Context) ([]types.Address, error)
+}
+
+// TopicSet defines the methods that need to be implemented to provide a set of event topics.
+type TopicSet interface {
+	// ContainsTopic returns true if the topic is a member of the set.
+	ContainsTopic(topic types.Hash) bool
+}
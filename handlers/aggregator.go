@@ -0,0 +1,130 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// wordLength is the length of an ABI-encoded word.
+const wordLength = 32
+
+// EventValue extracts a numeric value from an event.
+type EventValue func(event *spec.BerlinTransactionEvent) (*big.Int, error)
+
+// EventAddress extracts an address from an event.
+type EventAddress func(event *spec.BerlinTransactionEvent) (types.Address, error)
+
+// DataWord returns an event value that decodes the given word of the event's data as an unsigned integer.
+func DataWord(index int) EventValue {
+	return func(event *spec.BerlinTransactionEvent) (*big.Int, error) {
+		if len(event.Data) < (index+1)*wordLength {
+			return nil, fmt.Errorf("event data does not contain word %d", index)
+		}
+
+		return new(big.Int).SetBytes(event.Data[index*wordLength : (index+1)*wordLength]), nil
+	}
+}
+
+// TopicWord returns an event value that decodes the given topic of the event as an unsigned integer.
+func TopicWord(index int) EventValue {
+	return func(event *spec.BerlinTransactionEvent) (*big.Int, error) {
+		if len(event.Topics) <= index {
+			return nil, fmt.Errorf("event does not contain topic %d", index)
+		}
+
+		return new(big.Int).SetBytes(event.Topics[index][:]), nil
+	}
+}
+
+// TopicAddress returns an event address that decodes the given topic of the event as an address.
+func TopicAddress(index int) EventAddress {
+	return func(event *spec.BerlinTransactionEvent) (types.Address, error) {
+		if len(event.Topics) <= index {
+			return types.Address{}, fmt.Errorf("event does not contain topic %d", index)
+		}
+
+		return types.Address(event.Topics[index][wordLength-types.AddressLength:]), nil
+	}
+}
+
+// EventSource is an event address that returns the address of the contract that emitted the event.
+func EventSource(event *spec.BerlinTransactionEvent) (types.Address, error) {
+	return event.Address, nil
+}
+
+// Aggregator defines the methods that need to be implemented to aggregate events.
+type Aggregator interface {
+	// Aggregate returns the aggregate value of the events.
+	Aggregate(events []*spec.BerlinTransactionEvent) (*big.Int, error)
+}
+
+// CountAggregator aggregates events by counting them.
+type CountAggregator struct{}
+
+// Aggregate returns the number of events.
+func (*CountAggregator) Aggregate(events []*spec.BerlinTransactionEvent) (*big.Int, error) {
+	return big.NewInt(int64(len(events))), nil
+}
+
+// SumAggregator aggregates events by summing a value from each of them.
+type SumAggregator struct {
+	Value EventValue
+}
+
+// Aggregate returns the sum of the values of the events.
+func (a *SumAggregator) Aggregate(events []*spec.BerlinTransactionEvent) (*big.Int, error) {
+	if a.Value == nil {
+		return nil, errors.New("no value specified for sum")
+	}
+
+	sum := new(big.Int)
+	for _, event := range events {
+		value, err := a.Value(event)
+		if err != nil {
+			return nil, err
+		}
+		sum.Add(sum, value)
+	}
+
+	return sum, nil
+}
+
+// DistinctAggregator aggregates events by counting the distinct addresses obtained from them.
+type DistinctAggregator struct {
+	Address EventAddress
+}
+
+// Aggregate returns the number of distinct addresses in the events.
+func (a *DistinctAggregator) Aggregate(events []*spec.BerlinTransactionEvent) (*big.Int, error) {
+	if a.Address == nil {
+		return nil, errors.New("no address specified for distinct count")
+	}
+
+	addresses := make(map[types.Address]struct{})
+	for _, event := range events {
+		address, err := a.Address(event)
+		if err != nil {
+			return nil, err
+		}
+		addresses[address] = struct{}{}
+	}
+
+	return big.NewInt(int64(len(addresses))), nil
+}
@@ -0,0 +1,114 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// maxBatchSize is the maximum number of messages in a batch permitted by SQS and SNS.
+const maxBatchSize = 10
+
+type parameters struct {
+	logLevel   zerolog.Level
+	publisher  Publisher
+	batchSize  int
+	maxRetries int
+	retryDelay time.Duration
+	ceSource   string
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithPublisher sets the publisher for messages.
+func WithPublisher(publisher Publisher) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.publisher = publisher
+	})
+}
+
+// WithBatchSize sets the number of messages to send in each batch.
+func WithBatchSize(batchSize int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.batchSize = batchSize
+	})
+}
+
+// WithMaxRetries sets the maximum number of retries for failed messages in each batch.
+func WithMaxRetries(maxRetries int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxRetries = maxRetries
+	})
+}
+
+// WithRetryDelay sets the initial delay between retries, which doubles with each retry.
+func WithRetryDelay(retryDelay time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.retryDelay = retryDelay
+	})
+}
+
+// WithCloudEventsSource wraps items in CloudEvents envelopes in structured mode, with the given source.
+// If the source is empty, which is the default, items are published without an envelope.
+func WithCloudEventsSource(source string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.ceSource = source
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:   zerolog.GlobalLevel(),
+		batchSize:  maxBatchSize,
+		maxRetries: 3,
+		retryDelay: 100 * time.Millisecond,
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.publisher == nil {
+		return nil, errors.New("no publisher specified")
+	}
+	if parameters.batchSize < 1 || parameters.batchSize > maxBatchSize {
+		return nil, errors.New("batch size must be between 1 and 10")
+	}
+	if parameters.maxRetries < 0 {
+		return nil, errors.New("max retries cannot be negative")
+	}
+
+	return &parameters, nil
+}
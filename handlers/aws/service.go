@@ -0,0 +1,323 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aws provides handlers that publish blocks, transactions and events to AWS SQS queues or SNS topics.
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+	"github.com/wealdtech/go-eth-listener/handlers/cloudevents"
+)
+
+// Message is a message to be published.
+type Message struct {
+	// ID is the identifier of the message, unique within the chain.
+	// It is suitable for use as a deduplication ID for FIFO queues and topics, but can exceed the
+	// length permitted for batch entry IDs so adapters should generate their own entry IDs, and
+	// map any failed entries back to this ID.
+	ID string
+	// GroupID is the message group for FIFO queues and topics, being the address of the item.
+	GroupID string
+	// Body is the JSON body of the message.
+	Body string
+	// Attributes are the message attributes.
+	Attributes map[string]string
+}
+
+// Publisher defines the methods that need to be implemented to publish messages.
+// It is expected to be a thin adapter around an SQS SendMessageBatch or SNS PublishBatch call.
+type Publisher interface {
+	// PublishBatch publishes a batch of messages, returning the IDs, as given in Message.ID, of any
+	// messages that failed.
+	PublishBatch(ctx context.Context, messages []*Message) ([]string, error)
+}
+
+// Service publishes items to SQS or SNS in batches.
+// It implements the block, transaction and event handler interfaces.
+//
+// A handler does not return until its message has been published, so that the listener does not
+// record progress for messages that could be lost.  Messages from concurrent handlers, for example
+// isolated triggers, are published together in batches.  If a message cannot be published after
+// retries then the handler returns an error, so the listener will redeliver the item.
+type Service struct {
+	log        zerolog.Logger
+	publisher  Publisher
+	batchSize  int
+	maxRetries int
+	retryDelay time.Duration
+	ceSource   string
+	// publishMu ensures that one batch is published at a time.
+	publishMu sync.Mutex
+	// mu protects the pending messages.
+	mu      sync.Mutex
+	pending []*pendingMessage
+	// pendingIDs indexes the pending messages by message ID.
+	pendingIDs map[string]*pendingMessage
+}
+
+// pendingMessage is a message awaiting publication.
+type pendingMessage struct {
+	message *Message
+	// done is closed once the message has been published or has failed.
+	done chan struct{}
+	err  error
+}
+
+// New creates a new AWS handler.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "aws").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	return &Service{
+		log:        log,
+		publisher:  parameters.publisher,
+		batchSize:  parameters.batchSize,
+		maxRetries: parameters.maxRetries,
+		retryDelay: parameters.retryDelay,
+		ceSource:   parameters.ceSource,
+		pending:    make([]*pendingMessage, 0, parameters.batchSize),
+		pendingIDs: make(map[string]*pendingMessage),
+	}, nil
+}
+
+// HandleBlock publishes a block.
+func (s *Service) HandleBlock(ctx context.Context, block *spec.Block, trigger *handlers.BlockTrigger) error {
+	attributes := map[string]string{
+		"block_number": fmt.Sprintf("%d", block.Number()),
+	}
+
+	number := fmt.Sprintf("%d", block.Number())
+
+	return s.enqueue(ctx, "block", trigger.Name, number, "", number, attributes, block)
+}
+
+// HandleTx publishes a transaction.
+func (s *Service) HandleTx(ctx context.Context, tx *spec.Transaction, trigger *handlers.TxTrigger) {
+	attributes := map[string]string{
+		"from": tx.From().String(),
+	}
+	address := tx.From().String()
+	if tx.To() != nil {
+		attributes["to"] = tx.To().String()
+		address = tx.To().String()
+	}
+	if tx.BlockNumber() != nil {
+		attributes["block_number"] = fmt.Sprintf("%d", *tx.BlockNumber())
+	}
+
+	if err := s.enqueue(ctx, "transaction", trigger.Name, tx.Hash().String(), address, tx.Hash().String(), attributes, tx); err != nil {
+		s.log.Error().Stringer("tx", tx.Hash()).Err(err).Msg("Failed to publish transaction")
+	}
+}
+
+// HandleEvent publishes an event.
+func (s *Service) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, trigger *handlers.EventTrigger) error {
+	attributes := map[string]string{
+		"address":      event.Address.String(),
+		"block_number": fmt.Sprintf("%d", event.BlockNumber),
+	}
+	if len(event.Topics) > 0 {
+		attributes["topic0"] = event.Topics[0].String()
+	}
+	id := fmt.Sprintf("%s-%d", event.TransactionHash.String(), event.Index)
+
+	return s.enqueue(ctx, "event", trigger.Name, id, event.Address.String(), event.Address.String(), attributes, event)
+}
+
+// Flush publishes any pending messages, such as those whose handler was cancelled before they
+// were published.
+func (s *Service) Flush(ctx context.Context) error {
+	s.publishMu.Lock()
+	defer s.publishMu.Unlock()
+
+	for {
+		batch := s.nextBatch()
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := s.publishBatch(ctx, batch); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Service) enqueue(ctx context.Context,
+	itemType string,
+	trigger string,
+	id string,
+	groupID string,
+	subject string,
+	attributes map[string]string,
+	item any,
+) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return errors.Join(errors.New("failed to marshal item"), err)
+	}
+	if s.ceSource != "" {
+		data, err = cloudevents.Wrap(ctx, s.ceSource, itemType, trigger, fmt.Sprintf("%s-%s", itemType, id), subject, data)
+		if err != nil {
+			return err
+		}
+		attributes["content-type"] = cloudevents.ContentType
+	}
+	attributes["type"] = itemType
+	attributes["trigger"] = trigger
+	if key := handlers.IdempotencyKeyFromContext(ctx); key != "" {
+		attributes["idempotency_key"] = key
+	}
+
+	id = fmt.Sprintf("%s-%s-%s", itemType, trigger, id)
+	s.mu.Lock()
+	entry, exists := s.pendingIDs[id]
+	if !exists {
+		// A redelivered item that is still pending is published once only.
+		entry = &pendingMessage{
+			message: &Message{
+				ID:         id,
+				GroupID:    groupID,
+				Body:       string(data),
+				Attributes: attributes,
+			},
+			done: make(chan struct{}),
+		}
+		s.pending = append(s.pending, entry)
+		s.pendingIDs[id] = entry
+	}
+	s.mu.Unlock()
+
+	return s.await(ctx, entry)
+}
+
+// await publishes batches of pending messages until the given message has been published or has failed.
+func (s *Service) await(ctx context.Context, entry *pendingMessage) error {
+	for {
+		select {
+		case <-entry.done:
+			return entry.err
+		default:
+		}
+
+		s.publishMu.Lock()
+		select {
+		case <-entry.done:
+			// Published by another handler while waiting for the lock.
+			s.publishMu.Unlock()
+
+			return entry.err
+		default:
+		}
+		err := s.publishBatch(ctx, s.nextBatch())
+		s.publishMu.Unlock()
+		if ctx.Err() != nil {
+			return errors.Join(err, ctx.Err())
+		}
+	}
+}
+
+// nextBatch removes and returns the next batch of pending messages.
+func (s *Service) nextBatch() []*pendingMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	size := min(s.batchSize, len(s.pending))
+	batch := s.pending[:size:size]
+	s.pending = s.pending[size:]
+	for _, entry := range batch {
+		delete(s.pendingIDs, entry.message.ID)
+	}
+
+	return batch
+}
+
+// publishBatch publishes a batch of messages, retrying failed messages, and completes each
+// message with its result.
+// Must be called with the publish lock held.
+func (s *Service) publishBatch(ctx context.Context, batch []*pendingMessage) error {
+	var err error
+	defer func() {
+		for _, entry := range batch {
+			entry.err = err
+			close(entry.done)
+		}
+	}()
+
+	delay := s.retryDelay
+	for attempt := 0; len(batch) > 0; attempt++ {
+		messages := make([]*Message, len(batch))
+		for i, entry := range batch {
+			messages[i] = entry.message
+		}
+		var failedIDs []string
+		failedIDs, err = s.publisher.PublishBatch(ctx, messages)
+		if err == nil && len(failedIDs) == 0 {
+			s.log.Trace().Int("messages", len(messages)).Msg("Published batch")
+
+			return nil
+		}
+
+		if err == nil {
+			// Complete the published messages, and retain only the failed messages for retry.
+			failed := make(map[string]struct{}, len(failedIDs))
+			for _, id := range failedIDs {
+				failed[id] = struct{}{}
+			}
+			retry := make([]*pendingMessage, 0, len(failedIDs))
+			for _, entry := range batch {
+				if _, exists := failed[entry.message.ID]; exists {
+					retry = append(retry, entry)
+				} else {
+					close(entry.done)
+				}
+			}
+			batch = retry
+			err = fmt.Errorf("%d messages failed to publish", len(retry))
+		}
+
+		if attempt >= s.maxRetries {
+			err = errors.Join(errors.New("failed to publish batch"), err)
+
+			return err
+		}
+		s.log.Debug().Int("attempt", attempt+1).Err(err).Msg("Failed to publish batch; retrying")
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			err = ctx.Err()
+
+			return err
+		}
+		delay *= 2
+	}
+
+	return nil
+}
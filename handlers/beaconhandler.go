@@ -0,0 +1,98 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// BeaconBlock is a block on the beacon chain, or a missed slot.
+type BeaconBlock struct {
+	// Slot is the slot of the block.
+	Slot uint64
+	// Missed is true if no block was proposed in the slot, in which case only the slot and proposer index are set.
+	Missed bool
+	// ProposerIndex is the index of the validator that proposed the block, or was due to propose it.
+	ProposerIndex uint64
+	// Root is the root of the block.
+	Root types.Root
+	// ParentRoot is the root of the parent of the block.
+	ParentRoot types.Root
+	// StateRoot is the root of the state after the block.
+	StateRoot types.Root
+	// ExecutionBlockNumber is the number of the execution block in the block, if present.
+	ExecutionBlockNumber *uint64
+	// ExecutionBlockHash is the hash of the execution block in the block, if present.
+	ExecutionBlockHash *types.Hash
+}
+
+// ValidatorSet defines the methods that need to be implemented to provide a set of validators.
+type ValidatorSet interface {
+	// ContainsValidator returns true if the validator with the given index is a member of the set.
+	ContainsValidator(index uint64) bool
+}
+
+// BeaconBlockTrigger is a trigger for a beacon block.
+type BeaconBlockTrigger struct {
+	Name string
+	// Namespace is the namespace of the trigger, isolating its progress from triggers in other namespaces.
+	Namespace    string
+	EarliestSlot uint64
+	// Proposers is a set of validators, one of which must be the proposer of the block.
+	Proposers ValidatorSet
+	// IncludeMissed passes missed slots to the handler as well as blocks.
+	IncludeMissed bool
+	Handler       BeaconBlockHandler
+	// Labels are arbitrary key/value pairs attached to logs and metrics for the trigger.
+	Labels map[string]string
+}
+
+// BeaconBlockHandlerFunc defines the handler function.
+type BeaconBlockHandlerFunc func(ctx context.Context, block *BeaconBlock, trigger *BeaconBlockTrigger) error
+
+// BeaconBlockHandler defines the methods that need to be implemented to handle beacon blocks.
+type BeaconBlockHandler interface {
+	// HandleBeaconBlock handles a beacon block provided by the listener.
+	// If this call returns an error then the listener will start again with this block on the next poll.
+	HandleBeaconBlock(ctx context.Context, block *BeaconBlock, trigger *BeaconBlockTrigger) error
+}
+
+// Checkpoint is a beacon chain checkpoint.
+type Checkpoint struct {
+	// Epoch is the epoch of the checkpoint.
+	Epoch uint64
+	// Root is the root of the block at the checkpoint.
+	Root types.Root
+}
+
+// FinalityTrigger is a trigger for beacon chain finality.
+type FinalityTrigger struct {
+	Name string
+	// Namespace is the namespace of the trigger, isolating its progress from triggers in other namespaces.
+	Namespace string
+	Handler   FinalityHandler
+	// Labels are arbitrary key/value pairs attached to logs and metrics for the trigger.
+	Labels map[string]string
+}
+
+// FinalityHandlerFunc defines the handler function.
+type FinalityHandlerFunc func(ctx context.Context, checkpoint *Checkpoint, trigger *FinalityTrigger) error
+
+// FinalityHandler defines the methods that need to be implemented to handle finality.
+type FinalityHandler interface {
+	// HandleFinality handles a newly finalized checkpoint.
+	HandleFinality(ctx context.Context, checkpoint *Checkpoint, trigger *FinalityTrigger) error
+}
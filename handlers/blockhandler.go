@@ -21,9 +21,17 @@ import (
 
 // BlockTrigger is a trigger for a block.
 type BlockTrigger struct {
-	Name          string
-	EarliestBlock uint32
+	Name string
+	// Namespace is the namespace of the trigger, isolating its progress from triggers in other namespaces.
+	Namespace     string
+	EarliestBlock uint64
 	Handler       BlockHandler
+	// Labels are arbitrary key/value pairs attached to logs and metrics for the trigger.
+	Labels map[string]string
+	// Priority is the priority of the trigger.  Triggers with higher priority are processed first within a poll.
+	Priority int
+	// DependsOn are the qualified names of triggers that this trigger must not advance past.
+	DependsOn []string
 }
 
 // BlockHandlerFunc defines the handler function.
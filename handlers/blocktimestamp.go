@@ -0,0 +1,33 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+	"time"
+)
+
+// WithBlockTimestamp returns a copy of the context containing the timestamp of the block of the item being handled.
+func WithBlockTimestamp(ctx context.Context, timestamp time.Time) context.Context {
+	return context.WithValue(ctx, blockTimestampKey, timestamp)
+}
+
+// BlockTimestampFromContext returns the timestamp of the block of the item being handled.
+// The timestamp is only supplied if the block was already available to the listener, so
+// it is not guaranteed to be present for transactions and events.
+func BlockTimestampFromContext(ctx context.Context) (time.Time, bool) {
+	timestamp, exists := ctx.Value(blockTimestampKey).(time.Time)
+
+	return timestamp, exists
+}
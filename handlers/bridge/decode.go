@@ -0,0 +1,181 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bridge
+
+import (
+	"errors"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/wealdtech/go-eth-listener/abi"
+)
+
+// decoder decodes a bridge event into a message.
+type decoder struct {
+	event     string
+	direction Direction
+	topics    int
+	decode    func(msg *Message, event *spec.BerlinTransactionEvent) error
+}
+
+// opStackDecoders are the decoders for OP-stack L1StandardBridge events, keyed by topic.
+var opStackDecoders = map[types.Hash]*decoder{
+	abi.EventTopic("ETHDepositInitiated(address,address,uint256,bytes)"): {
+		event:     "ETHDepositInitiated",
+		direction: Deposit,
+		topics:    3,
+		decode:    decodeOPStackETH,
+	},
+	abi.EventTopic("ETHWithdrawalFinalized(address,address,uint256,bytes)"): {
+		event:     "ETHWithdrawalFinalized",
+		direction: Withdrawal,
+		topics:    3,
+		decode:    decodeOPStackETH,
+	},
+	abi.EventTopic("ERC20DepositInitiated(address,address,address,address,uint256,bytes)"): {
+		event:     "ERC20DepositInitiated",
+		direction: Deposit,
+		topics:    4,
+		decode:    decodeOPStackERC20,
+	},
+	abi.EventTopic("ERC20WithdrawalFinalized(address,address,address,address,uint256,bytes)"): {
+		event:     "ERC20WithdrawalFinalized",
+		direction: Withdrawal,
+		topics:    4,
+		decode:    decodeOPStackERC20,
+	},
+}
+
+// arbitrumDecoders are the decoders for Arbitrum Bridge, Outbox and gateway router events, keyed by topic.
+var arbitrumDecoders = map[types.Hash]*decoder{
+	abi.EventTopic("MessageDelivered(uint256,bytes32,address,uint8,address,bytes32,uint256,uint64)"): {
+		event:     "MessageDelivered",
+		direction: Deposit,
+		topics:    3,
+		decode:    decodeArbitrumMessageDelivered,
+	},
+	abi.EventTopic("OutBoxTransactionExecuted(address,address,uint256,uint256)"): {
+		event:     "OutBoxTransactionExecuted",
+		direction: Withdrawal,
+		topics:    4,
+		decode:    decodeArbitrumOutBoxTransactionExecuted,
+	},
+	abi.EventTopic("DepositInitiated(address,address,address,uint256,uint256)"): {
+		event:     "DepositInitiated",
+		direction: Deposit,
+		topics:    4,
+		decode:    decodeArbitrumGateway,
+	},
+	abi.EventTopic("WithdrawalFinalized(address,address,address,uint256,uint256)"): {
+		event:     "WithdrawalFinalized",
+		direction: Withdrawal,
+		topics:    4,
+		decode:    decodeArbitrumGateway,
+	},
+}
+
+// decodeOPStackETH decodes ETHDepositInitiated and ETHWithdrawalFinalized events:
+// (address indexed from, address indexed to, uint256 amount, bytes extraData).
+func decodeOPStackETH(msg *Message, event *spec.BerlinTransactionEvent) error {
+	var err error
+	msg.From = abi.TopicAddress(event.Topics[1])
+	msg.To = abi.TopicAddress(event.Topics[2])
+	if msg.Amount, err = abi.Uint(event.Data, 0); err != nil {
+		return err
+	}
+	if msg.Data, err = abi.Bytes(event.Data, 1); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// decodeOPStackERC20 decodes ERC20DepositInitiated and ERC20WithdrawalFinalized events:
+// (address indexed l1Token, address indexed l2Token, address indexed from, address to, uint256 amount, bytes extraData).
+func decodeOPStackERC20(msg *Message, event *spec.BerlinTransactionEvent) error {
+	l1Token := abi.TopicAddress(event.Topics[1])
+	l2Token := abi.TopicAddress(event.Topics[2])
+	msg.L1Token = &l1Token
+	msg.L2Token = &l2Token
+	msg.From = abi.TopicAddress(event.Topics[3])
+	var err error
+	if msg.To, err = abi.Address(event.Data, 0); err != nil {
+		return err
+	}
+	if msg.Amount, err = abi.Uint(event.Data, 1); err != nil {
+		return err
+	}
+	if msg.Data, err = abi.Bytes(event.Data, 2); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// decodeArbitrumMessageDelivered decodes Bridge MessageDelivered events:
+// (uint256 indexed messageIndex, bytes32 indexed beforeInboxAcc, address inbox, uint8 kind,
+// address sender, bytes32 messageDataHash, uint256 baseFeeL1, uint64 timestamp).
+func decodeArbitrumMessageDelivered(msg *Message, event *spec.BerlinTransactionEvent) error {
+	msg.Sequence = abi.TopicUint(event.Topics[1])
+	kind, err := abi.Uint(event.Data, 1)
+	if err != nil {
+		return err
+	}
+	if !kind.IsUint64() || kind.Uint64() > 0xff {
+		return errors.New("message kind out of range")
+	}
+	msgKind := uint8(kind.Uint64())
+	msg.Kind = &msgKind
+	if msg.From, err = abi.Address(event.Data, 2); err != nil {
+		return err
+	}
+	dataHash, err := abi.Word(event.Data, 3)
+	if err != nil {
+		return err
+	}
+	msg.Data = append([]byte{}, dataHash...)
+
+	return nil
+}
+
+// decodeArbitrumOutBoxTransactionExecuted decodes Outbox OutBoxTransactionExecuted events:
+// (address indexed to, address indexed l2Sender, uint256 indexed zero, uint256 transactionIndex).
+func decodeArbitrumOutBoxTransactionExecuted(msg *Message, event *spec.BerlinTransactionEvent) error {
+	msg.To = abi.TopicAddress(event.Topics[1])
+	msg.From = abi.TopicAddress(event.Topics[2])
+	var err error
+	if msg.Sequence, err = abi.Uint(event.Data, 0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// decodeArbitrumGateway decodes gateway router DepositInitiated and WithdrawalFinalized events:
+// (address l1Token, address indexed from, address indexed to, uint256 indexed sequence, uint256 amount).
+func decodeArbitrumGateway(msg *Message, event *spec.BerlinTransactionEvent) error {
+	msg.From = abi.TopicAddress(event.Topics[1])
+	msg.To = abi.TopicAddress(event.Topics[2])
+	msg.Sequence = abi.TopicUint(event.Topics[3])
+	l1Token, err := abi.Address(event.Data, 0)
+	if err != nil {
+		return err
+	}
+	msg.L1Token = &l1Token
+	if msg.Amount, err = abi.Uint(event.Data, 1); err != nil {
+		return err
+	}
+
+	return nil
+}
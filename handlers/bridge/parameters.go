@@ -0,0 +1,119 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bridge
+
+import (
+	"errors"
+
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel         zerolog.Level
+	opStackBridges   []types.Address
+	arbitrumBridges  []types.Address
+	includeDeposits  bool
+	includeWithdraws bool
+	handler          MessageHandler
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithOPStackBridges sets the OP-stack L1StandardBridge contracts to watch.
+func WithOPStackBridges(addresses []types.Address) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.opStackBridges = addresses
+	})
+}
+
+// WithArbitrumBridges sets the Arbitrum contracts to watch, which may be any combination of
+// the Bridge, Outbox and L1 gateway router contracts.
+func WithArbitrumBridges(addresses []types.Address) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.arbitrumBridges = addresses
+	})
+}
+
+// WithDeposits sets whether to deliver deposits from L1 to L2.
+// Defaults to true.
+func WithDeposits(include bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.includeDeposits = include
+	})
+}
+
+// WithWithdrawals sets whether to deliver withdrawals from L2 to L1.
+// Defaults to true.
+func WithWithdrawals(include bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.includeWithdraws = include
+	})
+}
+
+// WithHandler sets the handler for cross-domain messages.
+func WithHandler(handler MessageHandler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.handler = handler
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:         zerolog.GlobalLevel(),
+		includeDeposits:  true,
+		includeWithdraws: true,
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if len(parameters.opStackBridges) == 0 && len(parameters.arbitrumBridges) == 0 {
+		return nil, errors.New("no bridges specified")
+	}
+	for _, opStackBridge := range parameters.opStackBridges {
+		for _, arbitrumBridge := range parameters.arbitrumBridges {
+			if opStackBridge == arbitrumBridge {
+				return nil, errors.New("bridge specified as both OP-stack and Arbitrum")
+			}
+		}
+	}
+	if !parameters.includeDeposits && !parameters.includeWithdraws {
+		return nil, errors.New("neither deposits nor withdrawals included")
+	}
+	if parameters.handler == nil {
+		return nil, errors.New("no handler specified")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,211 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bridge provides triggers that deliver typed cross-domain messages from the
+// canonical OP-stack and Arbitrum bridge contracts.
+package bridge
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// Protocol is the protocol of a bridge.
+type Protocol string
+
+const (
+	// OPStack is the OP-stack canonical bridge.
+	OPStack Protocol = "op-stack"
+	// Arbitrum is the Arbitrum canonical bridge.
+	Arbitrum Protocol = "arbitrum"
+)
+
+// Direction is the direction of a cross-domain message.
+type Direction string
+
+const (
+	// Deposit is a message from L1 to L2.
+	Deposit Direction = "deposit"
+	// Withdrawal is a message from L2 to L1.
+	Withdrawal Direction = "withdrawal"
+)
+
+// Message is a cross-domain message observed on an L1 bridge contract.
+type Message struct {
+	// Protocol is the protocol of the bridge.
+	Protocol Protocol
+	// Direction is the direction of the message.
+	Direction Direction
+	// Event is the name of the event that carried the message.
+	Event string
+	// Bridge is the contract that emitted the event.
+	Bridge types.Address
+	// From is the sender of the message.
+	From types.Address
+	// To is the recipient of the message, if known.
+	To types.Address
+	// L1Token is the token on L1, or nil for native ether or messages without a token.
+	L1Token *types.Address
+	// L2Token is the token on L2, if known.
+	L2Token *types.Address
+	// Amount is the amount bridged, or nil for messages without an amount.
+	Amount *big.Int
+	// Sequence is the protocol's sequence number for the message, or nil if it has none.
+	Sequence *big.Int
+	// Kind is the Arbitrum message kind, or nil for other messages.
+	Kind *uint8
+	// Data is the message's extra data for OP-stack messages, or the message data hash
+	// for Arbitrum MessageDelivered messages.
+	Data []byte
+	// TransactionHash is the hash of the transaction containing the message.
+	TransactionHash types.Hash
+	// Block is the block containing the message.
+	Block uint64
+	// LogIndex is the index of the event in the block.
+	LogIndex uint32
+}
+
+// MessageHandler defines the methods that need to be implemented to handle cross-domain messages.
+type MessageHandler interface {
+	// HandleBridgeMessage handles a cross-domain message.
+	HandleBridgeMessage(ctx context.Context, msg *Message) error
+}
+
+// Service delivers cross-domain messages.
+type Service struct {
+	log       zerolog.Logger
+	protocols map[types.Address]Protocol
+	decoders  map[Protocol]map[types.Hash]*decoder
+	handler   MessageHandler
+}
+
+// New creates a new bridge message watcher.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "bridge").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	protocols := make(map[types.Address]Protocol, len(parameters.opStackBridges)+len(parameters.arbitrumBridges))
+	for _, address := range parameters.opStackBridges {
+		protocols[address] = OPStack
+	}
+	for _, address := range parameters.arbitrumBridges {
+		protocols[address] = Arbitrum
+	}
+
+	return &Service{
+		log:       log,
+		protocols: protocols,
+		decoders: map[Protocol]map[types.Hash]*decoder{
+			OPStack:  filterDecoders(opStackDecoders, parameters.includeDeposits, parameters.includeWithdraws),
+			Arbitrum: filterDecoders(arbitrumDecoders, parameters.includeDeposits, parameters.includeWithdraws),
+		},
+		handler: parameters.handler,
+	}, nil
+}
+
+// filterDecoders returns the decoders for the included directions.
+func filterDecoders(decoders map[types.Hash]*decoder, deposits bool, withdrawals bool) map[types.Hash]*decoder {
+	res := make(map[types.Hash]*decoder, len(decoders))
+	for topic, decoder := range decoders {
+		if (decoder.direction == Deposit && deposits) || (decoder.direction == Withdrawal && withdrawals) {
+			res[topic] = decoder
+		}
+	}
+
+	return res
+}
+
+// Trigger returns the event trigger that feeds the watcher.
+func (s *Service) Trigger(name string, earliestBlock uint64) *handlers.EventTrigger {
+	return &handlers.EventTrigger{
+		Name:          name,
+		SourceSet:     s,
+		TopicSets:     []handlers.TopicSet{s},
+		EarliestBlock: earliestBlock,
+		Handler:       s,
+	}
+}
+
+// Contains returns true if the address is a watched bridge contract.
+func (s *Service) Contains(address types.Address) bool {
+	_, exists := s.protocols[address]
+
+	return exists
+}
+
+// Addresses returns the watched bridge contracts.
+func (s *Service) Addresses() []types.Address {
+	res := make([]types.Address, 0, len(s.protocols))
+	for address := range s.protocols {
+		res = append(res, address)
+	}
+
+	return res
+}
+
+// ContainsTopic returns true if the topic is that of a delivered bridge event.
+func (s *Service) ContainsTopic(topic types.Hash) bool {
+	for _, decoders := range s.decoders {
+		if _, exists := decoders[topic]; exists {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HandleEvent handles an event, delivering it as a cross-domain message if it is a bridge event.
+func (s *Service) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, _ *handlers.EventTrigger) error {
+	protocol, exists := s.protocols[event.Address]
+	if !exists || len(event.Topics) == 0 {
+		return nil
+	}
+	decoder, exists := s.decoders[protocol][event.Topics[0]]
+	if !exists || len(event.Topics) != decoder.topics {
+		return nil
+	}
+
+	msg := &Message{
+		Protocol:        protocol,
+		Direction:       decoder.direction,
+		Event:           decoder.event,
+		Bridge:          event.Address,
+		TransactionHash: event.TransactionHash,
+		Block:           uint64(event.BlockNumber),
+		LogIndex:        event.Index,
+	}
+	if err := decoder.decode(msg, event); err != nil {
+		// Malformed events cannot be delivered, but should not halt the listener.
+		s.log.Debug().Stringer("tx", event.TransactionHash).Uint32("index", event.Index).Err(err).Msg("Failed to decode bridge event")
+
+		return nil
+	}
+	s.log.Trace().Stringer("tx", msg.TransactionHash).Str("protocol", string(protocol)).Str("event", msg.Event).Msg("Bridge message")
+
+	return s.handler.HandleBridgeMessage(ctx, msg)
+}
@@ -0,0 +1,81 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canary
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/wealdtech/go-eth-listener/services/metrics"
+)
+
+var metricsNamespace = "eth_listener"
+
+var (
+	comparisonsMetric *prometheus.CounterVec
+	divergencesMetric *prometheus.CounterVec
+)
+
+func registerMetrics(_ context.Context, monitor metrics.Service) error {
+	if comparisonsMetric != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics()
+	}
+
+	return nil
+}
+
+func registerPrometheusMetrics() error {
+	comparisonsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "canary",
+		Name:      "comparisons_total",
+		Help:      "The number of ranges of blocks compared between providers, by trigger and outcome.",
+	}, []string{"trigger", "outcome"})
+	if err := prometheus.Register(comparisonsMetric); err != nil {
+		return errors.Join(errors.New("failed to register canary comparisons"), err)
+	}
+
+	divergencesMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "canary",
+		Name:      "divergences_total",
+		Help:      "The number of events that diverged between providers, by trigger and type.",
+	}, []string{"trigger", "type"})
+	if err := prometheus.Register(divergencesMetric); err != nil {
+		return errors.Join(errors.New("failed to register canary divergences"), err)
+	}
+
+	return nil
+}
+
+func monitorComparison(trigger string, outcome string) {
+	if comparisonsMetric != nil {
+		comparisonsMetric.WithLabelValues(trigger, outcome).Inc()
+	}
+}
+
+func monitorDivergence(trigger string, divergenceType DivergenceType) {
+	if divergencesMetric != nil {
+		divergencesMetric.WithLabelValues(trigger, string(divergenceType)).Inc()
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canary
+
+import (
+	"errors"
+
+	execclient "github.com/attestantio/go-execution-client"
+	"github.com/rs/zerolog"
+	"github.com/wealdtech/go-eth-listener/handlers"
+	"github.com/wealdtech/go-eth-listener/services/metrics"
+	nullmetrics "github.com/wealdtech/go-eth-listener/services/metrics/null"
+)
+
+type parameters struct {
+	logLevel  zerolog.Level
+	monitor   metrics.Service
+	primary   execclient.EventsProvider
+	secondary execclient.EventsProvider
+	trigger   *handlers.EventTrigger
+	blocks    uint32
+	handler   DivergenceHandler
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the metrics monitor.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithPrimary sets the primary provider of events.
+func WithPrimary(provider execclient.EventsProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.primary = provider
+	})
+}
+
+// WithSecondary sets the secondary provider of events, which is compared with the primary.
+func WithSecondary(provider execclient.EventsProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.secondary = provider
+	})
+}
+
+// WithEventTrigger sets the event trigger whose filters are used to obtain events from both providers.
+// Its handler and progress fields are ignored.
+func WithEventTrigger(trigger *handlers.EventTrigger) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.trigger = trigger
+	})
+}
+
+// WithBlocks sets the number of blocks compared in each request to the providers.
+func WithBlocks(blocks uint32) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.blocks = blocks
+	})
+}
+
+// WithHandler sets the handler for divergences.
+func WithHandler(handler DivergenceHandler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.handler = handler
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+		monitor:  nullmetrics.New(),
+		blocks:   1,
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.primary == nil {
+		return nil, errors.New("no primary provider specified")
+	}
+	if parameters.secondary == nil {
+		return nil, errors.New("no secondary provider specified")
+	}
+	if parameters.trigger == nil {
+		return nil, errors.New("no event trigger specified")
+	}
+	if parameters.blocks == 0 {
+		return nil, errors.New("blocks must be at least 1")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,316 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package canary provides a diagnostic handler that obtains the events for an event trigger from
+// two providers and reports divergences between them, to detect unreliable providers with real workloads.
+package canary
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+
+	execclient "github.com/attestantio/go-execution-client"
+	"github.com/attestantio/go-execution-client/api"
+	"github.com/attestantio/go-execution-client/spec"
+	executil "github.com/attestantio/go-execution-client/util"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// DivergenceType is the type of a divergence between providers.
+type DivergenceType string
+
+const (
+	// MissingFromPrimary is an event returned by the secondary provider but not the primary.
+	MissingFromPrimary DivergenceType = "missing_primary"
+	// MissingFromSecondary is an event returned by the primary provider but not the secondary.
+	MissingFromSecondary DivergenceType = "missing_secondary"
+	// DifferingBlockHash is an event returned by both providers in blocks with different hashes.
+	DifferingBlockHash DivergenceType = "block_hash"
+	// DifferingContent is an event returned by both providers with different transactions, addresses, topics or data.
+	DifferingContent DivergenceType = "content"
+)
+
+// Divergence is a divergence between the events returned by the providers.
+type Divergence struct {
+	// Type is the type of the divergence.
+	Type DivergenceType
+	// Block is the number of the block containing the event.
+	Block uint64
+	// LogIndex is the index of the event in the block.
+	LogIndex uint32
+	// Primary is the event returned by the primary provider, if any.
+	Primary *spec.BerlinTransactionEvent
+	// Secondary is the event returned by the secondary provider, if any.
+	Secondary *spec.BerlinTransactionEvent
+}
+
+// DivergenceHandler defines the methods that need to be implemented to handle divergences.
+type DivergenceHandler interface {
+	// HandleDivergence handles a divergence between providers.
+	HandleDivergence(ctx context.Context, divergence *Divergence)
+}
+
+// eventKey identifies an event within the chain.
+type eventKey struct {
+	block    uint64
+	logIndex uint32
+}
+
+// Service compares the events for a trigger between providers.
+// It implements the block handler interface.
+//
+// Blocks are compared in ranges as the block trigger advances, so divergences are only reported for
+// blocks that the listener has reached.  Failure to obtain events from a provider is recorded but
+// does not stop the trigger, so the canary does not stall on the unreliable providers it is detecting.
+type Service struct {
+	log       zerolog.Logger
+	primary   execclient.EventsProvider
+	secondary execclient.EventsProvider
+	trigger   *handlers.EventTrigger
+	blocks    uint32
+	handler   DivergenceHandler
+	from      uint64
+	started   bool
+}
+
+// New creates a new canary handler.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "canary").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.Join(errors.New("failed to register metrics"), err)
+	}
+
+	return &Service{
+		log:       log,
+		primary:   parameters.primary,
+		secondary: parameters.secondary,
+		trigger:   parameters.trigger,
+		blocks:    parameters.blocks,
+		handler:   parameters.handler,
+	}, nil
+}
+
+// Trigger returns a block trigger that compares the providers as the chain advances.
+func (s *Service) Trigger(name string, earliestBlock uint64) *handlers.BlockTrigger {
+	return &handlers.BlockTrigger{
+		Name:          name,
+		EarliestBlock: earliestBlock,
+		Handler:       s,
+	}
+}
+
+// HandleBlock compares the providers once a full range of blocks has been reached.
+func (s *Service) HandleBlock(ctx context.Context, block *spec.Block, trigger *handlers.BlockTrigger) error {
+	height := uint64(block.Number())
+	if !s.started {
+		s.from = height
+		s.started = true
+	}
+	if height < s.from {
+		// Already compared.
+		return nil
+	}
+	if height-s.from+1 < uint64(s.blocks) {
+		return nil
+	}
+
+	if err := s.compare(ctx, trigger.Name, s.from, height); err != nil {
+		s.log.Warn().Uint64("from", s.from).Uint64("to", height).Err(err).Msg("Failed to compare providers")
+		monitorComparison(trigger.Name, "failed")
+	}
+	s.from = height + 1
+
+	return nil
+}
+
+// compare compares the events from the providers in the given range.
+func (s *Service) compare(ctx context.Context, trigger string, from uint64, to uint64) error {
+	filter, err := s.filter(ctx, from, to)
+	if err != nil {
+		return err
+	}
+
+	primaryEvents, err := s.events(ctx, s.primary, filter)
+	if err != nil {
+		return errors.Join(errors.New("failed to obtain events from primary provider"), err)
+	}
+	secondaryEvents, err := s.events(ctx, s.secondary, filter)
+	if err != nil {
+		return errors.Join(errors.New("failed to obtain events from secondary provider"), err)
+	}
+
+	divergences := diverge(primaryEvents, secondaryEvents)
+	if len(divergences) == 0 {
+		s.log.Trace().Uint64("from", from).Uint64("to", to).Int("events", len(primaryEvents)).Msg("Providers agree")
+		monitorComparison(trigger, "match")
+
+		return nil
+	}
+
+	monitorComparison(trigger, "diverged")
+	for _, divergence := range divergences {
+		s.log.Warn().
+			Str("type", string(divergence.Type)).
+			Uint64("block", divergence.Block).
+			Uint32("log_index", divergence.LogIndex).
+			Msg("Providers diverged")
+		monitorDivergence(trigger, divergence.Type)
+		if s.handler != nil {
+			s.handler.HandleDivergence(ctx, divergence)
+		}
+	}
+
+	return nil
+}
+
+// filter returns the events filter for the trigger in the given range.
+func (s *Service) filter(ctx context.Context, from uint64, to uint64) (*api.EventsFilter, error) {
+	filter := &api.EventsFilter{
+		FromBlock: executil.MarshalUint64(from),
+		ToBlock:   executil.MarshalUint64(to),
+		Topics:    s.trigger.Topics,
+	}
+	switch {
+	case s.trigger.SourceResolver != nil:
+		source, err := s.trigger.SourceResolver.Resolve(ctx)
+		if err != nil {
+			return nil, errors.Join(errors.New("failed to resolve source"), err)
+		}
+		filter.Address = source
+	case s.trigger.Source != nil:
+		filter.Address = s.trigger.Source
+	}
+
+	return filter, nil
+}
+
+// events returns the events from a provider that match the trigger.
+func (s *Service) events(ctx context.Context,
+	provider execclient.EventsProvider,
+	filter *api.EventsFilter,
+) (
+	map[eventKey]*spec.BerlinTransactionEvent,
+	error,
+) {
+	events, err := provider.Events(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[eventKey]*spec.BerlinTransactionEvent, len(events))
+	for _, event := range events {
+		if !s.matches(event) {
+			continue
+		}
+		res[eventKey{block: uint64(event.BlockNumber), logIndex: event.Index}] = event
+	}
+
+	return res, nil
+}
+
+// matches returns true if the event matches the trigger's address and topic sets.
+func (s *Service) matches(event *spec.BerlinTransactionEvent) bool {
+	if event.Removed {
+		return false
+	}
+	if s.trigger.SourceSet != nil && !s.trigger.SourceSet.Contains(event.Address) {
+		return false
+	}
+	for i, topicSet := range s.trigger.TopicSets {
+		if topicSet == nil {
+			continue
+		}
+		if i >= len(event.Topics) || !topicSet.ContainsTopic(event.Topics[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// diverge returns the divergences between the events of the providers.
+func diverge(primary map[eventKey]*spec.BerlinTransactionEvent,
+	secondary map[eventKey]*spec.BerlinTransactionEvent,
+) []*Divergence {
+	divergences := make([]*Divergence, 0)
+	for key, primaryEvent := range primary {
+		divergence := &Divergence{
+			Block:     key.block,
+			LogIndex:  key.logIndex,
+			Primary:   primaryEvent,
+			Secondary: secondary[key],
+		}
+		switch {
+		case divergence.Secondary == nil:
+			divergence.Type = MissingFromSecondary
+		case primaryEvent.BlockHash != divergence.Secondary.BlockHash:
+			divergence.Type = DifferingBlockHash
+		case !sameContent(primaryEvent, divergence.Secondary):
+			divergence.Type = DifferingContent
+		default:
+			continue
+		}
+		divergences = append(divergences, divergence)
+	}
+	for key, secondaryEvent := range secondary {
+		if _, exists := primary[key]; !exists {
+			divergences = append(divergences, &Divergence{
+				Type:      MissingFromPrimary,
+				Block:     key.block,
+				LogIndex:  key.logIndex,
+				Secondary: secondaryEvent,
+			})
+		}
+	}
+	sort.Slice(divergences, func(i, j int) bool {
+		if divergences[i].Block != divergences[j].Block {
+			return divergences[i].Block < divergences[j].Block
+		}
+
+		return divergences[i].LogIndex < divergences[j].LogIndex
+	})
+
+	return divergences
+}
+
+// sameContent returns true if the events have the same transaction, address, topics and data.
+func sameContent(a *spec.BerlinTransactionEvent, b *spec.BerlinTransactionEvent) bool {
+	if a.TransactionHash != b.TransactionHash ||
+		a.TransactionIndex != b.TransactionIndex ||
+		a.Address != b.Address ||
+		len(a.Topics) != len(b.Topics) ||
+		!bytes.Equal(a.Data, b.Data) {
+		return false
+	}
+	for i := range a.Topics {
+		if a.Topics[i] != b.Topics[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,93 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cloudevents provides CloudEvents 1.0 envelopes for items published by the queue handlers,
+// for integration with consumers such as Knative and EventBridge that expect CloudEvents.
+package cloudevents
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// SpecVersion is the version of the CloudEvents specification to which envelopes conform.
+const SpecVersion = "1.0"
+
+// ContentType is the content type of envelopes in structured mode.
+const ContentType = "application/cloudevents+json"
+
+// TypePrefix is the prefix of the type attribute of envelopes, to which the item type is appended.
+const TypePrefix = "com.wealdtech.ethlistener."
+
+// Envelope is a CloudEvents envelope in structured JSON mode.
+type Envelope struct {
+	// SpecVersion is the version of the CloudEvents specification.
+	SpecVersion string `json:"specversion"`
+	// ID is the identifier of the event, unique within the source.
+	ID string `json:"id"`
+	// Source identifies the context in which the event occurred.
+	Source string `json:"source"`
+	// Type is the type of the event, for example com.wealdtech.ethlistener.event.
+	Type string `json:"type"`
+	// Subject is the subject of the event within the source.
+	Subject string `json:"subject,omitempty"`
+	// Time is the time of the event, being the timestamp of the block of the item.
+	Time string `json:"time,omitempty"`
+	// DataContentType is the content type of the data.
+	DataContentType string `json:"datacontenttype"`
+	// Trigger is an extension attribute holding the name of the trigger that delivered the item.
+	Trigger string `json:"trigger"`
+	// Data is the JSON representation of the item.
+	Data json.RawMessage `json:"data"`
+}
+
+// Wrap wraps the JSON representation of an item in an envelope, returning the JSON representation
+// of the envelope.
+// The idempotency key of the item is used as the event ID if the listener supplied one, otherwise
+// the supplied ID is used.  The event time is only set if the listener supplied the block timestamp.
+func Wrap(ctx context.Context,
+	source string,
+	itemType string,
+	trigger string,
+	id string,
+	subject string,
+	data []byte,
+) ([]byte, error) {
+	envelope := &Envelope{
+		SpecVersion:     SpecVersion,
+		ID:              id,
+		Source:          source,
+		Type:            TypePrefix + itemType,
+		Subject:         subject,
+		DataContentType: "application/json",
+		Trigger:         trigger,
+		Data:            data,
+	}
+	if key := handlers.IdempotencyKeyFromContext(ctx); key != "" {
+		envelope.ID = key
+	}
+	if timestamp, exists := handlers.BlockTimestampFromContext(ctx); exists {
+		envelope.Time = timestamp.UTC().Format(time.RFC3339)
+	}
+
+	res, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to marshal envelope"), err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,52 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+
+	"github.com/attestantio/go-execution-client/spec"
+)
+
+// CompositeTrigger is a trigger for a transaction that both matches transaction filters
+// and emits at least one event matching event filters.
+// Composite triggers are processed alongside transaction triggers, and have progress of their own.
+type CompositeTrigger struct {
+	Name string
+	// Namespace is the namespace of the trigger, isolating its progress from triggers in other namespaces.
+	Namespace string
+	// Tx holds the filters that the transaction must match.
+	// Its name, namespace, handler and progress fields are ignored.
+	Tx *TxTrigger
+	// Event holds the filters that at least one event emitted by the transaction must match.
+	// Its name, namespace, handler and progress fields are ignored, and only a static source is supported.
+	Event         *EventTrigger
+	EarliestBlock uint64
+	Handler       CompositeHandler
+	// Labels are arbitrary key/value pairs attached to logs and metrics for the trigger.
+	Labels map[string]string
+	// Priority is the priority of the trigger.  Triggers with higher priority are processed first within a poll.
+	Priority int
+	// DependsOn are the qualified names of triggers that this trigger must not advance past.
+	DependsOn []string
+}
+
+// CompositeHandlerFunc defines the handler function.
+type CompositeHandlerFunc func(ctx context.Context, tx *spec.Transaction, events []*spec.BerlinTransactionEvent, trigger *CompositeTrigger)
+
+// CompositeHandler defines the methods that need to be implemented to handle composite triggers.
+type CompositeHandler interface {
+	// HandleComposite handles a transaction and the events it emitted that match the trigger.
+	HandleComposite(ctx context.Context, tx *spec.Transaction, events []*spec.BerlinTransactionEvent, trigger *CompositeTrigger)
+}
@@ -0,0 +1,118 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+
+	"github.com/attestantio/go-execution-client/types"
+)
+
+type contextKey int
+
+const (
+	confirmationsKey contextKey = iota
+	addressLabelsKey
+	tokenMetadataKey
+	signaturesKey
+	receiptProofKey
+	runInfoKey
+	setCodeKey
+	triggerStateKey
+	idempotencyKey
+	blockTimestampKey
+	decodedEventKey
+)
+
+// WithConfirmations returns a copy of the context containing the confirmation depth of the item being handled.
+func WithConfirmations(ctx context.Context, confirmations uint32) context.Context {
+	return context.WithValue(ctx, confirmationsKey, confirmations)
+}
+
+// ConfirmationsFromContext returns the confirmation depth of the item being handled,
+// being the chain head minus the item's block number.
+// It returns false if the confirmation depth is not present in the context.
+func ConfirmationsFromContext(ctx context.Context) (uint32, bool) {
+	confirmations, ok := ctx.Value(confirmationsKey).(uint32)
+
+	return confirmations, ok
+}
+
+// WithAddressLabels returns a copy of the context containing labels for the addresses in the item being handled.
+func WithAddressLabels(ctx context.Context, labels map[types.Address]*AddressLabel) context.Context {
+	return context.WithValue(ctx, addressLabelsKey, labels)
+}
+
+// AddressLabelsFromContext returns the labels for the addresses in the item being handled.
+// It returns nil if there are no labels in the context.
+func AddressLabelsFromContext(ctx context.Context) map[types.Address]*AddressLabel {
+	labels, _ := ctx.Value(addressLabelsKey).(map[types.Address]*AddressLabel)
+
+	return labels
+}
+
+// WithTokenMetadata returns a copy of the context containing the metadata of the token that emitted the event being handled.
+func WithTokenMetadata(ctx context.Context, metadata *TokenMetadata) context.Context {
+	return context.WithValue(ctx, tokenMetadataKey, metadata)
+}
+
+// TokenMetadataFromContext returns the metadata of the token that emitted the event being handled.
+// It returns nil if the metadata is not present in the context.
+func TokenMetadataFromContext(ctx context.Context) *TokenMetadata {
+	metadata, _ := ctx.Value(tokenMetadataKey).(*TokenMetadata)
+
+	return metadata
+}
+
+// WithSignatures returns a copy of the context containing the probable signatures of the
+// function called by the transaction, or the event, being handled.
+func WithSignatures(ctx context.Context, signatures []string) context.Context {
+	return context.WithValue(ctx, signaturesKey, signatures)
+}
+
+// SignaturesFromContext returns the probable signatures of the function called by the
+// transaction, or the event, being handled.
+// It returns nil if there are no signatures in the context.
+func SignaturesFromContext(ctx context.Context) []string {
+	signatures, _ := ctx.Value(signaturesKey).([]string)
+
+	return signatures
+}
+
+// WithReceiptProof returns a copy of the context containing the proof of inclusion of the
+// receipt containing the event being handled.
+func WithReceiptProof(ctx context.Context, proof *ReceiptProof) context.Context {
+	return context.WithValue(ctx, receiptProofKey, proof)
+}
+
+// ReceiptProofFromContext returns the proof of inclusion of the receipt containing the event being handled.
+// It returns nil if there is no proof in the context.
+func ReceiptProofFromContext(ctx context.Context) *ReceiptProof {
+	proof, _ := ctx.Value(receiptProofKey).(*ReceiptProof)
+
+	return proof
+}
+
+// WithRunInfo returns a copy of the context containing information about the run in which the item is being handled.
+func WithRunInfo(ctx context.Context, info *RunInfo) context.Context {
+	return context.WithValue(ctx, runInfoKey, info)
+}
+
+// RunInfoFromContext returns information about the run in which the item is being handled.
+// It returns nil if there is no run information in the context.
+func RunInfoFromContext(ctx context.Context) *RunInfo {
+	info, _ := ctx.Value(runInfoKey).(*RunInfo)
+
+	return info
+}
@@ -0,0 +1,54 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+
+	"github.com/attestantio/go-execution-client/spec"
+)
+
+// CorrelatedBlock is a beacon block combined with the execution block that it contains.
+type CorrelatedBlock struct {
+	// Beacon is the beacon block, or missed slot.
+	Beacon *BeaconBlock
+	// Execution is the execution block contained in the beacon block, or nil if the slot
+	// was missed or the block is from before the merge.
+	Execution *spec.Block
+}
+
+// CorrelationTrigger is a trigger for beacon blocks correlated with their execution blocks.
+type CorrelationTrigger struct {
+	Name string
+	// Namespace is the namespace of the trigger, isolating its progress from triggers in other namespaces.
+	Namespace    string
+	EarliestSlot uint64
+	// Proposers is a set of validators, one of which must be the proposer of the block.
+	Proposers ValidatorSet
+	// IncludeMissed passes missed slots to the handler as well as blocks.
+	IncludeMissed bool
+	Handler       CorrelationHandler
+	// Labels are arbitrary key/value pairs attached to logs and metrics for the trigger.
+	Labels map[string]string
+}
+
+// CorrelationHandlerFunc defines the handler function.
+type CorrelationHandlerFunc func(ctx context.Context, block *CorrelatedBlock, trigger *CorrelationTrigger) error
+
+// CorrelationHandler defines the methods that need to be implemented to handle correlated blocks.
+type CorrelationHandler interface {
+	// HandleCorrelatedBlock handles a beacon block and its execution block.
+	// If this call returns an error then the listener will start again with this block on the next poll.
+	HandleCorrelatedBlock(ctx context.Context, block *CorrelatedBlock, trigger *CorrelationTrigger) error
+}
@@ -0,0 +1,52 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+
+	"github.com/attestantio/go-execution-client/spec"
+)
+
+// DecodedEvent is an event decoded with its ABI.
+type DecodedEvent struct {
+	// Name is the name of the event, for example "Transfer".
+	Name string
+	// Signature is the signature of the event, for example "Transfer(address,address,uint256)".
+	Signature string
+	// Args are the arguments of the event by name, with values as decoded by abi.Event.Decode().
+	Args map[string]any
+}
+
+// DecodedEventHandler defines the methods that need to be implemented to handle decoded events.
+// An event handler that also implements this interface is passed events that can be decoded with
+// the ABIs supplied to the listener or the trigger here rather than to HandleEvent().
+type DecodedEventHandler interface {
+	// HandleDecodedEvent handles a decoded event provided by the listener.
+	// Errors are treated in the same way as those returned by HandleEvent().
+	HandleDecodedEvent(ctx context.Context, event *spec.BerlinTransactionEvent, decoded *DecodedEvent, trigger *EventTrigger) error
+}
+
+// WithDecodedEvent returns a copy of the context containing the decoded event being handled.
+func WithDecodedEvent(ctx context.Context, decoded *DecodedEvent) context.Context {
+	return context.WithValue(ctx, decodedEventKey, decoded)
+}
+
+// DecodedEventFromContext returns the decoded event being handled.
+// It returns nil if the event has not been decoded.
+func DecodedEventFromContext(ctx context.Context) *DecodedEvent {
+	decoded, _ := ctx.Value(decodedEventKey).(*DecodedEvent)
+
+	return decoded
+}
@@ -0,0 +1,216 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dex
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/wealdtech/go-eth-listener/abi"
+)
+
+var (
+	// v2SwapTopic is the topic of the Uniswap v2 Swap event.
+	v2SwapTopic = abi.EventTopic("Swap(address,uint256,uint256,uint256,uint256,address)")
+	// v2MintTopic is the topic of the Uniswap v2 Mint event.
+	v2MintTopic = abi.EventTopic("Mint(address,uint256,uint256)")
+	// v2BurnTopic is the topic of the Uniswap v2 Burn event.
+	v2BurnTopic = abi.EventTopic("Burn(address,uint256,uint256,address)")
+	// v3SwapTopic is the topic of the Uniswap v3 Swap event.
+	v3SwapTopic = abi.EventTopic("Swap(address,address,int256,int256,uint160,uint128,int24)")
+	// v3MintTopic is the topic of the Uniswap v3 Mint event.
+	v3MintTopic = abi.EventTopic("Mint(address,address,int24,int24,uint128,uint256,uint256)")
+	// v3BurnTopic is the topic of the Uniswap v3 Burn event.
+	v3BurnTopic = abi.EventTopic("Burn(address,int24,int24,uint128,uint256,uint256)")
+)
+
+// q96 is 2^96, the scale of Uniswap v3 square root prices.
+var q96 = new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), 96))
+
+// pricePrecision is the precision of calculated prices.
+const pricePrecision = 256
+
+// decodeV2Swap decodes a Uniswap v2 Swap event:
+// (address indexed sender, uint256 amount0In, uint256 amount1In, uint256 amount0Out, uint256 amount1Out, address indexed to).
+func decodeV2Swap(swap *Swap, event *spec.BerlinTransactionEvent) error {
+	if len(event.Topics) != 3 {
+		return errors.New("unexpected number of topics")
+	}
+	amounts := make([]*big.Int, 4)
+	for i := range amounts {
+		var err error
+		if amounts[i], err = abi.Uint(event.Data, i); err != nil {
+			return err
+		}
+	}
+	swap.Version = V2
+	swap.Sender = abi.TopicAddress(event.Topics[1])
+	swap.Recipient = abi.TopicAddress(event.Topics[2])
+	swap.Amount0 = new(big.Int).Sub(amounts[0], amounts[2])
+	swap.Amount1 = new(big.Int).Sub(amounts[1], amounts[3])
+	swap.Price = ratio(swap.Amount1, swap.Amount0)
+
+	return nil
+}
+
+// decodeV3Swap decodes a Uniswap v3 Swap event:
+// (address indexed sender, address indexed recipient, int256 amount0, int256 amount1,
+// uint160 sqrtPriceX96, uint128 liquidity, int24 tick).
+func decodeV3Swap(swap *Swap, event *spec.BerlinTransactionEvent) error {
+	if len(event.Topics) != 3 {
+		return errors.New("unexpected number of topics")
+	}
+	var err error
+	swap.Version = V3
+	swap.Sender = abi.TopicAddress(event.Topics[1])
+	swap.Recipient = abi.TopicAddress(event.Topics[2])
+	if swap.Amount0, err = abi.Int(event.Data, 0); err != nil {
+		return err
+	}
+	if swap.Amount1, err = abi.Int(event.Data, 1); err != nil {
+		return err
+	}
+	if swap.SqrtPriceX96, err = abi.Uint(event.Data, 2); err != nil {
+		return err
+	}
+	if swap.Liquidity, err = abi.Uint(event.Data, 3); err != nil {
+		return err
+	}
+	tick, err := abi.Int(event.Data, 4)
+	if err != nil {
+		return err
+	}
+	if swap.Tick, err = int24(tick); err != nil {
+		return err
+	}
+	swap.Price = sqrtPriceX96ToPrice(swap.SqrtPriceX96)
+
+	return nil
+}
+
+// decodeV2Liquidity decodes Uniswap v2 Mint and Burn events:
+// (address indexed sender, uint256 amount0, uint256 amount1) and
+// (address indexed sender, uint256 amount0, uint256 amount1, address indexed to).
+func decodeV2Liquidity(change *LiquidityChange, event *spec.BerlinTransactionEvent) error {
+	switch {
+	case change.Type == Mint && len(event.Topics) == 2:
+	case change.Type == Burn && len(event.Topics) == 3:
+		change.Owner = abi.TopicAddress(event.Topics[2])
+	default:
+		return errors.New("unexpected number of topics")
+	}
+	var err error
+	change.Version = V2
+	change.Sender = abi.TopicAddress(event.Topics[1])
+	if change.Amount0, err = abi.Uint(event.Data, 0); err != nil {
+		return err
+	}
+	if change.Amount1, err = abi.Uint(event.Data, 1); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// decodeV3Mint decodes a Uniswap v3 Mint event:
+// (address sender, address indexed owner, int24 indexed tickLower, int24 indexed tickUpper,
+// uint128 amount, uint256 amount0, uint256 amount1).
+func decodeV3Mint(change *LiquidityChange, event *spec.BerlinTransactionEvent) error {
+	if len(event.Topics) != 4 {
+		return errors.New("unexpected number of topics")
+	}
+	var err error
+	if change.Sender, err = abi.Address(event.Data, 0); err != nil {
+		return err
+	}
+	change.Owner = abi.TopicAddress(event.Topics[1])
+
+	return decodeV3Position(change, event, event.Topics[2], event.Topics[3], 1)
+}
+
+// decodeV3Burn decodes a Uniswap v3 Burn event:
+// (address indexed owner, int24 indexed tickLower, int24 indexed tickUpper,
+// uint128 amount, uint256 amount0, uint256 amount1).
+func decodeV3Burn(change *LiquidityChange, event *spec.BerlinTransactionEvent) error {
+	if len(event.Topics) != 4 {
+		return errors.New("unexpected number of topics")
+	}
+	change.Owner = abi.TopicAddress(event.Topics[1])
+	change.Sender = change.Owner
+
+	return decodeV3Position(change, event, event.Topics[2], event.Topics[3], 0)
+}
+
+// decodeV3Position decodes the position common to Uniswap v3 Mint and Burn events,
+// with the liquidity amount at the given word of the data.
+func decodeV3Position(change *LiquidityChange,
+	event *spec.BerlinTransactionEvent,
+	tickLower types.Hash,
+	tickUpper types.Hash,
+	index int,
+) error {
+	var err error
+	change.Version = V3
+	if change.TickLower, err = int24(abi.TopicInt(tickLower)); err != nil {
+		return err
+	}
+	if change.TickUpper, err = int24(abi.TopicInt(tickUpper)); err != nil {
+		return err
+	}
+	if change.Liquidity, err = abi.Uint(event.Data, index); err != nil {
+		return err
+	}
+	if change.Amount0, err = abi.Uint(event.Data, index+1); err != nil {
+		return err
+	}
+	if change.Amount1, err = abi.Uint(event.Data, index+2); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// int24 returns the value as a tick, ensuring that it is in range.
+func int24(value *big.Int) (*int32, error) {
+	if !value.IsInt64() || value.Int64() < -(1<<23) || value.Int64() >= 1<<23 {
+		return nil, errors.New("tick out of range")
+	}
+	tick := int32(value.Int64())
+
+	return &tick, nil
+}
+
+// ratio returns the absolute ratio of numerator to denominator, or nil if either is zero.
+func ratio(numerator *big.Int, denominator *big.Int) *big.Float {
+	if numerator.Sign() == 0 || denominator.Sign() == 0 {
+		return nil
+	}
+	num := new(big.Float).SetPrec(pricePrecision).SetInt(new(big.Int).Abs(numerator))
+	den := new(big.Float).SetPrec(pricePrecision).SetInt(new(big.Int).Abs(denominator))
+
+	return num.Quo(num, den)
+}
+
+// sqrtPriceX96ToPrice converts a Uniswap v3 square root price to a price.
+func sqrtPriceX96ToPrice(sqrtPriceX96 *big.Int) *big.Float {
+	if sqrtPriceX96.Sign() == 0 {
+		return nil
+	}
+	sqrtPrice := new(big.Float).SetPrec(pricePrecision).SetInt(sqrtPriceX96)
+	sqrtPrice.Quo(sqrtPrice, q96)
+
+	return sqrtPrice.Mul(sqrtPrice, sqrtPrice)
+}
@@ -0,0 +1,88 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dex
+
+import (
+	"errors"
+
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel         zerolog.Level
+	pools            []types.Address
+	swapHandler      SwapHandler
+	liquidityHandler LiquidityHandler
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithPools sets the Uniswap v2 or v3 pools, or their forks, to watch.
+func WithPools(pools []types.Address) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.pools = pools
+	})
+}
+
+// WithSwapHandler sets the handler for swaps.
+func WithSwapHandler(handler SwapHandler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.swapHandler = handler
+	})
+}
+
+// WithLiquidityHandler sets the handler for liquidity changes.
+func WithLiquidityHandler(handler LiquidityHandler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.liquidityHandler = handler
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if len(parameters.pools) == 0 {
+		return nil, errors.New("no pools specified")
+	}
+	if parameters.swapHandler == nil && parameters.liquidityHandler == nil {
+		return nil, errors.New("no handler specified")
+	}
+
+	return &parameters, nil
+}
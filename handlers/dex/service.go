@@ -0,0 +1,256 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dex provides triggers that deliver typed swaps and liquidity changes from
+// Uniswap v2 and v3 pools, and their forks.
+package dex
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// Version is the version of a Uniswap pool.
+type Version string
+
+const (
+	// V2 is a Uniswap v2 pool.
+	V2 Version = "v2"
+	// V3 is a Uniswap v3 pool.
+	V3 Version = "v3"
+)
+
+// LiquidityChangeType is the type of a liquidity change.
+type LiquidityChangeType string
+
+const (
+	// Mint is the addition of liquidity.
+	Mint LiquidityChangeType = "mint"
+	// Burn is the removal of liquidity.
+	Burn LiquidityChangeType = "burn"
+)
+
+// Swap is a swap in a pool.
+type Swap struct {
+	// Version is the version of the pool.
+	Version Version
+	// Pool is the pool in which the swap took place.
+	Pool types.Address
+	// Sender is the address that initiated the swap.
+	Sender types.Address
+	// Recipient is the address that received the output of the swap.
+	Recipient types.Address
+	// Amount0 is the net change in the pool's balance of token0, in its smallest unit;
+	// positive if the pool received token0.
+	Amount0 *big.Int
+	// Amount1 is the net change in the pool's balance of token1, in its smallest unit;
+	// positive if the pool received token1.
+	Amount1 *big.Int
+	// Price is the price of token0 in token1, in their smallest units, or nil if it cannot be calculated.
+	// For v2 pools this is the execution price of the swap; for v3 pools it is the pool price after the swap.
+	Price *big.Float
+	// SqrtPriceX96 is the square root of the pool price after the swap, as a Q64.96 value, for v3 pools.
+	SqrtPriceX96 *big.Int
+	// Liquidity is the in-range liquidity of the pool after the swap, for v3 pools.
+	Liquidity *big.Int
+	// Tick is the tick of the pool after the swap, for v3 pools.
+	Tick *int32
+	// TransactionHash is the hash of the transaction containing the swap.
+	TransactionHash types.Hash
+	// Block is the block containing the swap.
+	Block uint64
+	// LogIndex is the index of the swap event in the block.
+	LogIndex uint32
+}
+
+// LiquidityChange is the addition or removal of liquidity in a pool.
+type LiquidityChange struct {
+	// Version is the version of the pool.
+	Version Version
+	// Type is the type of the change.
+	Type LiquidityChangeType
+	// Pool is the pool in which the change took place.
+	Pool types.Address
+	// Sender is the address that initiated the change.
+	Sender types.Address
+	// Owner is the owner of the position for v3 pools, or the recipient of the tokens for v2 burns.
+	Owner types.Address
+	// Amount0 is the amount of token0 added or removed, in its smallest unit.
+	Amount0 *big.Int
+	// Amount1 is the amount of token1 added or removed, in its smallest unit.
+	Amount1 *big.Int
+	// Liquidity is the liquidity added or removed, for v3 pools.
+	Liquidity *big.Int
+	// TickLower is the lower tick of the position, for v3 pools.
+	TickLower *int32
+	// TickUpper is the upper tick of the position, for v3 pools.
+	TickUpper *int32
+	// TransactionHash is the hash of the transaction containing the change.
+	TransactionHash types.Hash
+	// Block is the block containing the change.
+	Block uint64
+	// LogIndex is the index of the change event in the block.
+	LogIndex uint32
+}
+
+// SwapHandler defines the methods that need to be implemented to handle swaps.
+type SwapHandler interface {
+	// HandleSwap handles a swap.
+	HandleSwap(ctx context.Context, swap *Swap) error
+}
+
+// LiquidityHandler defines the methods that need to be implemented to handle liquidity changes.
+type LiquidityHandler interface {
+	// HandleLiquidityChange handles a liquidity change.
+	HandleLiquidityChange(ctx context.Context, change *LiquidityChange) error
+}
+
+// swapDecoders are the decoders for swap events, keyed by topic.
+var swapDecoders = map[types.Hash]func(*Swap, *spec.BerlinTransactionEvent) error{
+	v2SwapTopic: decodeV2Swap,
+	v3SwapTopic: decodeV3Swap,
+}
+
+// liquidityDecoder decodes a liquidity change event.
+type liquidityDecoder struct {
+	changeType LiquidityChangeType
+	decode     func(*LiquidityChange, *spec.BerlinTransactionEvent) error
+}
+
+// liquidityDecoders are the decoders for liquidity change events, keyed by topic.
+var liquidityDecoders = map[types.Hash]*liquidityDecoder{
+	v2MintTopic: {changeType: Mint, decode: decodeV2Liquidity},
+	v2BurnTopic: {changeType: Burn, decode: decodeV2Liquidity},
+	v3MintTopic: {changeType: Mint, decode: decodeV3Mint},
+	v3BurnTopic: {changeType: Burn, decode: decodeV3Burn},
+}
+
+// Service delivers swaps and liquidity changes.
+type Service struct {
+	log              zerolog.Logger
+	pools            map[types.Address]struct{}
+	swapHandler      SwapHandler
+	liquidityHandler LiquidityHandler
+}
+
+// New creates a new DEX event decoder.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "dex").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	pools := make(map[types.Address]struct{}, len(parameters.pools))
+	for _, pool := range parameters.pools {
+		pools[pool] = struct{}{}
+	}
+
+	return &Service{
+		log:              log,
+		pools:            pools,
+		swapHandler:      parameters.swapHandler,
+		liquidityHandler: parameters.liquidityHandler,
+	}, nil
+}
+
+// Trigger returns the event trigger that feeds the decoder.
+func (s *Service) Trigger(name string, earliestBlock uint64) *handlers.EventTrigger {
+	return &handlers.EventTrigger{
+		Name:          name,
+		SourceSet:     s,
+		TopicSets:     []handlers.TopicSet{s},
+		EarliestBlock: earliestBlock,
+		Handler:       s,
+	}
+}
+
+// Contains returns true if the address is a watched pool.
+func (s *Service) Contains(address types.Address) bool {
+	_, exists := s.pools[address]
+
+	return exists
+}
+
+// Addresses returns the watched pools.
+func (s *Service) Addresses() []types.Address {
+	res := make([]types.Address, 0, len(s.pools))
+	for address := range s.pools {
+		res = append(res, address)
+	}
+
+	return res
+}
+
+// ContainsTopic returns true if the topic is that of an event for which there is a handler.
+func (s *Service) ContainsTopic(topic types.Hash) bool {
+	if _, exists := swapDecoders[topic]; exists {
+		return s.swapHandler != nil
+	}
+	if _, exists := liquidityDecoders[topic]; exists {
+		return s.liquidityHandler != nil
+	}
+
+	return false
+}
+
+// HandleEvent handles an event, delivering it as a swap or liquidity change.
+func (s *Service) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, _ *handlers.EventTrigger) error {
+	if !s.Contains(event.Address) || len(event.Topics) == 0 || !s.ContainsTopic(event.Topics[0]) {
+		return nil
+	}
+
+	if decode, exists := swapDecoders[event.Topics[0]]; exists {
+		swap := &Swap{
+			Pool:            event.Address,
+			TransactionHash: event.TransactionHash,
+			Block:           uint64(event.BlockNumber),
+			LogIndex:        event.Index,
+		}
+		if err := decode(swap, event); err != nil {
+			s.log.Debug().Stringer("tx", event.TransactionHash).Uint32("index", event.Index).Err(err).Msg("Failed to decode swap")
+
+			return nil
+		}
+
+		return s.swapHandler.HandleSwap(ctx, swap)
+	}
+
+	decoder := liquidityDecoders[event.Topics[0]]
+	change := &LiquidityChange{
+		Type:            decoder.changeType,
+		Pool:            event.Address,
+		TransactionHash: event.TransactionHash,
+		Block:           uint64(event.BlockNumber),
+		LogIndex:        event.Index,
+	}
+	if err := decoder.decode(change, event); err != nil {
+		s.log.Debug().Stringer("tx", event.TransactionHash).Uint32("index", event.Index).Err(err).Msg("Failed to decode liquidity change")
+
+		return nil
+	}
+
+	return s.liquidityHandler.HandleLiquidityChange(ctx, change)
+}
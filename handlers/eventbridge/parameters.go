@@ -0,0 +1,144 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventbridge
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// maxBatchSize is the maximum number of entries in a PutEvents call permitted by EventBridge.
+const maxBatchSize = 10
+
+type parameters struct {
+	logLevel    zerolog.Level
+	putter      Putter
+	eventBus    string
+	source      string
+	detailTypes map[string]string
+	batchSize   int
+	maxRetries  int
+	retryDelay  time.Duration
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithPutter sets the putter for entries.
+func WithPutter(putter Putter) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.putter = putter
+	})
+}
+
+// WithEventBus sets the name or ARN of the event bus; if not set then the account's default bus is used.
+func WithEventBus(eventBus string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.eventBus = eventBus
+	})
+}
+
+// WithSource sets the source of entries.
+func WithSource(source string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.source = source
+	})
+}
+
+// WithDetailTypes sets the detail types of entries for triggers, keyed by trigger name.
+// Triggers without a detail type use their name.
+func WithDetailTypes(detailTypes map[string]string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.detailTypes = detailTypes
+	})
+}
+
+// WithBatchSize sets the number of entries to send in each batch.
+func WithBatchSize(batchSize int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.batchSize = batchSize
+	})
+}
+
+// WithMaxRetries sets the maximum number of retries for failed entries in each flush.
+func WithMaxRetries(maxRetries int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxRetries = maxRetries
+	})
+}
+
+// WithRetryDelay sets the initial delay between retries, which doubles with each retry.
+func WithRetryDelay(retryDelay time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.retryDelay = retryDelay
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:   zerolog.GlobalLevel(),
+		source:     "eth-listener",
+		batchSize:  maxBatchSize,
+		maxRetries: 3,
+		retryDelay: 100 * time.Millisecond,
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.putter == nil {
+		return nil, errors.New("no putter specified")
+	}
+	if parameters.source == "" {
+		return nil, errors.New("no source specified")
+	}
+	if strings.HasPrefix(parameters.source, "aws.") {
+		return nil, errors.New("source cannot start with \"aws.\"")
+	}
+	for trigger, detailType := range parameters.detailTypes {
+		if detailType == "" {
+			return nil, fmt.Errorf("no detail type specified for trigger %s", trigger)
+		}
+	}
+	if parameters.batchSize < 1 || parameters.batchSize > maxBatchSize {
+		return nil, errors.New("batch size must be between 1 and 10")
+	}
+	if parameters.maxRetries < 0 {
+		return nil, errors.New("max retries cannot be negative")
+	}
+
+	return &parameters, nil
+}
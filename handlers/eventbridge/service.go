@@ -0,0 +1,242 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package eventbridge provides handlers that put blocks, transactions and events on to an AWS EventBridge bus,
+// for routing with rules rather than queues.
+package eventbridge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// Entry is an entry to be put on to an event bus.
+type Entry struct {
+	// ID is the identifier of the entry, unique within the chain.
+	// It is not sent to EventBridge, but is used to identify failed entries.
+	ID string
+	// EventBusName is the name or ARN of the event bus, or empty for the default bus.
+	EventBusName string
+	// Source is the source of the entry.
+	Source string
+	// DetailType is the detail type of the entry, which is set per trigger.
+	DetailType string
+	// Detail is the JSON detail of the entry.
+	Detail string
+	// Time is the timestamp of the block of the item, or zero if not known.
+	Time time.Time
+}
+
+// Putter defines the methods that need to be implemented to put entries on to an event bus.
+// It is expected to be a thin adapter around an EventBridge PutEvents call.
+type Putter interface {
+	// PutEvents puts a batch of entries, returning the IDs of any entries that failed.
+	PutEvents(ctx context.Context, entries []*Entry) ([]string, error)
+}
+
+// detail is the JSON detail of an entry.
+type detail struct {
+	Type           string  `json:"type"`
+	Trigger        string  `json:"trigger"`
+	IdempotencyKey string  `json:"idempotency_key,omitempty"`
+	Confirmations  *uint32 `json:"confirmations,omitempty"`
+	Data           any     `json:"data"`
+}
+
+// Service puts items on to an EventBridge bus in batches.
+// It implements the block, transaction and event handler interfaces.
+//
+// Entries are buffered until a full batch is available.  If a batch cannot be put after retries
+// then its entries are retained for the next attempt and the handler returns an error, so the
+// listener will redeliver the current item.  Partial batches are put by Flush, which should be
+// called regularly, for example from a post-poll hook.
+//
+// EventBridge limits entries to 256KB, so block triggers are best combined with
+// transaction or event triggers rather than used to forward full blocks.
+type Service struct {
+	log         zerolog.Logger
+	putter      Putter
+	eventBus    string
+	source      string
+	detailTypes map[string]string
+	batchSize   int
+	maxRetries  int
+	retryDelay  time.Duration
+	mu          sync.Mutex
+	pending     []*Entry
+}
+
+// New creates a new EventBridge handler.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "eventbridge").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	return &Service{
+		log:         log,
+		putter:      parameters.putter,
+		eventBus:    parameters.eventBus,
+		source:      parameters.source,
+		detailTypes: parameters.detailTypes,
+		batchSize:   parameters.batchSize,
+		maxRetries:  parameters.maxRetries,
+		retryDelay:  parameters.retryDelay,
+		pending:     make([]*Entry, 0, parameters.batchSize),
+	}, nil
+}
+
+// HandleBlock puts a block.
+func (s *Service) HandleBlock(ctx context.Context, block *spec.Block, trigger *handlers.BlockTrigger) error {
+	return s.enqueue(ctx, "block", trigger.Name, fmt.Sprintf("%d", block.Number()), block)
+}
+
+// HandleTx puts a transaction.
+func (s *Service) HandleTx(ctx context.Context, tx *spec.Transaction, trigger *handlers.TxTrigger) {
+	if err := s.enqueue(ctx, "transaction", trigger.Name, tx.Hash().String(), tx); err != nil {
+		s.log.Error().Stringer("tx", tx.Hash()).Err(err).Msg("Failed to put transaction")
+	}
+}
+
+// HandleEvent puts an event.
+func (s *Service) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, trigger *handlers.EventTrigger) error {
+	id := fmt.Sprintf("%s-%d", event.TransactionHash.String(), event.Index)
+
+	return s.enqueue(ctx, "event", trigger.Name, id, event)
+}
+
+// Flush puts any buffered entries.
+func (s *Service) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(s.pending) > 0 {
+		if err := s.putBatch(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) enqueue(ctx context.Context,
+	itemType string,
+	trigger string,
+	id string,
+	item any,
+) error {
+	d := &detail{
+		Type:           itemType,
+		Trigger:        trigger,
+		IdempotencyKey: handlers.IdempotencyKeyFromContext(ctx),
+		Data:           item,
+	}
+	if confirmations, exists := handlers.ConfirmationsFromContext(ctx); exists {
+		d.Confirmations = &confirmations
+	}
+	data, err := json.Marshal(d)
+	if err != nil {
+		return errors.Join(errors.New("failed to marshal item"), err)
+	}
+
+	detailType, exists := s.detailTypes[trigger]
+	if !exists {
+		detailType = trigger
+	}
+	entry := &Entry{
+		ID:           fmt.Sprintf("%s-%s-%s", itemType, trigger, id),
+		EventBusName: s.eventBus,
+		Source:       s.source,
+		DetailType:   detailType,
+		Detail:       string(data),
+	}
+	if timestamp, exists := handlers.BlockTimestampFromContext(ctx); exists {
+		entry.Time = timestamp
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending = append(s.pending, entry)
+	if len(s.pending) < s.batchSize {
+		return nil
+	}
+
+	return s.putBatch(ctx)
+}
+
+// putBatch puts the first batch of pending entries, retrying failed entries.
+// Must be called with the lock held.
+func (s *Service) putBatch(ctx context.Context) error {
+	size := s.batchSize
+	if size > len(s.pending) {
+		size = len(s.pending)
+	}
+	batch := s.pending[:size]
+
+	delay := s.retryDelay
+	for attempt := 0; ; attempt++ {
+		failedIDs, err := s.putter.PutEvents(ctx, batch)
+		if err == nil && len(failedIDs) == 0 {
+			s.log.Trace().Int("entries", size).Msg("Put batch")
+			s.pending = s.pending[size:]
+
+			return nil
+		}
+
+		if err == nil {
+			// Retain only the failed entries for retry.
+			failed := make(map[string]struct{}, len(failedIDs))
+			for _, id := range failedIDs {
+				failed[id] = struct{}{}
+			}
+			retry := make([]*Entry, 0, len(failedIDs))
+			for _, entry := range batch {
+				if _, exists := failed[entry.ID]; exists {
+					retry = append(retry, entry)
+				}
+			}
+			s.pending = append(retry, s.pending[size:]...)
+			size = len(retry)
+			batch = retry
+			err = fmt.Errorf("%d entries failed to put", len(retry))
+		}
+
+		if attempt >= s.maxRetries {
+			return errors.Join(errors.New("failed to put batch"), err)
+		}
+		s.log.Debug().Int("attempt", attempt+1).Err(err).Msg("Failed to put batch; retrying")
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		delay *= 2
+	}
+}
@@ -23,13 +23,31 @@ import (
 // EventTrigger is a trigger for an event.
 type EventTrigger struct {
 	Name string
+	// Namespace is the namespace of the trigger, isolating its progress from triggers in other namespaces.
+	Namespace string
 	// Source is a static address to use for event addresses.
 	Source *types.Address
 	// SourceResolver is a dynamic resolver use for event addresses.
 	SourceResolver SourceResolver
-	Topics         []types.Hash
-	EarliestBlock  uint32
-	Handler        EventHandler
+	// SourceSet is a set of addresses, one of which must be the event address.
+	// It is applied in addition to any static or dynamic source.  If there is no such source then
+	// events are obtained separately for each address in the set.
+	SourceSet AddressSet
+	Topics    []types.Hash
+	// TopicSets are positional sets of topics; if a set is present then the event's
+	// topic at the same position must be a member of the set.
+	TopicSets     []TopicSet
+	EarliestBlock uint64
+	Handler       EventHandler
+	// Labels are arbitrary key/value pairs attached to logs and metrics for the trigger.
+	Labels map[string]string
+	// Priority is the priority of the trigger.  Triggers with higher priority are processed first within a poll.
+	Priority int
+	// DependsOn are the qualified names of triggers that this trigger must not advance past.
+	DependsOn []string
+	// ABI is a JSON ABI with which to decode the trigger's events, in addition to any ABIs
+	// supplied to the listener.
+	ABI []byte
 }
 
 // SourceResolver defines the methods that need to be implemented to resolve sources.
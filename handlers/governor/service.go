@@ -0,0 +1,391 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package governor provides triggers that deliver typed proposal lifecycle events from
+// OpenZeppelin Governor contracts.
+package governor
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/abi"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+var (
+	// proposalCreatedTopic is the topic of the ProposalCreated event.
+	proposalCreatedTopic = abi.EventTopic("ProposalCreated(uint256,address,address[],uint256[],string[],bytes[],uint256,uint256,string)")
+	// voteCastTopic is the topic of the VoteCast event.
+	voteCastTopic = abi.EventTopic("VoteCast(address,uint256,uint8,uint256,string)")
+	// voteCastWithParamsTopic is the topic of the VoteCastWithParams event.
+	voteCastWithParamsTopic = abi.EventTopic("VoteCastWithParams(address,uint256,uint8,uint256,string,bytes)")
+	// proposalExecutedTopic is the topic of the ProposalExecuted event.
+	proposalExecutedTopic = abi.EventTopic("ProposalExecuted(uint256)")
+)
+
+// Support is the support of a vote.
+type Support uint8
+
+const (
+	// Against is a vote against a proposal.
+	Against Support = iota
+	// For is a vote for a proposal.
+	For
+	// Abstain is a vote abstaining from a proposal.
+	Abstain
+)
+
+// Action is an action carried out by a proposal.
+type Action struct {
+	// Target is the contract called.
+	Target types.Address
+	// Value is the value sent with the call, in wei.
+	Value *big.Int
+	// Signature is the signature of the function called, or empty if the selector is in the call data.
+	Signature string
+	// CallData is the data of the call.
+	CallData []byte
+}
+
+// Proposal is a proposal created on a Governor contract.
+type Proposal struct {
+	// Governor is the Governor contract.
+	Governor types.Address
+	// ID is the ID of the proposal.
+	ID *big.Int
+	// Proposer is the account that created the proposal.
+	Proposer types.Address
+	// Actions are the actions carried out by the proposal.
+	Actions []*Action
+	// VoteStart is the timepoint at which voting starts, as a block or timestamp depending on the Governor's clock.
+	VoteStart *big.Int
+	// VoteEnd is the timepoint at which voting ends, as a block or timestamp depending on the Governor's clock.
+	VoteEnd *big.Int
+	// Description is the description of the proposal.
+	Description string
+	// TransactionHash is the hash of the transaction containing the proposal.
+	TransactionHash types.Hash
+	// Block is the block containing the proposal.
+	Block uint64
+	// LogIndex is the index of the proposal event in the block.
+	LogIndex uint32
+}
+
+// Vote is a vote cast on a proposal.
+type Vote struct {
+	// Governor is the Governor contract.
+	Governor types.Address
+	// ProposalID is the ID of the proposal.
+	ProposalID *big.Int
+	// Voter is the account that cast the vote.
+	Voter types.Address
+	// Support is the support of the vote.
+	Support Support
+	// Weight is the voting weight of the vote.
+	Weight *big.Int
+	// Reason is the reason given for the vote.
+	Reason string
+	// Params are the parameters of the vote, or nil if the vote had none.
+	Params []byte
+	// TransactionHash is the hash of the transaction containing the vote.
+	TransactionHash types.Hash
+	// Block is the block containing the vote.
+	Block uint64
+	// LogIndex is the index of the vote event in the block.
+	LogIndex uint32
+}
+
+// Execution is the execution of a proposal.
+type Execution struct {
+	// Governor is the Governor contract.
+	Governor types.Address
+	// ProposalID is the ID of the proposal.
+	ProposalID *big.Int
+	// TransactionHash is the hash of the transaction containing the execution.
+	TransactionHash types.Hash
+	// Block is the block containing the execution.
+	Block uint64
+	// LogIndex is the index of the execution event in the block.
+	LogIndex uint32
+}
+
+// ProposalHandler defines the methods that need to be implemented to handle created proposals.
+type ProposalHandler interface {
+	// HandleProposal handles a created proposal.
+	HandleProposal(ctx context.Context, proposal *Proposal) error
+}
+
+// VoteHandler defines the methods that need to be implemented to handle votes.
+type VoteHandler interface {
+	// HandleVote handles a vote.
+	HandleVote(ctx context.Context, vote *Vote) error
+}
+
+// ExecutionHandler defines the methods that need to be implemented to handle executed proposals.
+type ExecutionHandler interface {
+	// HandleExecution handles an executed proposal.
+	HandleExecution(ctx context.Context, execution *Execution) error
+}
+
+// Service delivers Governor events.
+type Service struct {
+	log              zerolog.Logger
+	governors        map[types.Address]struct{}
+	proposalHandler  ProposalHandler
+	voteHandler      VoteHandler
+	executionHandler ExecutionHandler
+}
+
+// New creates a new governance proposal watcher.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "governor").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	governors := make(map[types.Address]struct{}, len(parameters.governors))
+	for _, governor := range parameters.governors {
+		governors[governor] = struct{}{}
+	}
+
+	return &Service{
+		log:              log,
+		governors:        governors,
+		proposalHandler:  parameters.proposalHandler,
+		voteHandler:      parameters.voteHandler,
+		executionHandler: parameters.executionHandler,
+	}, nil
+}
+
+// Trigger returns the event trigger that feeds the watcher.
+func (s *Service) Trigger(name string, earliestBlock uint64) *handlers.EventTrigger {
+	return &handlers.EventTrigger{
+		Name:          name,
+		SourceSet:     s,
+		TopicSets:     []handlers.TopicSet{s},
+		EarliestBlock: earliestBlock,
+		Handler:       s,
+	}
+}
+
+// Contains returns true if the address is a watched Governor.
+func (s *Service) Contains(address types.Address) bool {
+	_, exists := s.governors[address]
+
+	return exists
+}
+
+// Addresses returns the watched Governors.
+func (s *Service) Addresses() []types.Address {
+	res := make([]types.Address, 0, len(s.governors))
+	for address := range s.governors {
+		res = append(res, address)
+	}
+
+	return res
+}
+
+// ContainsTopic returns true if the topic is that of an event for which there is a handler.
+func (s *Service) ContainsTopic(topic types.Hash) bool {
+	switch topic {
+	case proposalCreatedTopic:
+		return s.proposalHandler != nil
+	case voteCastTopic, voteCastWithParamsTopic:
+		return s.voteHandler != nil
+	case proposalExecutedTopic:
+		return s.executionHandler != nil
+	default:
+		return false
+	}
+}
+
+// HandleEvent handles an event, delivering it to the relevant handler.
+func (s *Service) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, _ *handlers.EventTrigger) error {
+	if !s.Contains(event.Address) || len(event.Topics) == 0 || !s.ContainsTopic(event.Topics[0]) {
+		return nil
+	}
+
+	var err error
+	switch event.Topics[0] {
+	case proposalCreatedTopic:
+		err = s.handleProposalCreated(ctx, event)
+	case voteCastTopic, voteCastWithParamsTopic:
+		err = s.handleVoteCast(ctx, event)
+	case proposalExecutedTopic:
+		err = s.handleProposalExecuted(ctx, event)
+	}
+
+	return err
+}
+
+// handleProposalCreated handles ProposalCreated events:
+// (uint256 proposalId, address proposer, address[] targets, uint256[] values, string[] signatures,
+// bytes[] calldatas, uint256 voteStart, uint256 voteEnd, string description).
+func (s *Service) handleProposalCreated(ctx context.Context, event *spec.BerlinTransactionEvent) error {
+	proposal, err := decodeProposal(event)
+	if err != nil {
+		return s.decodeFailure(event, err)
+	}
+
+	return s.proposalHandler.HandleProposal(ctx, proposal)
+}
+
+// decodeProposal decodes a proposal from a ProposalCreated event.
+func decodeProposal(event *spec.BerlinTransactionEvent) (*Proposal, error) {
+	proposal := &Proposal{
+		Governor:        event.Address,
+		TransactionHash: event.TransactionHash,
+		Block:           uint64(event.BlockNumber),
+		LogIndex:        event.Index,
+	}
+	var err error
+	if proposal.ID, err = abi.Uint(event.Data, 0); err != nil {
+		return nil, err
+	}
+	if proposal.Proposer, err = abi.Address(event.Data, 1); err != nil {
+		return nil, err
+	}
+	if proposal.Actions, err = decodeActions(event.Data); err != nil {
+		return nil, err
+	}
+	if proposal.VoteStart, err = abi.Uint(event.Data, 6); err != nil {
+		return nil, err
+	}
+	if proposal.VoteEnd, err = abi.Uint(event.Data, 7); err != nil {
+		return nil, err
+	}
+	if proposal.Description, err = abi.String(event.Data, 8); err != nil {
+		return nil, err
+	}
+
+	return proposal, nil
+}
+
+// decodeActions decodes the targets, values, signatures and calldatas arrays of a proposal.
+func decodeActions(data []byte) ([]*Action, error) {
+	numTargets, targets, err := abi.Array(data, 2)
+	if err != nil {
+		return nil, err
+	}
+	numValues, values, err := abi.Array(data, 3)
+	if err != nil {
+		return nil, err
+	}
+	numSignatures, signatures, err := abi.Array(data, 4)
+	if err != nil {
+		return nil, err
+	}
+	numCallDatas, callDatas, err := abi.Array(data, 5)
+	if err != nil {
+		return nil, err
+	}
+	if numValues != numTargets || numSignatures != numTargets || numCallDatas != numTargets {
+		return nil, errors.New("action arrays differ in length")
+	}
+
+	actions := make([]*Action, numTargets)
+	for i := range actions {
+		action := &Action{}
+		if action.Target, err = abi.Address(targets, i); err != nil {
+			return nil, err
+		}
+		if action.Value, err = abi.Uint(values, i); err != nil {
+			return nil, err
+		}
+		if action.Signature, err = abi.String(signatures, i); err != nil {
+			return nil, err
+		}
+		if action.CallData, err = abi.Bytes(callDatas, i); err != nil {
+			return nil, err
+		}
+		actions[i] = action
+	}
+
+	return actions, nil
+}
+
+// handleVoteCast handles VoteCast and VoteCastWithParams events:
+// (address indexed voter, uint256 proposalId, uint8 support, uint256 weight, string reason[, bytes params]).
+func (s *Service) handleVoteCast(ctx context.Context, event *spec.BerlinTransactionEvent) error {
+	if len(event.Topics) != 2 {
+		return s.decodeFailure(event, errors.New("unexpected number of topics"))
+	}
+	vote := &Vote{
+		Governor:        event.Address,
+		Voter:           abi.TopicAddress(event.Topics[1]),
+		TransactionHash: event.TransactionHash,
+		Block:           uint64(event.BlockNumber),
+		LogIndex:        event.Index,
+	}
+	var err error
+	if vote.ProposalID, err = abi.Uint(event.Data, 0); err != nil {
+		return s.decodeFailure(event, err)
+	}
+	support, err := abi.Uint(event.Data, 1)
+	if err != nil {
+		return s.decodeFailure(event, err)
+	}
+	if !support.IsUint64() || support.Uint64() > 0xff {
+		return s.decodeFailure(event, errors.New("support out of range"))
+	}
+	vote.Support = Support(support.Uint64())
+	if vote.Weight, err = abi.Uint(event.Data, 2); err != nil {
+		return s.decodeFailure(event, err)
+	}
+	if vote.Reason, err = abi.String(event.Data, 3); err != nil {
+		return s.decodeFailure(event, err)
+	}
+	if event.Topics[0] == voteCastWithParamsTopic {
+		if vote.Params, err = abi.Bytes(event.Data, 4); err != nil {
+			return s.decodeFailure(event, err)
+		}
+	}
+
+	return s.voteHandler.HandleVote(ctx, vote)
+}
+
+// handleProposalExecuted handles ProposalExecuted events: (uint256 proposalId).
+func (s *Service) handleProposalExecuted(ctx context.Context, event *spec.BerlinTransactionEvent) error {
+	proposalID, err := abi.Uint(event.Data, 0)
+	if err != nil {
+		return s.decodeFailure(event, err)
+	}
+
+	return s.executionHandler.HandleExecution(ctx, &Execution{
+		Governor:        event.Address,
+		ProposalID:      proposalID,
+		TransactionHash: event.TransactionHash,
+		Block:           uint64(event.BlockNumber),
+		LogIndex:        event.Index,
+	})
+}
+
+// decodeFailure logs an event that could not be decoded.  Such events cannot be delivered,
+// but should not halt the listener, so no error is returned.
+func (s *Service) decodeFailure(event *spec.BerlinTransactionEvent, err error) error {
+	s.log.Debug().Stringer("tx", event.TransactionHash).Uint32("index", event.Index).Err(err).Msg("Failed to decode Governor event")
+
+	return nil
+}
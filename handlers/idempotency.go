@@ -0,0 +1,51 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// BlockIdempotencyKey returns the idempotency key of a block, in the form chainID:blockHash.
+func BlockIdempotencyKey(chainID uint64, blockHash types.Hash) string {
+	return fmt.Sprintf("%d:%#x", chainID, blockHash[:])
+}
+
+// TxIdempotencyKey returns the idempotency key of a transaction, in the form chainID:blockHash:txIndex.
+func TxIdempotencyKey(chainID uint64, blockHash types.Hash, txIndex uint32) string {
+	return fmt.Sprintf("%d:%#x:%d", chainID, blockHash[:], txIndex)
+}
+
+// EventIdempotencyKey returns the idempotency key of an event, in the form chainID:blockHash:txIndex:logIndex.
+func EventIdempotencyKey(chainID uint64, blockHash types.Hash, txIndex uint32, logIndex uint32) string {
+	return fmt.Sprintf("%d:%#x:%d:%d", chainID, blockHash[:], txIndex, logIndex)
+}
+
+// WithIdempotencyKey returns a copy of the context containing the idempotency key of the item being handled.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey, key)
+}
+
+// IdempotencyKeyFromContext returns the idempotency key of the item being handled.
+// The key is stable across redeliveries of the item, and changes if the item is included in a
+// different block following a chain reorg, so can be used by downstream systems to deduplicate.
+// It returns an empty string if the listener did not supply a key.
+func IdempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKey).(string)
+
+	return key
+}
@@ -0,0 +1,105 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"errors"
+
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel      zerolog.Level
+	producer      Producer
+	topic         string
+	progressTopic string
+	protobuf      bool
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithProducer sets the producer for records.
+func WithProducer(producer Producer) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.producer = producer
+	})
+}
+
+// WithTopic sets the topic to which items are produced.
+func WithTopic(topic string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.topic = topic
+	})
+}
+
+// WithProgressTopic sets the compacted topic to which the progress of each trigger is produced,
+// within the same transaction as its items.
+// If set then the producer must implement TransactionalProducer.
+func WithProgressTopic(topic string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.progressTopic = topic
+	})
+}
+
+// WithProtobuf produces items encoded with the listenerv1 protobuf schema rather than as JSON.
+func WithProtobuf(protobuf bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.protobuf = protobuf
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.producer == nil {
+		return nil, errors.New("no producer specified")
+	}
+	if parameters.topic == "" {
+		return nil, errors.New("no topic specified")
+	}
+	if parameters.progressTopic != "" {
+		if _, isTransactional := parameters.producer.(TransactionalProducer); !isTransactional {
+			return nil, errors.New("producer must be transactional to use a progress topic")
+		}
+		if parameters.progressTopic == parameters.topic {
+			return nil, errors.New("progress topic must differ from topic")
+		}
+	}
+
+	return &parameters, nil
+}
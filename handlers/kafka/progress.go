@@ -0,0 +1,66 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// progress is the position of the latest item produced for a trigger, as stored in the progress topic.
+// Progress committed when the block was held as 32 bits decodes unchanged.
+type progress struct {
+	Block     uint64 `json:"block"`
+	BlockHash string `json:"block_hash"`
+	TxIndex   uint32 `json:"tx_index"`
+	LogIndex  uint32 `json:"log_index"`
+}
+
+// produced returns true if the item at the given position was produced at or before the progress.
+// Items in a block with a different hash to that of the progress have replaced it in a reorg, so
+// are not considered to have been produced.
+func (p *progress) produced(position *progress) bool {
+	if position.Block != p.Block {
+		return position.Block < p.Block
+	}
+	if position.BlockHash != p.BlockHash {
+		return false
+	}
+	if position.TxIndex != p.TxIndex {
+		return position.TxIndex < p.TxIndex
+	}
+
+	return position.LogIndex <= p.LogIndex
+}
+
+// loadProgress loads the committed progress of each trigger from the progress topic.
+func (s *Service) loadProgress(ctx context.Context) error {
+	values, err := s.producer.(TransactionalProducer).CommittedProgress(ctx, s.progressTopic)
+	if err != nil {
+		return errors.Join(errors.New("failed to obtain committed progress"), err)
+	}
+
+	for trigger, value := range values {
+		p := &progress{}
+		if err := json.Unmarshal(value, p); err != nil {
+			return errors.Join(fmt.Errorf("invalid progress for trigger %s", trigger), err)
+		}
+		s.progress[trigger] = p
+		s.log.Trace().Str("trigger", trigger).Uint64("block", p.Block).Msg("Loaded committed progress")
+	}
+
+	return nil
+}
@@ -0,0 +1,318 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kafka provides handlers that produce blocks, transactions and events to Apache Kafka.
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+	listenerv1 "github.com/wealdtech/go-eth-listener/proto/listener/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Record is a record to be produced.
+type Record struct {
+	// Topic is the topic of the record.
+	Topic string
+	// Key is the key of the record, being the address of the item for items so that records for
+	// each contract are in the same partition, and the trigger for progress records.
+	Key []byte
+	// Value is the value of the record.
+	Value []byte
+	// Headers are the headers of the record.
+	Headers map[string]string
+}
+
+// Producer defines the methods that need to be implemented to produce records.
+// It is expected to be a thin adapter around an idempotent producer, with acks from all in-sync replicas.
+type Producer interface {
+	// Produce produces records, returning once all of them have been acknowledged.
+	Produce(ctx context.Context, records []*Record) error
+}
+
+// TransactionalProducer defines the methods that need to be implemented to produce records in transactions.
+// It is expected to be a thin adapter around a producer with a transactional ID, which is fenced
+// when initialised so that only one listener can produce with the ID at a time.
+type TransactionalProducer interface {
+	Producer
+	// BeginTransaction begins a transaction.
+	BeginTransaction(ctx context.Context) error
+	// CommitTransaction commits the current transaction, retrying as required by the client.
+	CommitTransaction(ctx context.Context) error
+	// AbortTransaction aborts the current transaction.
+	AbortTransaction(ctx context.Context) error
+	// CommittedProgress returns the value of the latest committed record for each key in the
+	// given compacted topic, reading with read_committed isolation.
+	CommittedProgress(ctx context.Context, topic string) (map[string][]byte, error)
+}
+
+// record is the JSON representation of an item produced by the handler.
+type record struct {
+	Type           string   `json:"type"`
+	Trigger        string   `json:"trigger"`
+	IdempotencyKey string   `json:"idempotency_key,omitempty"`
+	Confirmations  *uint32  `json:"confirmations,omitempty"`
+	Signatures     []string `json:"signatures,omitempty"`
+	Data           any      `json:"data"`
+}
+
+// Service produces items to Kafka.
+// It implements the block, transaction and event handler interfaces.
+//
+// Each item is produced before the handler returns, so the listener only advances a trigger's
+// progress once the item has been acknowledged, giving at-least-once delivery.  If a progress
+// topic is configured then each item is produced in a transaction along with the position of
+// the item in a progress record for its trigger.  On start the committed progress is read back,
+// and items that the listener redelivers after a failure between the transaction committing and
+// the listener's own progress being stored are skipped, giving effectively-once delivery to
+// consumers that read with read_committed isolation.
+//
+// Transaction handlers cannot fail, so a transaction that cannot be produced is logged and lost;
+// event triggers should be preferred where delivery matters.
+type Service struct {
+	log           zerolog.Logger
+	producer      Producer
+	topic         string
+	progressTopic string
+	protobuf      bool
+	mu            sync.Mutex
+	progress      map[string]*progress
+}
+
+// New creates a new Kafka handler.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "kafka").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		log:           log,
+		producer:      parameters.producer,
+		topic:         parameters.topic,
+		progressTopic: parameters.progressTopic,
+		protobuf:      parameters.protobuf,
+		progress:      make(map[string]*progress),
+	}
+
+	if s.progressTopic != "" {
+		if err := s.loadProgress(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// HandleBlock produces a block.
+func (s *Service) HandleBlock(ctx context.Context, block *spec.Block, trigger *handlers.BlockTrigger) error {
+	value, err := s.value(ctx, "block", trigger.Name, block, func() proto.Message {
+		return listenerv1.NewBlockItem(ctx, block, trigger.Name)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Blocks are keyed as a single sequence.
+	return s.produce(ctx, "block", trigger.Name, trigger.QualifiedName(), []byte("blocks"), value, &progress{
+		Block:     uint64(block.Number()),
+		BlockHash: block.Hash().String(),
+	})
+}
+
+// HandleTx produces a transaction.
+func (s *Service) HandleTx(ctx context.Context, tx *spec.Transaction, trigger *handlers.TxTrigger) {
+	if tx.BlockNumber() == nil || tx.BlockHash() == nil || tx.TransactionIndex() == nil {
+		s.log.Error().Stringer("tx", tx.Hash()).Msg("Transaction does not have a position; cannot produce")
+		return
+	}
+
+	value, err := s.value(ctx, "transaction", trigger.Name, tx, func() proto.Message {
+		return listenerv1.NewTransactionItem(ctx, tx, trigger.Name)
+	})
+	if err != nil {
+		s.log.Error().Stringer("tx", tx.Hash()).Err(err).Msg("Failed to encode transaction")
+		return
+	}
+
+	key := tx.From()
+	if tx.To() != nil {
+		key = *tx.To()
+	}
+	if err := s.produce(ctx, "transaction", trigger.Name, trigger.QualifiedName(), key[:], value, &progress{
+		Block:     uint64(*tx.BlockNumber()),
+		BlockHash: tx.BlockHash().String(),
+		TxIndex:   *tx.TransactionIndex(),
+	}); err != nil {
+		s.log.Error().Stringer("tx", tx.Hash()).Err(err).Msg("Failed to produce transaction")
+	}
+}
+
+// HandleEvent produces an event.
+func (s *Service) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, trigger *handlers.EventTrigger) error {
+	value, err := s.value(ctx, "event", trigger.Name, event, func() proto.Message {
+		return listenerv1.NewEventItem(ctx, event, trigger.Name)
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.produce(ctx, "event", trigger.Name, trigger.QualifiedName(), event.Address[:], value, &progress{
+		Block:     uint64(event.BlockNumber),
+		BlockHash: event.BlockHash.String(),
+		TxIndex:   event.TransactionIndex,
+		LogIndex:  event.Index,
+	})
+}
+
+// value returns the encoded value of an item.
+func (s *Service) value(ctx context.Context,
+	itemType string,
+	trigger string,
+	item any,
+	protoItem func() proto.Message,
+) ([]byte, error) {
+	if s.protobuf {
+		value, err := proto.Marshal(protoItem())
+		if err != nil {
+			return nil, errors.Join(errors.New("failed to marshal item"), err)
+		}
+
+		return value, nil
+	}
+
+	rec := &record{
+		Type:           itemType,
+		Trigger:        trigger,
+		IdempotencyKey: handlers.IdempotencyKeyFromContext(ctx),
+		Signatures:     handlers.SignaturesFromContext(ctx),
+		Data:           item,
+	}
+	if confirmations, exists := handlers.ConfirmationsFromContext(ctx); exists {
+		rec.Confirmations = &confirmations
+	}
+	value, err := json.Marshal(rec)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to marshal item"), err)
+	}
+
+	return value, nil
+}
+
+func (s *Service) produce(ctx context.Context,
+	itemType string,
+	trigger string,
+	qualifiedTrigger string,
+	key []byte,
+	value []byte,
+	position *progress,
+) error {
+	headers := map[string]string{
+		"type":    itemType,
+		"trigger": trigger,
+	}
+	if idempotencyKey := handlers.IdempotencyKeyFromContext(ctx); idempotencyKey != "" {
+		headers["idempotency_key"] = idempotencyKey
+	}
+	if s.protobuf {
+		headers["content-type"] = "application/x-protobuf"
+	} else {
+		headers["content-type"] = "application/json"
+	}
+	records := []*Record{
+		{
+			Topic:   s.topic,
+			Key:     key,
+			Value:   value,
+			Headers: headers,
+		},
+	}
+
+	if s.progressTopic == "" {
+		if err := s.producer.Produce(ctx, records); err != nil {
+			return errors.Join(errors.New("failed to produce item"), err)
+		}
+		s.log.Trace().Str("trigger", qualifiedTrigger).Msg("Produced item")
+
+		return nil
+	}
+
+	return s.produceTransactionally(ctx, qualifiedTrigger, records, position)
+}
+
+// produceTransactionally produces records along with the progress of the trigger in a single transaction.
+func (s *Service) produceTransactionally(ctx context.Context,
+	trigger string,
+	records []*Record,
+	position *progress,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if latest, exists := s.progress[trigger]; exists && latest.produced(position) {
+		s.log.Trace().Str("trigger", trigger).Uint64("block", position.Block).Msg("Item already produced")
+
+		return nil
+	}
+
+	progressValue, err := json.Marshal(position)
+	if err != nil {
+		return errors.Join(errors.New("failed to marshal progress"), err)
+	}
+	records = append(records, &Record{
+		Topic: s.progressTopic,
+		Key:   []byte(trigger),
+		Value: progressValue,
+	})
+
+	producer := s.producer.(TransactionalProducer)
+	if err := producer.BeginTransaction(ctx); err != nil {
+		return errors.Join(errors.New("failed to begin transaction"), err)
+	}
+	if err := producer.Produce(ctx, records); err != nil {
+		s.abort(ctx, producer)
+
+		return errors.Join(errors.New("failed to produce item"), err)
+	}
+	if err := producer.CommitTransaction(ctx); err != nil {
+		s.abort(ctx, producer)
+
+		return errors.Join(fmt.Errorf("failed to commit transaction for trigger %s", trigger), err)
+	}
+	s.progress[trigger] = position
+	s.log.Trace().Str("trigger", trigger).Uint64("block", position.Block).Msg("Produced item")
+
+	return nil
+}
+
+// abort aborts the current transaction.
+func (s *Service) abort(ctx context.Context, producer TransactionalProducer) {
+	if err := producer.AbortTransaction(ctx); err != nil {
+		s.log.Warn().Err(err).Msg("Failed to abort transaction")
+	}
+}
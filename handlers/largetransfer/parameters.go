@@ -0,0 +1,109 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package largetransfer
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+type parameters struct {
+	logLevel        zerolog.Level
+	etherThreshold  *big.Int
+	tokenThresholds map[types.Address]*big.Float
+	tokenMetadata   handlers.TokenMetadataProvider
+	handler         AlertHandler
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithEtherThreshold sets the value in wei at or above which native ether transfers are alerted.
+// If not supplied native ether transfers are not alerted.
+func WithEtherThreshold(threshold *big.Int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.etherThreshold = threshold
+	})
+}
+
+// WithTokenThresholds sets the tokens to watch, and the amounts in human units
+// (e.g. 1000000 for one million USDC) at or above which their transfers are alerted.
+func WithTokenThresholds(thresholds map[types.Address]*big.Float) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.tokenThresholds = thresholds
+	})
+}
+
+// WithTokenMetadataProvider sets the provider of token metadata, used to obtain token decimals
+// if they are not supplied by the listener.
+func WithTokenMetadataProvider(provider handlers.TokenMetadataProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.tokenMetadata = provider
+	})
+}
+
+// WithHandler sets the handler for large transfers.
+func WithHandler(handler AlertHandler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.handler = handler
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.etherThreshold == nil && len(parameters.tokenThresholds) == 0 {
+		return nil, errors.New("no ether or token thresholds specified")
+	}
+	if parameters.etherThreshold != nil && parameters.etherThreshold.Sign() <= 0 {
+		return nil, errors.New("ether threshold must be positive")
+	}
+	for _, threshold := range parameters.tokenThresholds {
+		if threshold == nil || threshold.Sign() <= 0 {
+			return nil, errors.New("token thresholds must be positive")
+		}
+	}
+	if parameters.handler == nil {
+		return nil, errors.New("no handler specified")
+	}
+
+	return &parameters, nil
+}
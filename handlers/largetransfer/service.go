@@ -0,0 +1,232 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package largetransfer provides a composite trigger that alerts on large transfers
+// of native ether or ERC-20 tokens.
+package largetransfer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/format"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// transferTopic is the topic of the ERC-20 Transfer(address,address,uint256) event.
+var transferTopic = types.Hash{
+	0xdd, 0xf2, 0x52, 0xad, 0x1b, 0xe2, 0xc8, 0x9b, 0x69, 0xc2, 0xb0, 0x68, 0xfc, 0x37, 0x8d, 0xaa,
+	0x95, 0x2b, 0xa7, 0xf1, 0x63, 0xc4, 0xa1, 0x16, 0x28, 0xf5, 0x5a, 0x4d, 0xf5, 0x23, 0xb3, 0xef,
+}
+
+// Transfer contains information about a large transfer.
+type Transfer struct {
+	// Token is the token transferred, or nil for native ether.
+	Token *handlers.TokenMetadata
+	// From is the sender of the transfer.
+	From types.Address
+	// To is the recipient of the transfer.
+	To types.Address
+	// Amount is the raw amount transferred, in wei or the token's smallest unit.
+	Amount *big.Int
+	// FormattedAmount is the amount transferred in human units.
+	FormattedAmount string
+	// TransactionHash is the hash of the transaction containing the transfer.
+	TransactionHash types.Hash
+	// Block is the block containing the transfer.
+	Block uint64
+	// LogIndex is the index of the transfer event in the block, or nil for native ether.
+	LogIndex *uint32
+}
+
+// AlertHandler defines the methods that need to be implemented to handle large transfers.
+type AlertHandler interface {
+	// HandleLargeTransfer handles a large transfer.
+	HandleLargeTransfer(ctx context.Context, transfer *Transfer)
+}
+
+// Service alerts on large transfers.
+type Service struct {
+	log             zerolog.Logger
+	etherThreshold  *big.Int
+	tokenThresholds map[types.Address]*big.Float
+	tokenMetadata   handlers.TokenMetadataProvider
+	handler         AlertHandler
+}
+
+// New creates a new large transfer alerter.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "largetransfer").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	return &Service{
+		log:             log,
+		etherThreshold:  parameters.etherThreshold,
+		tokenThresholds: parameters.tokenThresholds,
+		tokenMetadata:   parameters.tokenMetadata,
+		handler:         parameters.handler,
+	}, nil
+}
+
+// Triggers returns the transaction and event triggers that feed the alerter.
+// Either trigger is nil if the alerter is not configured for the relevant type of transfer.
+func (s *Service) Triggers(name string, earliestBlock uint64) (*handlers.TxTrigger, *handlers.EventTrigger) {
+	var txTrigger *handlers.TxTrigger
+	if s.etherThreshold != nil {
+		txTrigger = &handlers.TxTrigger{
+			Name:          name,
+			EarliestBlock: earliestBlock,
+			Handler:       s,
+		}
+	}
+
+	var eventTrigger *handlers.EventTrigger
+	if len(s.tokenThresholds) > 0 {
+		eventTrigger = &handlers.EventTrigger{
+			Name:          name,
+			SourceSet:     s,
+			Topics:        []types.Hash{transferTopic},
+			EarliestBlock: earliestBlock,
+			Handler:       s,
+		}
+	}
+
+	return txTrigger, eventTrigger
+}
+
+// Contains returns true if the address is a watched token.
+func (s *Service) Contains(address types.Address) bool {
+	_, exists := s.tokenThresholds[address]
+
+	return exists
+}
+
+// Addresses returns the watched tokens.
+func (s *Service) Addresses() []types.Address {
+	res := make([]types.Address, 0, len(s.tokenThresholds))
+	for address := range s.tokenThresholds {
+		res = append(res, address)
+	}
+
+	return res
+}
+
+// HandleTx handles a transaction, alerting if it transfers a large amount of ether.
+func (s *Service) HandleTx(ctx context.Context, tx *spec.Transaction, _ *handlers.TxTrigger) {
+	value := tx.Value()
+	if value == nil || value.Cmp(s.etherThreshold) < 0 {
+		return
+	}
+	to := tx.To()
+	if to == nil {
+		// Contract creation.
+		return
+	}
+
+	// Ether has 18 decimals, so this cannot fail.
+	formatted, _ := format.Amount(value, 18)
+	transfer := &Transfer{
+		From:            tx.From(),
+		To:              *to,
+		Amount:          value,
+		FormattedAmount: formatted,
+		TransactionHash: tx.Hash(),
+	}
+	if blockNumber := tx.BlockNumber(); blockNumber != nil {
+		transfer.Block = uint64(*blockNumber)
+	}
+	s.log.Debug().Stringer("tx", transfer.TransactionHash).Str("amount", formatted).Msg("Large ether transfer")
+	s.handler.HandleLargeTransfer(ctx, transfer)
+}
+
+// HandleEvent handles an event, alerting if it is a large transfer of a watched token.
+func (s *Service) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, _ *handlers.EventTrigger) error {
+	threshold, exists := s.tokenThresholds[event.Address]
+	if !exists {
+		return nil
+	}
+	// ERC-721 transfers share the topic but have an indexed token ID and no data, so are ignored.
+	if len(event.Topics) != 3 || event.Topics[0] != transferTopic || len(event.Data) != 32 {
+		return nil
+	}
+
+	metadata, err := s.metadata(ctx, event.Address)
+	if err != nil {
+		return err
+	}
+	if metadata == nil {
+		s.log.Debug().Stringer("token", event.Address).Msg("No metadata for token; cannot evaluate transfer")
+
+		return nil
+	}
+
+	amount := new(big.Int).SetBytes(event.Data)
+	if amount.Cmp(rawThreshold(threshold, metadata.Decimals)) < 0 {
+		return nil
+	}
+
+	logIndex := event.Index
+	transfer := &Transfer{
+		Token:           metadata,
+		Amount:          amount,
+		FormattedAmount: metadata.FormatAmount(amount),
+		TransactionHash: event.TransactionHash,
+		Block:           uint64(event.BlockNumber),
+		LogIndex:        &logIndex,
+	}
+	copy(transfer.From[:], event.Topics[1][len(event.Topics[1])-types.AddressLength:])
+	copy(transfer.To[:], event.Topics[2][len(event.Topics[2])-types.AddressLength:])
+	s.log.Debug().Stringer("tx", transfer.TransactionHash).Str("symbol", metadata.Symbol).Str("amount", transfer.FormattedAmount).Msg("Large token transfer")
+	s.handler.HandleLargeTransfer(ctx, transfer)
+
+	return nil
+}
+
+// metadata obtains the metadata for a token, from the context if supplied by the
+// listener, otherwise from the configured provider.
+func (s *Service) metadata(ctx context.Context, token types.Address) (*handlers.TokenMetadata, error) {
+	if metadata := handlers.TokenMetadataFromContext(ctx); metadata != nil && metadata.Address == token {
+		return metadata, nil
+	}
+	if s.tokenMetadata == nil {
+		return nil, nil
+	}
+
+	metadata, err := s.tokenMetadata.TokenMetadata(ctx, token)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to obtain token metadata"), err)
+	}
+
+	return metadata, nil
+}
+
+// rawThreshold converts a threshold in human units to the token's smallest unit.
+func rawThreshold(threshold *big.Float, decimals uint8) *big.Int {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	raw, _ := new(big.Float).Mul(threshold, new(big.Float).SetInt(scale)).Int(nil)
+
+	return raw
+}
@@ -0,0 +1,65 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+// NamespaceSeparator separates the namespace from the name in a qualified trigger name.
+const NamespaceSeparator = "/"
+
+// QualifiedName returns the name of the trigger qualified by its namespace, if it has one.
+func (t *BlockTrigger) QualifiedName() string {
+	return qualifiedName(t.Namespace, t.Name)
+}
+
+// QualifiedName returns the name of the trigger qualified by its namespace, if it has one.
+func (t *TxTrigger) QualifiedName() string {
+	return qualifiedName(t.Namespace, t.Name)
+}
+
+// QualifiedName returns the name of the trigger qualified by its namespace, if it has one.
+func (t *EventTrigger) QualifiedName() string {
+	return qualifiedName(t.Namespace, t.Name)
+}
+
+// QualifiedName returns the name of the trigger qualified by its namespace, if it has one.
+func (t *CompositeTrigger) QualifiedName() string {
+	return qualifiedName(t.Namespace, t.Name)
+}
+
+// QualifiedName returns the name of the trigger qualified by its namespace, if it has one.
+func (t *WindowTrigger) QualifiedName() string {
+	return qualifiedName(t.Namespace, t.Name)
+}
+
+// QualifiedName returns the name of the trigger qualified by its namespace, if it has one.
+func (t *BeaconBlockTrigger) QualifiedName() string {
+	return qualifiedName(t.Namespace, t.Name)
+}
+
+// QualifiedName returns the name of the trigger qualified by its namespace, if it has one.
+func (t *FinalityTrigger) QualifiedName() string {
+	return qualifiedName(t.Namespace, t.Name)
+}
+
+// QualifiedName returns the name of the trigger qualified by its namespace, if it has one.
+func (t *CorrelationTrigger) QualifiedName() string {
+	return qualifiedName(t.Namespace, t.Name)
+}
+
+func qualifiedName(namespace string, name string) string {
+	if namespace == "" {
+		return name
+	}
+
+	return namespace + NamespaceSeparator + name
+}
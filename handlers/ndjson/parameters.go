@@ -0,0 +1,104 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ndjson
+
+import (
+	"errors"
+	"io"
+
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel   zerolog.Level
+	writer     io.Writer
+	path       string
+	maxSize    int64
+	maxBackups int
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithWriter sets the writer to which output is written, for example os.Stdout.
+func WithWriter(writer io.Writer) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.writer = writer
+	})
+}
+
+// WithPath sets the path of a file to which output is written.
+func WithPath(path string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.path = path
+	})
+}
+
+// WithMaxSize sets the size in bytes at which the output file is rotated.
+// If not supplied the output file is not rotated.
+func WithMaxSize(maxSize int64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxSize = maxSize
+	})
+}
+
+// WithMaxBackups sets the maximum number of rotated output files to retain.
+// If not supplied all rotated output files are retained.
+func WithMaxBackups(maxBackups int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxBackups = maxBackups
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.writer == nil && parameters.path == "" {
+		return nil, errors.New("no writer or path specified")
+	}
+	if parameters.writer != nil && parameters.path != "" {
+		return nil, errors.New("only one of writer and path can be specified")
+	}
+	if parameters.maxSize < 0 {
+		return nil, errors.New("max size cannot be negative")
+	}
+	if parameters.maxBackups < 0 {
+		return nil, errors.New("max backups cannot be negative")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,163 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ndjson
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// rotatingFile is a file that is rotated when it reaches a maximum size.
+// Rotated files are renamed with a timestamp suffix.
+type rotatingFile struct {
+	log        zerolog.Logger
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func newRotatingFile(log zerolog.Logger, path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	f := &rotatingFile{
+		log:        log,
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Write writes data to the file, rotating it beforehand if required.
+func (f *rotatingFile) Write(data []byte) (int, error) {
+	if f.file == nil {
+		return 0, errors.New("file closed")
+	}
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(data)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(data)
+	f.size += int64(n)
+
+	return n, err
+}
+
+// Close closes the file.
+func (f *rotatingFile) Close() error {
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+
+	return err
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return errors.Join(errors.New("failed to open output file"), err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return errors.Join(errors.New("failed to obtain output file information"), err)
+	}
+	f.file = file
+	f.size = info.Size()
+
+	return nil
+}
+
+// rotate moves the current file aside and opens a new one in its place.
+// If rotation fails the current file is reopened, so that later writes can still succeed.
+func (f *rotatingFile) rotate() error {
+	err := f.file.Close()
+	f.file = nil
+	if err != nil {
+		return f.reopen(errors.Join(errors.New("failed to close output file for rotation"), err))
+	}
+
+	backup := fmt.Sprintf("%s.%s", f.path, time.Now().UTC().Format("20060102T150405.000000000"))
+	if err := os.Rename(f.path, backup); err != nil {
+		return f.reopen(errors.Join(errors.New("failed to rotate output file"), err))
+	}
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	// Failing to prune old backups does not affect the active file, so is not fatal.
+	if err := f.pruneBackups(); err != nil {
+		f.log.Warn().Err(err).Msg("Failed to prune rotated output files")
+	}
+
+	return nil
+}
+
+// reopen reopens the current file after a failed rotation, returning the rotation error.
+func (f *rotatingFile) reopen(rotateErr error) error {
+	if err := f.open(); err != nil {
+		return errors.Join(rotateErr, err)
+	}
+
+	return rotateErr
+}
+
+// pruneBackups removes the oldest rotated files beyond the maximum number of backups.
+func (f *rotatingFile) pruneBackups() error {
+	if f.maxBackups == 0 {
+		return nil
+	}
+
+	matches, err := filepath.Glob(f.path + ".*")
+	if err != nil {
+		return errors.Join(errors.New("failed to list rotated output files"), err)
+	}
+	backups := make([]string, 0, len(matches))
+	prefix := f.path + "."
+	for _, match := range matches {
+		if strings.HasPrefix(match, prefix) {
+			backups = append(backups, match)
+		}
+	}
+	if len(backups) <= f.maxBackups {
+		return nil
+	}
+
+	// Timestamp suffixes sort chronologically.
+	sort.Strings(backups)
+	for _, backup := range backups[:len(backups)-f.maxBackups] {
+		if err := os.Remove(backup); err != nil {
+			return errors.Join(errors.New("failed to remove rotated output file"), err)
+		}
+	}
+
+	return nil
+}
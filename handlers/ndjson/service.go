@@ -0,0 +1,132 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ndjson provides handlers that write blocks, transactions and events as newline-delimited JSON.
+package ndjson
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// record is the JSON representation of an item written by the handler.
+type record struct {
+	Type           string                 `json:"type"`
+	Trigger        string                 `json:"trigger"`
+	IdempotencyKey string                 `json:"idempotency_key,omitempty"`
+	Confirmations  *uint32                `json:"confirmations,omitempty"`
+	Signatures     []string               `json:"signatures,omitempty"`
+	Proof          *handlers.ReceiptProof `json:"proof,omitempty"`
+	Data           any                    `json:"data"`
+}
+
+// Service writes items as newline-delimited JSON.
+// It implements the block, transaction and event handler interfaces.
+type Service struct {
+	log    zerolog.Logger
+	mu     sync.Mutex
+	writer io.Writer
+	closer io.Closer
+}
+
+// New creates a new newline-delimited JSON handler.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "ndjson").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		log:    log,
+		writer: parameters.writer,
+	}
+
+	if parameters.path != "" {
+		file, err := newRotatingFile(log, parameters.path, parameters.maxSize, parameters.maxBackups)
+		if err != nil {
+			return nil, err
+		}
+		s.writer = file
+		s.closer = file
+
+		go func(ctx context.Context) {
+			<-ctx.Done()
+			s.mu.Lock()
+			if err := s.closer.Close(); err != nil {
+				s.log.Warn().Err(err).Msg("Failed to close output file")
+			}
+			s.mu.Unlock()
+		}(ctx)
+	}
+
+	return s, nil
+}
+
+// HandleBlock writes a block.
+func (s *Service) HandleBlock(ctx context.Context, block *spec.Block, trigger *handlers.BlockTrigger) error {
+	return s.write(ctx, "block", trigger.Name, block)
+}
+
+// HandleTx writes a transaction.
+func (s *Service) HandleTx(ctx context.Context, tx *spec.Transaction, trigger *handlers.TxTrigger) {
+	if err := s.write(ctx, "transaction", trigger.Name, tx); err != nil {
+		s.log.Error().Stringer("tx", tx.Hash()).Err(err).Msg("Failed to write transaction")
+	}
+}
+
+// HandleEvent writes an event.
+func (s *Service) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, trigger *handlers.EventTrigger) error {
+	return s.write(ctx, "event", trigger.Name, event)
+}
+
+func (s *Service) write(ctx context.Context, itemType string, trigger string, data any) error {
+	rec := &record{
+		Type:           itemType,
+		Trigger:        trigger,
+		IdempotencyKey: handlers.IdempotencyKeyFromContext(ctx),
+		Data:           data,
+	}
+	if confirmations, exists := handlers.ConfirmationsFromContext(ctx); exists {
+		rec.Confirmations = &confirmations
+	}
+	rec.Signatures = handlers.SignaturesFromContext(ctx)
+	rec.Proof = handlers.ReceiptProofFromContext(ctx)
+
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return errors.Join(errors.New("failed to marshal item"), err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.writer.Write(line); err != nil {
+		return errors.Join(errors.New("failed to write item"), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noncewatcher
+
+import (
+	"errors"
+
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel  zerolog.Level
+	addresses []types.Address
+	maxBlocks uint32
+	handler   StuckHandler
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithAddresses sets the sender addresses to watch.
+func WithAddresses(addresses []types.Address) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.addresses = addresses
+	})
+}
+
+// WithMaxBlocks sets the number of blocks without progress after which
+// expected transactions are considered stuck.
+func WithMaxBlocks(maxBlocks uint32) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxBlocks = maxBlocks
+	})
+}
+
+// WithHandler sets the handler for stuck transactions.
+func WithHandler(handler StuckHandler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.handler = handler
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if len(parameters.addresses) == 0 {
+		return nil, errors.New("no addresses specified")
+	}
+	if parameters.maxBlocks == 0 {
+		return nil, errors.New("no max blocks specified")
+	}
+	if parameters.handler == nil {
+		return nil, errors.New("no handler specified")
+	}
+
+	return &parameters, nil
+}
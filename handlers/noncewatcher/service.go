@@ -0,0 +1,215 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package noncewatcher watches the confirmed nonces of sender addresses and
+// reports transactions that have stopped confirming.
+package noncewatcher
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// Stuck contains information about a sender with transactions that are not confirming.
+type Stuck struct {
+	// Address is the address of the sender.
+	Address types.Address
+	// ConfirmedNonce is the highest confirmed nonce for the sender, or nil if none has been seen.
+	ConfirmedNonce *uint64
+	// ExpectedNonce is the highest nonce expected to confirm for the sender.
+	ExpectedNonce uint64
+	// LastProgressBlock is the block at which the sender last made progress.
+	LastProgressBlock uint64
+	// Block is the block at which the sender was found to be stuck.
+	Block uint64
+}
+
+// StuckHandler defines the methods that need to be implemented to handle stuck transactions.
+type StuckHandler interface {
+	// HandleStuck handles a sender with stuck transactions.
+	HandleStuck(ctx context.Context, stuck *Stuck)
+}
+
+type senderState struct {
+	confirmedNonce    *uint64
+	expectedNonce     *uint64
+	lastProgressBlock uint64
+	alerted           bool
+}
+
+// Service watches the nonces of sender addresses.
+type Service struct {
+	log       zerolog.Logger
+	maxBlocks uint64
+	handler   StuckHandler
+	mu        sync.Mutex
+	senders   map[types.Address]*senderState
+}
+
+// New creates a new nonce watcher.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "noncewatcher").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	senders := make(map[types.Address]*senderState, len(parameters.addresses))
+	for _, address := range parameters.addresses {
+		senders[address] = &senderState{}
+	}
+
+	return &Service{
+		log:       log,
+		maxBlocks: uint64(parameters.maxBlocks),
+		handler:   parameters.handler,
+		senders:   senders,
+	}, nil
+}
+
+// Trigger returns a block trigger that feeds the watcher.
+// The watcher obtains confirmed nonces from the transactions of each block before checking
+// for stuck senders, so it needs no transaction trigger.
+func (s *Service) Trigger(name string, earliestBlock uint64) *handlers.BlockTrigger {
+	return &handlers.BlockTrigger{
+		Name:          name,
+		EarliestBlock: earliestBlock,
+		Handler:       s,
+	}
+}
+
+// Expect notes that a transaction with the given nonce has been submitted by the sender,
+// and so is expected to confirm.
+func (s *Service) Expect(address types.Address, nonce uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, exists := s.senders[address]
+	if !exists {
+		return errors.New("address not watched")
+	}
+	if state.confirmedNonce != nil && *state.confirmedNonce >= nonce {
+		// Already confirmed.
+		return nil
+	}
+	if state.expectedNonce == nil || *state.expectedNonce < nonce {
+		state.expectedNonce = &nonce
+	}
+
+	return nil
+}
+
+// Contains returns true if the address is watched.
+func (s *Service) Contains(address types.Address) bool {
+	s.mu.Lock()
+	_, exists := s.senders[address]
+	s.mu.Unlock()
+
+	return exists
+}
+
+// Addresses returns the addresses that are watched.
+func (s *Service) Addresses() []types.Address {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]types.Address, 0, len(s.senders))
+	for address := range s.senders {
+		res = append(res, address)
+	}
+
+	return res
+}
+
+// confirm records a confirmed transaction from a watched sender.
+// This assumes that the lock is held.
+func (s *Service) confirm(tx *spec.Transaction, height uint64) {
+	state, exists := s.senders[tx.From()]
+	if !exists {
+		return
+	}
+
+	nonce := tx.Nonce()
+	if state.confirmedNonce == nil || *state.confirmedNonce < nonce {
+		state.confirmedNonce = &nonce
+	}
+	state.lastProgressBlock = height
+	state.alerted = false
+	if state.expectedNonce != nil && *state.expectedNonce <= nonce {
+		state.expectedNonce = nil
+	}
+	s.log.Trace().Stringer("address", tx.From()).Uint64("nonce", nonce).Msg("Confirmed nonce")
+}
+
+// HandleBlock records the transactions of watched senders in the block, then checks watched
+// senders for stuck transactions as of the block.
+func (s *Service) HandleBlock(ctx context.Context, block *spec.Block, _ *handlers.BlockTrigger) error {
+	height := uint64(block.Number())
+
+	stuck := make([]*Stuck, 0)
+	s.mu.Lock()
+	// Transactions in the block are confirmed before the check, so that a sender whose
+	// transaction confirms in this block is not reported.
+	for _, tx := range block.Transactions() {
+		s.confirm(tx, height)
+	}
+	for address, state := range s.senders {
+		if state.expectedNonce == nil {
+			// Nothing outstanding.
+			state.lastProgressBlock = height
+
+			continue
+		}
+		if state.lastProgressBlock == 0 {
+			// First block seen since the expectation.
+			state.lastProgressBlock = height
+
+			continue
+		}
+		if state.alerted || height < state.lastProgressBlock+s.maxBlocks {
+			continue
+		}
+		item := &Stuck{
+			Address:           address,
+			ExpectedNonce:     *state.expectedNonce,
+			LastProgressBlock: state.lastProgressBlock,
+			Block:             height,
+		}
+		if state.confirmedNonce != nil {
+			confirmedNonce := *state.confirmedNonce
+			item.ConfirmedNonce = &confirmedNonce
+		}
+		stuck = append(stuck, item)
+		state.alerted = true
+	}
+	s.mu.Unlock()
+
+	for _, item := range stuck {
+		s.log.Debug().Stringer("address", item.Address).Uint64("expected_nonce", item.ExpectedNonce).Msg("Transactions stuck")
+		s.handler.HandleStuck(ctx, item)
+	}
+
+	return nil
+}
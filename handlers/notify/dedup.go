@@ -0,0 +1,217 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// minCompactEntries is the number of entries below which the deduplication state is not compacted.
+const minCompactEntries = 1024
+
+// deduplicator suppresses repeated notifications for the same subject within a window.
+// If it has a path then the notifications it has seen are appended to a file, so that
+// suppression continues across restarts.  The file is compacted when it holds many more
+// entries than are within the window.
+type deduplicator struct {
+	window  time.Duration
+	path    string
+	mu      sync.Mutex
+	sent    map[string]time.Time
+	entries int
+}
+
+// dedupEntry is an entry in the deduplication state file.
+type dedupEntry struct {
+	Key  string    `json:"key"`
+	Sent time.Time `json:"sent"`
+}
+
+// newDeduplicator creates a deduplicator, loading previously sent notifications from the path if present.
+func newDeduplicator(window time.Duration, path string) (*deduplicator, error) {
+	d := &deduplicator{
+		window: window,
+		path:   path,
+		sent:   make(map[string]time.Time),
+	}
+	if path == "" {
+		return d, nil
+	}
+
+	if err := d.load(); err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.compact(time.Now()); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// load loads previously sent notifications from the deduplication state file, if present.
+func (d *deduplicator) load() error {
+	file, err := os.Open(d.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil
+	case err != nil:
+		return errors.Join(errors.New("failed to read deduplication state"), err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entry := &dedupEntry{}
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			return errors.Join(errors.New("failed to parse deduplication state"), err)
+		}
+		d.sent[entry.Key] = entry.Sent
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Join(errors.New("failed to read deduplication state"), err)
+	}
+
+	return nil
+}
+
+// suppressed returns true if a notification with the given key was sent within the window.
+func (d *deduplicator) suppressed(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	sent, exists := d.sent[key]
+
+	return exists && time.Since(sent) < d.window
+}
+
+// record records that a notification with the given key has been sent.
+func (d *deduplicator) record(key string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	d.sent[key] = now
+	d.expire(now)
+
+	if d.path == "" {
+		return nil
+	}
+
+	// The file holds an entry for each notification sent, so is compacted once most of its
+	// entries are superseded or expired.
+	if d.entries >= minCompactEntries && d.entries >= 2*len(d.sent) {
+		return d.compact(now)
+	}
+
+	return d.append(&dedupEntry{Key: key, Sent: now})
+}
+
+// append appends an entry to the deduplication state file.
+// This assumes that the lock is held.
+func (d *deduplicator) append(entry *dedupEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Join(errors.New("failed to marshal deduplication state"), err)
+	}
+	file, err := os.OpenFile(d.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Join(errors.New("failed to open deduplication state"), err)
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		_ = file.Close()
+
+		return errors.Join(errors.New("failed to write deduplication state"), err)
+	}
+	if err := file.Close(); err != nil {
+		return errors.Join(errors.New("failed to write deduplication state"), err)
+	}
+	d.entries++
+
+	return nil
+}
+
+// compact drops expired notifications and replaces the deduplication state file with those remaining.
+// This assumes that the lock is held.
+func (d *deduplicator) compact(now time.Time) error {
+	d.expire(now)
+
+	data := make([]byte, 0)
+	for key, sent := range d.sent {
+		entry, err := json.Marshal(&dedupEntry{Key: key, Sent: sent})
+		if err != nil {
+			return errors.Join(errors.New("failed to marshal deduplication state"), err)
+		}
+		data = append(append(data, entry...), '\n')
+	}
+	if err := os.MkdirAll(filepath.Dir(d.path), 0o700); err != nil {
+		return errors.Join(errors.New("failed to create deduplication state directory"), err)
+	}
+	tmpPath := d.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return errors.Join(errors.New("failed to write deduplication state"), err)
+	}
+	if err := os.Rename(tmpPath, d.path); err != nil {
+		return errors.Join(errors.New("failed to replace deduplication state"), err)
+	}
+	d.entries = len(d.sent)
+
+	return nil
+}
+
+// expire drops notifications sent outside the window.
+// This assumes that the lock is held.
+func (d *deduplicator) expire(now time.Time) {
+	for key, sent := range d.sent {
+		if now.Sub(sent) >= d.window {
+			delete(d.sent, key)
+		}
+	}
+}
+
+// notificationKey returns a key identifying the subject of a notification, so that alerts for
+// the same subject are suppressed regardless of the item that raised them.  Events are keyed by
+// the emitting address and event signature, and transactions by their sender and recipient.
+// Blocks have no subject beyond themselves, so are keyed by their number.
+func notificationKey(data *Data) string {
+	switch {
+	case data.Event != nil:
+		signature := ""
+		if len(data.Event.Topics) > 0 {
+			signature = data.Event.Topics[0].String()
+		}
+
+		return fmt.Sprintf("%s/event/%s/%s", data.Trigger, data.Event.Address.String(), signature)
+	case data.Tx != nil:
+		to := ""
+		if data.Tx.To() != nil {
+			to = data.Tx.To().String()
+		}
+
+		return fmt.Sprintf("%s/tx/%s/%s", data.Trigger, data.Tx.From().String(), to)
+	case data.Block != nil:
+		return fmt.Sprintf("%s/block/%d", data.Trigger, data.Block.Number())
+	default:
+		return data.Trigger
+	}
+}
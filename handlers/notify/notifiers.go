@@ -0,0 +1,117 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Notifier defines the methods that need to be implemented to send notifications.
+type Notifier interface {
+	// Notify sends a notification.
+	Notify(ctx context.Context, text string) error
+}
+
+// SlackWebhook sends notifications to a Slack incoming webhook.
+type SlackWebhook struct {
+	// URL is the URL of the webhook.
+	URL string
+	// Client is the HTTP client; if nil then http.DefaultClient is used.
+	Client *http.Client
+}
+
+// Notify sends a notification.
+func (n *SlackWebhook) Notify(ctx context.Context, text string) error {
+	return postJSON(ctx, n.Client, n.URL, map[string]string{
+		"text": text,
+	})
+}
+
+// DiscordWebhook sends notifications to a Discord webhook.
+type DiscordWebhook struct {
+	// URL is the URL of the webhook.
+	URL string
+	// Client is the HTTP client; if nil then http.DefaultClient is used.
+	Client *http.Client
+}
+
+// Notify sends a notification.
+func (n *DiscordWebhook) Notify(ctx context.Context, text string) error {
+	return postJSON(ctx, n.Client, n.URL, map[string]string{
+		"content": text,
+	})
+}
+
+// TelegramBot sends notifications to a Telegram chat via a bot.
+type TelegramBot struct {
+	// Token is the token of the bot.
+	Token string
+	// ChatID is the ID of the chat to which notifications are sent.
+	ChatID string
+	// BaseURL is the base URL of the Telegram bot API; if empty then https://api.telegram.org is used.
+	BaseURL string
+	// Client is the HTTP client; if nil then http.DefaultClient is used.
+	Client *http.Client
+}
+
+// Notify sends a notification.
+func (n *TelegramBot) Notify(ctx context.Context, text string) error {
+	baseURL := n.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.telegram.org"
+	}
+
+	return postJSON(ctx, n.Client, fmt.Sprintf("%s/bot%s/sendMessage", baseURL, n.Token), map[string]string{
+		"chat_id": n.ChatID,
+		"text":    text,
+	})
+}
+
+// postJSON posts a JSON body to a URL.
+func postJSON(ctx context.Context, client *http.Client, url string, body any) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return errors.Join(errors.New("failed to marshal notification"), err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return errors.Join(errors.New("failed to create notification request"), err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Join(errors.New("failed to post notification"), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+		return fmt.Errorf("notification request returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/wealdtech/go-eth-listener/format"
+)
+
+// Default templates for notifications.
+const (
+	defaultBlockTemplate = `{{.Trigger}}: block {{.Block.Number}} {{explorerBlock .Block.Number}}`
+	defaultTxTemplate    = `{{.Trigger}}: transaction from {{address .Tx.From}}{{with .Tx.To}} to {{address .}}{{end}}` +
+		` value {{ether .Tx.Value}} ETH {{explorerTx .Tx.Hash}}`
+	defaultEventTemplate = `{{.Trigger}}: event from {{address .Event.Address}} at block {{.Event.BlockNumber}}` +
+		` {{explorerTx .Event.TransactionHash}}`
+)
+
+type parameters struct {
+	logLevel      zerolog.Level
+	notifier      Notifier
+	blockTemplate string
+	txTemplate    string
+	eventTemplate string
+	formatParams  []format.Parameter
+	skipCatchUp   bool
+	dedupWindow   time.Duration
+	dedupPath     string
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithNotifier sets the notifier to which messages are sent.
+func WithNotifier(notifier Notifier) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.notifier = notifier
+	})
+}
+
+// WithBlockTemplate sets the text/template used to format block notifications.
+func WithBlockTemplate(tmpl string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.blockTemplate = tmpl
+	})
+}
+
+// WithTxTemplate sets the text/template used to format transaction notifications.
+func WithTxTemplate(tmpl string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.txTemplate = tmpl
+	})
+}
+
+// WithEventTemplate sets the text/template used to format event notifications.
+func WithEventTemplate(tmpl string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.eventTemplate = tmpl
+	})
+}
+
+// WithFormatParameters sets parameters for formatting templates, such as the block explorer URL.
+func WithFormatParameters(params ...format.Parameter) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.formatParams = append(p.formatParams, params...)
+	})
+}
+
+// WithSkipCatchUp suppresses notifications for items handled whilst the listener is catching up
+// with the chain, so that historical items do not generate alerts.
+func WithSkipCatchUp(skip bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.skipCatchUp = skip
+	})
+}
+
+// WithDeduplicationWindow suppresses repeated notifications for the same subject within the
+// given window, for example when an item is redelivered after a failed poll is retried.
+// Events are identified by their address and signature, and transactions by their sender and
+// recipient.
+func WithDeduplicationWindow(window time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.dedupWindow = window
+	})
+}
+
+// WithDeduplicationPath sets the path of a file in which sent notifications are stored, so that
+// deduplication continues across restarts.  It has no effect without a deduplication window.
+func WithDeduplicationPath(path string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.dedupPath = path
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:      zerolog.GlobalLevel(),
+		blockTemplate: defaultBlockTemplate,
+		txTemplate:    defaultTxTemplate,
+		eventTemplate: defaultEventTemplate,
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.notifier == nil {
+		return nil, errors.New("no notifier specified")
+	}
+	if parameters.dedupWindow < 0 {
+		return nil, errors.New("deduplication window cannot be negative")
+	}
+
+	return &parameters, nil
+}
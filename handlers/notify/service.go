@@ -0,0 +1,207 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notify provides handlers that send human-readable notifications of blocks, transactions
+// and events to chat services such as Slack, Discord and Telegram.
+// Notifications are formatted with templates, which have access to the helpers in the format package.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"text/template"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/format"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// Data is the data available to notification templates.
+type Data struct {
+	// Trigger is the name of the trigger.
+	Trigger string
+	// Confirmations is the confirmation depth of the item.
+	Confirmations uint32
+	// Block is the block, for block notifications.
+	Block *spec.Block
+	// Tx is the transaction, for transaction notifications.
+	Tx *spec.Transaction
+	// Event is the event, for event notifications.
+	Event *spec.BerlinTransactionEvent
+	// Labels are the labels for addresses in the item, if available.
+	Labels map[types.Address]*handlers.AddressLabel
+	// Token is the metadata of the token that emitted the event, if available.
+	Token *handlers.TokenMetadata
+	// Signatures are the probable signatures of the function called or the event, if available.
+	Signatures []string
+	// IdempotencyKey is the idempotency key of the item, if available.
+	IdempotencyKey string
+}
+
+// Label returns the label for an address if available, otherwise the abbreviated address.
+func (d *Data) Label(address types.Address) string {
+	if label, exists := d.Labels[address]; exists && label.Label != "" {
+		return label.Label
+	}
+	formatted := address.String()
+
+	return fmt.Sprintf("%s…%s", formatted[:6], formatted[len(formatted)-4:])
+}
+
+// TokenAmount returns a raw token amount in human units with the token's symbol, if token
+// metadata is available, otherwise the raw amount.
+func (d *Data) TokenAmount(amount *big.Int) string {
+	if d.Token == nil {
+		return amount.String()
+	}
+	if d.Token.Symbol == "" {
+		return d.Token.FormatAmount(amount)
+	}
+
+	return fmt.Sprintf("%s %s", d.Token.FormatAmount(amount), d.Token.Symbol)
+}
+
+// Service sends notifications.
+// It implements the block, transaction and event handler interfaces.
+type Service struct {
+	log           zerolog.Logger
+	notifier      Notifier
+	blockTemplate *template.Template
+	txTemplate    *template.Template
+	eventTemplate *template.Template
+	skipCatchUp   bool
+	dedup         *deduplicator
+}
+
+// New creates a new notification handler.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "notify").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	blockTemplate, err := format.Parse("block", parameters.blockTemplate, parameters.formatParams...)
+	if err != nil {
+		return nil, err
+	}
+	txTemplate, err := format.Parse("transaction", parameters.txTemplate, parameters.formatParams...)
+	if err != nil {
+		return nil, err
+	}
+	eventTemplate, err := format.Parse("event", parameters.eventTemplate, parameters.formatParams...)
+	if err != nil {
+		return nil, err
+	}
+
+	var dedup *deduplicator
+	if parameters.dedupWindow > 0 {
+		dedup, err = newDeduplicator(parameters.dedupWindow, parameters.dedupPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Service{
+		log:           log,
+		notifier:      parameters.notifier,
+		blockTemplate: blockTemplate,
+		txTemplate:    txTemplate,
+		eventTemplate: eventTemplate,
+		skipCatchUp:   parameters.skipCatchUp,
+		dedup:         dedup,
+	}, nil
+}
+
+// HandleBlock sends a notification for a block.
+func (s *Service) HandleBlock(ctx context.Context, block *spec.Block, trigger *handlers.BlockTrigger) error {
+	return s.notify(ctx, s.blockTemplate, &Data{
+		Trigger: trigger.Name,
+		Block:   block,
+	})
+}
+
+// HandleTx sends a notification for a transaction.
+func (s *Service) HandleTx(ctx context.Context, tx *spec.Transaction, trigger *handlers.TxTrigger) {
+	if err := s.notify(ctx, s.txTemplate, &Data{
+		Trigger: trigger.Name,
+		Tx:      tx,
+	}); err != nil {
+		s.log.Error().Stringer("tx", tx.Hash()).Err(err).Msg("Failed to send notification")
+	}
+}
+
+// HandleEvent sends a notification for an event.
+func (s *Service) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, trigger *handlers.EventTrigger) error {
+	return s.notify(ctx, s.eventTemplate, &Data{
+		Trigger: trigger.Name,
+		Event:   event,
+	})
+}
+
+func (s *Service) notify(ctx context.Context, tmpl *template.Template, data *Data) error {
+	if s.skipCatchUp {
+		if info := handlers.RunInfoFromContext(ctx); info != nil && info.CatchingUp {
+			s.log.Trace().Str("trigger", data.Trigger).Msg("Catching up; not sending notification")
+
+			return nil
+		}
+	}
+
+	var key string
+	if s.dedup != nil {
+		key = notificationKey(data)
+		if s.dedup.suppressed(key) {
+			s.log.Trace().Str("key", key).Msg("Notification recently sent; suppressing")
+
+			return nil
+		}
+	}
+
+	if confirmations, exists := handlers.ConfirmationsFromContext(ctx); exists {
+		data.Confirmations = confirmations
+	}
+	data.Labels = handlers.AddressLabelsFromContext(ctx)
+	data.Token = handlers.TokenMetadataFromContext(ctx)
+	data.Signatures = handlers.SignaturesFromContext(ctx)
+	data.IdempotencyKey = handlers.IdempotencyKeyFromContext(ctx)
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return errors.Join(errors.New("failed to format notification"), err)
+	}
+
+	if err := s.notifier.Notify(ctx, buf.String()); err != nil {
+		return errors.Join(errors.New("failed to send notification"), err)
+	}
+	s.log.Trace().Str("trigger", data.Trigger).Msg("Sent notification")
+	if s.dedup != nil {
+		if err := s.dedup.record(key); err != nil {
+			// The notification has been sent, so failure to record it is not an error for the handler.
+			s.log.Warn().Err(err).Msg("Failed to record notification for deduplication")
+		}
+	}
+
+	return nil
+}
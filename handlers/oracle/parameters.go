@@ -0,0 +1,103 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oracle
+
+import (
+	"errors"
+
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel         zerolog.Level
+	feeds            []types.Address
+	stalenessBlocks  uint32
+	updateHandler    UpdateHandler
+	stalenessHandler StalenessHandler
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithFeeds sets the aggregator contracts of the feeds to watch.
+func WithFeeds(feeds []types.Address) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.feeds = feeds
+	})
+}
+
+// WithStalenessBlocks sets the number of blocks without an update after which a feed is stale.
+// If not supplied feeds are not checked for staleness.
+func WithStalenessBlocks(blocks uint32) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.stalenessBlocks = blocks
+	})
+}
+
+// WithUpdateHandler sets the handler for feed updates.
+func WithUpdateHandler(handler UpdateHandler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.updateHandler = handler
+	})
+}
+
+// WithStalenessHandler sets the handler for stale feeds.
+func WithStalenessHandler(handler StalenessHandler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.stalenessHandler = handler
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if len(parameters.feeds) == 0 {
+		return nil, errors.New("no feeds specified")
+	}
+	if parameters.updateHandler == nil && parameters.stalenessHandler == nil {
+		return nil, errors.New("no handler specified")
+	}
+	if parameters.stalenessHandler != nil && parameters.stalenessBlocks == 0 {
+		return nil, errors.New("staleness handler specified without staleness blocks")
+	}
+	if parameters.stalenessBlocks > 0 && parameters.stalenessHandler == nil {
+		return nil, errors.New("staleness blocks specified without staleness handler")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,251 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package oracle provides triggers that deliver typed updates from Chainlink-style price feeds,
+// and alert when feeds become stale.
+package oracle
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/abi"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// answerUpdatedTopic is the topic of the AnswerUpdated(int256,uint256,uint256) event.
+var answerUpdatedTopic = abi.EventTopic("AnswerUpdated(int256,uint256,uint256)")
+
+// stalenessSuffix is appended to the name of the trigger to give the name of the staleness trigger.
+const stalenessSuffix = "-staleness"
+
+// Update is an update to a feed.
+type Update struct {
+	// Feed is the aggregator contract of the feed.
+	Feed types.Address
+	// RoundID is the round of the update.
+	RoundID *big.Int
+	// Answer is the answer of the round, in the feed's smallest unit.
+	Answer *big.Int
+	// UpdatedAt is the time at which the round was updated.
+	UpdatedAt time.Time
+	// TransactionHash is the hash of the transaction containing the update.
+	TransactionHash types.Hash
+	// Block is the block containing the update.
+	Block uint64
+	// LogIndex is the index of the update event in the block.
+	LogIndex uint32
+}
+
+// Staleness is an alert that a feed has not been updated within the configured number of blocks.
+type Staleness struct {
+	// Feed is the aggregator contract of the feed.
+	Feed types.Address
+	// LastUpdate is the last update seen for the feed, or nil if none has been seen.
+	LastUpdate *Update
+	// Since is the block from which the feed has not been updated.
+	Since uint64
+	// Block is the block at which the feed became stale.
+	Block uint64
+}
+
+// UpdateHandler defines the methods that need to be implemented to handle feed updates.
+type UpdateHandler interface {
+	// HandleUpdate handles a feed update.
+	HandleUpdate(ctx context.Context, update *Update) error
+}
+
+// StalenessHandler defines the methods that need to be implemented to handle stale feeds.
+type StalenessHandler interface {
+	// HandleStaleness handles a feed that has become stale.
+	HandleStaleness(ctx context.Context, staleness *Staleness) error
+}
+
+// feedState is the state of a feed.
+type feedState struct {
+	lastUpdate *Update
+	// since is the block from which the feed has not been updated.
+	since uint64
+	// tracked is true if since has been set.
+	tracked bool
+	// alerted is true if the feed has been alerted as stale since its last update.
+	alerted bool
+}
+
+// Service delivers feed updates and staleness alerts.
+type Service struct {
+	log              zerolog.Logger
+	stalenessBlocks  uint64
+	updateHandler    UpdateHandler
+	stalenessHandler StalenessHandler
+	feedsMu          sync.Mutex
+	feeds            map[types.Address]*feedState
+}
+
+// New creates a new oracle update watcher.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "oracle").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	feeds := make(map[types.Address]*feedState, len(parameters.feeds))
+	for _, feed := range parameters.feeds {
+		feeds[feed] = &feedState{}
+	}
+
+	return &Service{
+		log:              log,
+		stalenessBlocks:  uint64(parameters.stalenessBlocks),
+		updateHandler:    parameters.updateHandler,
+		stalenessHandler: parameters.stalenessHandler,
+		feeds:            feeds,
+	}, nil
+}
+
+// Triggers returns the event trigger that feeds the watcher, and the block trigger that checks
+// for staleness.  The block trigger is nil if the watcher is not configured to check staleness;
+// if present it depends on the event trigger so that it never checks a block whose updates have
+// not been seen.
+// Staleness is tracked in memory, so after a restart a feed is considered updated at the first
+// block checked.
+func (s *Service) Triggers(name string, earliestBlock uint64) (*handlers.EventTrigger, *handlers.BlockTrigger) {
+	eventTrigger := &handlers.EventTrigger{
+		Name:          name,
+		SourceSet:     s,
+		Topics:        []types.Hash{answerUpdatedTopic},
+		EarliestBlock: earliestBlock,
+		Handler:       s,
+	}
+
+	var blockTrigger *handlers.BlockTrigger
+	if s.stalenessHandler != nil {
+		blockTrigger = &handlers.BlockTrigger{
+			Name:          name + stalenessSuffix,
+			EarliestBlock: earliestBlock,
+			Handler:       s,
+			DependsOn:     []string{eventTrigger.QualifiedName()},
+		}
+	}
+
+	return eventTrigger, blockTrigger
+}
+
+// Contains returns true if the address is a watched feed.
+func (s *Service) Contains(address types.Address) bool {
+	_, exists := s.feeds[address]
+
+	return exists
+}
+
+// Addresses returns the watched feeds.
+func (s *Service) Addresses() []types.Address {
+	res := make([]types.Address, 0, len(s.feeds))
+	for address := range s.feeds {
+		res = append(res, address)
+	}
+
+	return res
+}
+
+// HandleEvent handles an event, delivering it as a feed update.
+// AnswerUpdated is (int256 indexed current, uint256 indexed roundId, uint256 updatedAt).
+func (s *Service) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, _ *handlers.EventTrigger) error {
+	if !s.Contains(event.Address) || len(event.Topics) != 3 || event.Topics[0] != answerUpdatedTopic {
+		return nil
+	}
+	updatedAt, err := abi.Uint(event.Data, 0)
+	if err != nil || !updatedAt.IsInt64() {
+		s.log.Debug().Stringer("tx", event.TransactionHash).Uint32("index", event.Index).Msg("Failed to decode feed update")
+
+		return nil
+	}
+
+	update := &Update{
+		Feed:            event.Address,
+		RoundID:         abi.TopicUint(event.Topics[2]),
+		Answer:          abi.TopicInt(event.Topics[1]),
+		UpdatedAt:       time.Unix(updatedAt.Int64(), 0),
+		TransactionHash: event.TransactionHash,
+		Block:           uint64(event.BlockNumber),
+		LogIndex:        event.Index,
+	}
+
+	if s.updateHandler != nil {
+		if err := s.updateHandler.HandleUpdate(ctx, update); err != nil {
+			return err
+		}
+	}
+
+	s.feedsMu.Lock()
+	state := s.feeds[update.Feed]
+	state.lastUpdate = update
+	state.since = update.Block
+	state.tracked = true
+	state.alerted = false
+	s.feedsMu.Unlock()
+
+	return nil
+}
+
+// HandleBlock handles a block, alerting on feeds that have become stale.
+func (s *Service) HandleBlock(ctx context.Context, block *spec.Block, _ *handlers.BlockTrigger) error {
+	height := uint64(block.Number())
+
+	s.feedsMu.Lock()
+	stale := make([]*Staleness, 0)
+	for feed, state := range s.feeds {
+		if !state.tracked {
+			// First block seen without an update; start tracking from here.
+			state.since = height
+			state.tracked = true
+		}
+		if state.alerted || height < state.since+s.stalenessBlocks {
+			continue
+		}
+		stale = append(stale, &Staleness{
+			Feed:       feed,
+			LastUpdate: state.lastUpdate,
+			Since:      state.since,
+			Block:      height,
+		})
+	}
+	s.feedsMu.Unlock()
+
+	for _, staleness := range stale {
+		s.log.Debug().Stringer("feed", staleness.Feed).Uint64("since", staleness.Since).Uint64("block", height).Msg("Feed is stale")
+		if err := s.stalenessHandler.HandleStaleness(ctx, staleness); err != nil {
+			return err
+		}
+		s.feedsMu.Lock()
+		if state := s.feeds[staleness.Feed]; state.since == staleness.Since {
+			state.alerted = true
+		}
+		s.feedsMu.Unlock()
+	}
+
+	return nil
+}
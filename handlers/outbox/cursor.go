@@ -0,0 +1,154 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// cursor is the position of the latest item written for a trigger, as stored in the cursor table.
+type cursor struct {
+	block     uint64
+	blockHash string
+	txIndex   uint32
+	logIndex  uint32
+}
+
+// written returns true if the item at the given position was written at or before the cursor.
+// Items in a block with a different hash to that of the cursor have replaced it in a reorg, so
+// are not considered to have been written.
+func (c *cursor) written(position *cursor) bool {
+	if position.block != c.block {
+		return position.block < c.block
+	}
+	if position.blockHash != c.blockHash {
+		return false
+	}
+	if position.txIndex != c.txIndex {
+		return position.txIndex < c.txIndex
+	}
+
+	return position.logIndex <= c.logIndex
+}
+
+// bind converts a query written with ? placeholders to the placeholder style of the database.
+func (s *Service) bind(query string) string {
+	if s.placeholder == PlaceholderQuestion {
+		return query
+	}
+
+	var builder strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			fmt.Fprintf(&builder, "$%d", n)
+			continue
+		}
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
+
+// createTables creates the outbox and cursor tables if they do not exist.
+func (s *Service) createTables(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+  trigger_name VARCHAR(255) NOT NULL,
+  idempotency_key VARCHAR(255) NOT NULL,
+  item_type VARCHAR(16) NOT NULL,
+  block_number BIGINT NOT NULL,
+  payload TEXT NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (trigger_name, idempotency_key)
+)`, s.outboxTable)); err != nil {
+		return errors.Join(errors.New("failed to create outbox table"), err)
+	}
+
+	if _, err := s.db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+  trigger_name VARCHAR(255) NOT NULL PRIMARY KEY,
+  block_number BIGINT NOT NULL,
+  block_hash VARCHAR(66) NOT NULL,
+  tx_index BIGINT NOT NULL,
+  log_index BIGINT NOT NULL
+)`, s.cursorTable)); err != nil {
+		return errors.Join(errors.New("failed to create cursor table"), err)
+	}
+
+	return nil
+}
+
+// loadCursors loads the cursor of each trigger from the cursor table.
+func (s *Service) loadCursors(ctx context.Context) error {
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(
+		"SELECT trigger_name, block_number, block_hash, tx_index, log_index FROM %s", s.cursorTable))
+	if err != nil {
+		return errors.Join(errors.New("failed to query cursors"), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var trigger string
+		c := &cursor{}
+		if err := rows.Scan(&trigger, &c.block, &c.blockHash, &c.txIndex, &c.logIndex); err != nil {
+			return errors.Join(errors.New("failed to read cursor"), err)
+		}
+		s.cursors[trigger] = c
+		s.log.Trace().Str("trigger", trigger).Uint64("block", c.block).Msg("Loaded cursor")
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Join(errors.New("failed to read cursors"), err)
+	}
+
+	return nil
+}
+
+// clearCursors removes the cursors of all triggers from the cursor table.
+func (s *Service) clearCursors(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s", s.cursorTable)); err != nil {
+		return errors.Join(errors.New("failed to clear cursors"), err)
+	}
+
+	return nil
+}
+
+// storeCursor stores the cursor of a trigger within a transaction.
+// An update followed by an insert is used rather than an upsert, as upsert syntax varies between databases.
+func (s *Service) storeCursor(ctx context.Context, tx *sql.Tx, trigger string, c *cursor) error {
+	res, err := tx.ExecContext(ctx,
+		s.bind(fmt.Sprintf("UPDATE %s SET block_number = ?, block_hash = ?, tx_index = ?, log_index = ? WHERE trigger_name = ?", s.cursorTable)),
+		c.block, c.blockHash, c.txIndex, c.logIndex, trigger)
+	if err != nil {
+		return errors.Join(errors.New("failed to update cursor"), err)
+	}
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return errors.Join(errors.New("failed to obtain updated cursors"), err)
+	}
+	if updated > 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		s.bind(fmt.Sprintf("INSERT INTO %s (trigger_name, block_number, block_hash, tx_index, log_index) VALUES (?, ?, ?, ?, ?)", s.cursorTable)),
+		trigger, c.block, c.blockHash, c.txIndex, c.logIndex); err != nil {
+		return errors.Join(errors.New("failed to insert cursor"), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outbox
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/rs/zerolog"
+)
+
+// Placeholder is the style of query placeholders used by the database driver.
+type Placeholder int
+
+const (
+	// PlaceholderDollar is the $1 style used by PostgreSQL.
+	PlaceholderDollar Placeholder = iota
+	// PlaceholderQuestion is the ? style used by MySQL and SQLite.
+	PlaceholderQuestion
+)
+
+// tableName matches permitted table names, which are interpolated into queries.
+var tableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+type parameters struct {
+	logLevel     zerolog.Level
+	db           *sql.DB
+	placeholder  Placeholder
+	outboxTable  string
+	cursorTable  string
+	createTables bool
+	writer       Writer
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithDB sets the database to which items are written.
+func WithDB(db *sql.DB) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.db = db
+	})
+}
+
+// WithPlaceholder sets the style of query placeholders used by the database driver.
+func WithPlaceholder(placeholder Placeholder) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.placeholder = placeholder
+	})
+}
+
+// WithOutboxTable sets the name of the outbox table.
+func WithOutboxTable(table string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.outboxTable = table
+	})
+}
+
+// WithCursorTable sets the name of the cursor table.
+func WithCursorTable(table string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.cursorTable = table
+	})
+}
+
+// WithCreateTables creates the outbox and cursor tables if they do not exist.
+func WithCreateTables(createTables bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.createTables = createTables
+	})
+}
+
+// WithWriter sets a writer for the application's own tables, which is called within the same
+// transaction as the outbox and cursor writes.
+func WithWriter(writer Writer) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.writer = writer
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:    zerolog.GlobalLevel(),
+		placeholder: PlaceholderDollar,
+		outboxTable: "eth_listener_outbox",
+		cursorTable: "eth_listener_cursors",
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.db == nil {
+		return nil, errors.New("no database specified")
+	}
+	if parameters.placeholder != PlaceholderDollar && parameters.placeholder != PlaceholderQuestion {
+		return nil, errors.New("unknown placeholder style")
+	}
+	if !tableName.MatchString(parameters.outboxTable) {
+		return nil, fmt.Errorf("invalid outbox table name %q", parameters.outboxTable)
+	}
+	if !tableName.MatchString(parameters.cursorTable) {
+		return nil, fmt.Errorf("invalid cursor table name %q", parameters.cursorTable)
+	}
+	if parameters.outboxTable == parameters.cursorTable {
+		return nil, errors.New("outbox and cursor tables must differ")
+	}
+
+	return &parameters, nil
+}
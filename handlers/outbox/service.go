@@ -0,0 +1,233 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package outbox provides handlers that write blocks, transactions and events to an outbox table in
+// the application's SQL database, in the same transaction as the progress of the trigger.
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// Item is an item being written.
+type Item struct {
+	// Type is the type of the item: block, transaction or event.
+	Type string
+	// Trigger is the qualified name of the trigger that delivered the item.
+	Trigger string
+	// IdempotencyKey is the idempotency key of the item.
+	IdempotencyKey string
+	// Block is the block, for block items.
+	Block *spec.Block
+	// Tx is the transaction, for transaction items.
+	Tx *spec.Transaction
+	// Event is the event, for event items.
+	Event *spec.BerlinTransactionEvent
+}
+
+// Writer defines the methods that need to be implemented to write items to the application's own tables.
+type Writer interface {
+	// WriteItem writes an item within the supplied transaction.
+	// If it returns an error then the transaction is rolled back, and the item will be redelivered.
+	WriteItem(ctx context.Context, tx *sql.Tx, item *Item) error
+}
+
+// Service writes items to an outbox table.
+// It implements the block, transaction and event handler interfaces.
+//
+// Each item is written to the outbox table, along with the application's own writes if a writer is
+// configured, and the position of the item in the cursor table, in a single database transaction.
+// The cursors are loaded on start, and items that the listener redelivers after a failure between
+// the database transaction committing and the listener's own progress being stored are skipped,
+// so the database holds each item exactly once.  Applications relay outbox rows onwards and delete
+// them as required.
+//
+// A cursor only identifies items on the chain that it was written from, so when the chain reorganizes
+// the cursors must be cleared with HandleReorg before the listener is rewound, otherwise items on the
+// new chain below the old cursors would be skipped.  The service implements the reorg handler interface
+// so that it can be passed to the listener directly, or called from the application's own reorg handler.
+//
+// Transaction handlers cannot fail, so a transaction that cannot be written is logged and lost;
+// event triggers should be preferred where delivery matters.
+type Service struct {
+	log         zerolog.Logger
+	db          *sql.DB
+	placeholder Placeholder
+	outboxTable string
+	cursorTable string
+	writer      Writer
+	mu          sync.Mutex
+	cursors     map[string]*cursor
+}
+
+// New creates a new outbox handler.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "outbox").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		log:         log,
+		db:          parameters.db,
+		placeholder: parameters.placeholder,
+		outboxTable: parameters.outboxTable,
+		cursorTable: parameters.cursorTable,
+		writer:      parameters.writer,
+		cursors:     make(map[string]*cursor),
+	}
+
+	if parameters.createTables {
+		if err := s.createTables(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.loadCursors(ctx); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// HandleBlock writes a block.
+func (s *Service) HandleBlock(ctx context.Context, block *spec.Block, trigger *handlers.BlockTrigger) error {
+	return s.write(ctx, &Item{
+		Type:    "block",
+		Trigger: trigger.QualifiedName(),
+		Block:   block,
+	}, block, &cursor{
+		block:     uint64(block.Number()),
+		blockHash: block.Hash().String(),
+	})
+}
+
+// HandleTx writes a transaction.
+func (s *Service) HandleTx(ctx context.Context, tx *spec.Transaction, trigger *handlers.TxTrigger) {
+	if tx.BlockNumber() == nil || tx.BlockHash() == nil || tx.TransactionIndex() == nil {
+		s.log.Error().Stringer("tx", tx.Hash()).Msg("Transaction does not have a position; cannot write")
+		return
+	}
+
+	if err := s.write(ctx, &Item{
+		Type:    "transaction",
+		Trigger: trigger.QualifiedName(),
+		Tx:      tx,
+	}, tx, &cursor{
+		block:     uint64(*tx.BlockNumber()),
+		blockHash: tx.BlockHash().String(),
+		txIndex:   *tx.TransactionIndex(),
+	}); err != nil {
+		s.log.Error().Stringer("tx", tx.Hash()).Err(err).Msg("Failed to write transaction")
+	}
+}
+
+// HandleEvent writes an event.
+func (s *Service) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, trigger *handlers.EventTrigger) error {
+	return s.write(ctx, &Item{
+		Type:    "event",
+		Trigger: trigger.QualifiedName(),
+		Event:   event,
+	}, event, &cursor{
+		block:     uint64(event.BlockNumber),
+		blockHash: event.BlockHash.String(),
+		txIndex:   event.TransactionIndex,
+		logIndex:  event.Index,
+	})
+}
+
+// HandleReorg clears the cursors of all triggers, as items written from the replaced chain no longer
+// identify the items that the listener will deliver from the new chain.
+func (s *Service) HandleReorg(ctx context.Context, oldHead *handlers.ChainHead, newHead *handlers.ChainHead) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cursors = make(map[string]*cursor)
+	if err := s.clearCursors(ctx); err != nil {
+		// The in-memory cursors are cleared, so items are written until the process restarts.
+		s.log.Error().Err(err).Msg("Failed to clear cursors after reorg")
+
+		return
+	}
+	s.log.Debug().Uint64("old_head", oldHead.Number).Uint64("new_head", newHead.Number).Msg("Cleared cursors after reorg")
+}
+
+func (s *Service) write(ctx context.Context, item *Item, data any, position *cursor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if latest, exists := s.cursors[item.Trigger]; exists && latest.written(position) {
+		s.log.Trace().Str("trigger", item.Trigger).Uint64("block", position.block).Msg("Item already written")
+
+		return nil
+	}
+
+	item.IdempotencyKey = handlers.IdempotencyKeyFromContext(ctx)
+	if item.IdempotencyKey == "" {
+		item.IdempotencyKey = fmt.Sprintf("%s:%d:%d:%d", position.blockHash, position.block, position.txIndex, position.logIndex)
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return errors.Join(errors.New("failed to marshal item"), err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Join(errors.New("failed to begin transaction"), err)
+	}
+	if err := s.writeInTx(ctx, tx, item, payload, position); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.log.Warn().Err(rollbackErr).Msg("Failed to roll back transaction")
+		}
+
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Join(errors.New("failed to commit transaction"), err)
+	}
+	s.cursors[item.Trigger] = position
+	s.log.Trace().Str("trigger", item.Trigger).Uint64("block", position.block).Msg("Wrote item")
+
+	return nil
+}
+
+func (s *Service) writeInTx(ctx context.Context, tx *sql.Tx, item *Item, payload []byte, position *cursor) error {
+	if _, err := tx.ExecContext(ctx,
+		s.bind(fmt.Sprintf("INSERT INTO %s (trigger_name, idempotency_key, item_type, block_number, payload) VALUES (?, ?, ?, ?, ?)", s.outboxTable)),
+		item.Trigger, item.IdempotencyKey, item.Type, position.block, string(payload)); err != nil {
+		return errors.Join(errors.New("failed to insert outbox row"), err)
+	}
+
+	if s.writer != nil {
+		if err := s.writer.WriteItem(ctx, tx, item); err != nil {
+			return errors.Join(errors.New("writer failed"), err)
+		}
+	}
+
+	return s.storeCursor(ctx, tx, item.Trigger, position)
+}
@@ -0,0 +1,145 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outbox_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/wealdtech/go-eth-listener/handlers"
+	"github.com/wealdtech/go-eth-listener/handlers/outbox"
+)
+
+// recordingDriver is a database driver that accepts all statements, recording those executed.
+type recordingDriver struct {
+	mu         sync.Mutex
+	statements []string
+}
+
+func (d *recordingDriver) Open(_ string) (driver.Conn, error) {
+	return &recordingConn{driver: d}, nil
+}
+
+// inserts returns the number of rows inserted in to the given table.
+func (d *recordingDriver) inserts(table string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	inserts := 0
+	for _, statement := range d.statements {
+		if strings.HasPrefix(statement, "INSERT INTO "+table+" ") {
+			inserts++
+		}
+	}
+
+	return inserts
+}
+
+type recordingConn struct {
+	driver *recordingDriver
+}
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return &recordingStmt{conn: c, query: query}, nil
+}
+
+func (c *recordingConn) Close() error { return nil }
+
+func (c *recordingConn) Begin() (driver.Tx, error) { return c, nil }
+
+func (c *recordingConn) Commit() error { return nil }
+
+func (c *recordingConn) Rollback() error { return nil }
+
+type recordingStmt struct {
+	conn  *recordingConn
+	query string
+}
+
+func (s *recordingStmt) Close() error { return nil }
+
+func (s *recordingStmt) NumInput() int { return -1 }
+
+func (s *recordingStmt) Exec(_ []driver.Value) (driver.Result, error) {
+	s.conn.driver.mu.Lock()
+	s.conn.driver.statements = append(s.conn.driver.statements, s.query)
+	s.conn.driver.mu.Unlock()
+
+	return driver.RowsAffected(1), nil
+}
+
+func (s *recordingStmt) Query(_ []driver.Value) (driver.Rows, error) {
+	return &emptyRows{}, nil
+}
+
+type emptyRows struct{}
+
+func (r *emptyRows) Columns() []string {
+	return []string{"trigger_name", "block_number", "block_hash", "tx_index", "log_index"}
+}
+
+func (r *emptyRows) Close() error { return nil }
+
+func (r *emptyRows) Next(_ []driver.Value) error { return io.EOF }
+
+func TestReorgClearsCursors(t *testing.T) {
+	ctx := context.Background()
+	recorder := &recordingDriver{}
+	sql.Register("outbox-recording", recorder)
+	db, err := sql.Open("outbox-recording", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := outbox.New(ctx, outbox.WithDB(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	trigger := &handlers.EventTrigger{Name: "test"}
+	event := func(block uint32, hash byte) *spec.BerlinTransactionEvent {
+		return &spec.BerlinTransactionEvent{
+			BlockNumber: block,
+			BlockHash:   types.Hash{hash},
+		}
+	}
+
+	if err := s.HandleEvent(ctx, event(10, 0x01), trigger); err != nil {
+		t.Fatal(err)
+	}
+	// A redelivered item is skipped.
+	if err := s.HandleEvent(ctx, event(10, 0x01), trigger); err != nil {
+		t.Fatal(err)
+	}
+	if inserts := recorder.inserts("eth_listener_outbox"); inserts != 1 {
+		t.Fatalf("expected 1 item written, found %d", inserts)
+	}
+
+	// Following a reorg, items from the new chain below the previous cursor are written.
+	s.HandleReorg(ctx, &handlers.ChainHead{Number: 10, Hash: types.Hash{0x01}}, &handlers.ChainHead{Number: 11, Hash: types.Hash{0x03}})
+	if err := s.HandleEvent(ctx, event(9, 0x02), trigger); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.HandleEvent(ctx, event(10, 0x03), trigger); err != nil {
+		t.Fatal(err)
+	}
+	if inserts := recorder.inserts("eth_listener_outbox"); inserts != 3 {
+		t.Fatalf("expected 3 items written, found %d", inserts)
+	}
+}
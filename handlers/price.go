@@ -0,0 +1,48 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// Price is the price of an asset at a point in time.
+type Price struct {
+	// Asset is the address of the asset's token, or the zero address for ether.
+	Asset types.Address
+	// Currency is the currency in which the price is quoted, for example "USD" or "ETH".
+	Currency string
+	// Timestamp is the time for which the price applies.
+	Timestamp time.Time
+	// Value is the price of one whole unit of the asset.
+	Value *big.Float
+}
+
+// PriceSource is the interface for sources of prices.
+type PriceSource interface {
+	// Price returns the price of an asset in a currency at the given time.
+	// The asset is the address of the asset's token, or the zero address for ether.
+	Price(ctx context.Context, asset types.Address, currency string, timestamp time.Time) (*Price, error)
+}
+
+// PriceEnricher is the interface used by handlers to obtain the price of an asset at the time of a block.
+type PriceEnricher interface {
+	// PriceAtBlock returns the price of an asset in a currency at the timestamp of the given block.
+	// The asset is the address of the asset's token, or the zero address for ether.
+	PriceAtBlock(ctx context.Context, asset types.Address, currency string, height uint64) (*Price, error)
+}
@@ -0,0 +1,184 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pubsub provides handlers that publish blocks, transactions and events to Google Cloud Pub/Sub.
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+	"github.com/wealdtech/go-eth-listener/handlers/cloudevents"
+)
+
+// Message is a message to be published.
+type Message struct {
+	// Data is the JSON representation of the item.
+	Data []byte
+	// Attributes are the message attributes.
+	Attributes map[string]string
+	// OrderingKey is the ordering key for the message, being the address of the item,
+	// so that messages for each contract are delivered in order.
+	OrderingKey string
+}
+
+// Publisher defines the methods that need to be implemented to publish messages.
+// It is expected to be a thin adapter around a Pub/Sub topic with message ordering enabled.
+// If a publish with an ordering key fails then the adapter should resume publishing for that
+// key before returning, to allow the message to be retried.
+type Publisher interface {
+	// Publish publishes a message, returning its server-assigned ID.
+	Publish(ctx context.Context, message *Message) (string, error)
+}
+
+// Service publishes items to Pub/Sub.
+// It implements the block, transaction and event handler interfaces.
+type Service struct {
+	log        zerolog.Logger
+	publisher  Publisher
+	maxRetries int
+	retryDelay time.Duration
+	ceSource   string
+}
+
+// New creates a new Pub/Sub handler.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "pubsub").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	return &Service{
+		log:        log,
+		publisher:  parameters.publisher,
+		maxRetries: parameters.maxRetries,
+		retryDelay: parameters.retryDelay,
+		ceSource:   parameters.ceSource,
+	}, nil
+}
+
+// HandleBlock publishes a block.
+func (s *Service) HandleBlock(ctx context.Context, block *spec.Block, trigger *handlers.BlockTrigger) error {
+	attributes := map[string]string{
+		"block_number": fmt.Sprintf("%d", block.Number()),
+		"block_hash":   block.Hash().String(),
+	}
+
+	// Blocks are ordered as a single sequence.
+	number := fmt.Sprintf("%d", block.Number())
+
+	return s.publish(ctx, "block", trigger.Name, "blocks", number, number, attributes, block)
+}
+
+// HandleTx publishes a transaction.
+func (s *Service) HandleTx(ctx context.Context, tx *spec.Transaction, trigger *handlers.TxTrigger) {
+	attributes := map[string]string{
+		"tx_hash": tx.Hash().String(),
+		"from":    tx.From().String(),
+	}
+	orderingKey := tx.From().String()
+	if tx.To() != nil {
+		attributes["to"] = tx.To().String()
+		orderingKey = tx.To().String()
+	}
+	if tx.BlockNumber() != nil {
+		attributes["block_number"] = fmt.Sprintf("%d", *tx.BlockNumber())
+	}
+
+	if err := s.publish(ctx, "transaction", trigger.Name, orderingKey, tx.Hash().String(), tx.Hash().String(), attributes, tx); err != nil {
+		s.log.Error().Stringer("tx", tx.Hash()).Err(err).Msg("Failed to publish transaction")
+	}
+}
+
+// HandleEvent publishes an event.
+func (s *Service) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, trigger *handlers.EventTrigger) error {
+	attributes := map[string]string{
+		"address":      event.Address.String(),
+		"block_number": fmt.Sprintf("%d", event.BlockNumber),
+		"tx_hash":      event.TransactionHash.String(),
+		"log_index":    fmt.Sprintf("%d", event.Index),
+	}
+	if len(event.Topics) > 0 {
+		attributes["topic0"] = event.Topics[0].String()
+	}
+
+	id := fmt.Sprintf("%s-%d", event.TransactionHash.String(), event.Index)
+
+	return s.publish(ctx, "event", trigger.Name, event.Address.String(), id, event.Address.String(), attributes, event)
+}
+
+func (s *Service) publish(ctx context.Context,
+	itemType string,
+	trigger string,
+	orderingKey string,
+	id string,
+	subject string,
+	attributes map[string]string,
+	item any,
+) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return errors.Join(errors.New("failed to marshal item"), err)
+	}
+	if s.ceSource != "" {
+		data, err = cloudevents.Wrap(ctx, s.ceSource, itemType, trigger, fmt.Sprintf("%s-%s", itemType, id), subject, data)
+		if err != nil {
+			return err
+		}
+		attributes["content-type"] = cloudevents.ContentType
+	}
+	attributes["type"] = itemType
+	attributes["trigger"] = trigger
+	if key := handlers.IdempotencyKeyFromContext(ctx); key != "" {
+		attributes["idempotency_key"] = key
+	}
+	message := &Message{
+		Data:        data,
+		Attributes:  attributes,
+		OrderingKey: orderingKey,
+	}
+
+	delay := s.retryDelay
+	for attempt := 0; ; attempt++ {
+		id, err := s.publisher.Publish(ctx, message)
+		if err == nil {
+			s.log.Trace().Str("id", id).Str("ordering_key", orderingKey).Msg("Published message")
+
+			return nil
+		}
+		if attempt >= s.maxRetries {
+			return errors.Join(errors.New("failed to publish message"), err)
+		}
+		s.log.Debug().Int("attempt", attempt+1).Err(err).Msg("Failed to publish message; retrying")
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		delay *= 2
+	}
+}
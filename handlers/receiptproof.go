@@ -0,0 +1,64 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// ReceiptProof is a Merkle proof of inclusion of a transaction receipt, and hence
+// its events, in a block's receipts trie.
+type ReceiptProof struct {
+	// BlockHash is the hash of the block containing the receipt.
+	BlockHash types.Hash
+	// ReceiptsRoot is the receipts root of the block.
+	ReceiptsRoot types.Root
+	// TransactionIndex is the index of the transaction in the block.
+	TransactionIndex uint32
+	// Key is the key of the receipt in the trie, being the RLP encoding of the transaction index.
+	Key []byte
+	// Receipt is the consensus encoding of the receipt.
+	Receipt []byte
+	// Proof is the list of encoded trie nodes from the root to the receipt.
+	Proof [][]byte
+}
+
+type receiptProofJSON struct {
+	BlockHash        string   `json:"block_hash"`
+	ReceiptsRoot     string   `json:"receipts_root"`
+	TransactionIndex uint32   `json:"transaction_index"`
+	Key              string   `json:"key"`
+	Receipt          string   `json:"receipt"`
+	Proof            []string `json:"proof"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r *ReceiptProof) MarshalJSON() ([]byte, error) {
+	proof := make([]string, len(r.Proof))
+	for i := range r.Proof {
+		proof[i] = fmt.Sprintf("%#x", r.Proof[i])
+	}
+
+	return json.Marshal(&receiptProofJSON{
+		BlockHash:        fmt.Sprintf("%#x", r.BlockHash),
+		ReceiptsRoot:     fmt.Sprintf("%#x", r.ReceiptsRoot),
+		TransactionIndex: r.TransactionIndex,
+		Key:              fmt.Sprintf("%#x", r.Key),
+		Receipt:          fmt.Sprintf("%#x", r.Receipt),
+		Proof:            proof,
+	})
+}
@@ -0,0 +1,101 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisstream
+
+import (
+	"errors"
+
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel zerolog.Level
+	client   Client
+	prefix   string
+	maxLen   int64
+	ceSource string
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithClient sets the Redis client.
+func WithClient(client Client) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.client = client
+	})
+}
+
+// WithStreamPrefix sets the prefix for stream names.
+func WithStreamPrefix(prefix string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.prefix = prefix
+	})
+}
+
+// WithMaxLen sets the approximate maximum length of each stream.
+// If not supplied streams are not trimmed.
+func WithMaxLen(maxLen int64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxLen = maxLen
+	})
+}
+
+// WithCloudEventsSource wraps items in CloudEvents envelopes in structured mode, with the given source.
+// If the source is empty, which is the default, items are published without an envelope.
+func WithCloudEventsSource(source string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.ceSource = source
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+		prefix:   "eth-listener",
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.client == nil {
+		return nil, errors.New("no client specified")
+	}
+	if parameters.prefix == "" {
+		return nil, errors.New("no stream prefix specified")
+	}
+	if parameters.maxLen < 0 {
+		return nil, errors.New("max length cannot be negative")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,251 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redisstream provides handlers that publish blocks, transactions and events to Redis Streams.
+package redisstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+	"github.com/wealdtech/go-eth-listener/handlers/cloudevents"
+)
+
+// Client defines the Redis method used by the handler.
+// It is expected to be a thin adapter around the application's Redis client.
+type Client interface {
+	// XAdd adds an entry with the given ID to a stream, trimming the stream to approximately
+	// maxLen entries if maxLen is greater than 0.
+	XAdd(ctx context.Context, stream string, id string, maxLen int64, values map[string]any) error
+	// LastID returns the ID of the last entry added to a stream, for example from the
+	// last-generated-id field of XINFO STREAM, or an empty string if the stream does not exist.
+	LastID(ctx context.Context, stream string) (string, error)
+}
+
+// Service publishes items to Redis Streams.
+// It implements the block, transaction and event handler interfaces.
+//
+// Each trigger publishes to its own stream, named <prefix>:<type>:<trigger>.  Entry IDs are derived
+// from the item's position in the chain so that they are stable across redeliveries and increase
+// monotonically within a stream: the first part of the ID is the block number, and the second part
+// is 1 for a block, the transaction index plus one shifted left by 32 bits for a transaction, and
+// additionally the log index plus one for an event.  Items with an ID that is not higher than the
+// stream's last ID have already been published, and are skipped.
+type Service struct {
+	log      zerolog.Logger
+	client   Client
+	prefix   string
+	maxLen   int64
+	ceSource string
+
+	lastIDsMu sync.Mutex
+	lastIDs   map[string]streamID
+}
+
+// streamID is the ID of an entry in a stream.
+type streamID struct {
+	ms  uint64
+	seq uint64
+}
+
+// parseStreamID parses a stream entry ID of the form <ms>-<seq>.
+func parseStreamID(input string) (streamID, error) {
+	if input == "" {
+		return streamID{}, nil
+	}
+	ms, seq, found := strings.Cut(input, "-")
+	if !found {
+		return streamID{}, fmt.Errorf("invalid stream ID %q", input)
+	}
+	var id streamID
+	var err error
+	if id.ms, err = strconv.ParseUint(ms, 10, 64); err != nil {
+		return streamID{}, errors.Join(fmt.Errorf("invalid stream ID %q", input), err)
+	}
+	if id.seq, err = strconv.ParseUint(seq, 10, 64); err != nil {
+		return streamID{}, errors.Join(fmt.Errorf("invalid stream ID %q", input), err)
+	}
+
+	return id, nil
+}
+
+// after returns true if the ID is higher than the other ID.
+func (id streamID) after(other streamID) bool {
+	return id.ms > other.ms || (id.ms == other.ms && id.seq > other.seq)
+}
+
+// String returns the ID in the form used by Redis.
+func (id streamID) String() string {
+	return fmt.Sprintf("%d-%d", id.ms, id.seq)
+}
+
+// New creates a new Redis Streams handler.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "redisstream").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	return &Service{
+		log:      log,
+		client:   parameters.client,
+		prefix:   parameters.prefix,
+		maxLen:   parameters.maxLen,
+		ceSource: parameters.ceSource,
+		lastIDs:  make(map[string]streamID),
+	}, nil
+}
+
+// HandleBlock publishes a block.
+func (s *Service) HandleBlock(ctx context.Context, block *spec.Block, trigger *handlers.BlockTrigger) error {
+	// Redis does not accept 0-0 as an ID, so blocks use a sequence of 1.
+	id := streamID{ms: uint64(block.Number()), seq: 1}
+	values := map[string]any{
+		"block_number": block.Number(),
+		"block_hash":   block.Hash().String(),
+	}
+
+	return s.publish(ctx, "block", trigger.Name, id, fmt.Sprintf("%d", block.Number()), values, block)
+}
+
+// HandleTx publishes a transaction.
+func (s *Service) HandleTx(ctx context.Context, tx *spec.Transaction, trigger *handlers.TxTrigger) {
+	if tx.BlockNumber() == nil || tx.TransactionIndex() == nil {
+		s.log.Error().Stringer("tx", tx.Hash()).Msg("Transaction does not have a position; cannot publish")
+		return
+	}
+
+	id := streamID{ms: uint64(*tx.BlockNumber()), seq: uint64(*tx.TransactionIndex()+1) << 32}
+	values := map[string]any{
+		"block_number": *tx.BlockNumber(),
+		"tx_hash":      tx.Hash().String(),
+		"from":         tx.From().String(),
+	}
+	if tx.To() != nil {
+		values["to"] = tx.To().String()
+	}
+
+	if err := s.publish(ctx, "transaction", trigger.Name, id, tx.Hash().String(), values, tx); err != nil {
+		s.log.Error().Stringer("tx", tx.Hash()).Err(err).Msg("Failed to publish transaction")
+	}
+}
+
+// HandleEvent publishes an event.
+func (s *Service) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, trigger *handlers.EventTrigger) error {
+	id := streamID{ms: uint64(event.BlockNumber), seq: uint64(event.TransactionIndex+1)<<32 | uint64(event.Index+1)}
+	values := map[string]any{
+		"block_number": event.BlockNumber,
+		"block_hash":   event.BlockHash.String(),
+		"tx_hash":      event.TransactionHash.String(),
+		"address":      event.Address.String(),
+	}
+	if len(event.Topics) > 0 {
+		values["topic0"] = event.Topics[0].String()
+	}
+
+	return s.publish(ctx, "event", trigger.Name, id, event.Address.String(), values, event)
+}
+
+func (s *Service) publish(ctx context.Context,
+	itemType string,
+	trigger string,
+	id streamID,
+	subject string,
+	values map[string]any,
+	item any,
+) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return errors.Join(errors.New("failed to marshal item"), err)
+	}
+	if s.ceSource != "" {
+		data, err = cloudevents.Wrap(ctx, s.ceSource, itemType, trigger, fmt.Sprintf("%s:%s:%s", itemType, trigger, id), subject, data)
+		if err != nil {
+			return err
+		}
+		values["content-type"] = cloudevents.ContentType
+	}
+	values["type"] = itemType
+	values["trigger"] = trigger
+	if key := handlers.IdempotencyKeyFromContext(ctx); key != "" {
+		values["idempotency_key"] = key
+	}
+	values["data"] = string(data)
+
+	stream := fmt.Sprintf("%s:%s:%s", s.prefix, itemType, trigger)
+	lastID, err := s.lastID(ctx, stream)
+	if err != nil {
+		return err
+	}
+	if !id.after(lastID) {
+		// Already published.
+		s.log.Trace().Str("stream", stream).Stringer("id", id).Msg("Item already published")
+
+		return nil
+	}
+
+	if err := s.client.XAdd(ctx, stream, id.String(), s.maxLen, values); err != nil {
+		// The stream may have been changed by someone else, so fetch its last ID afresh next time.
+		s.lastIDsMu.Lock()
+		delete(s.lastIDs, stream)
+		s.lastIDsMu.Unlock()
+
+		return errors.Join(errors.New("failed to add item to stream"), err)
+	}
+	s.lastIDsMu.Lock()
+	s.lastIDs[stream] = id
+	s.lastIDsMu.Unlock()
+	s.log.Trace().Str("stream", stream).Stringer("id", id).Msg("Published item")
+
+	return nil
+}
+
+// lastID returns the ID of the last entry in the stream, fetching it from Redis if not known.
+func (s *Service) lastID(ctx context.Context, stream string) (streamID, error) {
+	s.lastIDsMu.Lock()
+	id, exists := s.lastIDs[stream]
+	s.lastIDsMu.Unlock()
+	if exists {
+		return id, nil
+	}
+
+	input, err := s.client.LastID(ctx, stream)
+	if err != nil {
+		return streamID{}, errors.Join(errors.New("failed to obtain last ID of stream"), err)
+	}
+	id, err = parseStreamID(input)
+	if err != nil {
+		return streamID{}, err
+	}
+
+	s.lastIDsMu.Lock()
+	s.lastIDs[stream] = id
+	s.lastIDsMu.Unlock()
+
+	return id, nil
+}
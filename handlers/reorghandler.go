@@ -0,0 +1,37 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// ChainHead identifies the head block of a chain.
+type ChainHead struct {
+	Number uint64
+	Hash   types.Hash
+}
+
+// ReorgHandler defines the methods that need to be implemented to be notified of chain reorganizations.
+type ReorgHandler interface {
+	// HandleReorg is called when the chain reorganizes below blocks seen by the listener.
+	// oldHead is the most recent block seen by the listener on the replaced chain, and newHead is
+	// the block on the new chain through which the reorganization was detected.  Items passed to
+	// handlers from blocks after the common ancestor of the two heads, which can be found by
+	// following parent hashes, may no longer be canonical.
+	// This is called once the poll in which the reorganization was detected has completed.
+	HandleReorg(ctx context.Context, oldHead *ChainHead, newHead *ChainHead)
+}
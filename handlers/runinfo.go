@@ -0,0 +1,30 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+// RunInfo contains information about the run of the listener in which an item is being handled.
+type RunInfo struct {
+	// PollID is the identifier of the poll, increasing by one for each poll.
+	PollID uint64
+	// Attempt is the number of consecutive attempts that have been made to complete a poll,
+	// starting at 1 and increasing each time a poll fails.
+	Attempt int
+	// From is the first block of the range being processed.
+	From uint64
+	// To is the last block of the range being processed.
+	To uint64
+	// CatchingUp is true if the start of the range trails the chain head by more than the
+	// listener's catch-up threshold, in which case the items being handled are historical.
+	CatchingUp bool
+}
@@ -0,0 +1,98 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package safe
+
+import (
+	"errors"
+
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel         zerolog.Level
+	safes            []types.Address
+	executionHandler ExecutionHandler
+	ownerHandler     OwnerChangeHandler
+	thresholdHandler ThresholdChangeHandler
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithSafes sets the Safe contracts to watch.
+func WithSafes(safes []types.Address) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.safes = safes
+	})
+}
+
+// WithExecutionHandler sets the handler for executed transactions.
+func WithExecutionHandler(handler ExecutionHandler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.executionHandler = handler
+	})
+}
+
+// WithOwnerChangeHandler sets the handler for owner changes.
+func WithOwnerChangeHandler(handler OwnerChangeHandler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.ownerHandler = handler
+	})
+}
+
+// WithThresholdChangeHandler sets the handler for threshold changes.
+func WithThresholdChangeHandler(handler ThresholdChangeHandler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.thresholdHandler = handler
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if len(parameters.safes) == 0 {
+		return nil, errors.New("no safes specified")
+	}
+	if parameters.executionHandler == nil &&
+		parameters.ownerHandler == nil &&
+		parameters.thresholdHandler == nil {
+		return nil, errors.New("no handler specified")
+	}
+
+	return &parameters, nil
+}
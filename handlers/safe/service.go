@@ -0,0 +1,279 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package safe provides triggers that deliver typed activity from Safe multisig contracts.
+package safe
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/abi"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+var (
+	// executionSuccessTopic is the topic of the ExecutionSuccess event.
+	executionSuccessTopic = abi.EventTopic("ExecutionSuccess(bytes32,uint256)")
+	// executionFailureTopic is the topic of the ExecutionFailure event.
+	executionFailureTopic = abi.EventTopic("ExecutionFailure(bytes32,uint256)")
+	// addedOwnerTopic is the topic of the AddedOwner event.
+	addedOwnerTopic = abi.EventTopic("AddedOwner(address)")
+	// removedOwnerTopic is the topic of the RemovedOwner event.
+	removedOwnerTopic = abi.EventTopic("RemovedOwner(address)")
+	// changedThresholdTopic is the topic of the ChangedThreshold event.
+	changedThresholdTopic = abi.EventTopic("ChangedThreshold(uint256)")
+)
+
+// Execution is a Safe transaction that has been executed.
+type Execution struct {
+	// Safe is the Safe that executed the transaction.
+	Safe types.Address
+	// SafeTxHash is the Safe's hash of the transaction that was signed by the owners.
+	SafeTxHash types.Hash
+	// Success is true if the transaction succeeded.
+	Success bool
+	// Payment is the amount paid to the executor, in the gas token's smallest unit.
+	Payment *big.Int
+	// TransactionHash is the hash of the transaction containing the execution.
+	TransactionHash types.Hash
+	// Block is the block containing the execution.
+	Block uint64
+	// LogIndex is the index of the execution event in the block.
+	LogIndex uint32
+}
+
+// OwnerChange is the addition or removal of an owner of a Safe.
+type OwnerChange struct {
+	// Safe is the Safe whose owners changed.
+	Safe types.Address
+	// Owner is the owner added or removed.
+	Owner types.Address
+	// Added is true if the owner was added, false if removed.
+	Added bool
+	// TransactionHash is the hash of the transaction containing the change.
+	TransactionHash types.Hash
+	// Block is the block containing the change.
+	Block uint64
+	// LogIndex is the index of the change event in the block.
+	LogIndex uint32
+}
+
+// ThresholdChange is a change to the number of owners required to execute a transaction.
+type ThresholdChange struct {
+	// Safe is the Safe whose threshold changed.
+	Safe types.Address
+	// Threshold is the new threshold.
+	Threshold uint64
+	// TransactionHash is the hash of the transaction containing the change.
+	TransactionHash types.Hash
+	// Block is the block containing the change.
+	Block uint64
+	// LogIndex is the index of the change event in the block.
+	LogIndex uint32
+}
+
+// ExecutionHandler defines the methods that need to be implemented to handle executed transactions.
+type ExecutionHandler interface {
+	// HandleExecution handles an executed transaction.
+	HandleExecution(ctx context.Context, execution *Execution) error
+}
+
+// OwnerChangeHandler defines the methods that need to be implemented to handle owner changes.
+type OwnerChangeHandler interface {
+	// HandleOwnerChange handles an owner change.
+	HandleOwnerChange(ctx context.Context, change *OwnerChange) error
+}
+
+// ThresholdChangeHandler defines the methods that need to be implemented to handle threshold changes.
+type ThresholdChangeHandler interface {
+	// HandleThresholdChange handles a threshold change.
+	HandleThresholdChange(ctx context.Context, change *ThresholdChange) error
+}
+
+// Service delivers Safe activity.
+type Service struct {
+	log              zerolog.Logger
+	safes            map[types.Address]struct{}
+	executionHandler ExecutionHandler
+	ownerHandler     OwnerChangeHandler
+	thresholdHandler ThresholdChangeHandler
+}
+
+// New creates a new Safe activity watcher.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "safe").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	safes := make(map[types.Address]struct{}, len(parameters.safes))
+	for _, safe := range parameters.safes {
+		safes[safe] = struct{}{}
+	}
+
+	return &Service{
+		log:              log,
+		safes:            safes,
+		executionHandler: parameters.executionHandler,
+		ownerHandler:     parameters.ownerHandler,
+		thresholdHandler: parameters.thresholdHandler,
+	}, nil
+}
+
+// Trigger returns the event trigger that feeds the watcher.
+func (s *Service) Trigger(name string, earliestBlock uint64) *handlers.EventTrigger {
+	return &handlers.EventTrigger{
+		Name:          name,
+		SourceSet:     s,
+		TopicSets:     []handlers.TopicSet{s},
+		EarliestBlock: earliestBlock,
+		Handler:       s,
+	}
+}
+
+// Contains returns true if the address is a watched Safe.
+func (s *Service) Contains(address types.Address) bool {
+	_, exists := s.safes[address]
+
+	return exists
+}
+
+// Addresses returns the watched Safes.
+func (s *Service) Addresses() []types.Address {
+	res := make([]types.Address, 0, len(s.safes))
+	for address := range s.safes {
+		res = append(res, address)
+	}
+
+	return res
+}
+
+// ContainsTopic returns true if the topic is that of an event for which there is a handler.
+func (s *Service) ContainsTopic(topic types.Hash) bool {
+	switch topic {
+	case executionSuccessTopic, executionFailureTopic:
+		return s.executionHandler != nil
+	case addedOwnerTopic, removedOwnerTopic:
+		return s.ownerHandler != nil
+	case changedThresholdTopic:
+		return s.thresholdHandler != nil
+	default:
+		return false
+	}
+}
+
+// HandleEvent handles an event, delivering it to the relevant handler.
+func (s *Service) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, _ *handlers.EventTrigger) error {
+	if !s.Contains(event.Address) || len(event.Topics) == 0 || !s.ContainsTopic(event.Topics[0]) {
+		return nil
+	}
+
+	var err error
+	switch event.Topics[0] {
+	case executionSuccessTopic, executionFailureTopic:
+		err = s.handleExecution(ctx, event)
+	case addedOwnerTopic, removedOwnerTopic:
+		err = s.handleOwnerChange(ctx, event)
+	case changedThresholdTopic:
+		err = s.handleThresholdChange(ctx, event)
+	}
+
+	return err
+}
+
+// handleExecution handles ExecutionSuccess and ExecutionFailure events: (bytes32 txHash, uint256 payment).
+func (s *Service) handleExecution(ctx context.Context, event *spec.BerlinTransactionEvent) error {
+	safeTxHash, err := abi.Word(event.Data, 0)
+	if err != nil {
+		return s.decodeFailure(event, err)
+	}
+	payment, err := abi.Uint(event.Data, 1)
+	if err != nil {
+		return s.decodeFailure(event, err)
+	}
+
+	return s.executionHandler.HandleExecution(ctx, &Execution{
+		Safe:            event.Address,
+		SafeTxHash:      types.Hash(safeTxHash),
+		Success:         event.Topics[0] == executionSuccessTopic,
+		Payment:         payment,
+		TransactionHash: event.TransactionHash,
+		Block:           uint64(event.BlockNumber),
+		LogIndex:        event.Index,
+	})
+}
+
+// handleOwnerChange handles AddedOwner and RemovedOwner events: (address owner).
+// The owner is indexed from Safe v1.4.0 and in the data prior to that.
+func (s *Service) handleOwnerChange(ctx context.Context, event *spec.BerlinTransactionEvent) error {
+	var owner types.Address
+	switch len(event.Topics) {
+	case 1:
+		var err error
+		if owner, err = abi.Address(event.Data, 0); err != nil {
+			return s.decodeFailure(event, err)
+		}
+	case 2:
+		owner = abi.TopicAddress(event.Topics[1])
+	default:
+		return s.decodeFailure(event, errors.New("unexpected number of topics"))
+	}
+
+	return s.ownerHandler.HandleOwnerChange(ctx, &OwnerChange{
+		Safe:            event.Address,
+		Owner:           owner,
+		Added:           event.Topics[0] == addedOwnerTopic,
+		TransactionHash: event.TransactionHash,
+		Block:           uint64(event.BlockNumber),
+		LogIndex:        event.Index,
+	})
+}
+
+// handleThresholdChange handles ChangedThreshold events: (uint256 threshold).
+func (s *Service) handleThresholdChange(ctx context.Context, event *spec.BerlinTransactionEvent) error {
+	threshold, err := abi.Uint(event.Data, 0)
+	if err != nil {
+		return s.decodeFailure(event, err)
+	}
+	if !threshold.IsUint64() {
+		return s.decodeFailure(event, errors.New("threshold out of range"))
+	}
+
+	return s.thresholdHandler.HandleThresholdChange(ctx, &ThresholdChange{
+		Safe:            event.Address,
+		Threshold:       threshold.Uint64(),
+		TransactionHash: event.TransactionHash,
+		Block:           uint64(event.BlockNumber),
+		LogIndex:        event.Index,
+	})
+}
+
+// decodeFailure logs an event that could not be decoded.  Such events cannot be delivered,
+// but should not halt the listener, so no error is returned.
+func (s *Service) decodeFailure(event *spec.BerlinTransactionEvent, err error) error {
+	s.log.Debug().Stringer("tx", event.TransactionHash).Uint32("index", event.Index).Err(err).Msg("Failed to decode Safe event")
+
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/go-execution-client/spec"
+)
+
+// ScheduleTrigger is a trigger that runs on a schedule.
+// Exactly one of Every and Cron must be supplied.
+type ScheduleTrigger struct {
+	Name string
+	// Every is the interval between runs.
+	Every time.Duration
+	// Cron is a standard five-field cron expression, evaluated in UTC.
+	Cron    string
+	Handler ScheduleHandler
+	// Labels are arbitrary key/value pairs attached to logs and metrics for the trigger.
+	Labels map[string]string
+}
+
+// ScheduleInfo is the chain context at the time a schedule trigger runs.
+type ScheduleInfo struct {
+	// Time is the time at which the trigger was scheduled to run.
+	Time time.Time
+	// ChainHead is the height of the chain.
+	ChainHead uint64
+	// HeadBlock is the block at the head of the chain.
+	HeadBlock *spec.Block
+}
+
+// ScheduleHandler defines the methods that need to be implemented to handle schedule triggers.
+type ScheduleHandler interface {
+	// HandleSchedule handles a scheduled run.
+	HandleSchedule(ctx context.Context, info *ScheduleInfo, trigger *ScheduleTrigger) error
+}
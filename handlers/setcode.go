@@ -0,0 +1,71 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// SetCodeAuthorization is an authorization in an EIP-7702 set-code transaction, designating
+// code to which the authority's account delegates.
+// The authority is not recovered from the signature.
+type SetCodeAuthorization struct {
+	// ChainID is the chain ID for which the authorization is valid, or 0 for all chains.
+	ChainID *big.Int
+	// Address is the address to which the authority delegates.
+	Address types.Address
+	// Nonce is the nonce of the authority's account at which the authorization is valid.
+	Nonce uint64
+	// YParity is the parity of the y value of the signature.
+	YParity uint8
+	// R is the r value of the signature.
+	R *big.Int
+	// S is the s value of the signature.
+	S *big.Int
+}
+
+// WithSetCodeAuthorizations returns a copy of the context containing the authorization list
+// of the EIP-7702 set-code transaction being handled.
+func WithSetCodeAuthorizations(ctx context.Context, authorizations []*SetCodeAuthorization) context.Context {
+	return context.WithValue(ctx, setCodeKey, authorizations)
+}
+
+// SetCodeAuthorizationsFromContext returns the authorization list of the EIP-7702 set-code
+// transaction being handled.
+// It returns nil if the transaction is not a set-code transaction.
+//
+// Set-code transactions are presented to handlers as EIP-1559 transactions, as the client library
+// cannot represent them; TransactionTypeFromContext returns their real type.
+func SetCodeAuthorizationsFromContext(ctx context.Context) []*SetCodeAuthorization {
+	authorizations, _ := ctx.Value(setCodeKey).([]*SetCodeAuthorization)
+
+	return authorizations
+}
+
+// SetCodeTransactionType is the type of EIP-7702 set-code transactions.
+const SetCodeTransactionType = spec.TransactionType(4)
+
+// TransactionTypeFromContext returns the type of the transaction being handled as given by the
+// Ethereum client, which differs from the type of the transaction itself for set-code transactions.
+func TransactionTypeFromContext(ctx context.Context, tx *spec.Transaction) spec.TransactionType {
+	if SetCodeAuthorizationsFromContext(ctx) != nil {
+		return SetCodeTransactionType
+	}
+
+	return tx.Type
+}
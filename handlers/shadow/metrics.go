@@ -0,0 +1,83 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shadow
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/wealdtech/go-eth-listener/services/metrics"
+)
+
+var metricsNamespace = "eth_listener"
+
+var (
+	itemsMetric    *prometheus.CounterVec
+	durationMetric *prometheus.HistogramVec
+)
+
+func registerMetrics(_ context.Context, monitor metrics.Service) error {
+	if itemsMetric != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics()
+	}
+
+	return nil
+}
+
+func registerPrometheusMetrics() error {
+	itemsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "shadow",
+		Name:      "items_total",
+		Help:      "The number of items handled by primary and shadow handlers, by outcome.",
+	}, []string{"name", "role", "outcome"})
+	if err := prometheus.Register(itemsMetric); err != nil {
+		return errors.Join(errors.New("failed to register shadow items"), err)
+	}
+
+	durationMetric = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "shadow",
+		Name:      "duration_seconds",
+		Help:      "The time taken to handle items by primary and shadow handlers.",
+		Buckets:   []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30},
+	}, []string{"name", "role"})
+	if err := prometheus.Register(durationMetric); err != nil {
+		return errors.Join(errors.New("failed to register shadow duration"), err)
+	}
+
+	return nil
+}
+
+func monitorItem(name string, role string, outcome string) {
+	if itemsMetric != nil {
+		itemsMetric.WithLabelValues(name, role, outcome).Inc()
+	}
+}
+
+func monitorDuration(name string, role string, duration time.Duration) {
+	if durationMetric != nil {
+		durationMetric.WithLabelValues(name, role).Observe(duration.Seconds())
+	}
+}
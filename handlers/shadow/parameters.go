@@ -0,0 +1,129 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shadow
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/wealdtech/go-eth-listener/services/metrics"
+	nullmetrics "github.com/wealdtech/go-eth-listener/services/metrics/null"
+)
+
+type parameters struct {
+	logLevel  zerolog.Level
+	monitor   metrics.Service
+	name      string
+	primary   any
+	shadow    any
+	queueSize int
+	timeout   time.Duration
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the metrics monitor.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithName sets the name of the pair of handlers, used to label logs and metrics.
+func WithName(name string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.name = name
+	})
+}
+
+// WithPrimary sets the primary handler, whose outcome is returned to the listener.
+func WithPrimary(handler any) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.primary = handler
+	})
+}
+
+// WithShadow sets the shadow handler, which receives the same items as the primary.
+func WithShadow(handler any) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.shadow = handler
+	})
+}
+
+// WithQueueSize sets the number of items that can be queued for the shadow handler before further items are dropped.
+func WithQueueSize(queueSize int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.queueSize = queueSize
+	})
+}
+
+// WithTimeout sets the maximum time for which the shadow handler can handle an item.
+func WithTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.timeout = timeout
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:  zerolog.GlobalLevel(),
+		monitor:   nullmetrics.New(),
+		queueSize: 1000,
+		timeout:   30 * time.Second,
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.name == "" {
+		return nil, errors.New("no name specified")
+	}
+	if parameters.primary == nil {
+		return nil, errors.New("no primary handler specified")
+	}
+	if parameters.shadow == nil {
+		return nil, errors.New("no shadow handler specified")
+	}
+	if parameters.queueSize < 1 {
+		return nil, errors.New("queue size must be at least 1")
+	}
+	if parameters.timeout <= 0 {
+		return nil, errors.New("timeout must be positive")
+	}
+
+	return &parameters, nil
+}
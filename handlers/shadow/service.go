@@ -0,0 +1,216 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package shadow provides a handler that sends items to a primary handler and, in the background,
+// to a shadow handler, for safely testing a replacement handler against live traffic.
+package shadow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// job is an item queued for the shadow handler.
+type job struct {
+	ctx        context.Context
+	handle     func(ctx context.Context) error
+	primaryErr error
+}
+
+// Service sends items to a primary and a shadow handler.
+// It implements the block, transaction and event handler interfaces.
+//
+// The outcome of the primary handler is returned to the listener.  Once the primary has handled an
+// item the item is queued for the shadow handler, which runs in the background so that it cannot
+// stall or fail the trigger.  If the queue is full the item is dropped for the shadow.  The outcome
+// and duration of both handlers are recorded in metrics, and items for which the shadow's outcome
+// differs from the primary's are logged.
+//
+// The shadow handler does not receive the trigger state, which belongs to the primary.
+type Service struct {
+	log     zerolog.Logger
+	name    string
+	primary any
+	shadow  any
+	timeout time.Duration
+	queue   chan *job
+}
+
+// New creates a new shadow handler.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "shadow").Str("name", parameters.name).Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.Join(errors.New("failed to register metrics"), err)
+	}
+
+	s := &Service{
+		log:     log,
+		name:    parameters.name,
+		primary: parameters.primary,
+		shadow:  parameters.shadow,
+		timeout: parameters.timeout,
+		queue:   make(chan *job, parameters.queueSize),
+	}
+
+	go s.run(ctx)
+
+	return s, nil
+}
+
+// HandleBlock sends a block to the primary and shadow handlers.
+func (s *Service) HandleBlock(ctx context.Context, block *spec.Block, trigger *handlers.BlockTrigger) error {
+	primary, isHandler := s.primary.(handlers.BlockHandler)
+	if !isHandler {
+		return fmt.Errorf("primary handler %T does not handle blocks", s.primary)
+	}
+
+	started := time.Now()
+	err := primary.HandleBlock(ctx, block, trigger)
+	s.recordPrimary(started, err)
+
+	if shadow, isHandler := s.shadow.(handlers.BlockHandler); isHandler {
+		s.enqueue(ctx, func(ctx context.Context) error {
+			return shadow.HandleBlock(ctx, block, trigger)
+		}, err)
+	}
+
+	return err
+}
+
+// HandleTx sends a transaction to the primary and shadow handlers.
+func (s *Service) HandleTx(ctx context.Context, tx *spec.Transaction, trigger *handlers.TxTrigger) {
+	primary, isHandler := s.primary.(handlers.TxHandler)
+	if !isHandler {
+		s.log.Error().Str("handler", fmt.Sprintf("%T", s.primary)).Msg("Primary handler does not handle transactions")
+		return
+	}
+
+	started := time.Now()
+	primary.HandleTx(ctx, tx, trigger)
+	s.recordPrimary(started, nil)
+
+	if shadow, isHandler := s.shadow.(handlers.TxHandler); isHandler {
+		s.enqueue(ctx, func(ctx context.Context) error {
+			shadow.HandleTx(ctx, tx, trigger)
+
+			return nil
+		}, nil)
+	}
+}
+
+// HandleEvent sends an event to the primary and shadow handlers.
+func (s *Service) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, trigger *handlers.EventTrigger) error {
+	primary, isHandler := s.primary.(handlers.EventHandler)
+	if !isHandler {
+		return fmt.Errorf("primary handler %T does not handle events", s.primary)
+	}
+
+	started := time.Now()
+	err := primary.HandleEvent(ctx, event, trigger)
+	s.recordPrimary(started, err)
+
+	if shadow, isHandler := s.shadow.(handlers.EventHandler); isHandler {
+		s.enqueue(ctx, func(ctx context.Context) error {
+			return shadow.HandleEvent(ctx, event, trigger)
+		}, err)
+	}
+
+	return err
+}
+
+func (s *Service) recordPrimary(started time.Time, err error) {
+	monitorDuration(s.name, "primary", time.Since(started))
+	if err != nil {
+		monitorItem(s.name, "primary", "failed")
+	} else {
+		monitorItem(s.name, "primary", "succeeded")
+	}
+}
+
+// enqueue queues an item for the shadow handler, dropping it if the queue is full.
+func (s *Service) enqueue(ctx context.Context, handle func(ctx context.Context) error, primaryErr error) {
+	// The shadow outlives the handler call, and must not alter the primary's trigger state.
+	shadowCtx := handlers.WithTriggerState(context.WithoutCancel(ctx), nil)
+
+	select {
+	case s.queue <- &job{
+		ctx:        shadowCtx,
+		handle:     handle,
+		primaryErr: primaryErr,
+	}:
+	default:
+		s.log.Trace().Msg("Shadow queue full; dropping item")
+		monitorItem(s.name, "shadow", "dropped")
+	}
+}
+
+// run runs queued items through the shadow handler until the context is done.
+func (s *Service) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case j := <-s.queue:
+			s.handleShadow(j)
+		}
+	}
+}
+
+func (s *Service) handleShadow(j *job) {
+	ctx, cancel := context.WithTimeout(j.ctx, s.timeout)
+	defer cancel()
+
+	started := time.Now()
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("shadow handler panicked: %v", r)
+			}
+		}()
+
+		return j.handle(ctx)
+	}()
+	monitorDuration(s.name, "shadow", time.Since(started))
+
+	if err != nil {
+		monitorItem(s.name, "shadow", "failed")
+	} else {
+		monitorItem(s.name, "shadow", "succeeded")
+	}
+
+	switch {
+	case err != nil && j.primaryErr == nil:
+		s.log.Warn().Err(err).Msg("Shadow handler failed where primary succeeded")
+	case err == nil && j.primaryErr != nil:
+		s.log.Warn().AnErr("primary_err", j.primaryErr).Msg("Shadow handler succeeded where primary failed")
+	case err != nil:
+		s.log.Debug().Err(err).Msg("Shadow handler failed")
+	}
+}
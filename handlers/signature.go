@@ -0,0 +1,31 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// SignatureLookup is the interface for looking up human-readable signatures.
+type SignatureLookup interface {
+	// FunctionSignatures returns the probable signatures for a function selector,
+	// for example "transfer(address,uint256)" for 0xa9059cbb.
+	// It returns nil if the selector is not known.
+	FunctionSignatures(selector [4]byte) []string
+
+	// EventSignatures returns the probable signatures for an event topic,
+	// for example "Transfer(address,address,uint256)".
+	// It returns nil if the topic is not known.
+	EventSignatures(topic types.Hash) []string
+}
@@ -0,0 +1,66 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splitter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// KeyFunc returns the key by which an item is split.  The item is a *spec.Block, *spec.Transaction
+// or *spec.BerlinTransactionEvent.
+// Items with the same key are always sent to the same handler.
+type KeyFunc func(ctx context.Context, item any) string
+
+// ItemKey keys items by their idempotency key, so that each item is assigned independently and
+// is assigned to the same handler if it is redelivered.
+func ItemKey(ctx context.Context, item any) string {
+	if key := handlers.IdempotencyKeyFromContext(ctx); key != "" {
+		return key
+	}
+
+	switch v := item.(type) {
+	case *spec.Block:
+		return v.Hash().String()
+	case *spec.Transaction:
+		return v.Hash().String()
+	case *spec.BerlinTransactionEvent:
+		return fmt.Sprintf("%s:%d", v.TransactionHash.String(), v.Index)
+	default:
+		return ""
+	}
+}
+
+// AddressKey keys items by address, being the fee recipient of a block, the recipient of a
+// transaction (or its sender for contract creations) and the emitter of an event, so that all
+// items for an address are sent to the same handler.
+func AddressKey(_ context.Context, item any) string {
+	switch v := item.(type) {
+	case *spec.Block:
+		return v.FeeRecipient().String()
+	case *spec.Transaction:
+		if v.To() != nil {
+			return v.To().String()
+		}
+
+		return v.From().String()
+	case *spec.BerlinTransactionEvent:
+		return v.Address.String()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splitter
+
+import (
+	"errors"
+
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel zerolog.Level
+	handlers []any
+	weights  []uint
+	key      KeyFunc
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithHandlers sets the handlers between which items are split.
+// Each handler should implement the block, transaction or event handler interfaces for the
+// triggers that use the splitter.  A nil handler drops the items assigned to it, which allows
+// a percentage of items to be sampled.
+func WithHandlers(handlers ...any) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.handlers = handlers
+	})
+}
+
+// WithWeights sets the relative weights of the handlers; if not set then the handlers are weighted equally.
+func WithWeights(weights ...uint) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.weights = weights
+	})
+}
+
+// WithKey sets the function that provides the key by which items are split.
+func WithKey(key KeyFunc) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.key = key
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+		key:      ItemKey,
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if len(parameters.handlers) == 0 {
+		return nil, errors.New("no handlers specified")
+	}
+	if parameters.weights == nil {
+		parameters.weights = make([]uint, len(parameters.handlers))
+		for i := range parameters.weights {
+			parameters.weights[i] = 1
+		}
+	}
+	if len(parameters.weights) != len(parameters.handlers) {
+		return nil, errors.New("number of weights must match number of handlers")
+	}
+	total := uint(0)
+	for _, weight := range parameters.weights {
+		total += weight
+	}
+	if total == 0 {
+		return nil, errors.New("at least one weight must be non-zero")
+	}
+	if parameters.key == nil {
+		return nil, errors.New("no key specified")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,134 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package splitter provides a handler that splits items between other handlers by weight, for
+// sampling a percentage of items or sharding them by key, for example to canary a new version of
+// a handler against live traffic.
+package splitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// Service splits items between handlers.
+// It implements the block, transaction and event handler interfaces.
+//
+// Each item is sent to exactly one handler, chosen by hashing the item's key on to the handlers'
+// weights, so the choice is stable across redeliveries and restarts.  Errors from the chosen handler
+// are returned to the listener as usual.
+type Service struct {
+	log      zerolog.Logger
+	handlers []any
+	bounds   []uint64
+	key      KeyFunc
+}
+
+// New creates a new splitter handler.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "splitter").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	// Bounds are the cumulative weights of the handlers.
+	bounds := make([]uint64, len(parameters.weights))
+	total := uint64(0)
+	for i, weight := range parameters.weights {
+		total += uint64(weight)
+		bounds[i] = total
+	}
+
+	return &Service{
+		log:      log,
+		handlers: parameters.handlers,
+		bounds:   bounds,
+		key:      parameters.key,
+	}, nil
+}
+
+// HandleBlock sends a block to its handler.
+func (s *Service) HandleBlock(ctx context.Context, block *spec.Block, trigger *handlers.BlockTrigger) error {
+	handler := s.handlerFor(ctx, block)
+	if handler == nil {
+		return nil
+	}
+	blockHandler, isHandler := handler.(handlers.BlockHandler)
+	if !isHandler {
+		return fmt.Errorf("handler %T does not handle blocks", handler)
+	}
+
+	return blockHandler.HandleBlock(ctx, block, trigger)
+}
+
+// HandleTx sends a transaction to its handler.
+func (s *Service) HandleTx(ctx context.Context, tx *spec.Transaction, trigger *handlers.TxTrigger) {
+	handler := s.handlerFor(ctx, tx)
+	if handler == nil {
+		return
+	}
+	txHandler, isHandler := handler.(handlers.TxHandler)
+	if !isHandler {
+		s.log.Error().Str("handler", fmt.Sprintf("%T", handler)).Msg("Handler does not handle transactions")
+		return
+	}
+
+	txHandler.HandleTx(ctx, tx, trigger)
+}
+
+// HandleEvent sends an event to its handler.
+func (s *Service) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, trigger *handlers.EventTrigger) error {
+	handler := s.handlerFor(ctx, event)
+	if handler == nil {
+		return nil
+	}
+	eventHandler, isHandler := handler.(handlers.EventHandler)
+	if !isHandler {
+		return fmt.Errorf("handler %T does not handle events", handler)
+	}
+
+	return eventHandler.HandleEvent(ctx, event, trigger)
+}
+
+// handlerFor returns the handler for an item, or nil if the item is dropped.
+func (s *Service) handlerFor(ctx context.Context, item any) any {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(s.key(ctx, item)))
+	point := hash.Sum64() % s.bounds[len(s.bounds)-1]
+
+	for i, bound := range s.bounds {
+		if point < bound {
+			if s.handlers[i] == nil {
+				s.log.Trace().Int("handler", i).Msg("Item not sampled")
+			}
+
+			return s.handlers[i]
+		}
+	}
+
+	// Unreachable, as the point is always below the final bound.
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statemachine
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel     zerolog.Level
+	name         string
+	store        Store
+	initialState string
+	transitions  []*Transition
+	handler      TransitionHandler
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithName sets the name of the machine, which isolates its state from other machines in the store.
+func WithName(name string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.name = name
+	})
+}
+
+// WithStore sets the store in which state is persisted.
+// The execution listener implements this interface, allowing state to be held in its metadata database.
+func WithStore(store Store) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.store = store
+	})
+}
+
+// WithInitialState sets the state of keys that have not yet been advanced.
+func WithInitialState(state string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.initialState = state
+	})
+}
+
+// WithTransitions sets the transitions of the machine.
+// The key of the machine advanced by an input is given by the first transition that matches it,
+// and the first matching transition that can be taken from the machine's current state is taken.
+func WithTransitions(transitions []*Transition) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.transitions = transitions
+	})
+}
+
+// WithHandler sets the handler for transitions.
+func WithHandler(handler TransitionHandler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.handler = handler
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.name == "" {
+		return nil, errors.New("no name specified")
+	}
+	if strings.Contains(parameters.name, keySeparator) {
+		return nil, errors.New("name cannot contain the key separator")
+	}
+	if parameters.store == nil {
+		return nil, errors.New("no store specified")
+	}
+	if parameters.initialState == "" {
+		return nil, errors.New("no initial state specified")
+	}
+	if len(parameters.transitions) == 0 {
+		return nil, errors.New("no transitions specified")
+	}
+	for _, transition := range parameters.transitions {
+		if transition.Name == "" {
+			return nil, errors.New("transition name missing")
+		}
+		if transition.To == "" {
+			return nil, errors.New("transition destination state missing")
+		}
+		if (transition.MatchTx == nil) == (transition.MatchEvent == nil) {
+			return nil, errors.New("transition must match exactly one of transactions or events")
+		}
+	}
+
+	return &parameters, nil
+}
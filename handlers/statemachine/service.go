@@ -0,0 +1,357 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package statemachine provides a framework for keyed state machines that are advanced by
+// transactions and events, with state persisted in a metadata store.  This allows small
+// indexers, such as of escrow or order states, to be built without an external database.
+//
+// The service is a transaction and event handler; it is advanced by setting it as the handler
+// of triggers that select the relevant transactions and events.
+package statemachine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+const (
+	// keyPrefix is the prefix of keys in the store.
+	keyPrefix = "statemachine"
+	// keySeparator separates the components of keys in the store.
+	keySeparator = "/"
+)
+
+// Store defines the methods that need to be implemented to persist state.
+type Store interface {
+	// Metadata returns the value stored under the given key, or nil if there is no value.
+	Metadata(ctx context.Context, key []byte) ([]byte, error)
+	// SetMetadata stores a value under the given key.
+	SetMetadata(ctx context.Context, key []byte, value []byte) error
+}
+
+// Input is a transaction or event that may advance a machine.
+type Input struct {
+	// Tx is the transaction, if the input is a transaction.
+	Tx *spec.Transaction
+	// Event is the event, if the input is an event.
+	Event *spec.BerlinTransactionEvent
+}
+
+// Transition is a transition of a machine from one state to another.
+type Transition struct {
+	// Name is the name of the transition.
+	Name string
+	// From are the states from which the transition can be taken; if empty it can be taken from any state.
+	From []string
+	// To is the state to which the transition leads.
+	To string
+	// MatchTx returns the key of the machine advanced by a transaction, and true if the transaction applies.
+	MatchTx func(tx *spec.Transaction) (string, bool)
+	// MatchEvent returns the key of the machine advanced by an event, and true if the event applies.
+	MatchEvent func(event *spec.BerlinTransactionEvent) (string, bool)
+	// Update optionally returns the data of the machine after the transition.  If not supplied the data is unchanged.
+	Update func(instance *Instance, input *Input) ([]byte, error)
+}
+
+// Instance is the state of the machine for a key.
+type Instance struct {
+	// Key is the key of the machine.
+	Key string `json:"key"`
+	// State is the current state of the machine.
+	State string `json:"state"`
+	// Data is arbitrary data attached to the machine by transitions.
+	Data []byte `json:"data,omitempty"`
+	// Block is the block at which the machine last transitioned, or 0 if it has not transitioned.
+	Block uint64 `json:"block"`
+	// Transitions is the number of transitions the machine has taken.
+	Transitions uint64 `json:"transitions"`
+	// LastTx is the position of the last transaction that advanced the machine.
+	LastTx *Position `json:"last_tx,omitempty"`
+	// LastEvent is the position of the last event that advanced the machine.
+	LastEvent *Position `json:"last_event,omitempty"`
+}
+
+// Position is the position of a transaction or event in the chain.
+type Position struct {
+	// Block is the block of the transaction or event.
+	Block uint64 `json:"block"`
+	// Index is the index of the transaction or event in the block.
+	Index uint32 `json:"index"`
+}
+
+// after returns true if the position is after the other position, or the other position is nil.
+func (p *Position) after(other *Position) bool {
+	if other == nil {
+		return true
+	}
+
+	return p.Block > other.Block || (p.Block == other.Block && p.Index > other.Index)
+}
+
+// Change is a transition taken by a machine.
+type Change struct {
+	// Transition is the name of the transition.
+	Transition string
+	// From is the state of the machine before the transition.
+	From string
+	// Instance is the machine after the transition.
+	Instance *Instance
+	// Input is the input that advanced the machine.
+	Input *Input
+}
+
+// TransitionHandler defines the methods that need to be implemented to handle transitions.
+type TransitionHandler interface {
+	// HandleTransition handles a transition.
+	HandleTransition(ctx context.Context, change *Change) error
+}
+
+// Service is a keyed state machine.
+type Service struct {
+	log          zerolog.Logger
+	name         string
+	store        Store
+	initialState string
+	transitions  []*Transition
+	handler      TransitionHandler
+	mu           sync.Mutex
+}
+
+// New creates a new state machine.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "statemachine").Str("machine", parameters.name).Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	return &Service{
+		log:          log,
+		name:         parameters.name,
+		store:        parameters.store,
+		initialState: parameters.initialState,
+		transitions:  parameters.transitions,
+		handler:      parameters.handler,
+	}, nil
+}
+
+// Instance returns the machine for the given key.  Keys that have not been advanced are
+// returned in the initial state.
+func (s *Service) Instance(ctx context.Context, key string) (*Instance, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.instance(ctx, key)
+}
+
+// HandleTx handles a transaction, advancing the machine it applies to.
+// Failures are logged, as transaction handlers cannot return errors.
+func (s *Service) HandleTx(ctx context.Context, tx *spec.Transaction, _ *handlers.TxTrigger) {
+	position := &Position{}
+	if blockNumber := tx.BlockNumber(); blockNumber != nil {
+		position.Block = uint64(*blockNumber)
+	}
+	if index := tx.TransactionIndex(); index != nil {
+		position.Index = *index
+	}
+
+	for _, transition := range s.transitions {
+		if transition.MatchTx == nil {
+			continue
+		}
+		key, matched := transition.MatchTx(tx)
+		if !matched {
+			continue
+		}
+		if err := s.advance(ctx, key, &Input{Tx: tx}, position, true); err != nil {
+			s.log.Error().Stringer("tx", tx.Hash()).Str("key", key).Err(err).Msg("Failed to advance machine")
+		}
+
+		return
+	}
+}
+
+// HandleEvent handles an event, advancing the machine it applies to.
+func (s *Service) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, _ *handlers.EventTrigger) error {
+	position := &Position{
+		Block: uint64(event.BlockNumber),
+		Index: event.Index,
+	}
+
+	for _, transition := range s.transitions {
+		if transition.MatchEvent == nil {
+			continue
+		}
+		key, matched := transition.MatchEvent(event)
+		if !matched {
+			continue
+		}
+
+		return s.advance(ctx, key, &Input{Event: event}, position, false)
+	}
+
+	return nil
+}
+
+// advance advances the machine for the key with the input.
+func (s *Service) advance(ctx context.Context, key string, input *Input, position *Position, isTx bool) error {
+	s.mu.Lock()
+	instance, err := s.instance(ctx, key)
+	if err != nil {
+		s.mu.Unlock()
+
+		return err
+	}
+
+	// Inputs at or before the last that advanced the machine have already been applied,
+	// which happens if a block is reprocessed after a restart.
+	if (isTx && !position.after(instance.LastTx)) || (!isTx && !position.after(instance.LastEvent)) {
+		s.mu.Unlock()
+
+		return nil
+	}
+
+	transition := s.transitionFor(instance.State, input)
+	if transition == nil {
+		s.mu.Unlock()
+		s.log.Trace().Str("key", key).Str("state", instance.State).Msg("No transition from state")
+
+		return nil
+	}
+
+	from := instance.State
+	if transition.Update != nil {
+		data, err := transition.Update(instance, input)
+		if err != nil {
+			s.mu.Unlock()
+
+			return errors.Join(errors.New("failed to update machine data"), err)
+		}
+		instance.Data = data
+	}
+	instance.State = transition.To
+	instance.Block = position.Block
+	instance.Transitions++
+	if isTx {
+		instance.LastTx = position
+	} else {
+		instance.LastEvent = position
+	}
+	if err := s.setInstance(ctx, instance); err != nil {
+		s.mu.Unlock()
+
+		return err
+	}
+	s.mu.Unlock()
+
+	s.log.Trace().Str("key", key).Str("transition", transition.Name).Str("from", from).Str("to", instance.State).Msg("Transition")
+	if s.handler == nil {
+		return nil
+	}
+
+	return s.handler.HandleTransition(ctx, &Change{
+		Transition: transition.Name,
+		From:       from,
+		Instance:   instance,
+		Input:      input,
+	})
+}
+
+// transitionFor returns the first transition that applies to the input from the given state.
+func (s *Service) transitionFor(state string, input *Input) *Transition {
+	for _, transition := range s.transitions {
+		if !transition.from(state) {
+			continue
+		}
+		if input.Tx != nil && transition.MatchTx != nil {
+			if _, matched := transition.MatchTx(input.Tx); matched {
+				return transition
+			}
+		}
+		if input.Event != nil && transition.MatchEvent != nil {
+			if _, matched := transition.MatchEvent(input.Event); matched {
+				return transition
+			}
+		}
+	}
+
+	return nil
+}
+
+// from returns true if the transition can be taken from the state.
+func (t *Transition) from(state string) bool {
+	if len(t.From) == 0 {
+		return true
+	}
+	for _, from := range t.From {
+		if from == state {
+			return true
+		}
+	}
+
+	return false
+}
+
+// instance returns the machine for the key.
+// This assumes that the lock is held.
+func (s *Service) instance(ctx context.Context, key string) (*Instance, error) {
+	data, err := s.store.Metadata(ctx, s.storeKey(key))
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to obtain machine state"), err)
+	}
+	if data == nil {
+		return &Instance{
+			Key:   key,
+			State: s.initialState,
+		}, nil
+	}
+
+	instance := &Instance{}
+	if err := json.Unmarshal(data, instance); err != nil {
+		return nil, errors.Join(errors.New("failed to unmarshal machine state"), err)
+	}
+
+	return instance, nil
+}
+
+// setInstance stores the machine.
+// Heights are stored as JSON numbers, so machines stored when they were held as 32 bits decode unchanged.
+// This assumes that the lock is held.
+func (s *Service) setInstance(ctx context.Context, instance *Instance) error {
+	data, err := json.Marshal(instance)
+	if err != nil {
+		return errors.Join(errors.New("failed to marshal machine state"), err)
+	}
+	if err := s.store.SetMetadata(ctx, s.storeKey(instance.Key), data); err != nil {
+		return errors.Join(errors.New("failed to store machine state"), err)
+	}
+
+	return nil
+}
+
+// storeKey returns the key in the store for the machine with the given key.
+func (s *Service) storeKey(key string) []byte {
+	return []byte(keyPrefix + keySeparator + s.name + keySeparator + key)
+}
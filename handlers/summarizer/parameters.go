@@ -0,0 +1,100 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package summarizer
+
+import (
+	"errors"
+
+	execclient "github.com/attestantio/go-execution-client"
+	"github.com/rs/zerolog"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+type parameters struct {
+	logLevel         zerolog.Level
+	addresses        handlers.AddressSet
+	eventsProvider   execclient.EventsProvider
+	receiptsProvider execclient.TransactionReceiptsProvider
+	handler          SummaryHandler
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithAddresses sets the addresses to summarize, for example a watchlist.
+func WithAddresses(addresses handlers.AddressSet) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.addresses = addresses
+	})
+}
+
+// WithEventsProvider sets the provider of events, used to count events emitted by addresses.
+// If not supplied events are not counted.
+func WithEventsProvider(provider execclient.EventsProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.eventsProvider = provider
+	})
+}
+
+// WithReceiptsProvider sets the provider of transaction receipts, used to include transaction
+// fees in ether deltas.
+// If not supplied fees are not included.
+func WithReceiptsProvider(provider execclient.TransactionReceiptsProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.receiptsProvider = provider
+	})
+}
+
+// WithHandler sets the handler for summaries.
+func WithHandler(handler SummaryHandler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.handler = handler
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.addresses == nil {
+		return nil, errors.New("no addresses specified")
+	}
+	if parameters.handler == nil {
+		return nil, errors.New("no handler specified")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,171 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package summarizer provides a block handler that produces per-block summaries of the
+// activity of a set of addresses.
+package summarizer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	execclient "github.com/attestantio/go-execution-client"
+	"github.com/attestantio/go-execution-client/api"
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	executil "github.com/attestantio/go-execution-client/util"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// Summary is the activity of an address in a block.
+type Summary struct {
+	// Address is the address.
+	Address types.Address
+	// Block is the block.
+	Block uint64
+	// TxsSent is the number of transactions sent by the address.
+	TxsSent int
+	// TxsReceived is the number of transactions received by the address.
+	TxsReceived int
+	// EtherDelta is the change in the address's ether balance from the value of top-level
+	// transactions, in wei.  Fees are included if a receipts provider is available.
+	// Internal transfers are not included.
+	EtherDelta *big.Int
+	// EventsEmitted is the number of events emitted by the address, if an events provider is available.
+	EventsEmitted int
+}
+
+// SummaryHandler defines the methods that need to be implemented to handle summaries.
+type SummaryHandler interface {
+	// HandleSummaries handles the summaries of the addresses with activity in a block.
+	// If this call returns an error then the block will be summarized again.
+	HandleSummaries(ctx context.Context, block uint64, summaries []*Summary) error
+}
+
+// Service summarizes the activity of addresses.
+type Service struct {
+	log              zerolog.Logger
+	addresses        handlers.AddressSet
+	eventsProvider   execclient.EventsProvider
+	receiptsProvider execclient.TransactionReceiptsProvider
+	handler          SummaryHandler
+}
+
+// New creates a new summarizer.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "summarizer").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	return &Service{
+		log:              log,
+		addresses:        parameters.addresses,
+		eventsProvider:   parameters.eventsProvider,
+		receiptsProvider: parameters.receiptsProvider,
+		handler:          parameters.handler,
+	}, nil
+}
+
+// Trigger returns the block trigger that feeds the summarizer.
+func (s *Service) Trigger(name string, earliestBlock uint64) *handlers.BlockTrigger {
+	return &handlers.BlockTrigger{
+		Name:          name,
+		EarliestBlock: earliestBlock,
+		Handler:       s,
+	}
+}
+
+// HandleBlock summarizes the activity of the addresses in a block.
+func (s *Service) HandleBlock(ctx context.Context, block *spec.Block, _ *handlers.BlockTrigger) error {
+	height := uint64(block.Number())
+	// Summaries are supplied in the order in which their addresses first had activity in the block.
+	summaries := make([]*Summary, 0)
+	indices := make(map[types.Address]int)
+	summary := func(address types.Address) *Summary {
+		if _, exists := indices[address]; !exists {
+			indices[address] = len(summaries)
+			summaries = append(summaries, &Summary{
+				Address:    address,
+				Block:      height,
+				EtherDelta: new(big.Int),
+			})
+		}
+
+		return summaries[indices[address]]
+	}
+
+	for _, tx := range block.Transactions() {
+		if from := tx.From(); s.addresses.Contains(from) {
+			sender := summary(from)
+			sender.TxsSent++
+			sender.EtherDelta.Sub(sender.EtherDelta, tx.Value())
+			if s.receiptsProvider != nil {
+				fee, err := s.fee(ctx, tx)
+				if err != nil {
+					return err
+				}
+				sender.EtherDelta.Sub(sender.EtherDelta, fee)
+			}
+		}
+		if to := tx.To(); to != nil && s.addresses.Contains(*to) {
+			recipient := summary(*to)
+			recipient.TxsReceived++
+			recipient.EtherDelta.Add(recipient.EtherDelta, tx.Value())
+		}
+	}
+
+	if s.eventsProvider != nil {
+		events, err := s.eventsProvider.Events(ctx, &api.EventsFilter{
+			FromBlock: executil.MarshalUint64(height),
+			ToBlock:   executil.MarshalUint64(height),
+		})
+		if err != nil {
+			return errors.Join(errors.New("failed to obtain events for block"), err)
+		}
+		for _, event := range events {
+			if s.addresses.Contains(event.Address) {
+				summary(event.Address).EventsEmitted++
+			}
+		}
+	}
+
+	if len(summaries) == 0 {
+		return nil
+	}
+
+	s.log.Trace().Uint64("block", height).Int("summaries", len(summaries)).Msg("Summarized block")
+
+	return s.handler.HandleSummaries(ctx, height, summaries)
+}
+
+// fee returns the fee paid by the sender of a transaction.
+func (s *Service) fee(ctx context.Context, tx *spec.Transaction) (*big.Int, error) {
+	receipt, err := s.receiptsProvider.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to obtain transaction receipt"), err)
+	}
+
+	fee := new(big.Int).SetUint64(receipt.EffectiveGasPrice())
+
+	return fee.Mul(fee, new(big.Int).SetUint64(uint64(receipt.GasUsed()))), nil
+}
@@ -0,0 +1,46 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/wealdtech/go-eth-listener/format"
+)
+
+// TokenMetadata is the metadata for an ERC-20 token.
+type TokenMetadata struct {
+	Address  types.Address
+	Name     string
+	Symbol   string
+	Decimals uint8
+}
+
+// FormatAmount formats a raw token amount in human units, for example
+// 1500000 for a token with 6 decimals is formatted as "1.5".
+func (t *TokenMetadata) FormatAmount(amount *big.Int) string {
+	// Decimals are always non-negative, so this cannot fail.
+	formatted, _ := format.Amount(amount, int(t.Decimals))
+
+	return formatted
+}
+
+// TokenMetadataProvider is the interface for providing token metadata.
+type TokenMetadataProvider interface {
+	// TokenMetadata returns the metadata for the token at the given address.
+	// It returns nil if the address is not a token.
+	TokenMetadata(ctx context.Context, address types.Address) (*TokenMetadata, error)
+}
@@ -0,0 +1,42 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+)
+
+// TriggerState provides access to a small opaque blob of handler state held for a trigger.
+// State set by a handler is committed atomically with the trigger's progress once the item
+// being handled has been handled successfully, so the state and the trigger's progress
+// cannot diverge after a crash.  State set while handling an item that fails is discarded.
+type TriggerState interface {
+	// State returns the state of the trigger, or nil if it has none.
+	State() []byte
+	// SetState sets the state of the trigger.
+	SetState(state []byte)
+}
+
+// WithTriggerState returns a copy of the context containing the state of the trigger whose item is being handled.
+func WithTriggerState(ctx context.Context, state TriggerState) context.Context {
+	return context.WithValue(ctx, triggerStateKey, state)
+}
+
+// TriggerStateFromContext returns the state of the trigger whose item is being handled.
+// It returns nil if the listener does not hold state for the trigger.
+func TriggerStateFromContext(ctx context.Context) TriggerState {
+	state, _ := ctx.Value(triggerStateKey).(TriggerState)
+
+	return state
+}
@@ -22,11 +22,47 @@ import (
 
 // TxTrigger is a trigger for a transaction.
 type TxTrigger struct {
-	Name          string
-	From          *types.Address
-	To            *types.Address
-	EarliestBlock uint32
+	Name string
+	// Namespace is the namespace of the trigger, isolating its progress from triggers in other namespaces.
+	Namespace string
+	From      *types.Address
+	// FromSet is a set of addresses, one of which must be the sender of the transaction.
+	FromSet AddressSet
+	To      *types.Address
+	// ToSet is a set of addresses, one of which must be the recipient of the transaction.
+	ToSet AddressSet
+	// InputPrefix is a prefix that the transaction's input data must start with.
+	InputPrefix []byte
+	// InputMatcher is a matcher that the transaction's input data must satisfy.
+	InputMatcher InputMatcher
+	// SetCode requires the transaction to be an EIP-7702 set-code transaction.
+	SetCode bool
+	// DelegatesTo is a set of addresses, one of which must be the delegation target of an
+	// authorization in an EIP-7702 set-code transaction.
+	DelegatesTo   AddressSet
+	EarliestBlock uint64
 	Handler       TxHandler
+	// Labels are arbitrary key/value pairs attached to logs and metrics for the trigger.
+	Labels map[string]string
+	// Priority is the priority of the trigger.  Triggers with higher priority are processed first within a poll.
+	Priority int
+	// DependsOn are the qualified names of triggers that this trigger must not advance past.
+	DependsOn []string
+}
+
+// InputMatcher defines the methods that need to be implemented to match transaction input data.
+// Note that *regexp.Regexp implements this interface.
+type InputMatcher interface {
+	// Match returns true if the input data matches.
+	Match(input []byte) bool
+}
+
+// InputMatcherFunc is an adapter to allow the use of an ordinary function as an input matcher.
+type InputMatcherFunc func(input []byte) bool
+
+// Match returns true if the input data matches.
+func (f InputMatcherFunc) Match(input []byte) bool {
+	return f(input)
 }
 
 // TxHandlerFunc defines the handler function.
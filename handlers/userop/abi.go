@@ -0,0 +1,179 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userop
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/wealdtech/go-eth-listener/abi"
+)
+
+// wordLength is the length of an ABI-encoded word.
+const wordLength = abi.WordLength
+
+var (
+	// userOperationEventTopic is the topic of the UserOperationEvent event, common to EntryPoint v0.6 and v0.7.
+	userOperationEventTopic = abi.EventTopic("UserOperationEvent(bytes32,address,address,uint256,bool,uint256,uint256)")
+	// handleOpsV06Selector is the selector of handleOps for EntryPoint v0.6.
+	handleOpsV06Selector = abi.Selector("handleOps((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes)[],address)")
+	// handleOpsV07Selector is the selector of handleOps for EntryPoint v0.7, with packed user operations.
+	handleOpsV07Selector = abi.Selector("handleOps((address,uint256,bytes,bytes,bytes32,uint256,bytes32,bytes,bytes)[],address)")
+)
+
+// userOpLayout is the position of fields in the encoding of a user operation.
+type userOpLayout struct {
+	callData         int
+	paymasterAndData int
+}
+
+var (
+	v06Layout = &userOpLayout{callData: 3, paymasterAndData: 9}
+	v07Layout = &userOpLayout{callData: 3, paymasterAndData: 7}
+)
+
+// decodeHandleOps decodes the user operations and beneficiary from handleOps call data.
+func decodeHandleOps(input []byte) ([]*BundledOperation, types.Address, error) {
+	if len(input) < 4 {
+		return nil, types.Address{}, errors.New("input too short")
+	}
+	var layout *userOpLayout
+	switch [4]byte(input[:4]) {
+	case handleOpsV06Selector:
+		layout = v06Layout
+	case handleOpsV07Selector:
+		layout = v07Layout
+	default:
+		return nil, types.Address{}, errors.New("not a handleOps call")
+	}
+	args := input[4:]
+
+	opsOffset, err := wordInt(args, 0)
+	if err != nil {
+		return nil, types.Address{}, err
+	}
+	beneficiary, err := wordAddress(args, wordLength)
+	if err != nil {
+		return nil, types.Address{}, err
+	}
+
+	count, err := wordInt(args, opsOffset)
+	if err != nil {
+		return nil, types.Address{}, err
+	}
+	// Offsets of the operations are relative to the start of the array contents.
+	contents := opsOffset + wordLength
+	if count > (len(args)-contents)/wordLength {
+		return nil, types.Address{}, errors.New("operation count too large")
+	}
+	ops := make([]*BundledOperation, 0, count)
+	for i := 0; i < count; i++ {
+		opOffset, err := wordInt(args, contents+i*wordLength)
+		if err != nil {
+			return nil, types.Address{}, err
+		}
+		op, err := decodeUserOp(args, contents+opOffset, layout)
+		if err != nil {
+			return nil, types.Address{}, errors.Join(fmt.Errorf("failed to decode operation %d", i), err)
+		}
+		ops = append(ops, op)
+	}
+
+	return ops, beneficiary, nil
+}
+
+// decodeUserOp decodes a user operation starting at the given position.
+func decodeUserOp(data []byte, start int, layout *userOpLayout) (*BundledOperation, error) {
+	sender, err := wordAddress(data, start)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := word(data, start+wordLength)
+	if err != nil {
+		return nil, err
+	}
+	callData, err := dynamicBytes(data, start, layout.callData)
+	if err != nil {
+		return nil, err
+	}
+	paymasterAndData, err := dynamicBytes(data, start, layout.paymasterAndData)
+	if err != nil {
+		return nil, err
+	}
+
+	op := &BundledOperation{
+		Sender:   sender,
+		Nonce:    new(big.Int).SetBytes(nonce),
+		CallData: callData,
+	}
+	if len(paymasterAndData) >= types.AddressLength {
+		paymaster := types.Address(paymasterAndData[:types.AddressLength])
+		op.Paymaster = &paymaster
+	}
+
+	return op, nil
+}
+
+// dynamicBytes returns the bytes whose offset, relative to the start of the tuple, is in the given field of the tuple.
+func dynamicBytes(data []byte, start int, field int) ([]byte, error) {
+	offset, err := wordInt(data, start+field*wordLength)
+	if err != nil {
+		return nil, err
+	}
+	length, err := wordInt(data, start+offset)
+	if err != nil {
+		return nil, err
+	}
+	begin := start + offset + wordLength
+	if length > len(data)-begin {
+		return nil, errors.New("bytes extend beyond data")
+	}
+
+	return append([]byte{}, data[begin:begin+length]...), nil
+}
+
+// word returns the word at the given position.
+func word(data []byte, pos int) ([]byte, error) {
+	if pos < 0 || pos > len(data)-wordLength {
+		return nil, errors.New("word extends beyond data")
+	}
+
+	return data[pos : pos+wordLength], nil
+}
+
+// wordInt returns the word at the given position as an integer, for offsets and lengths.
+func wordInt(data []byte, pos int) (int, error) {
+	value, err := word(data, pos)
+	if err != nil {
+		return 0, err
+	}
+	res := new(big.Int).SetBytes(value)
+	if !res.IsInt64() || res.Int64() > int64(len(data)) {
+		return 0, errors.New("value out of range")
+	}
+
+	return int(res.Int64()), nil
+}
+
+// wordAddress returns the word at the given position as an address.
+func wordAddress(data []byte, pos int) (types.Address, error) {
+	value, err := word(data, pos)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	return types.Address(value[wordLength-types.AddressLength:]), nil
+}
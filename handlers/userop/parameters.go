@@ -0,0 +1,107 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userop
+
+import (
+	"errors"
+
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+type parameters struct {
+	logLevel      zerolog.Level
+	entryPoints   []types.Address
+	senders       handlers.AddressSet
+	paymasters    handlers.AddressSet
+	opHandler     UserOperationHandler
+	bundleHandler BundleHandler
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithEntryPoints sets the addresses of the EntryPoint contracts to watch.
+func WithEntryPoints(entryPoints []types.Address) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.entryPoints = entryPoints
+	})
+}
+
+// WithSenders sets the senders of user operations to watch.
+// If not supplied user operations from all senders are delivered.
+func WithSenders(senders handlers.AddressSet) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.senders = senders
+	})
+}
+
+// WithPaymasters sets the paymasters of user operations to watch.
+// If not supplied user operations with any or no paymaster are delivered.
+func WithPaymasters(paymasters handlers.AddressSet) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.paymasters = paymasters
+	})
+}
+
+// WithUserOperationHandler sets the handler for executed user operations.
+func WithUserOperationHandler(handler UserOperationHandler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.opHandler = handler
+	})
+}
+
+// WithBundleHandler sets the handler for bundles of user operations.
+func WithBundleHandler(handler BundleHandler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.bundleHandler = handler
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if len(parameters.entryPoints) == 0 {
+		return nil, errors.New("no entry points specified")
+	}
+	if parameters.opHandler == nil && parameters.bundleHandler == nil {
+		return nil, errors.New("no handler specified")
+	}
+
+	return &parameters, nil
+}
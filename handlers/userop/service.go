@@ -0,0 +1,263 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package userop provides triggers that deliver typed ERC-4337 user operations from
+// EntryPoint contracts.
+package userop
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// UserOperation is a user operation executed by an EntryPoint contract.
+type UserOperation struct {
+	// Hash is the hash of the user operation.
+	Hash types.Hash
+	// EntryPoint is the EntryPoint contract that executed the user operation.
+	EntryPoint types.Address
+	// Sender is the account that sent the user operation.
+	Sender types.Address
+	// Paymaster is the paymaster of the user operation, or nil if there was none.
+	Paymaster *types.Address
+	// Nonce is the nonce of the user operation.
+	Nonce *big.Int
+	// Success is true if the user operation's call succeeded.
+	Success bool
+	// ActualGasCost is the cost of the user operation, in wei.
+	ActualGasCost *big.Int
+	// ActualGasUsed is the gas used by the user operation.
+	ActualGasUsed *big.Int
+	// TransactionHash is the hash of the transaction containing the user operation.
+	TransactionHash types.Hash
+	// Block is the block containing the user operation.
+	Block uint64
+	// LogIndex is the index of the UserOperationEvent in the block.
+	LogIndex uint32
+}
+
+// BundledOperation is a user operation as submitted in a bundle.
+type BundledOperation struct {
+	// Sender is the account that sent the user operation.
+	Sender types.Address
+	// Nonce is the nonce of the user operation.
+	Nonce *big.Int
+	// Paymaster is the paymaster of the user operation, or nil if there was none.
+	Paymaster *types.Address
+	// CallData is the data passed to the sender's account.
+	CallData []byte
+}
+
+// Bundle is a handleOps call to an EntryPoint contract.
+type Bundle struct {
+	// EntryPoint is the EntryPoint contract called.
+	EntryPoint types.Address
+	// Bundler is the sender of the transaction.
+	Bundler types.Address
+	// Beneficiary is the address that receives the bundle's fees.
+	Beneficiary types.Address
+	// Operations are the user operations in the bundle that match the filters.
+	Operations []*BundledOperation
+	// TransactionHash is the hash of the transaction.
+	TransactionHash types.Hash
+	// Block is the block containing the transaction.
+	Block uint64
+}
+
+// UserOperationHandler defines the methods that need to be implemented to handle executed user operations.
+type UserOperationHandler interface {
+	// HandleUserOperation handles an executed user operation.
+	HandleUserOperation(ctx context.Context, op *UserOperation) error
+}
+
+// BundleHandler defines the methods that need to be implemented to handle bundles.
+type BundleHandler interface {
+	// HandleBundle handles a bundle of user operations.
+	HandleBundle(ctx context.Context, bundle *Bundle)
+}
+
+// Service decodes user operations.
+type Service struct {
+	log           zerolog.Logger
+	entryPoints   map[types.Address]struct{}
+	senders       handlers.AddressSet
+	paymasters    handlers.AddressSet
+	opHandler     UserOperationHandler
+	bundleHandler BundleHandler
+}
+
+// New creates a new user operation decoder.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "userop").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	entryPoints := make(map[types.Address]struct{}, len(parameters.entryPoints))
+	for _, entryPoint := range parameters.entryPoints {
+		entryPoints[entryPoint] = struct{}{}
+	}
+
+	return &Service{
+		log:           log,
+		entryPoints:   entryPoints,
+		senders:       parameters.senders,
+		paymasters:    parameters.paymasters,
+		opHandler:     parameters.opHandler,
+		bundleHandler: parameters.bundleHandler,
+	}, nil
+}
+
+// Triggers returns the transaction and event triggers that feed the decoder.
+// Either trigger is nil if there is no handler for the relevant type.
+func (s *Service) Triggers(name string, earliestBlock uint64) (*handlers.TxTrigger, *handlers.EventTrigger) {
+	var txTrigger *handlers.TxTrigger
+	if s.bundleHandler != nil {
+		txTrigger = &handlers.TxTrigger{
+			Name:          name,
+			ToSet:         s,
+			InputMatcher:  handlers.InputMatcherFunc(isHandleOps),
+			EarliestBlock: earliestBlock,
+			Handler:       s,
+		}
+	}
+
+	var eventTrigger *handlers.EventTrigger
+	if s.opHandler != nil {
+		eventTrigger = &handlers.EventTrigger{
+			Name:          name,
+			SourceSet:     s,
+			Topics:        []types.Hash{userOperationEventTopic},
+			EarliestBlock: earliestBlock,
+			Handler:       s,
+		}
+	}
+
+	return txTrigger, eventTrigger
+}
+
+// Contains returns true if the address is a watched EntryPoint contract.
+func (s *Service) Contains(address types.Address) bool {
+	_, exists := s.entryPoints[address]
+
+	return exists
+}
+
+// Addresses returns the watched EntryPoint contracts.
+func (s *Service) Addresses() []types.Address {
+	res := make([]types.Address, 0, len(s.entryPoints))
+	for address := range s.entryPoints {
+		res = append(res, address)
+	}
+
+	return res
+}
+
+// HandleTx handles a transaction, decoding the user operations in a handleOps call.
+func (s *Service) HandleTx(ctx context.Context, tx *spec.Transaction, _ *handlers.TxTrigger) {
+	to := tx.To()
+	if to == nil {
+		return
+	}
+
+	ops, beneficiary, err := decodeHandleOps(tx.Input())
+	if err != nil {
+		s.log.Debug().Stringer("tx", tx.Hash()).Err(err).Msg("Failed to decode handleOps call")
+
+		return
+	}
+
+	bundle := &Bundle{
+		EntryPoint:      *to,
+		Bundler:         tx.From(),
+		Beneficiary:     beneficiary,
+		Operations:      make([]*BundledOperation, 0, len(ops)),
+		TransactionHash: tx.Hash(),
+	}
+	if blockNumber := tx.BlockNumber(); blockNumber != nil {
+		bundle.Block = uint64(*blockNumber)
+	}
+	for _, op := range ops {
+		if s.matches(op.Sender, op.Paymaster) {
+			bundle.Operations = append(bundle.Operations, op)
+		}
+	}
+	if len(bundle.Operations) == 0 {
+		return
+	}
+
+	s.log.Trace().Stringer("tx", bundle.TransactionHash).Int("operations", len(bundle.Operations)).Msg("Bundle")
+	s.bundleHandler.HandleBundle(ctx, bundle)
+}
+
+// HandleEvent handles an event, decoding a UserOperationEvent.
+func (s *Service) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, _ *handlers.EventTrigger) error {
+	if len(event.Topics) != 4 || event.Topics[0] != userOperationEventTopic || len(event.Data) != 4*wordLength {
+		return nil
+	}
+
+	op := &UserOperation{
+		Hash:            event.Topics[1],
+		EntryPoint:      event.Address,
+		Sender:          types.Address(event.Topics[2][wordLength-types.AddressLength:]),
+		Nonce:           new(big.Int).SetBytes(event.Data[0:wordLength]),
+		Success:         new(big.Int).SetBytes(event.Data[wordLength:2*wordLength]).Sign() != 0,
+		ActualGasCost:   new(big.Int).SetBytes(event.Data[2*wordLength : 3*wordLength]),
+		ActualGasUsed:   new(big.Int).SetBytes(event.Data[3*wordLength : 4*wordLength]),
+		TransactionHash: event.TransactionHash,
+		Block:           uint64(event.BlockNumber),
+		LogIndex:        event.Index,
+	}
+	paymaster := types.Address(event.Topics[3][wordLength-types.AddressLength:])
+	if paymaster != (types.Address{}) {
+		op.Paymaster = &paymaster
+	}
+	if !s.matches(op.Sender, op.Paymaster) {
+		return nil
+	}
+
+	s.log.Trace().Stringer("tx", op.TransactionHash).Stringer("sender", op.Sender).Bool("success", op.Success).Msg("User operation")
+
+	return s.opHandler.HandleUserOperation(ctx, op)
+}
+
+// matches returns true if the sender and paymaster of a user operation match the filters.
+func (s *Service) matches(sender types.Address, paymaster *types.Address) bool {
+	if s.senders != nil && !s.senders.Contains(sender) {
+		return false
+	}
+	if s.paymasters != nil && (paymaster == nil || !s.paymasters.Contains(*paymaster)) {
+		return false
+	}
+
+	return true
+}
+
+// isHandleOps returns true if the input is a handleOps call.
+func isHandleOps(input []byte) bool {
+	return len(input) >= 4 && (bytes.Equal(input[:4], handleOpsV06Selector[:]) || bytes.Equal(input[:4], handleOpsV07Selector[:]))
+}
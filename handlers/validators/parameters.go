@@ -0,0 +1,110 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"errors"
+
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// mainnetDepositContract is the address of the deposit contract on mainnet.
+var mainnetDepositContract = types.Address{
+	0x00, 0x00, 0x00, 0x00, 0x21, 0x9a, 0xb5, 0x40, 0x35, 0x6c,
+	0xbb, 0x83, 0x9c, 0xbe, 0x05, 0x30, 0x3d, 0x77, 0x05, 0xfa,
+}
+
+type parameters struct {
+	logLevel            zerolog.Level
+	depositContract     types.Address
+	pubKeys             []PubKey
+	withdrawalAddresses handlers.AddressSet
+	indexProvider       IndexProvider
+	handler             LifecycleHandler
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithDepositContract sets the address of the deposit contract.
+// Defaults to the mainnet deposit contract.
+func WithDepositContract(address types.Address) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.depositContract = address
+	})
+}
+
+// WithPubKeys sets the public keys of validators to track.
+func WithPubKeys(pubKeys []PubKey) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.pubKeys = pubKeys
+	})
+}
+
+// WithWithdrawalAddresses sets the withdrawal addresses whose validators are tracked.
+func WithWithdrawalAddresses(addresses handlers.AddressSet) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.withdrawalAddresses = addresses
+	})
+}
+
+// WithIndexProvider sets the provider of validator indices, used to link deposits to withdrawals.
+func WithIndexProvider(provider IndexProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.indexProvider = provider
+	})
+}
+
+// WithHandler sets the handler for validator lifecycle changes.
+func WithHandler(handler LifecycleHandler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.handler = handler
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:        zerolog.GlobalLevel(),
+		depositContract: mainnetDepositContract,
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if len(parameters.pubKeys) == 0 && parameters.withdrawalAddresses == nil {
+		return nil, errors.New("no public keys or withdrawal addresses specified")
+	}
+
+	return &parameters, nil
+}
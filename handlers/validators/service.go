@@ -0,0 +1,456 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package validators provides a tracker of the execution layer lifecycle of validators,
+// from deposits to the deposit contract through to withdrawals.
+package validators
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/abi"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// depositEventTopic is the topic of the deposit contract's DepositEvent event.
+var depositEventTopic = abi.EventTopic("DepositEvent(bytes,bytes,bytes,bytes,bytes)")
+
+const (
+	// withdrawalsSuffix is appended to the name of the trigger to give the name of the withdrawals trigger.
+	withdrawalsSuffix = "-withdrawals"
+	// exitThreshold is the withdrawal amount, in gwei, at or above which a withdrawal is considered
+	// to be the full withdrawal of an exited validator.
+	exitThreshold = 16_000_000_000
+	// resolveInterval is the number of blocks between attempts to resolve the indices of validators.
+	resolveInterval = 32
+)
+
+// PubKey is a validator public key.
+type PubKey [48]byte
+
+// String returns the public key as a hex string.
+func (p PubKey) String() string {
+	return fmt.Sprintf("%#x", p[:])
+}
+
+// Status is the execution layer status of a validator.
+type Status string
+
+const (
+	// StatusDeposited is a validator that has deposits but no withdrawals.
+	StatusDeposited Status = "deposited"
+	// StatusWithdrawing is a validator that is receiving partial withdrawals.
+	StatusWithdrawing Status = "withdrawing"
+	// StatusExited is a validator that has received a full withdrawal.
+	StatusExited Status = "exited"
+)
+
+// Deposit is a deposit to the deposit contract.
+type Deposit struct {
+	// PubKey is the public key of the validator.
+	PubKey PubKey
+	// WithdrawalCredentials are the withdrawal credentials of the deposit.
+	WithdrawalCredentials []byte
+	// Amount is the amount deposited, in gwei.
+	Amount uint64
+	// Index is the index of the deposit in the deposit contract.
+	Index uint64
+	// TransactionHash is the hash of the transaction containing the deposit.
+	TransactionHash types.Hash
+	// Block is the block containing the deposit.
+	Block uint64
+}
+
+// Withdrawal is a withdrawal from the beacon chain.
+type Withdrawal struct {
+	// Index is the index of the withdrawal.
+	Index uint64
+	// ValidatorIndex is the index of the validator.
+	ValidatorIndex uint64
+	// Address is the address that received the withdrawal.
+	Address types.Address
+	// Amount is the amount withdrawn, in gwei.
+	Amount uint64
+	// Block is the block containing the withdrawal.
+	Block uint64
+}
+
+// Validator is the execution layer lifecycle of a validator.
+type Validator struct {
+	// PubKey is the public key of the validator, or nil if only its withdrawals have been seen.
+	PubKey *PubKey
+	// Index is the index of the validator, or nil if not yet known.
+	Index *uint64
+	// WithdrawalAddress is the withdrawal address of the validator, or nil if it has BLS credentials.
+	WithdrawalAddress *types.Address
+	// Status is the status of the validator.
+	Status Status
+	// Deposits are the deposits for the validator.
+	Deposits []*Deposit
+	// Deposited is the total amount deposited, in gwei.
+	Deposited uint64
+	// Withdrawals is the number of withdrawals received.
+	Withdrawals uint64
+	// Withdrawn is the total amount withdrawn, in gwei.
+	Withdrawn uint64
+	// LastWithdrawalBlock is the block of the most recent withdrawal, or 0 if none.
+	LastWithdrawalBlock uint64
+}
+
+// IndexProvider defines the methods that need to be implemented to provide validator indices.
+type IndexProvider interface {
+	// ValidatorIndex returns the index of the validator with the given public key,
+	// or nil if the validator does not yet have an index.
+	ValidatorIndex(ctx context.Context, pubKey PubKey) (*uint64, error)
+}
+
+// LifecycleHandler defines the methods that need to be implemented to handle validator lifecycle changes.
+type LifecycleHandler interface {
+	// HandleDeposit handles a deposit for a tracked validator.
+	HandleDeposit(ctx context.Context, deposit *Deposit, validator *Validator) error
+	// HandleWithdrawal handles a withdrawal for a tracked validator.
+	HandleWithdrawal(ctx context.Context, withdrawal *Withdrawal, validator *Validator) error
+}
+
+// Service tracks validator lifecycles.
+type Service struct {
+	log                 zerolog.Logger
+	depositContract     types.Address
+	pubKeys             map[PubKey]struct{}
+	withdrawalAddresses handlers.AddressSet
+	indexProvider       IndexProvider
+	handler             LifecycleHandler
+	validatorsMu        sync.RWMutex
+	byPubKey            map[PubKey]*Validator
+	byIndex             map[uint64]*Validator
+}
+
+// New creates a new validator lifecycle tracker.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "handler").Str("impl", "validators").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	pubKeys := make(map[PubKey]struct{}, len(parameters.pubKeys))
+	for _, pubKey := range parameters.pubKeys {
+		pubKeys[pubKey] = struct{}{}
+	}
+
+	return &Service{
+		log:                 log,
+		depositContract:     parameters.depositContract,
+		pubKeys:             pubKeys,
+		withdrawalAddresses: parameters.withdrawalAddresses,
+		indexProvider:       parameters.indexProvider,
+		handler:             parameters.handler,
+		byPubKey:            make(map[PubKey]*Validator),
+		byIndex:             make(map[uint64]*Validator),
+	}, nil
+}
+
+// Triggers returns the event trigger that tracks deposits, and the block trigger that tracks
+// withdrawals.  The block trigger depends on the event trigger, so that a validator's deposits
+// are always seen before its withdrawals.
+// Lifecycles are tracked in memory, so the triggers should start from a block before the
+// earliest deposit of interest.
+func (s *Service) Triggers(name string, earliestBlock uint64) (*handlers.EventTrigger, *handlers.BlockTrigger) {
+	eventTrigger := &handlers.EventTrigger{
+		Name:          name,
+		Source:        &s.depositContract,
+		Topics:        []types.Hash{depositEventTopic},
+		EarliestBlock: earliestBlock,
+		Handler:       s,
+	}
+	blockTrigger := &handlers.BlockTrigger{
+		Name:          name + withdrawalsSuffix,
+		EarliestBlock: earliestBlock,
+		Handler:       s,
+		DependsOn:     []string{eventTrigger.QualifiedName()},
+	}
+
+	return eventTrigger, blockTrigger
+}
+
+// Validator returns a copy of the lifecycle of the validator with the given public key,
+// or nil if it is not tracked.
+func (s *Service) Validator(pubKey PubKey) *Validator {
+	s.validatorsMu.RLock()
+	defer s.validatorsMu.RUnlock()
+
+	validator, exists := s.byPubKey[pubKey]
+	if !exists {
+		return nil
+	}
+
+	return validator.copy()
+}
+
+// ValidatorByIndex returns a copy of the lifecycle of the validator with the given index,
+// or nil if it is not tracked.
+func (s *Service) ValidatorByIndex(index uint64) *Validator {
+	s.validatorsMu.RLock()
+	defer s.validatorsMu.RUnlock()
+
+	validator, exists := s.byIndex[index]
+	if !exists {
+		return nil
+	}
+
+	return validator.copy()
+}
+
+// Validators returns copies of the lifecycles of all tracked validators.
+func (s *Service) Validators() []*Validator {
+	s.validatorsMu.RLock()
+	defer s.validatorsMu.RUnlock()
+
+	res := make([]*Validator, 0, len(s.byPubKey)+len(s.byIndex))
+	for _, validator := range s.byPubKey {
+		res = append(res, validator.copy())
+	}
+	for _, validator := range s.byIndex {
+		if validator.PubKey == nil {
+			res = append(res, validator.copy())
+		}
+	}
+
+	return res
+}
+
+// SetIndex links the validator with the given public key to its index, merging any
+// withdrawals already seen for the index.
+func (s *Service) SetIndex(pubKey PubKey, index uint64) {
+	s.validatorsMu.Lock()
+	defer s.validatorsMu.Unlock()
+
+	s.setIndex(pubKey, index)
+}
+
+// setIndex links a validator to its index.
+// This assumes that the validators lock is held.
+func (s *Service) setIndex(pubKey PubKey, index uint64) {
+	validator, exists := s.byPubKey[pubKey]
+	if !exists || validator.Index != nil {
+		return
+	}
+	validator.Index = &index
+	if existing, exists := s.byIndex[index]; exists && existing.PubKey == nil {
+		validator.Withdrawals += existing.Withdrawals
+		validator.Withdrawn += existing.Withdrawn
+		validator.LastWithdrawalBlock = existing.LastWithdrawalBlock
+		validator.Status = existing.Status
+	}
+	s.byIndex[index] = validator
+}
+
+// HandleEvent handles a deposit event.
+// DepositEvent is (bytes pubkey, bytes withdrawal_credentials, bytes amount, bytes signature, bytes index),
+// with amount and index little-endian.
+func (s *Service) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, _ *handlers.EventTrigger) error {
+	if event.Address != s.depositContract || len(event.Topics) == 0 || event.Topics[0] != depositEventTopic {
+		return nil
+	}
+	deposit, err := decodeDeposit(event)
+	if err != nil {
+		s.log.Debug().Stringer("tx", event.TransactionHash).Uint32("index", event.Index).Err(err).Msg("Failed to decode deposit")
+
+		return nil
+	}
+	withdrawalAddress := credentialsAddress(deposit.WithdrawalCredentials)
+	if !s.tracks(deposit.PubKey, withdrawalAddress) {
+		return nil
+	}
+
+	s.validatorsMu.Lock()
+	validator, exists := s.byPubKey[deposit.PubKey]
+	if !exists {
+		pubKey := deposit.PubKey
+		validator = &Validator{
+			PubKey:            &pubKey,
+			WithdrawalAddress: withdrawalAddress,
+			Status:            StatusDeposited,
+		}
+		s.byPubKey[pubKey] = validator
+	}
+	validator.Deposits = append(validator.Deposits, deposit)
+	validator.Deposited += deposit.Amount
+	res := validator.copy()
+	s.validatorsMu.Unlock()
+
+	s.log.Trace().Stringer("pubkey", deposit.PubKey).Uint64("amount", deposit.Amount).Msg("Deposit")
+	if s.handler == nil {
+		return nil
+	}
+
+	return s.handler.HandleDeposit(ctx, deposit, res)
+}
+
+// HandleBlock handles a block, tracking its withdrawals.
+func (s *Service) HandleBlock(ctx context.Context, block *spec.Block, _ *handlers.BlockTrigger) error {
+	if block.Number()%resolveInterval == 0 {
+		s.resolveIndices(ctx)
+	}
+
+	blockWithdrawals, exists := block.Withdrawals()
+	if !exists {
+		return nil
+	}
+	for _, blockWithdrawal := range blockWithdrawals {
+		withdrawal := &Withdrawal{
+			Index:          blockWithdrawal.Index,
+			ValidatorIndex: blockWithdrawal.ValidatorIndex,
+			Address:        blockWithdrawal.Address,
+			Block:          uint64(block.Number()),
+		}
+		if blockWithdrawal.Amount != nil && blockWithdrawal.Amount.IsUint64() {
+			withdrawal.Amount = blockWithdrawal.Amount.Uint64()
+		}
+
+		validator := s.recordWithdrawal(withdrawal)
+		if validator == nil || s.handler == nil {
+			continue
+		}
+		if err := s.handler.HandleWithdrawal(ctx, withdrawal, validator); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordWithdrawal records a withdrawal, returning a copy of the validator's lifecycle,
+// or nil if the validator is not tracked.
+func (s *Service) recordWithdrawal(withdrawal *Withdrawal) *Validator {
+	s.validatorsMu.Lock()
+	defer s.validatorsMu.Unlock()
+
+	validator, exists := s.byIndex[withdrawal.ValidatorIndex]
+	if !exists {
+		if s.withdrawalAddresses == nil || !s.withdrawalAddresses.Contains(withdrawal.Address) {
+			return nil
+		}
+		index := withdrawal.ValidatorIndex
+		address := withdrawal.Address
+		validator = &Validator{
+			Index:             &index,
+			WithdrawalAddress: &address,
+		}
+		s.byIndex[index] = validator
+	}
+
+	validator.Withdrawals++
+	validator.Withdrawn += withdrawal.Amount
+	validator.LastWithdrawalBlock = withdrawal.Block
+	switch {
+	case withdrawal.Amount >= exitThreshold:
+		validator.Status = StatusExited
+	case validator.Status != StatusExited:
+		validator.Status = StatusWithdrawing
+	}
+
+	return validator.copy()
+}
+
+// resolveIndices attempts to obtain the indices of tracked validators that do not yet have them.
+func (s *Service) resolveIndices(ctx context.Context) {
+	if s.indexProvider == nil {
+		return
+	}
+
+	s.validatorsMu.RLock()
+	pubKeys := make([]PubKey, 0)
+	for pubKey, validator := range s.byPubKey {
+		if validator.Index == nil {
+			pubKeys = append(pubKeys, pubKey)
+		}
+	}
+	s.validatorsMu.RUnlock()
+
+	for _, pubKey := range pubKeys {
+		index, err := s.indexProvider.ValidatorIndex(ctx, pubKey)
+		if err != nil {
+			s.log.Debug().Stringer("pubkey", pubKey).Err(err).Msg("Failed to obtain validator index")
+
+			continue
+		}
+		if index != nil {
+			s.SetIndex(pubKey, *index)
+		}
+	}
+}
+
+// tracks returns true if the validator with the given public key and withdrawal address is tracked.
+func (s *Service) tracks(pubKey PubKey, withdrawalAddress *types.Address) bool {
+	if _, exists := s.pubKeys[pubKey]; exists {
+		return true
+	}
+
+	return withdrawalAddress != nil && s.withdrawalAddresses != nil && s.withdrawalAddresses.Contains(*withdrawalAddress)
+}
+
+// decodeDeposit decodes a deposit from a DepositEvent event.
+func decodeDeposit(event *spec.BerlinTransactionEvent) (*Deposit, error) {
+	fields := make([][]byte, 5)
+	for i := range fields {
+		var err error
+		if fields[i], err = abi.Bytes(event.Data, i); err != nil {
+			return nil, err
+		}
+	}
+	if len(fields[0]) != len(PubKey{}) || len(fields[2]) != 8 || len(fields[4]) != 8 {
+		return nil, errors.New("unexpected field length")
+	}
+
+	return &Deposit{
+		PubKey:                PubKey(fields[0]),
+		WithdrawalCredentials: fields[1],
+		Amount:                binary.LittleEndian.Uint64(fields[2]),
+		Index:                 binary.LittleEndian.Uint64(fields[4]),
+		TransactionHash:       event.TransactionHash,
+		Block:                 uint64(event.BlockNumber),
+	}, nil
+}
+
+// credentialsAddress returns the execution address of withdrawal credentials, or nil
+// if the credentials are BLS credentials.
+func credentialsAddress(credentials []byte) *types.Address {
+	if len(credentials) != 32 || credentials[0] == 0x00 {
+		return nil
+	}
+	address := types.Address(credentials[32-types.AddressLength:])
+
+	return &address
+}
+
+// copy returns a copy of the validator.
+func (v *Validator) copy() *Validator {
+	res := *v
+	res.Deposits = append([]*Deposit{}, v.Deposits...)
+
+	return &res
+}
@@ -0,0 +1,75 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/attestantio/go-execution-client/spec"
+)
+
+// WindowTrigger is a trigger that collects events matching event filters over a sliding window
+// of blocks, and fires when the number of matching events in the window reaches a threshold, or
+// when any aggregate with a threshold reaches it.
+// Once the trigger has fired the window is emptied, so it fires again only when the threshold
+// is reached by subsequent events.
+// Windowed triggers are processed alongside event triggers.  The window is held in memory, so
+// starts empty when the listener restarts.
+type WindowTrigger struct {
+	Name string
+	// Namespace is the namespace of the trigger, isolating its progress from triggers in other namespaces.
+	Namespace string
+	// Event holds the filters that events must match.
+	// Its name, namespace, handler and progress fields are ignored.
+	Event *EventTrigger
+	// Blocks is the number of blocks in the window.
+	Blocks uint32
+	// Threshold is the number of matching events in the window at which the trigger fires.
+	// If zero, the trigger fires only on aggregate thresholds.
+	Threshold     int
+	EarliestBlock uint64
+	Handler       WindowHandler
+	// Aggregates are named aggregators calculated over the events in the window.
+	Aggregates map[string]Aggregator
+	// AggregateThresholds are the values of named aggregates at which the trigger fires.
+	AggregateThresholds map[string]*big.Int
+	// Labels are arbitrary key/value pairs attached to logs and metrics for the trigger.
+	Labels map[string]string
+	// Priority is the priority of the trigger.  Triggers with higher priority are processed first within a poll.
+	Priority int
+	// DependsOn are the qualified names of triggers that this trigger must not advance past.
+	DependsOn []string
+}
+
+// Window is the aggregate of events in a window.
+type Window struct {
+	// From is the first block of the window.
+	From uint64
+	// To is the last block of the window.
+	To uint64
+	// Events are the matching events in the window, in the order in which they occurred.
+	Events []*spec.BerlinTransactionEvent
+	// Aggregates are the values of the trigger's aggregates over the events.
+	Aggregates map[string]*big.Int
+}
+
+// WindowHandlerFunc defines the handler function.
+type WindowHandlerFunc func(ctx context.Context, window *Window, trigger *WindowTrigger) error
+
+// WindowHandler defines the methods that need to be implemented to handle windowed triggers.
+type WindowHandler interface {
+	// HandleWindow handles a window that has reached the threshold of the trigger.
+	HandleWindow(ctx context.Context, window *Window, trigger *WindowTrigger) error
+}
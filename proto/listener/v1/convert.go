@@ -0,0 +1,141 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listenerv1
+
+import (
+	"context"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// NewBlockItem returns the item for a block delivered by the given trigger, with the
+// metadata supplied by the listener in the handler context.
+func NewBlockItem(ctx context.Context, block *spec.Block, trigger string) *Item {
+	item := newItem(ctx, "block", trigger)
+	item.Data = &Item_Block{Block: FromBlock(block)}
+
+	return item
+}
+
+// NewTransactionItem returns the item for a transaction delivered by the given trigger, with the
+// metadata supplied by the listener in the handler context.
+func NewTransactionItem(ctx context.Context, tx *spec.Transaction, trigger string) *Item {
+	item := newItem(ctx, "transaction", trigger)
+	item.Data = &Item_Transaction{Transaction: FromTransaction(tx)}
+
+	return item
+}
+
+// NewEventItem returns the item for an event delivered by the given trigger, with the
+// metadata supplied by the listener in the handler context.
+func NewEventItem(ctx context.Context, event *spec.BerlinTransactionEvent, trigger string) *Item {
+	item := newItem(ctx, "event", trigger)
+	item.Data = &Item_Event{Event: FromEvent(event)}
+
+	return item
+}
+
+func newItem(ctx context.Context, itemType string, trigger string) *Item {
+	item := &Item{
+		Type:           itemType,
+		Trigger:        trigger,
+		IdempotencyKey: handlers.IdempotencyKeyFromContext(ctx),
+		Signatures:     handlers.SignaturesFromContext(ctx),
+	}
+	if confirmations, exists := handlers.ConfirmationsFromContext(ctx); exists {
+		item.Confirmations = confirmations
+	}
+
+	return item
+}
+
+// FromBlock converts a block to its protobuf representation.
+func FromBlock(block *spec.Block) *Block {
+	hash := block.Hash()
+	parentHash := block.ParentHash()
+	feeRecipient := block.FeeRecipient()
+	res := &Block{
+		Number:        uint64(block.Number()),
+		Hash:          hash[:],
+		ParentHash:    parentHash[:],
+		Timestamp:     block.Timestamp().Unix(),
+		FeeRecipient:  feeRecipient[:],
+		GasLimit:      uint64(block.GasLimit()),
+		GasUsed:       uint64(block.GasUsed()),
+		BaseFeePerGas: block.BaseFeePerGas(),
+	}
+	txs := block.Transactions()
+	res.TransactionHashes = make([][]byte, len(txs))
+	for i, tx := range txs {
+		txHash := tx.Hash()
+		res.TransactionHashes[i] = txHash[:]
+	}
+
+	return res
+}
+
+// FromTransaction converts a transaction to its protobuf representation.
+func FromTransaction(tx *spec.Transaction) *Transaction {
+	hash := tx.Hash()
+	from := tx.From()
+	res := &Transaction{
+		Hash:                 hash[:],
+		Type:                 uint32(tx.Type),
+		From:                 from[:],
+		Nonce:                tx.Nonce(),
+		Gas:                  uint64(tx.Gas()),
+		GasPrice:             tx.GasPrice(),
+		MaxFeePerGas:         tx.MaxFeePerGas(),
+		MaxPriorityFeePerGas: tx.MaxPriorityFeePerGas(),
+		Input:                tx.Input(),
+	}
+	if blockNumber := tx.BlockNumber(); blockNumber != nil {
+		res.BlockNumber = uint64(*blockNumber)
+	}
+	if blockHash := tx.BlockHash(); blockHash != nil {
+		res.BlockHash = blockHash[:]
+	}
+	if index := tx.TransactionIndex(); index != nil {
+		res.TransactionIndex = *index
+	}
+	if to := tx.To(); to != nil {
+		res.To = to[:]
+	}
+	if value := tx.Value(); value != nil {
+		res.Value = value.Bytes()
+	}
+
+	return res
+}
+
+// FromEvent converts an event to its protobuf representation.
+func FromEvent(event *spec.BerlinTransactionEvent) *Event {
+	res := &Event{
+		Address:          event.Address[:],
+		Topics:           make([][]byte, len(event.Topics)),
+		Data:             event.Data,
+		BlockNumber:      uint64(event.BlockNumber),
+		BlockHash:        event.BlockHash[:],
+		TransactionHash:  event.TransactionHash[:],
+		TransactionIndex: event.TransactionIndex,
+		LogIndex:         event.Index,
+		Removed:          event.Removed,
+	}
+	for i := range event.Topics {
+		res.Topics[i] = event.Topics[i][:]
+	}
+
+	return res
+}
@@ -0,0 +1,21 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package listenerv1 provides the version 1 protobuf schema for blocks, transactions and events
+// delivered by the listener, for sinks that emit protobuf messages to cross-language consumers.
+//
+// The schema is defined in listener.proto; field numbers are never reused, and incompatible
+// changes are made in a new version of the package.
+package listenerv1
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative listener.proto
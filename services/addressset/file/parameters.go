@@ -0,0 +1,110 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel    zerolog.Level
+	path        string
+	reader      Reader
+	parser      Parser
+	interval    time.Duration
+	reloadOnHUP bool
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithPath sets the path of the file containing the addresses.
+func WithPath(path string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.path = path
+	})
+}
+
+// WithReader sets the function that reads the contents of the set, in place of reading the file
+// at the path.
+func WithReader(reader Reader) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.reader = reader
+	})
+}
+
+// WithParser sets the function that parses the contents of the set in to addresses.
+// If not supplied the contents are a JSON array of addresses.
+func WithParser(parser Parser) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.parser = parser
+	})
+}
+
+// WithInterval sets the interval between reloads of the file.
+// If not supplied the file is loaded once only.
+func WithInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.interval = interval
+	})
+}
+
+// WithReloadOnHUP reloads the file when the process receives SIGHUP.
+func WithReloadOnHUP(reload bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.reloadOnHUP = reload
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.path == "" && parameters.reader == nil {
+		return nil, errors.New("no path or reader specified")
+	}
+	if parameters.path != "" && parameters.reader != nil {
+		return nil, errors.New("only one of path and reader can be specified")
+	}
+	if parameters.parser == nil {
+		parameters.parser = parseJSON
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,196 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package file provides an address set backed by a file, with optional hot reload.
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/attestantio/go-execution-client/util"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Reader reads the contents of an address set.
+type Reader func(ctx context.Context) ([]byte, error)
+
+// Parser parses the contents of an address set in to its addresses.
+type Parser func(data []byte) ([]types.Address, error)
+
+// Service is an address set backed by a file.
+// By default the file contains a JSON array of addresses.
+type Service struct {
+	log       zerolog.Logger
+	path      string
+	reader    Reader
+	parser    Parser
+	mu        sync.RWMutex
+	members   map[types.Address]struct{}
+	addresses []types.Address
+}
+
+// New creates a new file-backed address set.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "addressset").Str("impl", "file").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		log:    log,
+		path:   parameters.path,
+		reader: parameters.reader,
+		parser: parameters.parser,
+	}
+
+	// Initial load must succeed.
+	if err := s.reload(ctx); err != nil {
+		return nil, err
+	}
+
+	if parameters.interval > 0 || parameters.reloadOnHUP {
+		go s.reloader(ctx, parameters.interval, parameters.reloadOnHUP)
+	}
+
+	return s, nil
+}
+
+// Contains returns true if the address is a member of the set.
+func (s *Service) Contains(address types.Address) bool {
+	s.mu.RLock()
+	_, exists := s.members[address]
+	s.mu.RUnlock()
+
+	return exists
+}
+
+// Addresses returns the addresses that are members of the set.
+func (s *Service) Addresses() []types.Address {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]types.Address, len(s.addresses))
+	copy(res, s.addresses)
+
+	return res
+}
+
+func (s *Service) reloader(ctx context.Context, interval time.Duration, reloadOnHUP bool) {
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	var hup chan os.Signal
+	if reloadOnHUP {
+		hup = make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		defer signal.Stop(hup)
+	}
+
+	for {
+		select {
+		case <-tick:
+			s.log.Trace().Msg("Reloading address set on interval")
+		case <-hup:
+			s.log.Debug().Msg("Reloading address set on SIGHUP")
+		case <-ctx.Done():
+			s.log.Debug().Msg("Context done")
+			return
+		}
+		if err := s.reload(ctx); err != nil && ctx.Err() == nil {
+			// Keep the existing set in place.
+			s.log.Warn().Str("path", s.path).Err(err).Msg("Failed to reload address set; retaining previous addresses")
+		}
+	}
+}
+
+func (s *Service) reload(ctx context.Context) error {
+	data, err := s.read(ctx)
+	if err != nil {
+		return err
+	}
+
+	entries, err := s.parser(data)
+	if err != nil {
+		return err
+	}
+
+	members := make(map[types.Address]struct{}, len(entries))
+	addresses := make([]types.Address, 0, len(entries))
+	for _, address := range entries {
+		if _, exists := members[address]; exists {
+			continue
+		}
+		members[address] = struct{}{}
+		addresses = append(addresses, address)
+	}
+
+	s.mu.Lock()
+	s.members = members
+	s.addresses = addresses
+	s.mu.Unlock()
+	s.log.Trace().Int("addresses", len(addresses)).Msg("Loaded address set")
+
+	return nil
+}
+
+// read reads the contents of the set.
+func (s *Service) read(ctx context.Context) ([]byte, error) {
+	if s.reader != nil {
+		return s.reader(ctx)
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to read address file"), err)
+	}
+
+	return data, nil
+}
+
+// parseJSON parses a JSON array of addresses.
+func parseJSON(data []byte) ([]types.Address, error) {
+	var entries []string
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, errors.Join(errors.New("failed to parse address file"), err)
+	}
+
+	addresses := make([]types.Address, 0, len(entries))
+	for _, entry := range entries {
+		address, err := util.StrToAddress("address", entry)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
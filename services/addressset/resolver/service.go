@@ -0,0 +1,126 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resolver provides an address set populated by a resolver, with optional periodic refresh.
+package resolver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// Service is an address set populated by a resolver.
+type Service struct {
+	log       zerolog.Logger
+	resolver  handlers.AddressSetResolver
+	mu        sync.RWMutex
+	members   map[types.Address]struct{}
+	addresses []types.Address
+}
+
+// New creates a new resolver-backed address set.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "addressset").Str("impl", "resolver").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		log:      log,
+		resolver: parameters.resolver,
+	}
+
+	// Initial resolution must succeed.
+	if err := s.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	if parameters.interval > 0 {
+		go s.refresher(ctx, parameters.interval)
+	}
+
+	return s, nil
+}
+
+// Contains returns true if the address is a member of the set.
+func (s *Service) Contains(address types.Address) bool {
+	s.mu.RLock()
+	_, exists := s.members[address]
+	s.mu.RUnlock()
+
+	return exists
+}
+
+// Addresses returns the addresses that are members of the set.
+func (s *Service) Addresses() []types.Address {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]types.Address, len(s.addresses))
+	copy(res, s.addresses)
+
+	return res
+}
+
+func (s *Service) refresher(ctx context.Context, interval time.Duration) {
+	for {
+		select {
+		case <-time.After(interval):
+			if err := s.refresh(ctx); err != nil && ctx.Err() == nil {
+				// Keep the existing set in place.
+				s.log.Warn().Err(err).Msg("Failed to refresh address set; retaining previous addresses")
+			}
+		case <-ctx.Done():
+			s.log.Debug().Msg("Context done")
+			return
+		}
+	}
+}
+
+func (s *Service) refresh(ctx context.Context) error {
+	resolved, err := s.resolver.ResolveAddresses(ctx)
+	if err != nil {
+		return errors.Join(errors.New("failed to resolve addresses"), err)
+	}
+
+	members := make(map[types.Address]struct{}, len(resolved))
+	addresses := make([]types.Address, 0, len(resolved))
+	for _, address := range resolved {
+		if _, exists := members[address]; exists {
+			continue
+		}
+		members[address] = struct{}{}
+		addresses = append(addresses, address)
+	}
+
+	s.mu.Lock()
+	s.members = members
+	s.addresses = addresses
+	s.mu.Unlock()
+	s.log.Trace().Int("addresses", len(addresses)).Msg("Resolved address set")
+
+	return nil
+}
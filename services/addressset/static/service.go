@@ -0,0 +1,77 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package static provides an address set with a fixed list of addresses.
+package static
+
+import (
+	"context"
+	"errors"
+
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service is an address set with a fixed list of addresses.
+type Service struct {
+	log       zerolog.Logger
+	members   map[types.Address]struct{}
+	addresses []types.Address
+}
+
+// New creates a new static address set.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "addressset").Str("impl", "static").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	members := make(map[types.Address]struct{}, len(parameters.addresses))
+	addresses := make([]types.Address, 0, len(parameters.addresses))
+	for _, address := range parameters.addresses {
+		if _, exists := members[address]; exists {
+			continue
+		}
+		members[address] = struct{}{}
+		addresses = append(addresses, address)
+	}
+	log.Trace().Int("addresses", len(addresses)).Msg("Created address set")
+
+	return &Service{
+		log:       log,
+		members:   members,
+		addresses: addresses,
+	}, nil
+}
+
+// Contains returns true if the address is a member of the set.
+func (s *Service) Contains(address types.Address) bool {
+	_, exists := s.members[address]
+
+	return exists
+}
+
+// Addresses returns the addresses that are members of the set.
+func (s *Service) Addresses() []types.Address {
+	res := make([]types.Address, len(s.addresses))
+	copy(res, s.addresses)
+
+	return res
+}
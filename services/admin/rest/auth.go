@@ -0,0 +1,112 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/wealdtech/go-eth-listener/services/listener/ethclient"
+)
+
+// Role is a role granted to a client of the API.
+type Role string
+
+const (
+	// RoleReadOnly allows a client to read the state of the listener.
+	RoleReadOnly Role = "read_only"
+	// RoleOperator allows a client to read the state of the listener and to carry out
+	// operational actions such as pausing and rewinding namespaces.
+	RoleOperator Role = "operator"
+)
+
+// valid returns true if the role is known.
+func (r Role) valid() bool {
+	return r == RoleReadOnly || r == RoleOperator
+}
+
+// allows returns true if the role allows actions that require the given role.
+func (r Role) allows(required Role) bool {
+	return r == RoleOperator || r == required
+}
+
+// principal is an authenticated client of the API.
+type principal struct {
+	actor string
+	role  Role
+}
+
+// authenticate returns the principal that made the request, or nil if the request is not
+// authenticated.  A bearer token takes precedence over a client certificate.
+func (s *Service) authenticate(r *http.Request) *principal {
+	if header := r.Header.Get("Authorization"); header != "" {
+		token, isBearer := strings.CutPrefix(header, "Bearer ")
+		if !isBearer {
+			return nil
+		}
+
+		return s.tokenPrincipal(token)
+	}
+
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+		commonName := r.TLS.VerifiedChains[0][0].Subject.CommonName
+		role, exists := s.clientRoles[commonName]
+		if !exists {
+			return nil
+		}
+
+		return &principal{
+			actor: commonName,
+			role:  role,
+		}
+	}
+
+	return nil
+}
+
+// tokenPrincipal returns the principal to which the token is granted, or nil if none.
+// All tokens are compared in constant time to avoid leaking information about them.
+func (s *Service) tokenPrincipal(token string) *principal {
+	var granted *principal
+	for candidate, principal := range s.tokens {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(token)) == 1 {
+			granted = principal
+		}
+	}
+
+	return granted
+}
+
+// authorize wraps a handler so that it is only called for requests from principals with the
+// given role.  The principal is recorded as the actor in the request context.
+func (s *Service) authorize(required Role, handler http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal := s.authenticate(r)
+		if principal == nil {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			s.writeError(w, http.StatusUnauthorized, "not authenticated")
+
+			return
+		}
+		if !principal.role.allows(required) {
+			s.log.Debug().Str("actor", principal.actor).Str("path", r.URL.Path).Msg("Rejected request from actor without required role")
+			s.writeError(w, http.StatusForbidden, "not authorized")
+
+			return
+		}
+
+		handler(w, r.WithContext(ethclient.ContextWithActor(r.Context(), principal.actor)))
+	})
+}
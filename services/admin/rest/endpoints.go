@@ -0,0 +1,139 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// namespaceResponse is the state of a namespace.
+type namespaceResponse struct {
+	Namespace string `json:"namespace"`
+	Paused    bool   `json:"paused"`
+}
+
+// rewindRequest is a request to rewind a namespace.
+type rewindRequest struct {
+	Block *uint64 `json:"block"`
+}
+
+// getProgress returns the progress of triggers.
+func (s *Service) getProgress(w http.ResponseWriter, r *http.Request) {
+	progress, err := s.listener.Progress(r.Context())
+	if err != nil {
+		s.log.Error().Err(err).Msg("Failed to obtain progress")
+		s.writeError(w, http.StatusInternalServerError, "failed to obtain progress")
+
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, progress)
+}
+
+// getAuditLog returns the audit log.
+func (s *Service) getAuditLog(w http.ResponseWriter, r *http.Request) {
+	entries, err := s.listener.AuditLog(r.Context())
+	if err != nil {
+		s.log.Error().Err(err).Msg("Failed to obtain audit log")
+		s.writeError(w, http.StatusInternalServerError, "failed to obtain audit log")
+
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, entries)
+}
+
+// getNamespace returns the state of a namespace.
+func (s *Service) getNamespace(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+
+	s.writeJSON(w, http.StatusOK, &namespaceResponse{
+		Namespace: namespace,
+		Paused:    s.listener.NamespacePaused(namespace),
+	})
+}
+
+// pauseNamespace pauses a namespace.
+func (s *Service) pauseNamespace(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	s.listener.PauseNamespace(r.Context(), namespace)
+
+	s.writeJSON(w, http.StatusOK, &namespaceResponse{
+		Namespace: namespace,
+		Paused:    true,
+	})
+}
+
+// resumeNamespace resumes a namespace.
+func (s *Service) resumeNamespace(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	s.listener.ResumeNamespace(r.Context(), namespace)
+
+	s.writeJSON(w, http.StatusOK, &namespaceResponse{
+		Namespace: namespace,
+		Paused:    false,
+	})
+}
+
+// rewindNamespace rewinds a namespace to the block in the request body.
+func (s *Service) rewindNamespace(w http.ResponseWriter, r *http.Request) {
+	request := &rewindRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil || request.Block == nil {
+		s.writeError(w, http.StatusBadRequest, "request must supply a block")
+
+		return
+	}
+
+	namespace := r.PathValue("namespace")
+	if err := s.listener.RewindNamespace(r.Context(), namespace, *request.Block); err != nil {
+		s.log.Error().Str("namespace", namespace).Err(err).Msg("Failed to rewind namespace")
+		s.writeError(w, http.StatusInternalServerError, "failed to rewind namespace")
+
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, &namespaceResponse{
+		Namespace: namespace,
+		Paused:    s.listener.NamespacePaused(namespace),
+	})
+}
+
+// reload reloads the configuration of the listener.
+func (s *Service) reload(w http.ResponseWriter, r *http.Request) {
+	if err := s.listener.Reload(r.Context()); err != nil {
+		s.log.Error().Err(err).Msg("Failed to reload configuration")
+		// The configuration is supplied by operators, so the reason for failure is returned to them.
+		s.writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to reload configuration: %v", err))
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeJSON writes a JSON response.
+func (s *Service) writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		s.log.Debug().Err(err).Msg("Failed to write response")
+	}
+}
+
+// writeError writes an error response.
+func (s *Service) writeError(w http.ResponseWriter, status int, msg string) {
+	s.writeJSON(w, status, map[string]string{"error": msg})
+}
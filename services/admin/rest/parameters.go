@@ -0,0 +1,210 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel    zerolog.Level
+	address     string
+	listener    Listener
+	tokens      []*tokenGrant
+	certificate []byte
+	key         []byte
+	clientCAs   []byte
+	clientRoles map[string]Role
+	tlsConfig   *tls.Config
+	tokenActors map[string]*principal
+}
+
+// tokenGrant is a static token granted to an actor.
+type tokenGrant struct {
+	actor string
+	token string
+	role  Role
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithAddress sets the address on which the API listens.
+func WithAddress(address string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.address = address
+	})
+}
+
+// WithListener sets the listener administered by the API.
+func WithListener(listener Listener) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.listener = listener
+	})
+}
+
+// WithToken grants the given role to requests that supply the token as a bearer token.
+// The actor is recorded in the listener's audit log for actions carried out with the token.
+// This can be supplied multiple times to grant multiple tokens.
+func WithToken(actor string, token string, role Role) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.tokens = append(p.tokens, &tokenGrant{
+			actor: actor,
+			token: token,
+			role:  role,
+		})
+	})
+}
+
+// WithServerCertificate sets the PEM-encoded certificate and key with which the API serves
+// TLS.  If not supplied the API is served without TLS.
+func WithServerCertificate(certificate []byte, key []byte) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.certificate = certificate
+		p.key = key
+	})
+}
+
+// WithClientCAs sets the PEM-encoded certificate authorities that sign client certificates.
+// Requests with a client certificate signed by one of these authorities are granted the role
+// given to the certificate's common name by WithClientRole().  This requires a server
+// certificate.
+func WithClientCAs(cas []byte) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.clientCAs = cas
+	})
+}
+
+// WithClientRole grants the given role to requests with a client certificate that has the
+// given common name.  The common name is recorded as the actor in the listener's audit log.
+// This can be supplied multiple times to grant roles to multiple clients.
+func WithClientRole(commonName string, role Role) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.clientRoles[commonName] = role
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:    zerolog.GlobalLevel(),
+		clientRoles: make(map[string]Role),
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.address == "" {
+		return nil, errors.New("no address specified")
+	}
+	if parameters.listener == nil {
+		return nil, errors.New("no listener specified")
+	}
+	if len(parameters.tokens) == 0 && parameters.clientCAs == nil {
+		return nil, errors.New("no tokens or client certificate authorities specified")
+	}
+
+	parameters.tokenActors = make(map[string]*principal, len(parameters.tokens))
+	for _, grant := range parameters.tokens {
+		if grant.actor == "" {
+			return nil, errors.New("token has no actor")
+		}
+		if grant.token == "" {
+			return nil, fmt.Errorf("token for %s is empty", grant.actor)
+		}
+		if !grant.role.valid() {
+			return nil, fmt.Errorf("token for %s has invalid role %q", grant.actor, grant.role)
+		}
+		if _, exists := parameters.tokenActors[grant.token]; exists {
+			return nil, fmt.Errorf("token for %s is already granted", grant.actor)
+		}
+		parameters.tokenActors[grant.token] = &principal{
+			actor: grant.actor,
+			role:  grant.role,
+		}
+	}
+
+	if err := parameters.buildTLSConfig(); err != nil {
+		return nil, err
+	}
+
+	return &parameters, nil
+}
+
+// buildTLSConfig builds the TLS configuration from the certificate parameters.
+func (p *parameters) buildTLSConfig() error {
+	if len(p.clientRoles) > 0 && p.clientCAs == nil {
+		return errors.New("client roles specified without client certificate authorities")
+	}
+	for commonName, role := range p.clientRoles {
+		if commonName == "" {
+			return errors.New("client role has no common name")
+		}
+		if !role.valid() {
+			return fmt.Errorf("client %s has invalid role %q", commonName, role)
+		}
+	}
+
+	if p.certificate == nil {
+		if p.clientCAs != nil {
+			return errors.New("client certificate authorities specified without server certificate")
+		}
+
+		return nil
+	}
+
+	certificate, err := tls.X509KeyPair(p.certificate, p.key)
+	if err != nil {
+		return errors.Join(errors.New("invalid server certificate"), err)
+	}
+	p.tlsConfig = &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if p.clientCAs != nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(p.clientCAs) {
+			return errors.New("invalid client certificate authorities")
+		}
+		p.tlsConfig.ClientCAs = pool
+		// Client certificates are optional at the TLS level so that tokens can be used
+		// alongside them; requests without either are rejected by the API.
+		p.tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rest provides an admin API that exposes the controls of a listener over HTTP, with
+// clients authenticated by static tokens or client certificates and granted read-only or
+// operator roles.
+package rest
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/services/listener/ethclient"
+)
+
+const (
+	readHeaderTimeout = 5 * time.Second
+	shutdownTimeout   = 5 * time.Second
+)
+
+// Listener is the interface to the listener administered by the API.
+type Listener interface {
+	// Progress returns the progress of triggers.
+	Progress(ctx context.Context) (*ethclient.Progress, error)
+	// AuditLog returns the entries in the audit log.
+	AuditLog(ctx context.Context) ([]*ethclient.AuditEntry, error)
+	// NamespacePaused returns true if the triggers in the given namespace are paused.
+	NamespacePaused(namespace string) bool
+	// PauseNamespace pauses the triggers in the given namespace.
+	PauseNamespace(ctx context.Context, namespace string)
+	// ResumeNamespace resumes the triggers in the given namespace.
+	ResumeNamespace(ctx context.Context, namespace string)
+	// RewindNamespace rewinds the triggers in the given namespace to the given block.
+	RewindNamespace(ctx context.Context, namespace string, block uint64) error
+	// Reload reloads the configuration of the listener.
+	Reload(ctx context.Context) error
+}
+
+// Service is an admin service exposing listener controls via a REST API.
+type Service struct {
+	log         zerolog.Logger
+	listener    Listener
+	tokens      map[string]*principal
+	clientRoles map[string]Role
+	server      *http.Server
+}
+
+// New creates a new REST admin service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "admin").Str("impl", "rest").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		log:         log,
+		listener:    parameters.listener,
+		tokens:      parameters.tokenActors,
+		clientRoles: parameters.clientRoles,
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /progress", s.authorize(RoleReadOnly, s.getProgress))
+	mux.Handle("GET /audit", s.authorize(RoleReadOnly, s.getAuditLog))
+	mux.Handle("GET /namespaces/{namespace}", s.authorize(RoleReadOnly, s.getNamespace))
+	mux.Handle("POST /namespaces/{namespace}/pause", s.authorize(RoleOperator, s.pauseNamespace))
+	mux.Handle("POST /namespaces/{namespace}/resume", s.authorize(RoleOperator, s.resumeNamespace))
+	mux.Handle("POST /namespaces/{namespace}/rewind", s.authorize(RoleOperator, s.rewindNamespace))
+	mux.Handle("POST /reload", s.authorize(RoleOperator, s.reload))
+
+	listener, err := net.Listen("tcp", parameters.address)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to listen for admin API"), err)
+	}
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+		TLSConfig:         parameters.tlsConfig,
+	}
+
+	go s.serve(listener)
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := s.server.Shutdown(shutdownCtx); err != nil {
+			s.log.Warn().Err(err).Msg("Failed to shut down admin API")
+		}
+	}()
+
+	return s, nil
+}
+
+// serve serves the API until the server is shut down.
+func (s *Service) serve(listener net.Listener) {
+	var err error
+	if s.server.TLSConfig != nil {
+		err = s.server.ServeTLS(listener, "", "")
+	} else {
+		err = s.server.Serve(listener)
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		s.log.Error().Str("admin_address", listener.Addr().String()).Err(err).Msg("Failed to run admin API")
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// JSONRPCCodeProvider provides contract code using the eth_getCode JSON-RPC method.
+type JSONRPCCodeProvider struct {
+	// URL is the URL of the Ethereum client.
+	URL string
+	// Client is the HTTP client; if nil then http.DefaultClient is used.
+	Client *http.Client
+}
+
+type jsonRPCRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+type jsonRPCResponse struct {
+	Result *string `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Code returns the code at the given address as of the given block.
+func (p *JSONRPCCodeProvider) Code(ctx context.Context, address types.Address, height uint64) ([]byte, error) {
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	body, err := json.Marshal(&jsonRPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "eth_getCode",
+		Params:  []any{fmt.Sprintf("%#x", address), fmt.Sprintf("0x%x", height)},
+	})
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to create request"), err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to create request"), err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Join(errors.New("request failed"), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request returned status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to read response"), err)
+	}
+	var res jsonRPCResponse
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, errors.Join(errors.New("failed to parse response"), err)
+	}
+	if res.Error != nil {
+		return nil, fmt.Errorf("eth_getCode failed: %s (%d)", res.Error.Message, res.Error.Code)
+	}
+	if res.Result == nil {
+		return nil, errors.New("no result returned")
+	}
+
+	code, err := hex.DecodeString(strings.TrimPrefix(*res.Result, "0x"))
+	if err != nil {
+		return nil, errors.Join(errors.New("invalid code returned"), err)
+	}
+
+	return code, nil
+}
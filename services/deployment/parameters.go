@@ -0,0 +1,80 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"errors"
+
+	execclient "github.com/attestantio/go-execution-client"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel            zerolog.Level
+	codeProvider        CodeProvider
+	chainHeightProvider execclient.ChainHeightProvider
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithCodeProvider sets the provider of contract code.
+func WithCodeProvider(provider CodeProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.codeProvider = provider
+	})
+}
+
+// WithChainHeightProvider sets the provider of the chain height.
+func WithChainHeightProvider(provider execclient.ChainHeightProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainHeightProvider = provider
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.codeProvider == nil {
+		return nil, errors.New("no code provider specified")
+	}
+	if parameters.chainHeightProvider == nil {
+		return nil, errors.New("no chain height provider specified")
+	}
+
+	return &parameters, nil
+}
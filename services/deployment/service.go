@@ -0,0 +1,140 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package deployment finds the blocks at which contracts were deployed.
+package deployment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	execclient "github.com/attestantio/go-execution-client"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// CodeProvider is the interface for providing contract code.
+type CodeProvider interface {
+	// Code returns the code at the given address as of the given block.
+	Code(ctx context.Context, address types.Address, height uint64) ([]byte, error)
+}
+
+// Service finds the blocks at which contracts were deployed.
+type Service struct {
+	log                 zerolog.Logger
+	codeProvider        CodeProvider
+	chainHeightProvider execclient.ChainHeightProvider
+	mu                  sync.Mutex
+	blocks              map[types.Address]uint64
+}
+
+// New creates a new deployment block finder.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "deployment").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	return &Service{
+		log:                 log,
+		codeProvider:        parameters.codeProvider,
+		chainHeightProvider: parameters.chainHeightProvider,
+		blocks:              make(map[types.Address]uint64),
+	}, nil
+}
+
+// DeploymentBlock returns the block at which the contract at the given address was deployed,
+// using a binary search for the first block at which the address has code.
+// This requires a client that can serve historical state, such as an archive node.
+// Contracts that have been self-destructed and redeployed may return a later block.
+func (s *Service) DeploymentBlock(ctx context.Context, address types.Address) (uint64, error) {
+	s.mu.Lock()
+	block, exists := s.blocks[address]
+	s.mu.Unlock()
+	if exists {
+		return block, nil
+	}
+
+	head, err := s.chainHeightProvider.ChainHeight(ctx)
+	if err != nil {
+		return 0, errors.Join(errors.New("failed to obtain chain height"), err)
+	}
+	deployed, err := s.hasCode(ctx, address, uint64(head))
+	if err != nil {
+		return 0, err
+	}
+	if !deployed {
+		return 0, fmt.Errorf("no contract at %s", address.String())
+	}
+
+	// Invariant: the address has code at high, and does not at any block below low.
+	low := uint64(0)
+	high := uint64(head)
+	for low < high {
+		mid := low + (high-low)/2
+		deployed, err := s.hasCode(ctx, address, mid)
+		if err != nil {
+			return 0, err
+		}
+		if deployed {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+	s.log.Debug().Stringer("address", address).Uint64("block", high).Msg("Found deployment block")
+
+	s.mu.Lock()
+	s.blocks[address] = high
+	s.mu.Unlock()
+
+	return high, nil
+}
+
+// SetEarliestBlock sets the earliest block of an event trigger with a static source to the
+// deployment block of the source, if it is earlier.
+func (s *Service) SetEarliestBlock(ctx context.Context, trigger *handlers.EventTrigger) error {
+	if trigger.Source == nil {
+		return errors.New("trigger does not have a static source")
+	}
+
+	block, err := s.DeploymentBlock(ctx, *trigger.Source)
+	if err != nil {
+		return err
+	}
+	if trigger.EarliestBlock < block {
+		s.log.Debug().Str("trigger", trigger.Name).Uint64("earliest_block", block).Msg("Setting earliest block to deployment block")
+		trigger.EarliestBlock = block
+	}
+
+	return nil
+}
+
+func (s *Service) hasCode(ctx context.Context, address types.Address, height uint64) (bool, error) {
+	code, err := s.codeProvider.Code(ctx, address, height)
+	if err != nil {
+		return false, errors.Join(fmt.Errorf("failed to obtain code at block %d", height), err)
+	}
+
+	return len(code) > 0, nil
+}
@@ -0,0 +1,182 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failover
+
+import (
+	"context"
+	"time"
+)
+
+// candidates returns the providers to try for a request: the active provider, followed by
+// healthy providers and then unhealthy providers, each in order of priority.
+func (s *Service) candidates() []*endpoint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]*endpoint, 0, len(s.endpoints))
+	res = append(res, s.endpoints[s.active])
+	for _, healthy := range []bool{true, false} {
+		for _, endpoint := range s.endpoints {
+			if endpoint.index != s.active && s.healthy(endpoint) == healthy {
+				res = append(res, endpoint)
+			}
+		}
+	}
+
+	return res
+}
+
+// healthy returns true if the provider is healthy.
+// This assumes that the lock is held.
+func (s *Service) healthy(endpoint *endpoint) bool {
+	return endpoint.score >= s.healthThreshold && !endpoint.stalled
+}
+
+// succeeded records a successful request to a provider.
+func (s *Service) succeeded(endpoint *endpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	endpoint.score += scoreWeight * (1 - endpoint.score)
+	monitorHealth(endpoint.name, endpoint.score)
+	if endpoint.index != s.active && !s.healthy(s.endpoints[s.active]) && s.healthy(endpoint) {
+		s.switchTo(endpoint, "failure")
+	}
+}
+
+// failed records a failed request to a provider, failing over if it is the active provider
+// and has become unhealthy.
+func (s *Service) failed(endpoint *endpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	endpoint.score -= scoreWeight * endpoint.score
+	monitorHealth(endpoint.name, endpoint.score)
+	if endpoint.index != s.active || s.healthy(endpoint) {
+		return
+	}
+	for _, candidate := range s.endpoints {
+		if candidate.index != endpoint.index && s.healthy(candidate) {
+			s.switchTo(candidate, "failure")
+
+			return
+		}
+	}
+}
+
+// recordHeight records the chain height reported by a provider.
+// This assumes that the lock is held.
+func (s *Service) recordHeight(endpoint *endpoint, height uint32) {
+	if height > endpoint.height {
+		endpoint.height = height
+		endpoint.advanced = time.Now()
+		endpoint.stalled = false
+	}
+}
+
+// switchTo makes the provider the active provider.
+// This assumes that the lock is held.
+func (s *Service) switchTo(endpoint *endpoint, reason string) {
+	s.log.Warn().
+		Str("from", s.endpoints[s.active].name).
+		Str("to", endpoint.name).
+		Str("reason", reason).
+		Msg("Switched provider")
+	s.active = endpoint.index
+	monitorActive(s.active)
+	monitorSwitch(reason)
+}
+
+// replaceStalled looks for a provider that has advanced beyond the given height of a stalled
+// provider.  If one is found it becomes the active provider, and its chain height is returned.
+func (s *Service) replaceStalled(ctx context.Context, stalled *endpoint, height uint32) (uint32, bool) {
+	s.mu.Lock()
+	s.lastStallCheck = time.Now()
+	s.mu.Unlock()
+
+	for _, endpoint := range s.endpoints {
+		if endpoint == stalled {
+			continue
+		}
+		alternative, err := endpoint.provider.ChainHeight(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0, false
+			}
+			s.failed(endpoint)
+
+			continue
+		}
+
+		s.mu.Lock()
+		s.recordHeight(endpoint, alternative)
+		if alternative <= height || !s.healthy(endpoint) {
+			s.mu.Unlock()
+
+			continue
+		}
+		s.log.Warn().Str("provider", stalled.name).Uint32("height", height).Uint32("alternative_height", alternative).Msg("Provider has stalled")
+		stalled.stalled = true
+		s.switchTo(endpoint, "stall")
+		s.mu.Unlock()
+
+		return alternative, true
+	}
+
+	return 0, false
+}
+
+// failBack checks higher-priority providers than the active provider once the failback interval
+// has passed, making the first that is reachable and has caught up the active provider.
+func (s *Service) failBack(ctx context.Context) {
+	s.mu.Lock()
+	if s.active == 0 || time.Since(s.lastFailback) < s.failbackInterval {
+		s.mu.Unlock()
+
+		return
+	}
+	s.lastFailback = time.Now()
+	activeHeight := s.endpoints[s.active].height
+	candidates := append([]*endpoint(nil), s.endpoints[:s.active]...)
+	s.mu.Unlock()
+
+	for _, endpoint := range candidates {
+		height, err := endpoint.provider.ChainHeight(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.log.Trace().Str("provider", endpoint.name).Err(err).Msg("Provider not yet recovered")
+
+			continue
+		}
+
+		s.mu.Lock()
+		s.recordHeight(endpoint, height)
+		if height < activeHeight {
+			// The provider has not caught up.
+			s.mu.Unlock()
+
+			continue
+		}
+		// The provider has recovered, so its history of failures is discarded.
+		endpoint.score = 1
+		endpoint.stalled = false
+		monitorHealth(endpoint.name, endpoint.score)
+		s.switchTo(endpoint, "failback")
+		s.mu.Unlock()
+
+		return
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failover
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/wealdtech/go-eth-listener/services/metrics"
+)
+
+var metricsNamespace = "eth_listener"
+
+var (
+	activeMetric   prometheus.Gauge
+	switchesMetric *prometheus.CounterVec
+	healthMetric   *prometheus.GaugeVec
+)
+
+func registerMetrics(_ context.Context, monitor metrics.Service) error {
+	if activeMetric != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics()
+	}
+
+	return nil
+}
+
+func registerPrometheusMetrics() error {
+	activeMetric = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "failover",
+		Name:      "active_provider",
+		Help:      "The index of the provider in use, where 0 is the primary.",
+	})
+	if err := prometheus.Register(activeMetric); err != nil {
+		return errors.Join(errors.New("failed to register active provider"), err)
+	}
+
+	switchesMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "failover",
+		Name:      "switches_total",
+		Help:      "The number of switches between providers, by reason.",
+	}, []string{"reason"})
+	if err := prometheus.Register(switchesMetric); err != nil {
+		return errors.Join(errors.New("failed to register provider switches"), err)
+	}
+
+	healthMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "failover",
+		Name:      "provider_health",
+		Help:      "The health score of each provider, between 0 and 1.",
+	}, []string{"provider"})
+	if err := prometheus.Register(healthMetric); err != nil {
+		return errors.Join(errors.New("failed to register provider health"), err)
+	}
+
+	return nil
+}
+
+func monitorActive(active int) {
+	if activeMetric != nil {
+		activeMetric.Set(float64(active))
+	}
+}
+
+func monitorSwitch(reason string) {
+	if switchesMetric != nil {
+		switchesMetric.WithLabelValues(reason).Inc()
+	}
+}
+
+func monitorHealth(provider string, score float64) {
+	if healthMetric != nil {
+		healthMetric.WithLabelValues(provider).Set(score)
+	}
+}
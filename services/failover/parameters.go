@@ -0,0 +1,129 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failover
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/wealdtech/go-eth-listener/services/metrics"
+	nullmetrics "github.com/wealdtech/go-eth-listener/services/metrics/null"
+)
+
+type parameters struct {
+	logLevel         zerolog.Level
+	monitor          metrics.Service
+	providers        []Provider
+	stallTimeout     time.Duration
+	failbackInterval time.Duration
+	healthThreshold  float64
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the metrics monitor.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithProviders sets the providers between which to fail over, in order of priority.
+// The first provider is the primary, to which the service fails back when it is healthy.
+func WithProviders(providers []Provider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.providers = providers
+	})
+}
+
+// WithStallTimeout sets the time after which a provider whose chain height has not advanced is
+// treated as stalled, if another provider has a higher chain height.
+func WithStallTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.stallTimeout = timeout
+	})
+}
+
+// WithFailbackInterval sets the interval at which higher-priority providers are checked, so that
+// the service can fail back to them once they are healthy.
+func WithFailbackInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.failbackInterval = interval
+	})
+}
+
+// WithHealthThreshold sets the health score, between 0 and 1, below which a provider is treated
+// as unhealthy.  The score of a provider moves towards 1 with each successful request and
+// towards 0 with each failed request.
+func WithHealthThreshold(threshold float64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.healthThreshold = threshold
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:         zerolog.GlobalLevel(),
+		monitor:          nullmetrics.New(),
+		stallTimeout:     time.Minute,
+		failbackInterval: 30 * time.Second,
+		healthThreshold:  0.5,
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if len(parameters.providers) < 2 {
+		return nil, errors.New("at least two providers must be specified")
+	}
+	for _, provider := range parameters.providers {
+		if provider == nil {
+			return nil, errors.New("nil provider specified")
+		}
+	}
+	if parameters.stallTimeout <= 0 {
+		return nil, errors.New("stall timeout must be positive")
+	}
+	if parameters.failbackInterval <= 0 {
+		return nil, errors.New("failback interval must be positive")
+	}
+	if parameters.healthThreshold <= 0 || parameters.healthThreshold >= 1 {
+		return nil, errors.New("health threshold must be between 0 and 1")
+	}
+
+	return &parameters, nil
+}
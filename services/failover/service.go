@@ -0,0 +1,231 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package failover provides chain data from the first healthy provider of a prioritised set,
+// failing over when a provider becomes unreachable or stops advancing, and failing back to the
+// primary once it has recovered.
+package failover
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	execclient "github.com/attestantio/go-execution-client"
+	"github.com/attestantio/go-execution-client/api"
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Provider is the interface for the providers between which the service fails over.
+type Provider interface {
+	execclient.ChainHeightProvider
+	execclient.BlocksProvider
+	execclient.EventsProvider
+}
+
+// rawBlocksProvider is the interface for providers that supply blocks as returned by the client,
+// for blocks that the client library cannot decode.
+type rawBlocksProvider interface {
+	RawBlock(ctx context.Context, blockID string) (json.RawMessage, error)
+}
+
+// scoreWeight is the weight given to the outcome of the latest request in a provider's health score.
+const scoreWeight = 0.2
+
+// endpoint is a provider along with its health.
+type endpoint struct {
+	index    int
+	name     string
+	provider Provider
+	score    float64
+	height   uint32
+	advanced time.Time
+	stalled  bool
+}
+
+// Service provides chain data from the first healthy of a prioritised set of providers.
+// It implements the chain height, chain ID, blocks, events and transaction receipts provider interfaces.
+type Service struct {
+	log              zerolog.Logger
+	stallTimeout     time.Duration
+	failbackInterval time.Duration
+	healthThreshold  float64
+	mu               sync.Mutex
+	endpoints        []*endpoint
+	active           int
+	lastFailback     time.Time
+	lastStallCheck   time.Time
+}
+
+// New creates a new failover service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "failover").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.Join(errors.New("failed to register metrics"), err)
+	}
+
+	endpoints := make([]*endpoint, len(parameters.providers))
+	for i, provider := range parameters.providers {
+		endpoints[i] = &endpoint{
+			index:    i,
+			name:     strconv.Itoa(i),
+			provider: provider,
+			score:    1,
+			advanced: time.Now(),
+		}
+		monitorHealth(endpoints[i].name, 1)
+	}
+	monitorActive(0)
+
+	return &Service{
+		log:              log,
+		stallTimeout:     parameters.stallTimeout,
+		failbackInterval: parameters.failbackInterval,
+		healthThreshold:  parameters.healthThreshold,
+		endpoints:        endpoints,
+	}, nil
+}
+
+// do carries out a request against the active provider, falling back to other providers in
+// order of health and priority if it fails.
+func do[T any](ctx context.Context,
+	s *Service,
+	request func(ctx context.Context, provider Provider) (T, error),
+) (
+	T,
+	*endpoint,
+	error,
+) {
+	s.failBack(ctx)
+
+	var empty T
+	var errs error
+	for _, endpoint := range s.candidates() {
+		value, err := request(ctx, endpoint.provider)
+		if err == nil {
+			s.succeeded(endpoint)
+
+			return value, endpoint, nil
+		}
+		if ctx.Err() != nil {
+			// The request was cancelled, which says nothing about the provider.
+			return empty, nil, err
+		}
+		s.log.Debug().Str("provider", endpoint.name).Err(err).Msg("Request failed")
+		s.failed(endpoint)
+		errs = errors.Join(errs, err)
+	}
+
+	return empty, nil, errors.Join(errors.New("all providers failed"), errs)
+}
+
+// ChainHeight returns the height of the chain.
+func (s *Service) ChainHeight(ctx context.Context) (uint32, error) {
+	height, endpoint, err := do(ctx, s, func(ctx context.Context, provider Provider) (uint32, error) {
+		return provider.ChainHeight(ctx)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	s.recordHeight(endpoint, height)
+	checkStall := endpoint.index == s.active &&
+		time.Since(endpoint.advanced) > s.stallTimeout &&
+		time.Since(s.lastStallCheck) > s.stallTimeout
+	s.mu.Unlock()
+
+	if checkStall {
+		if alternative, exists := s.replaceStalled(ctx, endpoint, height); exists {
+			return alternative, nil
+		}
+	}
+
+	return height, nil
+}
+
+// ChainID returns the chain ID.
+func (s *Service) ChainID(ctx context.Context) (uint64, error) {
+	chainID, _, err := do(ctx, s, func(ctx context.Context, provider Provider) (uint64, error) {
+		chainIDProvider, isProvider := provider.(execclient.ChainIDProvider)
+		if !isProvider {
+			return 0, errors.New("provider does not provide chain ID")
+		}
+
+		return chainIDProvider.ChainID(ctx)
+	})
+
+	return chainID, err
+}
+
+// Block returns the block with the given ID.
+func (s *Service) Block(ctx context.Context, blockID string) (*spec.Block, error) {
+	block, _, err := do(ctx, s, func(ctx context.Context, provider Provider) (*spec.Block, error) {
+		return provider.Block(ctx, blockID)
+	})
+
+	return block, err
+}
+
+// RawBlock returns the JSON of the block with the given ID.
+func (s *Service) RawBlock(ctx context.Context, blockID string) (json.RawMessage, error) {
+	data, _, err := do(ctx, s, func(ctx context.Context, provider Provider) (json.RawMessage, error) {
+		rawBlocksProvider, isProvider := provider.(rawBlocksProvider)
+		if !isProvider {
+			return nil, errors.New("provider does not provide raw blocks")
+		}
+
+		return rawBlocksProvider.RawBlock(ctx, blockID)
+	})
+
+	return data, err
+}
+
+// Events returns the events matching the filter.
+func (s *Service) Events(ctx context.Context, filter *api.EventsFilter) ([]*spec.BerlinTransactionEvent, error) {
+	events, _, err := do(ctx, s, func(ctx context.Context, provider Provider) ([]*spec.BerlinTransactionEvent, error) {
+		return provider.Events(ctx, filter)
+	})
+
+	return events, err
+}
+
+// TransactionReceipt returns the transaction receipt for the given transaction hash.
+func (s *Service) TransactionReceipt(ctx context.Context, hash types.Hash) (*spec.TransactionReceipt, error) {
+	receipt, _, err := do(ctx, s, func(ctx context.Context, provider Provider) (*spec.TransactionReceipt, error) {
+		receiptsProvider, isProvider := provider.(execclient.TransactionReceiptsProvider)
+		if !isProvider {
+			return nil, errors.New("provider does not provide transaction receipts")
+		}
+
+		return receiptsProvider.TransactionReceipt(ctx, hash)
+	})
+
+	return receipt, err
+}
@@ -0,0 +1,87 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package labeler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// HTTPSource is a source of labels from an HTTP API.
+// The API is expected to return a JSON object containing the label.
+type HTTPSource struct {
+	// URL is the URL of the API, in which "{address}" is replaced with the address.
+	URL string
+	// Field is the field of the JSON response that contains the label; if empty then "label" is used.
+	Field string
+	// Headers are additional headers sent with each request, for example for authentication.
+	Headers map[string]string
+	// Client is the HTTP client; if nil then http.DefaultClient is used.
+	Client *http.Client
+}
+
+// LookupLabel looks up the label for an address.
+func (h *HTTPSource) LookupLabel(ctx context.Context, address types.Address) (string, error) {
+	client := h.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	field := h.Field
+	if field == "" {
+		field = "label"
+	}
+
+	url := strings.ReplaceAll(h.URL, "{address}", address.String())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", errors.Join(errors.New("failed to create label request"), err)
+	}
+	for k, v := range h.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", errors.Join(errors.New("failed to request label"), err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		// No label for this address.
+		return "", nil
+	default:
+		return "", fmt.Errorf("label request returned status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1024*1024))
+	if err != nil {
+		return "", errors.Join(errors.New("failed to read label response"), err)
+	}
+	res := make(map[string]any)
+	if err := json.Unmarshal(data, &res); err != nil {
+		return "", errors.Join(errors.New("failed to parse label response"), err)
+	}
+	label, _ := res[field].(string)
+
+	return label, nil
+}
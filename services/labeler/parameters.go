@@ -0,0 +1,103 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package labeler
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel    zerolog.Level
+	labels      map[types.Address]string
+	source      Source
+	explorerURL string
+	cacheTTL    time.Duration
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithLabels sets user-supplied labels for addresses.
+// These take precedence over labels from the source.
+func WithLabels(labels map[types.Address]string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.labels = labels
+	})
+}
+
+// WithSource sets an external source of labels, for addresses without user-supplied labels.
+func WithSource(source Source) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.source = source
+	})
+}
+
+// WithExplorerURL sets the base URL of the block explorer used for address URLs.
+func WithExplorerURL(url string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.explorerURL = url
+	})
+}
+
+// WithCacheTTL sets the time for which labels obtained from the source are cached.
+func WithCacheTTL(ttl time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.cacheTTL = ttl
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:    zerolog.GlobalLevel(),
+		labels:      make(map[types.Address]string),
+		explorerURL: "https://etherscan.io",
+		cacheTTL:    time.Hour,
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.labels == nil {
+		parameters.labels = make(map[types.Address]string)
+	}
+	if parameters.explorerURL == "" {
+		return nil, errors.New("no explorer URL specified")
+	}
+	parameters.explorerURL = strings.TrimSuffix(parameters.explorerURL, "/")
+
+	return &parameters, nil
+}
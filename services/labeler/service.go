@@ -0,0 +1,114 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package labeler provides labels and block explorer URLs for addresses.
+package labeler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// Source defines the methods that need to be implemented to provide labels from an external source.
+type Source interface {
+	// LookupLabel looks up the label for an address, returning an empty string if there is no label.
+	LookupLabel(ctx context.Context, address types.Address) (string, error)
+}
+
+type cacheEntry struct {
+	label   string
+	expires time.Time
+}
+
+// Service labels addresses.
+// It implements the handlers.AddressLabeler interface.
+type Service struct {
+	log         zerolog.Logger
+	labels      map[types.Address]string
+	source      Source
+	explorerURL string
+	cacheTTL    time.Duration
+	cacheMu     sync.Mutex
+	cache       map[types.Address]*cacheEntry
+}
+
+// New creates a new labeler.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "labeler").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	return &Service{
+		log:         log,
+		labels:      parameters.labels,
+		source:      parameters.source,
+		explorerURL: parameters.explorerURL,
+		cacheTTL:    parameters.cacheTTL,
+		cache:       make(map[types.Address]*cacheEntry),
+	}, nil
+}
+
+// LabelAddress returns the label for an address.
+func (s *Service) LabelAddress(ctx context.Context, address types.Address) (*handlers.AddressLabel, error) {
+	label := &handlers.AddressLabel{
+		URL: fmt.Sprintf("%s/address/%s", s.explorerURL, address.String()),
+	}
+
+	if userLabel, exists := s.labels[address]; exists {
+		label.Label = userLabel
+
+		return label, nil
+	}
+
+	if s.source == nil {
+		return label, nil
+	}
+
+	s.cacheMu.Lock()
+	entry, exists := s.cache[address]
+	s.cacheMu.Unlock()
+	if exists && time.Now().Before(entry.expires) {
+		label.Label = entry.label
+
+		return label, nil
+	}
+
+	sourceLabel, err := s.source.LookupLabel(ctx, address)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to look up label"), err)
+	}
+	s.cacheMu.Lock()
+	s.cache[address] = &cacheEntry{
+		label:   sourceLabel,
+		expires: time.Now().Add(s.cacheTTL),
+	}
+	s.cacheMu.Unlock()
+	label.Label = sourceLabel
+
+	return label, nil
+}
@@ -0,0 +1,250 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconclient
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// maxResponseSize is the maximum size of a response from the beacon node.
+const maxResponseSize = 64 * 1024 * 1024
+
+type headerResponseJSON struct {
+	Data struct {
+		Root   string `json:"root"`
+		Header struct {
+			Message struct {
+				Slot          string `json:"slot"`
+				ProposerIndex string `json:"proposer_index"`
+				ParentRoot    string `json:"parent_root"`
+				StateRoot     string `json:"state_root"`
+			} `json:"message"`
+		} `json:"header"`
+	} `json:"data"`
+}
+
+type blockResponseJSON struct {
+	Data struct {
+		Message struct {
+			Body struct {
+				ExecutionPayload *struct {
+					BlockNumber string `json:"block_number"`
+					BlockHash   string `json:"block_hash"`
+				} `json:"execution_payload"`
+			} `json:"body"`
+		} `json:"message"`
+	} `json:"data"`
+}
+
+type finalityResponseJSON struct {
+	Data struct {
+		Finalized struct {
+			Epoch string `json:"epoch"`
+			Root  string `json:"root"`
+		} `json:"finalized"`
+	} `json:"data"`
+}
+
+type proposerDutiesResponseJSON struct {
+	Data []struct {
+		ValidatorIndex string `json:"validator_index"`
+		Slot           string `json:"slot"`
+	} `json:"data"`
+}
+
+// get fetches the given path from the beacon node, returning false if it is not found.
+func (s *Service) get(ctx context.Context, path string, res any) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(s.address, "/")+path, nil)
+	if err != nil {
+		return false, errors.Join(errors.New("failed to create request"), err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return false, errors.Join(errors.New("failed to call beacon node"), err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("request to %s returned status %d", path, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return false, errors.Join(errors.New("failed to read response"), err)
+	}
+	if err := json.Unmarshal(data, res); err != nil {
+		return false, errors.Join(fmt.Errorf("failed to parse response from %s", path), err)
+	}
+
+	return true, nil
+}
+
+// headSlot returns the slot of the head of the chain.
+func (s *Service) headSlot(ctx context.Context) (uint64, error) {
+	resp := &headerResponseJSON{}
+	found, err := s.get(ctx, "/eth/v1/beacon/headers/head", resp)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, errors.New("head not found")
+	}
+
+	return strconv.ParseUint(resp.Data.Header.Message.Slot, 10, 64)
+}
+
+// beaconBlock returns the block at the given slot, or nil if the slot was missed.
+func (s *Service) beaconBlock(ctx context.Context, slot uint64) (*handlers.BeaconBlock, error) {
+	resp := &headerResponseJSON{}
+	found, err := s.get(ctx, fmt.Sprintf("/eth/v1/beacon/headers/%d", slot), resp)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+
+	block := &handlers.BeaconBlock{
+		Slot: slot,
+	}
+	message := resp.Data.Header.Message
+	block.ProposerIndex, err = strconv.ParseUint(message.ProposerIndex, 10, 64)
+	if err != nil {
+		return nil, errors.Join(errors.New("invalid proposer index"), err)
+	}
+	if block.Root, err = parseRoot(resp.Data.Root); err != nil {
+		return nil, errors.Join(errors.New("invalid root"), err)
+	}
+	if block.ParentRoot, err = parseRoot(message.ParentRoot); err != nil {
+		return nil, errors.Join(errors.New("invalid parent root"), err)
+	}
+	if block.StateRoot, err = parseRoot(message.StateRoot); err != nil {
+		return nil, errors.Join(errors.New("invalid state root"), err)
+	}
+
+	return block, nil
+}
+
+// addExecutionPayload adds the details of the execution payload to a block, if it has one.
+func (s *Service) addExecutionPayload(ctx context.Context, block *handlers.BeaconBlock) error {
+	resp := &blockResponseJSON{}
+	found, err := s.get(ctx, fmt.Sprintf("/eth/v2/beacon/blocks/%#x", block.Root[:]), resp)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("block %#x not found", block.Root[:])
+	}
+
+	payload := resp.Data.Message.Body.ExecutionPayload
+	if payload == nil {
+		// Pre-merge block.
+		return nil
+	}
+	number, err := strconv.ParseUint(payload.BlockNumber, 10, 64)
+	if err != nil {
+		return errors.Join(errors.New("invalid execution block number"), err)
+	}
+	hash, err := parseRoot(payload.BlockHash)
+	if err != nil {
+		return errors.Join(errors.New("invalid execution block hash"), err)
+	}
+	executionHash := types.Hash(hash)
+	block.ExecutionBlockNumber = &number
+	block.ExecutionBlockHash = &executionHash
+
+	return nil
+}
+
+// finalizedCheckpoint returns the latest finalized checkpoint.
+func (s *Service) finalizedCheckpoint(ctx context.Context) (*handlers.Checkpoint, error) {
+	resp := &finalityResponseJSON{}
+	found, err := s.get(ctx, "/eth/v1/beacon/states/head/finality_checkpoints", resp)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errors.New("finality checkpoints not found")
+	}
+
+	epoch, err := strconv.ParseUint(resp.Data.Finalized.Epoch, 10, 64)
+	if err != nil {
+		return nil, errors.Join(errors.New("invalid finalized epoch"), err)
+	}
+	root, err := parseRoot(resp.Data.Finalized.Root)
+	if err != nil {
+		return nil, errors.Join(errors.New("invalid finalized root"), err)
+	}
+
+	return &handlers.Checkpoint{
+		Epoch: epoch,
+		Root:  root,
+	}, nil
+}
+
+// proposerDuties returns the proposers for the slots of the given epoch.
+func (s *Service) proposerDuties(ctx context.Context, epoch uint64) (map[uint64]uint64, error) {
+	resp := &proposerDutiesResponseJSON{}
+	found, err := s.get(ctx, fmt.Sprintf("/eth/v1/validator/duties/proposer/%d", epoch), resp)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("proposer duties for epoch %d not found", epoch)
+	}
+
+	duties := make(map[uint64]uint64, len(resp.Data))
+	for _, duty := range resp.Data {
+		slot, err := strconv.ParseUint(duty.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Join(errors.New("invalid duty slot"), err)
+		}
+		index, err := strconv.ParseUint(duty.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Join(errors.New("invalid duty validator index"), err)
+		}
+		duties[slot] = index
+	}
+
+	return duties, nil
+}
+
+// parseRoot parses a hex string in to a root.
+func parseRoot(input string) (types.Root, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return types.Root{}, err
+	}
+	if len(data) != len(types.Root{}) {
+		return types.Root{}, fmt.Errorf("incorrect length %d", len(data))
+	}
+
+	return types.Root(data), nil
+}
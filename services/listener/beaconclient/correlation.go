@@ -0,0 +1,100 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	execclient "github.com/attestantio/go-execution-client"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// correlationHandler handles beacon blocks for a correlation trigger, adding their execution blocks.
+type correlationHandler struct {
+	blocksProvider execclient.BlocksProvider
+	trigger        *handlers.CorrelationTrigger
+}
+
+// HandleBeaconBlock obtains the execution block for the beacon block and passes both to the handler.
+func (h *correlationHandler) HandleBeaconBlock(ctx context.Context, block *handlers.BeaconBlock, _ *handlers.BeaconBlockTrigger) error {
+	correlated := &handlers.CorrelatedBlock{
+		Beacon: block,
+	}
+	if block.ExecutionBlockNumber != nil {
+		execution, err := h.blocksProvider.Block(ctx, fmt.Sprintf("%d", *block.ExecutionBlockNumber))
+		if err != nil {
+			return errors.Join(errors.New("failed to obtain execution block"), err)
+		}
+		if execution == nil {
+			return fmt.Errorf("execution block %d not found", *block.ExecutionBlockNumber)
+		}
+		if execution.Hash() != *block.ExecutionBlockHash {
+			return fmt.Errorf("execution block %d does not match beacon block", *block.ExecutionBlockNumber)
+		}
+		correlated.Execution = execution
+	}
+
+	return h.trigger.Handler.HandleCorrelatedBlock(ctx, correlated, h.trigger)
+}
+
+// correlationBlockTriggers returns beacon block triggers that carry out the correlation triggers.
+func correlationBlockTriggers(blocksProvider execclient.BlocksProvider,
+	triggers []*handlers.CorrelationTrigger,
+) []*handlers.BeaconBlockTrigger {
+	blockTriggers := make([]*handlers.BeaconBlockTrigger, 0, len(triggers))
+	for _, trigger := range triggers {
+		blockTriggers = append(blockTriggers, &handlers.BeaconBlockTrigger{
+			Name:          trigger.Name,
+			Namespace:     trigger.Namespace,
+			EarliestSlot:  trigger.EarliestSlot,
+			Proposers:     trigger.Proposers,
+			IncludeMissed: trigger.IncludeMissed,
+			Handler: &correlationHandler{
+				blocksProvider: blocksProvider,
+				trigger:        trigger,
+			},
+			Labels: trigger.Labels,
+		})
+	}
+
+	return blockTriggers
+}
+
+// checkCorrelationTriggerParameters checks the parameters of correlation triggers.
+func checkCorrelationTriggerParameters(parameters *parameters) error {
+	if len(parameters.correlationTriggers) > 0 && parameters.executionBlocks == nil {
+		return errors.New("no execution blocks provider specified for correlation triggers")
+	}
+	for _, trigger := range parameters.correlationTriggers {
+		if trigger.Name == "" {
+			return errors.New("no correlation trigger name specified")
+		}
+		if strings.Contains(trigger.Namespace, handlers.NamespaceSeparator) {
+			return fmt.Errorf("correlation trigger namespace cannot contain %q", handlers.NamespaceSeparator)
+		}
+		if trigger.Handler == nil {
+			return errors.New("no correlation trigger handler specified")
+		}
+		for _, blockTrigger := range parameters.blockTriggers {
+			if blockTrigger.QualifiedName() == trigger.QualifiedName() {
+				return fmt.Errorf("correlation trigger %s has the same name as a beacon block trigger", trigger.QualifiedName())
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconclient
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// maxSlotsPerPoll is the maximum number of slots processed in a single poll.
+const maxSlotsPerPoll = uint64(256)
+
+// maxCachedDuties is the maximum number of epochs for which proposer duties are cached.
+const maxCachedDuties = 4
+
+func (s *Service) listener(ctx context.Context) {
+	for {
+		// Errors are logged by the poll itself.
+		_ = s.poll(ctx)
+
+		select {
+		case <-time.After(s.interval):
+		case <-ctx.Done():
+			s.log.Debug().Msg("Context done")
+			return
+		}
+	}
+}
+
+// PollOnce carries out a single poll, for use when polling is driven externally.
+func (s *Service) PollOnce(ctx context.Context) error {
+	return s.poll(ctx)
+}
+
+func (s *Service) poll(ctx context.Context) error {
+	if !s.pollMu.TryLock() {
+		s.log.Debug().Msg("Poll already in progress; skipping")
+
+		return ErrPollInProgress
+	}
+	defer s.pollMu.Unlock()
+
+	var blocksErr error
+	if len(s.blockTriggers) > 0 {
+		blocksErr = s.pollBlocks(ctx)
+		if blocksErr != nil && ctx.Err() == nil {
+			s.log.Error().Err(blocksErr).Msg("Beacon block poll failed")
+			monitorFailure()
+		}
+	}
+
+	var finalityErr error
+	if len(s.finalityTriggers) > 0 {
+		finalityErr = s.pollFinality(ctx)
+		if finalityErr != nil && ctx.Err() == nil {
+			s.log.Error().Err(finalityErr).Msg("Finality poll failed")
+			monitorFailure()
+		}
+	}
+
+	return errors.Join(blocksErr, finalityErr)
+}
+
+func (s *Service) pollBlocks(ctx context.Context) error {
+	md, err := s.getBlocksMetadata(ctx)
+	if err != nil {
+		return err
+	}
+
+	head, err := s.headSlot(ctx)
+	if err != nil {
+		return errors.Join(errors.New("failed to obtain head slot"), err)
+	}
+
+	from := head + 1
+	for _, trigger := range s.blockTriggers {
+		if next := s.nextSlot(md, trigger); next < from {
+			from = next
+		}
+	}
+	if from > head {
+		s.log.Trace().Uint64("head", head).Msg("No new slots")
+
+		return nil
+	}
+	to := head
+	if to-from >= maxSlotsPerPoll {
+		to = from + maxSlotsPerPoll - 1
+	}
+
+	failed := make(map[string]bool)
+	for slot := from; slot <= to; slot++ {
+		block, err := s.slotBlock(ctx, slot)
+		if err != nil {
+			return err
+		}
+
+		for _, trigger := range s.blockTriggers {
+			if failed[trigger.QualifiedName()] || s.nextSlot(md, trigger) > slot {
+				continue
+			}
+			if s.blockMatchesTrigger(block, trigger) {
+				if err := s.addExecutionPayloadOnce(ctx, block); err != nil {
+					return err
+				}
+				if err := trigger.Handler.HandleBeaconBlock(ctx, block, trigger); err != nil {
+					s.log.Debug().Str("trigger", trigger.QualifiedName()).Uint64("slot", slot).Err(err).Msg("Handler errored")
+					failed[trigger.QualifiedName()] = true
+
+					continue
+				}
+			}
+			md.LatestSlots[trigger.QualifiedName()] = int64(slot)
+		}
+
+		if err := s.setBlocksMetadata(ctx, md); err != nil {
+			return err
+		}
+		monitorLatestSlot(slot)
+	}
+
+	if len(failed) > 0 {
+		return errors.New("beacon block handler errored")
+	}
+
+	return nil
+}
+
+// nextSlot returns the next slot to process for the trigger.
+func (*Service) nextSlot(md *blocksMetadata, trigger *handlers.BeaconBlockTrigger) uint64 {
+	next := trigger.EarliestSlot
+	if latest, exists := md.LatestSlots[trigger.QualifiedName()]; exists && uint64(latest+1) > next {
+		next = uint64(latest + 1)
+	}
+
+	return next
+}
+
+// slotBlock returns the block at the slot, or a missed block if there is no block.
+func (s *Service) slotBlock(ctx context.Context, slot uint64) (*handlers.BeaconBlock, error) {
+	block, err := s.beaconBlock(ctx, slot)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to obtain beacon block"), err)
+	}
+	if block != nil {
+		return block, nil
+	}
+
+	block = &handlers.BeaconBlock{
+		Slot:   slot,
+		Missed: true,
+	}
+	if !s.missedSlotsRequired() {
+		return block, nil
+	}
+	duties, err := s.epochDuties(ctx, slot/s.slotsPerEpoch)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to obtain proposer duties"), err)
+	}
+	block.ProposerIndex = duties[slot]
+
+	return block, nil
+}
+
+// missedSlotsRequired returns true if any trigger requires missed slots.
+func (s *Service) missedSlotsRequired() bool {
+	for _, trigger := range s.blockTriggers {
+		if trigger.IncludeMissed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// epochDuties returns the proposer duties for the epoch, using a small cache.
+func (s *Service) epochDuties(ctx context.Context, epoch uint64) (map[uint64]uint64, error) {
+	if duties, exists := s.duties[epoch]; exists {
+		return duties, nil
+	}
+
+	duties, err := s.proposerDuties(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.duties) >= maxCachedDuties {
+		for cached := range s.duties {
+			if cached < epoch {
+				delete(s.duties, cached)
+			}
+		}
+	}
+	s.duties[epoch] = duties
+
+	return duties, nil
+}
+
+// addExecutionPayloadOnce adds the execution payload to a block if it has not already been added.
+func (s *Service) addExecutionPayloadOnce(ctx context.Context, block *handlers.BeaconBlock) error {
+	if block.Missed || block.ExecutionBlockHash != nil {
+		return nil
+	}
+	if err := s.addExecutionPayload(ctx, block); err != nil {
+		return errors.Join(errors.New("failed to obtain execution payload"), err)
+	}
+
+	return nil
+}
+
+// blockMatchesTrigger returns true if the block should be passed to the trigger's handler.
+func (*Service) blockMatchesTrigger(block *handlers.BeaconBlock, trigger *handlers.BeaconBlockTrigger) bool {
+	if block.Missed && !trigger.IncludeMissed {
+		return false
+	}
+	if trigger.Proposers != nil && !trigger.Proposers.ContainsValidator(block.ProposerIndex) {
+		return false
+	}
+
+	return true
+}
+
+func (s *Service) pollFinality(ctx context.Context) error {
+	md, err := s.getFinalityMetadata(ctx)
+	if err != nil {
+		return err
+	}
+
+	checkpoint, err := s.finalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Join(errors.New("failed to obtain finalized checkpoint"), err)
+	}
+
+	var handlerErr error
+	for _, trigger := range s.finalityTriggers {
+		if latest, exists := md.LatestEpochs[trigger.QualifiedName()]; exists && latest >= int64(checkpoint.Epoch) {
+			continue
+		}
+		if err := trigger.Handler.HandleFinality(ctx, checkpoint, trigger); err != nil {
+			s.log.Debug().Str("trigger", trigger.QualifiedName()).Uint64("epoch", checkpoint.Epoch).Err(err).Msg("Handler errored")
+			handlerErr = errors.Join(handlerErr, err)
+
+			continue
+		}
+		md.LatestEpochs[trigger.QualifiedName()] = int64(checkpoint.Epoch)
+	}
+
+	if err := s.setFinalityMetadata(ctx, md); err != nil {
+		return err
+	}
+
+	return handlerErr
+}
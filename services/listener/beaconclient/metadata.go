@@ -0,0 +1,167 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+)
+
+var (
+	blocksMetadataKey   = []byte("listener.beaconclient.blocks")
+	finalityMetadataKey = []byte("listener.beaconclient.finality")
+)
+
+// MetadataStore defines the methods that need to be implemented to store the listener's metadata.
+// The execution listener implements this interface, allowing the listeners to share a store.
+type MetadataStore interface {
+	// Metadata returns the value stored under the given key, or nil if there is no value.
+	Metadata(ctx context.Context, key []byte) ([]byte, error)
+	// SetMetadata stores a value under the given key.
+	SetMetadata(ctx context.Context, key []byte, value []byte) error
+}
+
+// pebbleStore is a metadata store backed by a pebble database owned by the listener.
+type pebbleStore struct {
+	mu     sync.Mutex
+	db     *pebble.DB
+	closed bool
+}
+
+func newPebbleStore(path string) (*pebbleStore, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pebbleStore{
+		db: db,
+	}, nil
+}
+
+// Metadata returns the value stored under the given key, or nil if there is no value.
+func (s *pebbleStore) Metadata(_ context.Context, key []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, errors.New("database closed")
+	}
+
+	data, closer, err := s.db.Get(key)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+	defer closer.Close()
+
+	return append([]byte{}, data...), nil
+}
+
+// SetMetadata stores a value under the given key.
+func (s *pebbleStore) SetMetadata(_ context.Context, key []byte, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errors.New("database closed")
+	}
+
+	return s.db.Set(key, value, pebble.Sync)
+}
+
+func (s *pebbleStore) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	return s.db.Close()
+}
+
+type blocksMetadata struct {
+	LatestSlots map[string]int64 `json:"latest_slots"`
+}
+
+type finalityMetadata struct {
+	LatestEpochs map[string]int64 `json:"latest_epochs"`
+}
+
+func (s *Service) getBlocksMetadata(ctx context.Context) (*blocksMetadata, error) {
+	md := &blocksMetadata{}
+	if err := s.getMetadata(ctx, blocksMetadataKey, md); err != nil {
+		return nil, errors.Join(errors.New("failed to get blocks metadata"), err)
+	}
+	if md.LatestSlots == nil {
+		md.LatestSlots = make(map[string]int64)
+	}
+
+	return md, nil
+}
+
+func (s *Service) setBlocksMetadata(ctx context.Context, md *blocksMetadata) error {
+	if err := s.setMetadata(ctx, blocksMetadataKey, md); err != nil {
+		return errors.Join(errors.New("failed to set blocks metadata"), err)
+	}
+
+	return nil
+}
+
+func (s *Service) getFinalityMetadata(ctx context.Context) (*finalityMetadata, error) {
+	md := &finalityMetadata{}
+	if err := s.getMetadata(ctx, finalityMetadataKey, md); err != nil {
+		return nil, errors.Join(errors.New("failed to get finality metadata"), err)
+	}
+	if md.LatestEpochs == nil {
+		md.LatestEpochs = make(map[string]int64)
+	}
+
+	return md, nil
+}
+
+func (s *Service) setFinalityMetadata(ctx context.Context, md *finalityMetadata) error {
+	if err := s.setMetadata(ctx, finalityMetadataKey, md); err != nil {
+		return errors.Join(errors.New("failed to set finality metadata"), err)
+	}
+
+	return nil
+}
+
+func (s *Service) getMetadata(ctx context.Context, key []byte, md any) error {
+	data, err := s.store.Metadata(ctx, key)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		return nil
+	}
+
+	return json.Unmarshal(data, md)
+}
+
+func (s *Service) setMetadata(ctx context.Context, key []byte, md any) error {
+	data, err := json.Marshal(md)
+	if err != nil {
+		return err
+	}
+
+	return s.store.SetMetadata(ctx, key, data)
+}
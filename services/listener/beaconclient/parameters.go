@@ -0,0 +1,208 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package beaconclient is a listener that listens to an Ethereum consensus client.
+package beaconclient
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	execclient "github.com/attestantio/go-execution-client"
+	"github.com/rs/zerolog"
+	"github.com/wealdtech/go-eth-listener/handlers"
+	"github.com/wealdtech/go-eth-listener/services/metrics"
+	nullmetrics "github.com/wealdtech/go-eth-listener/services/metrics/null"
+)
+
+type parameters struct {
+	logLevel            zerolog.Level
+	monitor             metrics.Service
+	metadataDBPath      string
+	metadataStore       MetadataStore
+	address             string
+	timeout             time.Duration
+	interval            time.Duration
+	slotsPerEpoch       uint64
+	blockTriggers       []*handlers.BeaconBlockTrigger
+	finalityTriggers    []*handlers.FinalityTrigger
+	correlationTriggers []*handlers.CorrelationTrigger
+	executionBlocks     execclient.BlocksProvider
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithMetadataDBPath sets the path for the metadata database.
+func WithMetadataDBPath(path string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.metadataDBPath = path
+	})
+}
+
+// WithMetadataStore sets an existing metadata store to use in place of a metadata database,
+// for example the execution listener, so that both layers share one metadata store.
+func WithMetadataStore(store MetadataStore) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.metadataStore = store
+	})
+}
+
+// WithAddress sets the address of the beacon node API.
+func WithAddress(address string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.address = address
+	})
+}
+
+// WithTimeout sets the timeout for requests to the beacon node.
+func WithTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.timeout = timeout
+	})
+}
+
+// WithInterval sets the interval between polls.
+// If zero the listener does not poll by itself, and polls must be driven by calling PollOnce().
+func WithInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.interval = interval
+	})
+}
+
+// WithSlotsPerEpoch sets the number of slots per epoch of the chain.
+// If not supplied this defaults to 32.
+func WithSlotsPerEpoch(slots uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.slotsPerEpoch = slots
+	})
+}
+
+// WithBlockTriggers sets the beacon block triggers for the listener.
+func WithBlockTriggers(triggers []*handlers.BeaconBlockTrigger) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.blockTriggers = triggers
+	})
+}
+
+// WithFinalityTriggers sets the finality triggers for the listener.
+func WithFinalityTriggers(triggers []*handlers.FinalityTrigger) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.finalityTriggers = triggers
+	})
+}
+
+// WithCorrelationTriggers sets the correlation triggers for the listener.
+// Correlation triggers require an execution blocks provider.
+func WithCorrelationTriggers(triggers []*handlers.CorrelationTrigger) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.correlationTriggers = triggers
+	})
+}
+
+// WithExecutionBlocksProvider sets the provider of execution blocks, used by correlation triggers.
+func WithExecutionBlocksProvider(provider execclient.BlocksProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.executionBlocks = provider
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:      zerolog.GlobalLevel(),
+		monitor:       nullmetrics.New(),
+		slotsPerEpoch: 32,
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.timeout == 0 {
+		return nil, errors.New("no timeout specified")
+	}
+	if parameters.address == "" {
+		return nil, errors.New("no address specified")
+	}
+	if parameters.interval < 0 {
+		return nil, errors.New("interval cannot be negative")
+	}
+	if parameters.slotsPerEpoch == 0 {
+		return nil, errors.New("slots per epoch cannot be zero")
+	}
+	switch {
+	case parameters.metadataDBPath == "" && parameters.metadataStore == nil:
+		return nil, errors.New("no metadata db path or metadata store specified")
+	case parameters.metadataDBPath != "" && parameters.metadataStore != nil:
+		return nil, errors.New("only one of metadata db path and metadata store can be specified")
+	}
+	if len(parameters.blockTriggers) == 0 && len(parameters.finalityTriggers) == 0 && len(parameters.correlationTriggers) == 0 {
+		return nil, errors.New("no triggers specified")
+	}
+	for _, trigger := range parameters.blockTriggers {
+		if trigger.Name == "" {
+			return nil, errors.New("no beacon block trigger name specified")
+		}
+		if strings.Contains(trigger.Namespace, handlers.NamespaceSeparator) {
+			return nil, fmt.Errorf("beacon block trigger namespace cannot contain %q", handlers.NamespaceSeparator)
+		}
+		if trigger.Handler == nil {
+			return nil, errors.New("no beacon block trigger handler specified")
+		}
+	}
+	for _, trigger := range parameters.finalityTriggers {
+		if trigger.Name == "" {
+			return nil, errors.New("no finality trigger name specified")
+		}
+		if strings.Contains(trigger.Namespace, handlers.NamespaceSeparator) {
+			return nil, fmt.Errorf("finality trigger namespace cannot contain %q", handlers.NamespaceSeparator)
+		}
+		if trigger.Handler == nil {
+			return nil, errors.New("no finality trigger handler specified")
+		}
+	}
+	if err := checkCorrelationTriggerParameters(&parameters); err != nil {
+		return nil, err
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,121 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// ErrPollInProgress is returned when a poll is requested while another is in progress.
+var ErrPollInProgress = errors.New("poll in progress")
+
+// Service is a listener that listens to an Ethereum consensus client.
+type Service struct {
+	log              zerolog.Logger
+	address          string
+	client           *http.Client
+	store            MetadataStore
+	ownStore         *pebbleStore
+	interval         time.Duration
+	slotsPerEpoch    uint64
+	blockTriggers    []*handlers.BeaconBlockTrigger
+	finalityTriggers []*handlers.FinalityTrigger
+	pollMu           sync.Mutex
+	duties           map[uint64]map[uint64]uint64
+	stopped          chan struct{}
+}
+
+// New creates a new service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "listener").Str("impl", "beaconclient").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, err
+	}
+
+	// Correlation triggers are carried out as beacon block triggers.
+	blockTriggers := append(append([]*handlers.BeaconBlockTrigger{}, parameters.blockTriggers...),
+		correlationBlockTriggers(parameters.executionBlocks, parameters.correlationTriggers)...)
+
+	s := &Service{
+		log:     log,
+		address: parameters.address,
+		client: &http.Client{
+			Timeout: parameters.timeout,
+		},
+		store:            parameters.metadataStore,
+		interval:         parameters.interval,
+		slotsPerEpoch:    parameters.slotsPerEpoch,
+		blockTriggers:    blockTriggers,
+		finalityTriggers: parameters.finalityTriggers,
+		duties:           make(map[uint64]map[uint64]uint64),
+		stopped:          make(chan struct{}),
+	}
+	if parameters.metadataDBPath != "" {
+		s.ownStore, err = newPebbleStore(parameters.metadataDBPath)
+		if err != nil {
+			return nil, errors.Join(errors.New("failed to start metadata database"), err)
+		}
+		s.store = s.ownStore
+	}
+
+	// Shut down on context done.
+	go s.shutdown(ctx)
+
+	// Kick off the listener, unless polling is driven externally.
+	if s.interval > 0 {
+		go s.listener(ctx)
+	}
+
+	return s, nil
+}
+
+// shutdown waits for the context to be done, then stops the listener.
+func (s *Service) shutdown(ctx context.Context) {
+	<-ctx.Done()
+
+	// Wait for any in-progress poll to complete, so that its progress is recorded.
+	s.pollMu.Lock()
+	if s.ownStore != nil {
+		if err := s.ownStore.close(); err != nil {
+			s.log.Warn().Err(err).Msg("Failed to close pebble")
+		}
+	}
+	s.pollMu.Unlock()
+
+	s.log.Debug().Msg("Listener stopped")
+	close(s.stopped)
+}
+
+// Stopped returns a channel that is closed when the listener has stopped, after its context is done.
+func (s *Service) Stopped() <-chan struct{} {
+	return s.stopped
+}
@@ -0,0 +1,169 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+)
+
+var auditPrefix = []byte("listener.ethclient.audit.")
+
+// AuditAction is an operational action recorded in the audit log.
+type AuditAction string
+
+const (
+	// AuditActionPause is the pausing of a namespace.
+	AuditActionPause AuditAction = "pause"
+	// AuditActionResume is the resumption of a namespace.
+	AuditActionResume AuditAction = "resume"
+	// AuditActionRewind is the rewinding of a namespace.
+	AuditActionRewind AuditAction = "rewind"
+	// AuditActionWriteProgress is the replacement of trigger progress outside of a running listener.
+	AuditActionWriteProgress AuditAction = "write_progress"
+	// AuditActionReconfigure is the reconfiguration of a running listener.
+	AuditActionReconfigure AuditAction = "reconfigure"
+)
+
+// AuditEntry is an entry in the audit log.
+type AuditEntry struct {
+	// Time is the time at which the action was carried out.
+	Time time.Time `json:"time"`
+	// Actor is the actor that carried out the action, if known.
+	Actor string `json:"actor,omitempty"`
+	// Action is the action.
+	Action AuditAction `json:"action"`
+	// Namespace is the namespace to which the action applied, if any.
+	Namespace string `json:"namespace,omitempty"`
+	// Details are human-readable details of the action.
+	Details []string `json:"details,omitempty"`
+}
+
+type auditContextKey int
+
+const actorKey auditContextKey = iota
+
+// ContextWithActor returns a context that attributes operational actions carried out with it,
+// such as rewinding a namespace, to the given actor in the audit log.
+func ContextWithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// ActorFromContext returns the actor from the context, or an empty string if not present.
+func ActorFromContext(ctx context.Context) string {
+	actor, isActor := ctx.Value(actorKey).(string)
+	if !isActor {
+		return ""
+	}
+
+	return actor
+}
+
+// auditKey returns the key for an audit entry.  Keys are ordered by time, with a sequence
+// number to separate entries recorded at the same time.
+func auditKey(recorded time.Time, sequence uint32) []byte {
+	key := make([]byte, len(auditPrefix)+12)
+	copy(key, auditPrefix)
+	binary.BigEndian.PutUint64(key[len(auditPrefix):], uint64(recorded.UnixNano()))
+	binary.BigEndian.PutUint32(key[len(auditPrefix)+8:], sequence)
+
+	return key
+}
+
+// audit records an action in the audit log.  Failure to record the action is logged but
+// does not affect the action itself, which has already been carried out.
+func (s *Service) audit(ctx context.Context, action AuditAction, namespace string, details ...string) {
+	entry := &AuditEntry{
+		Time:      time.Now().UTC(),
+		Actor:     ActorFromContext(ctx),
+		Action:    action,
+		Namespace: namespace,
+		Details:   details,
+	}
+	if err := s.writeAuditEntry(entry); err != nil {
+		s.log.Error().Err(err).Str("action", string(action)).Str("namespace", namespace).Msg("Failed to record action in audit log")
+	}
+}
+
+// writeAuditEntry writes an entry to the audit log.
+func (s *Service) writeAuditEntry(entry *AuditEntry) error {
+	data, err := s.marshalValue(entry)
+	if err != nil {
+		return errors.Join(errors.New("failed to marshal audit entry"), err)
+	}
+
+	s.metadataDBMu.Lock()
+	defer s.metadataDBMu.Unlock()
+	if !s.metadataDBOpen.Load() {
+		return errors.New("database closed")
+	}
+
+	started := time.Now()
+	err = s.metadataDB.Set(auditKey(entry.Time, s.auditSequence.Add(1)), data, pebble.Sync)
+	monitorMetadataDBOperation("write", time.Since(started))
+	if err != nil {
+		return errors.Join(errors.New("failed to write audit entry"), err)
+	}
+
+	return nil
+}
+
+// AuditLog returns the entries in the audit log, oldest first.
+func (s *Service) AuditLog(_ context.Context) ([]*AuditEntry, error) {
+	s.metadataDBMu.Lock()
+	defer s.metadataDBMu.Unlock()
+	if !s.metadataDBOpen.Load() {
+		return nil, errors.New("database closed")
+	}
+
+	iter, err := s.metadataDB.NewIter(&pebble.IterOptions{
+		LowerBound: auditPrefix,
+		UpperBound: prefixEnd(auditPrefix),
+	})
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to create iterator"), err)
+	}
+	defer iter.Close()
+
+	entries := make([]*AuditEntry, 0)
+	for iter.First(); iter.Valid(); iter.Next() {
+		entry := &AuditEntry{}
+		if err := s.unmarshalValue(iter.Value(), entry); err != nil {
+			return nil, errors.Join(errors.New("failed to unmarshal audit entry"), err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, errors.Join(errors.New("failed to iterate over audit log"), err)
+	}
+
+	return entries, nil
+}
+
+// ReadAuditLog reads the audit log from the metadata database at the given path.
+// The cipher is required if the database is encrypted.
+// The database must not be in use by a running listener.
+func ReadAuditLog(ctx context.Context, path string, cipher ValueCipher) ([]*AuditEntry, error) {
+	s, err := openInspection(path, cipher, true)
+	if err != nil {
+		return nil, err
+	}
+	defer s.closeMetadataDB()
+
+	return s.AuditLog(ctx)
+}
@@ -0,0 +1,271 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/rs/zerolog"
+)
+
+// backupName is the name under which metadata backups are stored.
+const backupName = "metadata.tar.gz"
+
+// ErrBackupNotFound is returned by a backup store if there is no backup with the requested name.
+var ErrBackupNotFound = errors.New("backup not found")
+
+// BackupStore defines the methods that need to be implemented to store metadata backups.
+// It is expected to be a thin adapter around a directory or an object store such as an
+// S3-compatible bucket.
+type BackupStore interface {
+	// Put stores a backup with the given name, replacing any existing backup with that name.
+	Put(ctx context.Context, name string, data []byte) error
+	// Get returns the backup with the given name, or ErrBackupNotFound if there is no such backup.
+	Get(ctx context.Context, name string) ([]byte, error)
+}
+
+// DirectoryBackupStore stores metadata backups in a directory.
+type DirectoryBackupStore struct {
+	// Path is the path to the directory.
+	Path string
+}
+
+// Put stores a backup with the given name.
+func (d *DirectoryBackupStore) Put(_ context.Context, name string, data []byte) error {
+	if err := os.MkdirAll(d.Path, 0o700); err != nil {
+		return errors.Join(errors.New("failed to create backup directory"), err)
+	}
+	tmpPath := filepath.Join(d.Path, name+".tmp")
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return errors.Join(errors.New("failed to write backup"), err)
+	}
+	if err := os.Rename(tmpPath, filepath.Join(d.Path, name)); err != nil {
+		return errors.Join(errors.New("failed to replace backup"), err)
+	}
+
+	return nil
+}
+
+// Get returns the backup with the given name.
+func (d *DirectoryBackupStore) Get(_ context.Context, name string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(d.Path, name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrBackupNotFound
+		}
+
+		return nil, errors.Join(errors.New("failed to read backup"), err)
+	}
+
+	return data, nil
+}
+
+// openMetadataDB opens the metadata database.  If a backup store is configured then the
+// database is restored from backup if it is missing or corrupt.
+func openMetadataDB(ctx context.Context, log zerolog.Logger, parameters *parameters) (*pebble.DB, error) {
+	path := parameters.metadataDBPath
+	store := parameters.backupStore
+
+	if store != nil {
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			err := restoreMetadataDB(ctx, store, path)
+			switch {
+			case errors.Is(err, ErrBackupNotFound):
+				log.Debug().Msg("No metadata database or backup; starting afresh")
+			case err != nil:
+				return nil, err
+			default:
+				log.Info().Msg("Restored missing metadata database from backup")
+			}
+		}
+	}
+
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err == nil || store == nil || !pebble.IsCorruptionError(err) {
+		return db, err
+	}
+
+	corruptPath := fmt.Sprintf("%s.corrupt-%d", path, time.Now().Unix())
+	log.Warn().Err(err).Str("moved_to", corruptPath).Msg("Metadata database is corrupt; restoring from backup")
+	if err := os.Rename(path, corruptPath); err != nil {
+		return nil, errors.Join(errors.New("failed to move corrupt metadata database"), err)
+	}
+	if err := restoreMetadataDB(ctx, store, path); err != nil {
+		return nil, errors.Join(errors.New("failed to restore metadata database"), err)
+	}
+
+	return pebble.Open(path, &pebble.Options{})
+}
+
+// restoreMetadataDB restores the metadata database at the given path from the backup store.
+func restoreMetadataDB(ctx context.Context, store BackupStore, path string) error {
+	data, err := store.Get(ctx, backupName)
+	if err != nil {
+		return err
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return errors.Join(errors.New("failed to decompress backup"), err)
+	}
+	defer gz.Close()
+
+	if err := os.MkdirAll(path, 0o700); err != nil {
+		return errors.Join(errors.New("failed to create metadata database directory"), err)
+	}
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return errors.Join(errors.New("failed to read backup"), err)
+		}
+		if header.Typeflag != tar.TypeReg || filepath.Base(header.Name) != header.Name {
+			return fmt.Errorf("unexpected entry %q in backup", header.Name)
+		}
+		if err := restoreFile(archive, filepath.Join(path, header.Name)); err != nil {
+			return err
+		}
+	}
+}
+
+// restoreFile writes the contents of the reader to the given path.
+func restoreFile(r io.Reader, path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Join(errors.New("failed to create restored file"), err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+
+		return errors.Join(errors.New("failed to write restored file"), err)
+	}
+	if err := f.Close(); err != nil {
+		return errors.Join(errors.New("failed to close restored file"), err)
+	}
+
+	return nil
+}
+
+// backupMetadataDB takes a snapshot of the metadata database and stores it in the backup store.
+func (s *Service) backupMetadataDB(ctx context.Context) error {
+	dir, err := os.MkdirTemp("", "eth-listener-backup")
+	if err != nil {
+		return errors.Join(errors.New("failed to create temporary directory"), err)
+	}
+	defer os.RemoveAll(dir)
+	checkpointDir := filepath.Join(dir, "checkpoint")
+
+	if err := s.checkpointMetadataDB(checkpointDir); err != nil {
+		return err
+	}
+
+	data, err := archiveDirectory(checkpointDir)
+	if err != nil {
+		return err
+	}
+
+	if err := s.backupStore.Put(ctx, backupName, data); err != nil {
+		return errors.Join(errors.New("failed to store backup"), err)
+	}
+
+	return nil
+}
+
+// checkpointMetadataDB creates a consistent snapshot of the metadata database in the given directory.
+func (s *Service) checkpointMetadataDB(dir string) error {
+	s.metadataDBMu.Lock()
+	defer s.metadataDBMu.Unlock()
+	if !s.metadataDBOpen.Load() {
+		return errors.New("database closed")
+	}
+
+	if err := s.metadataDB.Checkpoint(dir, pebble.WithFlushedWAL()); err != nil {
+		return errors.Join(errors.New("failed to checkpoint metadata database"), err)
+	}
+
+	return nil
+}
+
+// archiveDirectory returns a compressed archive of the files in a directory.
+func archiveDirectory(dir string) ([]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to read checkpoint"), err)
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	archive := tar.NewWriter(gz)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, errors.Join(errors.New("failed to read checkpoint file"), err)
+		}
+		if err := archive.WriteHeader(&tar.Header{
+			Name:     entry.Name(),
+			Mode:     0o600,
+			Size:     int64(len(data)),
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			return nil, errors.Join(errors.New("failed to write archive header"), err)
+		}
+		if _, err := archive.Write(data); err != nil {
+			return nil, errors.Join(errors.New("failed to write archive"), err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, errors.Join(errors.New("failed to close archive"), err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, errors.Join(errors.New("failed to compress archive"), err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// backupper periodically backs up the metadata database.
+func (s *Service) backupper(ctx context.Context) {
+	ticker := time.NewTicker(s.backupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopping:
+			return
+		case <-ticker.C:
+			if err := s.backupMetadataDB(ctx); err != nil {
+				s.log.Warn().Err(err).Msg("Failed to back up metadata database")
+
+				continue
+			}
+			s.log.Trace().Msg("Backed up metadata database")
+		}
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// maxCachedBlocks is the maximum number of blocks cached within a poll.
+const maxCachedBlocks = 64
+
+// Approximate sizes used to estimate the memory held by a block.
+const (
+	blockOverhead       = 1024
+	transactionOverhead = 512
+	accessListEntrySize = 64
+)
+
+// blockCache caches blocks for the duration of a poll, so that a block is not fetched
+// repeatedly for the block specifier, block triggers, transaction triggers and tracking.
+type blockCache struct {
+	mu     sync.Mutex
+	blocks map[uint64]*spec.Block
+	// maxBytes is the memory budget for cached blocks, or 0 if unlimited.
+	maxBytes uint64
+	bytes    uint64
+	sizes    map[uint64]uint64
+	// authorizations are the authorization lists of set-code transactions in the cached blocks.
+	authorizations map[types.Hash][]*handlers.SetCodeAuthorization
+}
+
+func newBlockCache(maxBytes uint64) *blockCache {
+	return &blockCache{
+		blocks:         make(map[uint64]*spec.Block),
+		maxBytes:       maxBytes,
+		sizes:          make(map[uint64]uint64),
+		authorizations: make(map[types.Hash][]*handlers.SetCodeAuthorization),
+	}
+}
+
+func (c *blockCache) get(height uint64) (*spec.Block, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	block, exists := c.blocks[height]
+
+	return block, exists
+}
+
+func (c *blockCache) put(block *spec.Block) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	size := uint64(0)
+	if c.maxBytes > 0 {
+		size = blockSize(block)
+		if size > c.maxBytes {
+			// The block alone exceeds the budget, so is not held beyond its use.
+			return
+		}
+	}
+
+	number := uint64(block.Number())
+	c.remove(number)
+	if len(c.blocks) >= maxCachedBlocks {
+		// Blocks are generally fetched in order, so older blocks are unlikely to be needed again.
+		for height := range c.blocks {
+			if height < number {
+				c.remove(height)
+			}
+		}
+	}
+	for c.maxBytes > 0 && c.bytes+size > c.maxBytes && len(c.blocks) > 0 {
+		c.remove(c.lowest())
+	}
+	c.blocks[number] = block
+	c.sizes[number] = size
+	c.bytes += size
+}
+
+// remove removes the block at the given height from the cache, if present.
+// This assumes that the lock is held.
+func (c *blockCache) remove(height uint64) {
+	if _, exists := c.blocks[height]; !exists {
+		return
+	}
+	delete(c.blocks, height)
+	c.bytes -= c.sizes[height]
+	delete(c.sizes, height)
+}
+
+// lowest returns the height of the lowest block in the cache.
+// This assumes that the lock is held, and that the cache is not empty.
+func (c *blockCache) lowest() uint64 {
+	lowest := maxUint64
+	for height := range c.blocks {
+		lowest = min(lowest, height)
+	}
+
+	return lowest
+}
+
+// blockSize returns the approximate memory held by a block.
+func blockSize(block *spec.Block) uint64 {
+	size := uint64(blockOverhead)
+	for _, tx := range block.Transactions() {
+		size += transactionOverhead + uint64(len(tx.Input())) + uint64(len(tx.AccessList()))*accessListEntrySize
+	}
+
+	return size
+}
+
+// clear empties the cache, to avoid stale blocks being seen in subsequent polls after a reorg.
+func (c *blockCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blocks = make(map[uint64]*spec.Block)
+	c.bytes = 0
+	c.sizes = make(map[uint64]uint64)
+	c.authorizations = make(map[types.Hash][]*handlers.SetCodeAuthorization)
+}
+
+func (c *blockCache) putAuthorizations(authorizations map[types.Hash][]*handlers.SetCodeAuthorization) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for hash, list := range authorizations {
+		c.authorizations[hash] = list
+	}
+}
+
+func (c *blockCache) authorizationsFor(hash types.Hash) []*handlers.SetCodeAuthorization {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.authorizations[hash]
+}
+
+// block returns the block at the given height, using the poll's cache where possible.
+func (s *Service) block(ctx context.Context, height uint64) (*spec.Block, error) {
+	if block, exists := s.blockCache.get(height); exists {
+		return block, nil
+	}
+
+	block, err := s.blocksProvider.Block(ctx, fmt.Sprintf("%d", height))
+	if errors.Is(err, ErrUnsupportedTransactionType) {
+		// The block contains transactions unknown to the client library, such as set-code transactions.
+		var authorizations map[types.Hash][]*handlers.SetCodeAuthorization
+		block, authorizations, err = s.setCodeBlock(ctx, height)
+		if err == nil {
+			s.blockCache.putAuthorizations(authorizations)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %d not found", height)
+	}
+	s.blockCache.put(block)
+	s.recordCanonicalBlock(block)
+
+	return block, nil
+}
+
+// cachedPollFrom returns the first block that block and transaction triggers need in a poll to the given block.
+// If only one of block and transaction triggers are present, or the first block cannot be calculated,
+// then the highest block is returned so that the poll is carried out in a single chunk.
+func (s *Service) cachedPollFrom(ctx context.Context, to uint64) uint64 {
+	if len(s.blockTriggers) == 0 || len(s.txTriggers) == 0 {
+		return to
+	}
+	if s.earliestBlock > -1 {
+		return min(uint64(s.earliestBlock), to)
+	}
+
+	blocksMD, err := s.getBlocksMetadata(ctx)
+	if err != nil {
+		return to
+	}
+	s.applyInitialBlocksProgress(blocksMD)
+	from := s.blocksProgressFrom(blocksMD)
+
+	txsMD, err := s.getTransactionsMetadata(ctx)
+	if err != nil {
+		return to
+	}
+	s.applyInitialTransactionsProgress(txsMD)
+	for _, trigger := range s.activeTxTriggers() {
+		from = min(from, uint64(txsMD.latestBlock(trigger)+1))
+	}
+
+	return min(s.availableFrom(from), to)
+}
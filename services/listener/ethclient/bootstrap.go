@@ -0,0 +1,59 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+// applyInitialBlocksProgress sets the progress of block triggers that have no progress
+// recorded to their initial progress, if supplied.
+func (s *Service) applyInitialBlocksProgress(md *blocksMetadata) {
+	for _, trigger := range s.blockTriggers {
+		if _, exists := md.LatestBlocks[trigger.QualifiedName()]; exists {
+			continue
+		}
+		if initial, exists := s.initialProgress[trigger.QualifiedName()]; exists {
+			s.log.Debug().Str("trigger", trigger.QualifiedName()).Uint64("initial_block", initial).Msg("Bootstrapping block trigger progress")
+			md.LatestBlocks[trigger.QualifiedName()] = int64(initial) - 1
+		}
+	}
+}
+
+// applyInitialTransactionsProgress sets the progress of transaction triggers that have no progress
+// recorded to their initial progress, if supplied.
+func (s *Service) applyInitialTransactionsProgress(md *transactionsMetadata) {
+	for _, trigger := range s.txTriggers {
+		if _, exists := md.LatestBlocks[trigger.QualifiedName()]; exists {
+			continue
+		}
+		if initial, exists := s.initialProgress[trigger.QualifiedName()]; exists {
+			s.log.Debug().Str("trigger", trigger.QualifiedName()).Uint64("initial_block", initial).Msg("Bootstrapping transaction trigger progress")
+			md.LatestBlocks[trigger.QualifiedName()] = int64(initial) - 1
+		}
+	}
+}
+
+// applyInitialEventsProgress sets the progress of event triggers that have no progress
+// recorded to their initial progress, if supplied.
+func (s *Service) applyInitialEventsProgress(md *eventsMetadata) {
+	for _, trigger := range s.eventTriggers {
+		if _, exists := md.Entries[trigger.QualifiedName()]; exists {
+			continue
+		}
+		if initial, exists := s.initialProgress[trigger.QualifiedName()]; exists {
+			s.log.Debug().Str("trigger", trigger.QualifiedName()).Uint64("initial_block", initial).Msg("Bootstrapping event trigger progress")
+			md.Entries[trigger.QualifiedName()] = &eventsEntryMetadata{
+				LatestBlock:      initial,
+				LatestEventIndex: -1,
+			}
+		}
+	}
+}
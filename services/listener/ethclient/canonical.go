@@ -0,0 +1,202 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/cockroachdb/pebble"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// maxCanonicalBlocks is the number of recent blocks for which canonical hashes are retained.
+const maxCanonicalBlocks = 256
+
+var canonicalPrefix = []byte("listener.ethclient.canonical.")
+
+// BlockHash returns the hash of the canonical block at the given height.
+// Recent blocks are served from the canonical chain cache without calling the client.
+func (s *Service) BlockHash(ctx context.Context, height uint64) (types.Hash, error) {
+	if hash, exists := s.canonicalHash(height); exists {
+		return hash, nil
+	}
+
+	block, err := s.block(ctx, height)
+	if err != nil {
+		return types.Hash{}, errors.Join(errors.New("failed to obtain block"), err)
+	}
+
+	return block.Hash(), nil
+}
+
+// canonicalHash returns the cached canonical hash at the given height.
+func (s *Service) canonicalHash(height uint64) (types.Hash, bool) {
+	s.canonicalMu.Lock()
+	defer s.canonicalMu.Unlock()
+	hash, exists := s.canonical[height]
+
+	return hash, exists
+}
+
+// recordCanonicalBlock records a block fetched from the client as canonical.
+// If a different block was previously recorded at the same height, or the block's parent is not
+// the block recorded at the height below, then the chain has reorged.  The depth of the reorg is
+// not known, so all cached hashes are discarded.
+func (s *Service) recordCanonicalBlock(block *spec.Block) {
+	height := uint64(block.Number())
+	hash := block.Hash()
+
+	s.canonicalMu.Lock()
+	defer s.canonicalMu.Unlock()
+
+	previous, exists := s.canonical[height]
+	if exists && previous == hash {
+		return
+	}
+
+	// replaced is the lowest height known to have been replaced by a reorg.
+	replaced := height
+	reorged := exists
+	if parent, parentExists := s.canonical[height-1]; height > 0 && parentExists && parent != block.ParentHash() {
+		replaced = height - 1
+		reorged = true
+	}
+
+	removed := make([]uint64, 0)
+	if reorged {
+		s.log.Warn().Uint64("height", replaced).Stringer("previous", s.canonical[replaced]).Stringer("hash", hash).Msg("Reorg detected")
+		monitorReorg()
+		oldHead := &handlers.ChainHead{}
+		for cached, cachedHash := range s.canonical {
+			if cached >= replaced && cached >= oldHead.Number {
+				oldHead.Number = cached
+				oldHead.Hash = cachedHash
+			}
+			delete(s.canonical, cached)
+			removed = append(removed, cached)
+		}
+		s.queueReorg(oldHead, &handlers.ChainHead{
+			Number: height,
+			Hash:   hash,
+		})
+	}
+	s.canonical[height] = hash
+	for cached := range s.canonical {
+		if cached+maxCanonicalBlocks <= height {
+			delete(s.canonical, cached)
+			removed = append(removed, cached)
+		}
+	}
+
+	if err := s.storeCanonicalHashes(height, hash, removed); err != nil {
+		// The cache is advisory, so failure to persist it does not stop processing.
+		s.log.Warn().Err(err).Msg("Failed to store canonical hash")
+	}
+}
+
+// checkCanonicalEvent checks that an event is from the cached canonical block at its height.
+// If it is not then the block is fetched again to check if the cache is stale.
+func (s *Service) checkCanonicalEvent(ctx context.Context, event *spec.BerlinTransactionEvent) error {
+	hash, exists := s.canonicalHash(uint64(event.BlockNumber))
+	if !exists || hash == event.BlockHash {
+		return nil
+	}
+
+	block, err := s.blocksProvider.Block(ctx, fmt.Sprintf("%d", event.BlockNumber))
+	if err != nil {
+		return errors.Join(errors.New("failed to obtain block to check event"), err)
+	}
+	if block == nil {
+		return fmt.Errorf("block %d not found", event.BlockNumber)
+	}
+	s.recordCanonicalBlock(block)
+	if block.Hash() != event.BlockHash {
+		return fmt.Errorf("event in block %d is not on the canonical chain", event.BlockNumber)
+	}
+
+	return nil
+}
+
+// storeCanonicalHashes stores a canonical hash and removes discarded hashes in the metadata database.
+func (s *Service) storeCanonicalHashes(height uint64, hash types.Hash, removed []uint64) error {
+	s.metadataDBMu.Lock()
+	defer s.metadataDBMu.Unlock()
+	if !s.metadataDBOpen.Load() {
+		return errors.New("database closed")
+	}
+
+	data, err := s.marshalValue(hash[:])
+	if err != nil {
+		return errors.Join(errors.New("failed to marshal canonical hash"), err)
+	}
+
+	batch := s.metadataDB.NewBatch()
+	defer batch.Close()
+	for _, height := range removed {
+		if err := batch.Delete(blockKey(canonicalPrefix, height), nil); err != nil {
+			return errors.Join(errors.New("failed to remove canonical hash"), err)
+		}
+	}
+	if err := batch.Set(blockKey(canonicalPrefix, height), data, nil); err != nil {
+		return errors.Join(errors.New("failed to set canonical hash"), err)
+	}
+
+	started := time.Now()
+	err = batch.Commit(pebble.Sync)
+	monitorMetadataDBOperation("write", time.Since(started))
+	if err != nil {
+		return errors.Join(errors.New("failed to commit canonical hash"), err)
+	}
+
+	return nil
+}
+
+// loadCanonicalChain loads the canonical chain cache from the metadata database.
+func (s *Service) loadCanonicalChain() error {
+	s.metadataDBMu.Lock()
+	defer s.metadataDBMu.Unlock()
+	if !s.metadataDBOpen.Load() {
+		return errors.New("database closed")
+	}
+
+	iter, err := s.metadataDB.NewIter(&pebble.IterOptions{
+		LowerBound: canonicalPrefix,
+		UpperBound: prefixEnd(canonicalPrefix),
+	})
+	if err != nil {
+		return errors.Join(errors.New("failed to iterate over canonical hashes"), err)
+	}
+	defer iter.Close()
+
+	s.canonicalMu.Lock()
+	defer s.canonicalMu.Unlock()
+	for iter.First(); iter.Valid(); iter.Next() {
+		var hash []byte
+		if err := s.unmarshalValue(iter.Value(), &hash); err != nil {
+			return errors.Join(errors.New("failed to unmarshal canonical hash"), err)
+		}
+		if len(hash) != len(types.Hash{}) {
+			return errors.New("invalid canonical hash")
+		}
+		s.canonical[binary.BigEndian.Uint64(iter.Key()[len(canonicalPrefix):])] = types.Hash(hash)
+	}
+
+	return iter.Error()
+}
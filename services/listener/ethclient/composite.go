@@ -0,0 +1,126 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	execclient "github.com/attestantio/go-execution-client"
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/rs/zerolog"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// compositeTxHandler handles transactions for a composite trigger, passing on those
+// that emitted matching events.
+type compositeTxHandler struct {
+	log              zerolog.Logger
+	receiptsProvider execclient.TransactionReceiptsProvider
+	trigger          *handlers.CompositeTrigger
+	// matcher matches events in receipts, which are not filtered.
+	matcher *eventMatcher
+}
+
+// HandleTx handles a transaction that matches the transaction filters of the composite trigger.
+func (h *compositeTxHandler) HandleTx(ctx context.Context, tx *spec.Transaction, _ *handlers.TxTrigger) {
+	receipt, err := h.receiptsProvider.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		h.log.Error().Str("trigger", h.trigger.QualifiedName()).Stringer("tx", tx.Hash()).Err(err).Msg("Failed to obtain receipt for composite trigger")
+
+		return
+	}
+
+	events := make([]*spec.BerlinTransactionEvent, 0)
+	for _, event := range receipt.Logs() {
+		if h.matcher.matches(event) {
+			events = append(events, event)
+		}
+	}
+	if len(events) == 0 {
+		h.log.Trace().Str("trigger", h.trigger.QualifiedName()).Stringer("tx", tx.Hash()).Msg("No matching events; ignoring")
+
+		return
+	}
+
+	h.trigger.Handler.HandleComposite(ctx, tx, events, h.trigger)
+}
+
+// compositeTxTriggers returns transaction triggers that carry out the composite triggers.
+func compositeTxTriggers(log zerolog.Logger,
+	receiptsProvider execclient.TransactionReceiptsProvider,
+	triggers []*handlers.CompositeTrigger,
+) []*handlers.TxTrigger {
+	txTriggers := make([]*handlers.TxTrigger, 0, len(triggers))
+	for _, trigger := range triggers {
+		txTriggers = append(txTriggers, &handlers.TxTrigger{
+			Name:          trigger.Name,
+			Namespace:     trigger.Namespace,
+			From:          trigger.Tx.From,
+			FromSet:       trigger.Tx.FromSet,
+			To:            trigger.Tx.To,
+			ToSet:         trigger.Tx.ToSet,
+			InputPrefix:   trigger.Tx.InputPrefix,
+			InputMatcher:  trigger.Tx.InputMatcher,
+			EarliestBlock: trigger.EarliestBlock,
+			Handler: &compositeTxHandler{
+				log:              log,
+				receiptsProvider: receiptsProvider,
+				trigger:          trigger,
+				matcher:          newEventMatcher(trigger.Event, false),
+			},
+			Labels:    trigger.Labels,
+			Priority:  trigger.Priority,
+			DependsOn: trigger.DependsOn,
+		})
+	}
+
+	return txTriggers
+}
+
+// checkCompositeTriggerParameters checks the parameters of composite triggers.
+func checkCompositeTriggerParameters(parameters *parameters) error {
+	for _, trigger := range parameters.compositeTriggers {
+		if trigger.Name == "" {
+			return errors.New("no composite trigger name specified")
+		}
+		if strings.Contains(trigger.Namespace, handlers.NamespaceSeparator) {
+			return fmt.Errorf("composite trigger namespace cannot contain %q", handlers.NamespaceSeparator)
+		}
+		if trigger.Handler == nil {
+			return errors.New("no composite trigger handler specified")
+		}
+		if trigger.Tx == nil {
+			return errors.New("no composite trigger transaction filters specified")
+		}
+		if trigger.Event == nil {
+			return errors.New("no composite trigger event filters specified")
+		}
+		if trigger.Event.SourceResolver != nil {
+			return errors.New("composite trigger event filters do not support a source resolver")
+		}
+		if err := checkEventTopics(trigger.Event); err != nil {
+			return errors.Join(fmt.Errorf("invalid topics for composite trigger %s", trigger.QualifiedName()), err)
+		}
+		for _, txTrigger := range parameters.txTriggers {
+			if txTrigger.QualifiedName() == trigger.QualifiedName() {
+				return fmt.Errorf("composite trigger %s has the same name as a transaction trigger", trigger.QualifiedName())
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"io"
+	"sync"
+)
+
+// compressedValueV1 flags a value in the metadata database that is compressed with DEFLATE.
+// Uncompressed values are JSON, which cannot start with this byte.
+const compressedValueV1 = byte(0x01)
+
+// compressors are reused between values, as creating a compressor is expensive.
+var compressors = sync.Pool{
+	New: func() any {
+		// Level is valid, so an error cannot occur.
+		compressor, _ := flate.NewWriter(nil, flate.BestSpeed)
+
+		return compressor
+	},
+}
+
+// compressValue compresses a value for the metadata database if it is above the compression
+// threshold, returning the value unchanged if it is not or if compression does not reduce its size.
+func (s *Service) compressValue(data []byte) ([]byte, error) {
+	if s.compressThreshold == 0 || len(data) <= s.compressThreshold {
+		return data, nil
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, len(data)/2))
+	buf.WriteByte(compressedValueV1)
+	compressor, isCompressor := compressors.Get().(*flate.Writer)
+	if !isCompressor {
+		return nil, errors.New("invalid compressor")
+	}
+	defer compressors.Put(compressor)
+	compressor.Reset(buf)
+	if _, err := compressor.Write(data); err != nil {
+		return nil, errors.Join(errors.New("failed to compress value"), err)
+	}
+	if err := compressor.Close(); err != nil {
+		return nil, errors.Join(errors.New("failed to compress value"), err)
+	}
+	if buf.Len() >= len(data) {
+		return data, nil
+	}
+	monitorMetadataCompressed(len(data), buf.Len())
+
+	return buf.Bytes(), nil
+}
+
+// decompressValue decompresses a value from the metadata database if it is compressed.
+func decompressValue(data []byte) ([]byte, error) {
+	if len(data) == 0 || data[0] != compressedValueV1 {
+		return data, nil
+	}
+
+	decompressor := flate.NewReader(bytes.NewReader(data[1:]))
+	defer decompressor.Close()
+	res, err := io.ReadAll(decompressor)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to decompress value"), err)
+	}
+
+	return res, nil
+}
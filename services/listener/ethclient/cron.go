@@ -0,0 +1,150 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a parsed five-field cron expression.
+type cronSchedule struct {
+	minutes uint64
+	hours   uint64
+	doms    uint64
+	months  uint64
+	dows    uint64
+	// domAny and dowAny note unrestricted day fields, as cron matches either day field if both are restricted.
+	domAny bool
+	dowAny bool
+}
+
+// parseCron parses a five-field cron expression.
+func parseCron(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q does not have five fields", expr)
+	}
+
+	schedule := &cronSchedule{
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}
+	var err error
+	if schedule.minutes, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, errors.Join(errors.New("invalid minute field"), err)
+	}
+	if schedule.hours, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, errors.Join(errors.New("invalid hour field"), err)
+	}
+	if schedule.doms, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, errors.Join(errors.New("invalid day of month field"), err)
+	}
+	if schedule.months, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, errors.Join(errors.New("invalid month field"), err)
+	}
+	if schedule.dows, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, errors.Join(errors.New("invalid day of week field"), err)
+	}
+	// Sunday can be 0 or 7.
+	if schedule.dows&(1<<7) != 0 {
+		schedule.dows |= 1
+	}
+
+	return schedule, nil
+}
+
+// parseCronField parses a single cron field in to a bitmap of permitted values.
+func parseCronField(field string, minVal int, maxVal int) (uint64, error) {
+	res := uint64(0)
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if rangePart, stepPart, found := strings.Cut(part, "/"); found {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+			part = rangePart
+		}
+
+		start, end := minVal, maxVal
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			startPart, endPart, _ := strings.Cut(part, "-")
+			var err error
+			if start, err = strconv.Atoi(startPart); err != nil {
+				return 0, fmt.Errorf("invalid value %q", startPart)
+			}
+			if end, err = strconv.Atoi(endPart); err != nil {
+				return 0, fmt.Errorf("invalid value %q", endPart)
+			}
+		default:
+			val, err := strconv.Atoi(part)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			start = val
+			if step == 1 {
+				end = val
+			}
+		}
+		if start < minVal || end > maxVal || start > end {
+			return 0, fmt.Errorf("value %q out of range %d-%d", part, minVal, maxVal)
+		}
+
+		for i := start; i <= end; i += step {
+			res |= 1 << uint(i)
+		}
+	}
+
+	return res, nil
+}
+
+// next returns the first time after t that matches the schedule.
+func (c *cronSchedule) next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	// Every valid schedule matches within a few years; the limit guards against impossible dates such as 30 February.
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case c.months&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case c.hours&(1<<uint(t.Hour())) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case c.minutes&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := c.doms&(1<<uint(t.Day())) != 0
+	dowMatch := c.dows&(1<<uint(t.Weekday())) != 0
+	if c.domAny || c.dowAny {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
@@ -0,0 +1,99 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/wealdtech/go-eth-listener/abi"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// newEventDecoders creates the registries with which to decode the events of each trigger, from
+// the ABIs supplied to the listener and those of the triggers themselves.
+func newEventDecoders(abis [][]byte, triggers []*handlers.EventTrigger) (map[*handlers.EventTrigger]*abi.Registry, error) {
+	registry, err := abi.NewRegistry(abis...)
+	if err != nil {
+		return nil, err
+	}
+
+	decoders := make(map[*handlers.EventTrigger]*abi.Registry)
+	for _, trigger := range triggers {
+		if len(trigger.ABI) == 0 {
+			if len(abis) > 0 {
+				decoders[trigger] = registry
+			}
+
+			continue
+		}
+		decoders[trigger], err = registry.Merge(trigger.ABI)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("invalid ABI for trigger %s", trigger.QualifiedName()), err)
+		}
+	}
+
+	return decoders, nil
+}
+
+// decodeEvent decodes an event with the ABIs for the trigger, returning nil if it cannot be decoded.
+func (s *Service) decodeEvent(trigger *handlers.EventTrigger, event *spec.BerlinTransactionEvent) *handlers.DecodedEvent {
+	s.triggersMu.RLock()
+	registry, exists := s.eventDecoders[trigger]
+	s.triggersMu.RUnlock()
+	if !exists {
+		if len(trigger.ABI) == 0 {
+			return nil
+		}
+		// The trigger is not registered with the listener, for example a replay.
+		var err error
+		registry, err = abi.NewRegistry(trigger.ABI)
+		if err != nil {
+			s.log.Debug().Str("trigger", trigger.QualifiedName()).Err(err).Msg("Invalid ABI for trigger")
+
+			return nil
+		}
+	}
+
+	definition, args, err := registry.Decode(event.Topics, event.Data)
+	if err != nil {
+		s.log.Trace().Stringer("tx", event.TransactionHash).Uint32("index", event.Index).Err(err).Msg("Event not decoded")
+
+		return nil
+	}
+
+	return &handlers.DecodedEvent{
+		Name:      definition.Name,
+		Signature: definition.Signature,
+		Args:      args,
+	}
+}
+
+// handleEvent passes an event to the trigger's handler, as a decoded event if it has been
+// decoded and the handler handles decoded events.
+func handleEvent(ctx context.Context,
+	trigger *handlers.EventTrigger,
+	event *spec.BerlinTransactionEvent,
+	decoded *handlers.DecodedEvent,
+) error {
+	if decoded != nil {
+		if handler, isHandler := trigger.Handler.(handlers.DecodedEventHandler); isHandler {
+			return handler.HandleDecodedEvent(ctx, event, decoded, trigger)
+		}
+	}
+
+	return trigger.Handler.HandleEvent(ctx, event, trigger)
+}
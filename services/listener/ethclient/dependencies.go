@@ -0,0 +1,114 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"fmt"
+	"math"
+)
+
+// noDependencyCap is the cap for triggers without dependencies.
+const noDependencyCap = int64(math.MaxInt64)
+
+// dependencyCap returns the highest block that a trigger with the given dependencies can
+// process, being the lowest progress of its dependencies, or -1 if any dependency has no progress.
+func (s *Service) dependencyCap(dependencies []string) int64 {
+	if len(dependencies) == 0 {
+		return noDependencyCap
+	}
+
+	s.progressMu.RLock()
+	defer s.progressMu.RUnlock()
+
+	limit := noDependencyCap
+	for _, dependency := range dependencies {
+		found := false
+		for _, triggerType := range []triggerType{blockTriggerType, txTriggerType, eventTriggerType} {
+			latest, exists := s.progress[progressKey{triggerType: triggerType, name: dependency}]
+			if !exists {
+				continue
+			}
+			found = true
+			if int64(latest) < limit {
+				limit = int64(latest)
+			}
+		}
+		if !found {
+			return -1
+		}
+	}
+
+	return limit
+}
+
+// checkTriggerDependencies checks that trigger dependencies refer to known triggers and do not form cycles.
+func checkTriggerDependencies(parameters *parameters) error {
+	dependencies := make(map[string][]string)
+	for _, trigger := range parameters.blockTriggers {
+		dependencies[trigger.QualifiedName()] = append(dependencies[trigger.QualifiedName()], trigger.DependsOn...)
+	}
+	for _, trigger := range parameters.txTriggers {
+		dependencies[trigger.QualifiedName()] = append(dependencies[trigger.QualifiedName()], trigger.DependsOn...)
+	}
+	for _, trigger := range parameters.compositeTriggers {
+		dependencies[trigger.QualifiedName()] = append(dependencies[trigger.QualifiedName()], trigger.DependsOn...)
+	}
+	for _, trigger := range parameters.eventTriggers {
+		dependencies[trigger.QualifiedName()] = append(dependencies[trigger.QualifiedName()], trigger.DependsOn...)
+	}
+	for _, trigger := range parameters.windowTriggers {
+		dependencies[trigger.QualifiedName()] = append(dependencies[trigger.QualifiedName()], trigger.DependsOn...)
+	}
+
+	for name, names := range dependencies {
+		for _, dependency := range names {
+			if _, exists := dependencies[dependency]; !exists {
+				return fmt.Errorf("trigger %s depends on unknown trigger %s", name, dependency)
+			}
+		}
+	}
+
+	// Depth-first search for cycles.
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("trigger %s has a circular dependency", name)
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, dependency := range dependencies[name] {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+
+		return nil
+	}
+	for name := range dependencies {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// discoverEarliestAvailableBlock finds the earliest block that the client can serve,
+// using a binary search between genesis and the chain head.
+// This assumes that the client can serve all blocks after the earliest available block.
+func (s *Service) discoverEarliestAvailableBlock(ctx context.Context) (uint64, error) {
+	head, err := s.chainHeight(ctx)
+	if err != nil {
+		return 0, errors.Join(errors.New("failed to obtain chain height"), err)
+	}
+	available, err := s.blockAvailable(ctx, head)
+	if err != nil {
+		return 0, err
+	}
+	if !available {
+		return 0, errors.New("chain head is unavailable")
+	}
+
+	available, err = s.blockAvailable(ctx, 0)
+	if err != nil {
+		return 0, err
+	}
+	if available {
+		return 0, nil
+	}
+
+	// Invariant: low is unavailable, high is available.
+	low := uint64(0)
+	high := head
+	for high-low > 1 {
+		mid := low + (high-low)/2
+		available, err := s.blockAvailable(ctx, mid)
+		if err != nil {
+			return 0, err
+		}
+		if available {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+
+	return high, nil
+}
+
+// blockAvailable returns true if the client can serve the given block.
+func (s *Service) blockAvailable(ctx context.Context, height uint64) (bool, error) {
+	block, err := s.blocksProvider.Block(ctx, fmt.Sprintf("%d", height))
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		s.log.Trace().Uint64("block", height).Err(err).Msg("Block unavailable")
+
+		return false, nil
+	}
+
+	return block != nil, nil
+}
+
+// applyEarliestAvailableBlock records the earliest available block and, if requested, clamps
+// trigger earliest blocks to it so that the listener does not request unavailable history.
+func (s *Service) applyEarliestAvailableBlock(earliest uint64, clamp bool) {
+	s.earliestAvailable.Store(int64(earliest))
+	s.log.Info().Uint64("earliest_available_block", earliest).Msg("Discovered earliest available block")
+	if !clamp || earliest == 0 {
+		return
+	}
+
+	if s.earliestBlock > -1 && s.earliestBlock < int64(earliest) {
+		s.log.Warn().Int64("earliest_block", s.earliestBlock).Uint64("earliest_available_block", earliest).Msg("Earliest block is unavailable; clamping")
+		s.earliestBlock = int64(earliest)
+	}
+	blockTriggers, txTriggers, eventTriggers := s.triggers()
+	for _, trigger := range blockTriggers {
+		if trigger.EarliestBlock < earliest {
+			s.log.Warn().Str("trigger", trigger.QualifiedName()).Uint64("earliest_block", trigger.EarliestBlock).Uint64("earliest_available_block", earliest).Msg("Block trigger earliest block is unavailable; clamping")
+			trigger.EarliestBlock = earliest
+		}
+	}
+	for _, trigger := range txTriggers {
+		if trigger.EarliestBlock < earliest {
+			s.log.Warn().Str("trigger", trigger.QualifiedName()).Uint64("earliest_block", trigger.EarliestBlock).Uint64("earliest_available_block", earliest).Msg("Transaction trigger earliest block is unavailable; clamping")
+			trigger.EarliestBlock = earliest
+		}
+	}
+	for _, trigger := range eventTriggers {
+		if trigger.EarliestBlock < earliest {
+			s.log.Warn().Str("trigger", trigger.QualifiedName()).Uint64("earliest_block", trigger.EarliestBlock).Uint64("earliest_available_block", earliest).Msg("Event trigger earliest block is unavailable; clamping")
+			trigger.EarliestBlock = earliest
+		}
+	}
+	s.clampToAvailable = true
+}
+
+// availableFrom returns the block from which to start fetching, taking in to account
+// the earliest available block if clamping is enabled.
+func (s *Service) availableFrom(from uint64) uint64 {
+	if !s.clampToAvailable {
+		return from
+	}
+	if earliest, known := s.earliestAvailableBlock(); known && from < earliest {
+		return earliest
+	}
+
+	return from
+}
@@ -0,0 +1,113 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+)
+
+// ValueCipher encrypts and decrypts values written to the metadata database.
+// Implementations can hold a key locally or delegate to a key management service.
+type ValueCipher interface {
+	// Encrypt encrypts a value.
+	Encrypt(plaintext []byte) ([]byte, error)
+	// Decrypt decrypts a value.
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// AESGCMCipher encrypts values with AES-GCM, using a random nonce for each value.
+type AESGCMCipher struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCMCipher creates a cipher with the given 16, 24 or 32 byte key.
+func NewAESGCMCipher(key []byte) (*AESGCMCipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Join(errors.New("invalid key"), err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to create AEAD"), err)
+	}
+
+	return &AESGCMCipher{
+		aead: aead,
+	}, nil
+}
+
+// Encrypt encrypts a value, prefixing the result with its nonce.
+func (c *AESGCMCipher) Encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize(), c.aead.NonceSize()+len(plaintext)+c.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Join(errors.New("failed to generate nonce"), err)
+	}
+
+	return c.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt decrypts a value.
+func (c *AESGCMCipher) Decrypt(ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < c.aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce := ciphertext[:c.aead.NonceSize()]
+
+	plaintext, err := c.aead.Open(nil, nonce, ciphertext[c.aead.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to decrypt"), err)
+	}
+
+	return plaintext, nil
+}
+
+// marshalValue marshals a value for the metadata database, compressing it if it is large and
+// encrypting it if a cipher is configured.
+func (s *Service) marshalValue(value any) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	data, err = s.compressValue(data)
+	if err != nil {
+		return nil, err
+	}
+	if s.metadataCipher == nil {
+		return data, nil
+	}
+
+	return s.metadataCipher.Encrypt(data)
+}
+
+// unmarshalValue unmarshals a value from the metadata database, decrypting it if a cipher is
+// configured and decompressing it if it was compressed.
+func (s *Service) unmarshalValue(data []byte, value any) error {
+	if s.metadataCipher != nil {
+		var err error
+		data, err = s.metadataCipher.Decrypt(data)
+		if err != nil {
+			return err
+		}
+	}
+	data, err := decompressValue(data)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, value)
+}
@@ -0,0 +1,140 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// txContext returns the context to pass to handlers for a transaction.
+func (s *Service) txContext(ctx context.Context, height uint64, tx *spec.Transaction) context.Context {
+	handlerCtx := s.handlerContext(ctx, height, txAddresses(tx)...)
+	if blockHash, index := tx.BlockHash(), tx.TransactionIndex(); blockHash != nil && index != nil {
+		handlerCtx = handlers.WithIdempotencyKey(handlerCtx, handlers.TxIdempotencyKey(s.chainID, *blockHash, *index))
+	}
+	if authorizations := s.setCodeAuthorizations(tx); authorizations != nil {
+		handlerCtx = handlers.WithSetCodeAuthorizations(handlerCtx, authorizations)
+	}
+	if s.signatureLookup != nil {
+		if input := tx.Input(); len(input) >= 4 {
+			if signatures := s.signatureLookup.FunctionSignatures([4]byte(input[:4])); len(signatures) > 0 {
+				s.log.Trace().Stringer("tx", tx.Hash()).Strs("signatures", signatures).Msg("Annotated transaction")
+				handlerCtx = handlers.WithSignatures(handlerCtx, signatures)
+			}
+		}
+	}
+
+	return handlerCtx
+}
+
+// eventContext returns the context to pass to handlers for an event.
+func (s *Service) eventContext(ctx context.Context, event *spec.BerlinTransactionEvent) context.Context {
+	handlerCtx := s.handlerContext(ctx, uint64(event.BlockNumber), eventAddresses(event)...)
+	handlerCtx = handlers.WithIdempotencyKey(handlerCtx, handlers.EventIdempotencyKey(s.chainID, event.BlockHash, event.TransactionIndex, event.Index))
+	if metadata := s.eventTokenMetadata(ctx, event.Address); metadata != nil {
+		handlerCtx = handlers.WithTokenMetadata(handlerCtx, metadata)
+	}
+	if s.signatureLookup != nil && len(event.Topics) > 0 {
+		if signatures := s.signatureLookup.EventSignatures(event.Topics[0]); len(signatures) > 0 {
+			s.log.Trace().Stringer("tx", event.TransactionHash).Uint32("index", event.Index).Strs("signatures", signatures).Msg("Annotated event")
+			handlerCtx = handlers.WithSignatures(handlerCtx, signatures)
+		}
+	}
+
+	return handlerCtx
+}
+
+// addressLabels returns labels for the given addresses, if an address labeler is configured.
+func (s *Service) addressLabels(ctx context.Context, addresses []types.Address) map[types.Address]*handlers.AddressLabel {
+	if s.addressLabeler == nil || len(addresses) == 0 {
+		return nil
+	}
+
+	labels := make(map[types.Address]*handlers.AddressLabel, len(addresses))
+	for _, address := range addresses {
+		if _, exists := labels[address]; exists {
+			continue
+		}
+		label, err := s.addressLabeler.LabelAddress(ctx, address)
+		if err != nil {
+			// Labels are informational, so failure to obtain one does not stop the item being handled.
+			s.log.Debug().Stringer("address", address).Err(err).Msg("Failed to label address")
+			continue
+		}
+		if label != nil {
+			labels[address] = label
+		}
+	}
+
+	return labels
+}
+
+// eventTokenMetadata returns the metadata for the token that emitted an event, if a token
+// metadata provider is configured and the address is a token.
+func (s *Service) eventTokenMetadata(ctx context.Context, address types.Address) *handlers.TokenMetadata {
+	if s.tokenMetadata == nil {
+		return nil
+	}
+
+	metadata, err := s.tokenMetadata.TokenMetadata(ctx, address)
+	if err != nil {
+		// Metadata is informational, so failure to obtain it does not stop the event being handled.
+		s.log.Debug().Stringer("address", address).Err(err).Msg("Failed to obtain token metadata")
+
+		return nil
+	}
+
+	return metadata
+}
+
+// txAddresses returns the addresses involved in a transaction.
+func txAddresses(tx *spec.Transaction) []types.Address {
+	addresses := []types.Address{tx.From()}
+	if to := tx.To(); to != nil {
+		addresses = append(addresses, *to)
+	}
+
+	return addresses
+}
+
+// eventAddresses returns the addresses involved in an event, being the address of the
+// event and any indexed parameters that hold addresses.
+func eventAddresses(event *spec.BerlinTransactionEvent) []types.Address {
+	addresses := []types.Address{event.Address}
+	for i := 1; i < len(event.Topics); i++ {
+		if address, isAddress := topicAddress(event.Topics[i]); isAddress {
+			addresses = append(addresses, address)
+		}
+	}
+
+	return addresses
+}
+
+// topicAddress returns the address in a topic, if the topic looks like an address.
+func topicAddress(topic types.Hash) (types.Address, bool) {
+	var address types.Address
+	padding := len(topic) - types.AddressLength
+	for i := 0; i < padding; i++ {
+		if topic[i] != 0 {
+			return address, false
+		}
+	}
+	copy(address[:], topic[padding:])
+
+	return address, !address.IsZero()
+}
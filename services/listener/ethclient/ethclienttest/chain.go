@@ -0,0 +1,266 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclienttest
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-execution-client/api"
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// genesis is the timestamp of the first block of a simulated chain.
+var genesis = time.Unix(1700000000, 0)
+
+// blockInterval is the time between blocks of a simulated chain.
+const blockInterval = 12 * time.Second
+
+// Chain is a simulated chain, providing the blocks and events that the listener requires.
+// It is safe for concurrent use.
+type Chain struct {
+	mu      sync.RWMutex
+	chainID uint64
+	blocks  []*spec.Block
+	events  map[uint32][]*spec.BerlinTransactionEvent
+	// forks is the number of reorgs, which distinguishes the hashes of replacement blocks.
+	forks uint32
+}
+
+// NewChain creates a simulated chain with blocks up to and including the given height.
+func NewChain(height uint32) *Chain {
+	c := &Chain{
+		chainID: 1,
+		events:  make(map[uint32][]*spec.BerlinTransactionEvent),
+	}
+	c.extend(height)
+
+	return c
+}
+
+// Advance adds the given number of blocks to the chain.
+func (c *Chain) Advance(blocks uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.extend(uint32(len(c.blocks)) - 1 + blocks)
+}
+
+// Reorg replaces the given number of most recent blocks with different blocks, removing their events.
+// The height of the chain is unchanged.
+func (c *Chain) Reorg(depth uint32) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	height := uint32(len(c.blocks)) - 1
+	if depth == 0 || depth > height {
+		return fmt.Errorf("invalid reorg depth %d", depth)
+	}
+
+	c.forks++
+	c.blocks = c.blocks[:height+1-depth]
+	for number := height + 1 - depth; number <= height; number++ {
+		delete(c.events, number)
+	}
+	c.extend(height)
+
+	return nil
+}
+
+// Height returns the height of the chain.
+func (c *Chain) Height() uint32 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return uint32(len(c.blocks)) - 1
+}
+
+// AddEvent adds an event emitted by the given address to the block at the given height.
+// The event is given the next index in the block, and its own transaction.
+func (c *Chain) AddEvent(height uint32,
+	address types.Address,
+	topics []types.Hash,
+	data []byte,
+) (
+	*spec.BerlinTransactionEvent,
+	error,
+) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if height >= uint32(len(c.blocks)) {
+		return nil, fmt.Errorf("block %d is beyond the chain height", height)
+	}
+
+	index := uint32(len(c.events[height]))
+	event := &spec.BerlinTransactionEvent{
+		Address:          address,
+		BlockHash:        c.blocks[height].Hash(),
+		BlockNumber:      height,
+		Data:             data,
+		Index:            index,
+		Topics:           topics,
+		TransactionHash:  itemHash(0x02, height, index),
+		TransactionIndex: index,
+	}
+	c.events[height] = append(c.events[height], event)
+
+	return event, nil
+}
+
+// ChainID returns the chain ID.
+func (c *Chain) ChainID(_ context.Context) (uint64, error) {
+	return c.chainID, nil
+}
+
+// ChainHeight returns the height of the chain.
+func (c *Chain) ChainHeight(_ context.Context) (uint32, error) {
+	return c.Height(), nil
+}
+
+// Block returns the block with the given ID, or nil if there is no such block.
+func (c *Chain) Block(_ context.Context, blockID string) (*spec.Block, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if strings.HasPrefix(blockID, "0x") && len(blockID) == 66 {
+		for _, block := range c.blocks {
+			if strings.EqualFold(block.Hash().String(), blockID) {
+				return block, nil
+			}
+		}
+
+		return nil, nil
+	}
+
+	height, err := c.height(blockID)
+	if err != nil {
+		return nil, err
+	}
+	if height >= uint32(len(c.blocks)) {
+		return nil, nil
+	}
+
+	return c.blocks[height], nil
+}
+
+// Events returns the events matching the filter.
+func (c *Chain) Events(_ context.Context, filter *api.EventsFilter) ([]*spec.BerlinTransactionEvent, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	from, err := c.height(filter.FromBlock)
+	if err != nil {
+		return nil, errors.Join(errors.New("invalid from block"), err)
+	}
+	to, err := c.height(filter.ToBlock)
+	if err != nil {
+		return nil, errors.Join(errors.New("invalid to block"), err)
+	}
+
+	res := make([]*spec.BerlinTransactionEvent, 0)
+	for height := from; height <= to && height < uint32(len(c.blocks)); height++ {
+		for _, event := range c.events[height] {
+			if filter.Address != nil && *filter.Address != event.Address {
+				continue
+			}
+			if !topicsMatch(filter.Topics, event.Topics) {
+				continue
+			}
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
+}
+
+// extend extends the chain to the given height.
+// This assumes that the lock is held, or that the chain is not yet shared.
+func (c *Chain) extend(height uint32) {
+	for number := uint32(len(c.blocks)); number <= height; number++ {
+		var parentHash types.Hash
+		if number > 0 {
+			parentHash = c.blocks[number-1].Hash()
+		}
+		c.blocks = append(c.blocks, &spec.Block{
+			Fork: spec.ForkShanghai,
+			Shanghai: &spec.ShanghaiBlock{
+				Hash:            itemHash(0x01, number, c.forks),
+				Number:          number,
+				ParentHash:      parentHash,
+				Timestamp:       genesis.Add(time.Duration(number) * blockInterval),
+				TotalDifficulty: big.NewInt(0),
+				Transactions:    []*spec.Transaction{},
+				Uncles:          []types.Hash{},
+				Withdrawals:     []*spec.Withdrawal{},
+			},
+		})
+	}
+}
+
+// height returns the height referred to by a block ID.
+// This assumes that the lock is held.
+func (c *Chain) height(blockID string) (uint32, error) {
+	switch blockID {
+	case "latest", "safe", "finalized", "pending":
+		return uint32(len(c.blocks)) - 1, nil
+	case "earliest":
+		return 0, nil
+	}
+
+	var height uint64
+	var err error
+	if strings.HasPrefix(blockID, "0x") {
+		height, err = strconv.ParseUint(blockID[2:], 16, 32)
+	} else {
+		height, err = strconv.ParseUint(blockID, 10, 32)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid block ID %q", blockID)
+	}
+
+	return uint32(height), nil
+}
+
+// itemHash returns a distinct hash for an item on the chain.
+func itemHash(kind byte, height uint32, index uint32) types.Hash {
+	var hash types.Hash
+	hash[0] = kind
+	binary.BigEndian.PutUint32(hash[24:28], height)
+	binary.BigEndian.PutUint32(hash[28:32], index)
+
+	return hash
+}
+
+// topicsMatch returns true if the topics of an event match those of a filter.
+func topicsMatch(filter []types.Hash, topics []types.Hash) bool {
+	if len(filter) > len(topics) {
+		return false
+	}
+	for i := range filter {
+		if filter[i] != topics[i] {
+			return false
+		}
+	}
+
+	return true
+}
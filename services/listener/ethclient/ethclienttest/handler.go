@@ -0,0 +1,161 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclienttest
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// ErrInjected is the error returned by a handler for an injected failure.
+var ErrInjected = errors.New("injected failure")
+
+// Delivery is a delivery of an item to a handler.
+type Delivery struct {
+	// Trigger is the qualified name of the trigger that delivered the item.
+	Trigger string
+	// Block is the number of the block, or of the block containing the event.
+	Block uint32
+	// Event is true if the item is an event.
+	Event bool
+	// Index is the index of the event in its block.
+	Index uint32
+	// Attempt is the number of times that the item has been delivered by the trigger, starting at 1.
+	Attempt int
+	// Failed is true if a failure was injected for the delivery.
+	Failed bool
+}
+
+// itemKey is the key of an item delivered to a handler.
+type itemKey struct {
+	block uint32
+	event bool
+	index uint32
+}
+
+// deliveryKey is the key of an item delivered by a trigger.
+type deliveryKey struct {
+	trigger string
+	item    itemKey
+}
+
+// Handler is a block and event handler that fails according to a script, recording each
+// delivery made to it.  Failures apply separately to each trigger using the handler.
+// It is safe for concurrent use.
+type Handler struct {
+	mu         sync.Mutex
+	failures   map[itemKey]int
+	attempts   map[deliveryKey]int
+	deliveries []*Delivery
+}
+
+// NewHandler creates a handler that succeeds until failures are added.
+func NewHandler() *Handler {
+	return &Handler{
+		failures: make(map[itemKey]int),
+		attempts: make(map[deliveryKey]int),
+	}
+}
+
+// FailBlock makes the handler fail the given number of times for the block at the given height,
+// after which it succeeds.
+func (h *Handler) FailBlock(height uint32, times int) *Handler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.failures[itemKey{block: height}] = times
+
+	return h
+}
+
+// FailEvent makes the handler fail the given number of times for the event with the given index
+// in the block at the given height, after which it succeeds.
+func (h *Handler) FailEvent(height uint32, index uint32, times int) *Handler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.failures[itemKey{block: height, event: true, index: index}] = times
+
+	return h
+}
+
+// HandleBlock handles a block.
+func (h *Handler) HandleBlock(_ context.Context, block *spec.Block, trigger *handlers.BlockTrigger) error {
+	return h.deliver(trigger.QualifiedName(), itemKey{block: block.Number()})
+}
+
+// HandleEvent handles an event.
+func (h *Handler) HandleEvent(_ context.Context, event *spec.BerlinTransactionEvent, trigger *handlers.EventTrigger) error {
+	return h.deliver(trigger.QualifiedName(), itemKey{block: event.BlockNumber, event: true, index: event.Index})
+}
+
+// Deliveries returns the deliveries made to the handler, in order.
+func (h *Handler) Deliveries() []*Delivery {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	res := make([]*Delivery, len(h.deliveries))
+	for i := range h.deliveries {
+		delivery := *h.deliveries[i]
+		res[i] = &delivery
+	}
+
+	return res
+}
+
+// BlockAttempts returns the number of times that the block at the given height has been
+// delivered by the given trigger.
+func (h *Handler) BlockAttempts(trigger string, height uint32) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.attempts[deliveryKey{trigger: trigger, item: itemKey{block: height}}]
+}
+
+// EventAttempts returns the number of times that the event with the given index in the block at
+// the given height has been delivered by the given trigger.
+func (h *Handler) EventAttempts(trigger string, height uint32, index uint32) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.attempts[deliveryKey{trigger: trigger, item: itemKey{block: height, event: true, index: index}}]
+}
+
+// deliver records the delivery of an item, returning an error if a failure is injected.
+func (h *Handler) deliver(trigger string, item itemKey) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := deliveryKey{trigger: trigger, item: item}
+	h.attempts[key]++
+	delivery := &Delivery{
+		Trigger: trigger,
+		Block:   item.block,
+		Event:   item.event,
+		Index:   item.index,
+		Attempt: h.attempts[key],
+		Failed:  h.attempts[key] <= h.failures[item],
+	}
+	h.deliveries = append(h.deliveries, delivery)
+
+	if delivery.Failed {
+		return ErrInjected
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclienttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/wealdtech/go-eth-listener/services/listener/ethclient"
+)
+
+// Harness runs a listener over a simulated chain, polling only when asked to, so that tests
+// can step it through a sequence of failures and check the resultant progress.
+// A trigger that failed is retried by polling again, whether or not the chain has advanced.
+type Harness struct {
+	chain    *Chain
+	listener *ethclient.Service
+	cancel   context.CancelFunc
+	tempDir  string
+}
+
+// New creates a new harness.
+func New(ctx context.Context, params ...Parameter) (*Harness, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	h := &Harness{
+		chain: parameters.chain,
+	}
+
+	path := parameters.metadataDBPath
+	if path == "" {
+		h.tempDir, err = os.MkdirTemp("", "ethclienttest")
+		if err != nil {
+			return nil, errors.Join(errors.New("failed to create metadata database directory"), err)
+		}
+		path = h.tempDir
+	}
+
+	listenerParams := append([]ethclient.Parameter{
+		ethclient.WithLogLevel(parameters.logLevel),
+		ethclient.WithClientLogLevel(parameters.logLevel),
+		ethclient.WithProvider(parameters.chain),
+		ethclient.WithMetadataDBPath(path),
+		ethclient.WithTimeout(time.Minute),
+		ethclient.WithInterval(0),
+	}, parameters.listenerParameters...)
+
+	ctx, h.cancel = context.WithCancel(ctx)
+	h.listener, err = ethclient.New(ctx, listenerParams...)
+	if err != nil {
+		h.cancel()
+		h.removeTempDir()
+
+		return nil, errors.Join(errors.New("failed to create listener"), err)
+	}
+
+	return h, nil
+}
+
+// Chain returns the simulated chain.
+func (h *Harness) Chain() *Chain {
+	return h.chain
+}
+
+// Listener returns the listener.
+func (h *Harness) Listener() *ethclient.Service {
+	return h.listener
+}
+
+// Poll carries out a single poll of the listener.
+func (h *Harness) Poll(ctx context.Context) error {
+	return h.listener.PollOnce(ctx)
+}
+
+// AdvanceAndPoll advances the chain by the given number of blocks and then polls the listener.
+func (h *Harness) AdvanceAndPoll(ctx context.Context, blocks uint32) error {
+	h.chain.Advance(blocks)
+
+	return h.Poll(ctx)
+}
+
+// Progress returns the progress of the listener's triggers.
+func (h *Harness) Progress(ctx context.Context) (*ethclient.Progress, error) {
+	return h.listener.Progress(ctx)
+}
+
+// CheckBlockProgress returns an error if the latest block processed by the block trigger with
+// the given qualified name is not as expected.  A latest block of -1 expects no progress.
+func (h *Harness) CheckBlockProgress(ctx context.Context, trigger string, latest int64) error {
+	progress, err := h.Progress(ctx)
+	if err != nil {
+		return err
+	}
+
+	actual, exists := progress.Blocks[trigger]
+	if !exists {
+		actual = -1
+	}
+	if actual != latest {
+		return fmt.Errorf("block trigger %q: expected latest block %d, found %d", trigger, latest, actual)
+	}
+
+	return nil
+}
+
+// CheckEventProgress returns an error if the progress of the event trigger with the given
+// qualified name is not as expected.
+func (h *Harness) CheckEventProgress(ctx context.Context, trigger string, nextBlock uint64, latestEventIndex int32) error {
+	progress, err := h.Progress(ctx)
+	if err != nil {
+		return err
+	}
+
+	actual, exists := progress.Events[trigger]
+	if !exists {
+		return fmt.Errorf("event trigger %q: no progress", trigger)
+	}
+	if actual.NextBlock != nextBlock || actual.LatestEventIndex != latestEventIndex {
+		return fmt.Errorf("event trigger %q: expected next block %d and latest event index %d, found %d and %d",
+			trigger, nextBlock, latestEventIndex, actual.NextBlock, actual.LatestEventIndex)
+	}
+
+	return nil
+}
+
+// Close stops the listener, and removes its metadata database if temporary.
+func (h *Harness) Close() error {
+	defer h.cancel()
+	if err := h.listener.Close(); err != nil {
+		return errors.Join(errors.New("failed to close listener"), err)
+	}
+
+	return h.removeTempDir()
+}
+
+// removeTempDir removes the temporary metadata database directory, if present.
+func (h *Harness) removeTempDir() error {
+	if h.tempDir == "" {
+		return nil
+	}
+	if err := os.RemoveAll(h.tempDir); err != nil {
+		return errors.Join(errors.New("failed to remove metadata database directory"), err)
+	}
+
+	return nil
+}
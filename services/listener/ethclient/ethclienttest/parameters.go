@@ -0,0 +1,87 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclienttest
+
+import (
+	"errors"
+
+	"github.com/rs/zerolog"
+	"github.com/wealdtech/go-eth-listener/services/listener/ethclient"
+)
+
+type parameters struct {
+	logLevel           zerolog.Level
+	chain              *Chain
+	metadataDBPath     string
+	listenerParameters []ethclient.Parameter
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the listener.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithChain sets the simulated chain over which the listener runs.
+func WithChain(chain *Chain) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chain = chain
+	})
+}
+
+// WithMetadataDBPath sets the path of the listener's metadata database.
+// If not supplied a temporary database is used, which is removed when the harness is closed.
+func WithMetadataDBPath(path string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.metadataDBPath = path
+	})
+}
+
+// WithListenerParameters sets additional parameters for the listener, such as its triggers.
+// These take precedence over the parameters set by the harness.
+func WithListenerParameters(params ...ethclient.Parameter) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.listenerParameters = append(p.listenerParameters, params...)
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.chain == nil {
+		return nil, errors.New("no chain specified")
+	}
+
+	return &parameters, nil
+}
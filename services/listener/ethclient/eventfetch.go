@@ -0,0 +1,117 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"sort"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// fetchEvents fetches the events for a trigger in the given range.  If the number of events is
+// limited then the range is narrowed until the events are within the limit or the range is a
+// single block, and the range used is remembered for the trigger's next fetch.
+// It returns the events along with the last block they cover.
+func (s *Service) fetchEvents(ctx context.Context,
+	trigger *handlers.EventTrigger,
+	source *types.Address,
+	fromBlock uint64,
+	toBlock uint64,
+) (
+	[]*spec.BerlinTransactionEvent,
+	uint64,
+	error,
+) {
+	if s.maxEventsPerFetch == 0 {
+		events, err := s.events(ctx, trigger, source, fromBlock, toBlock)
+
+		return events, toBlock, err
+	}
+
+	name := trigger.QualifiedName()
+	s.eventSpansMu.Lock()
+	span, exists := s.eventSpans[name]
+	s.eventSpansMu.Unlock()
+	if exists && toBlock-fromBlock >= span {
+		toBlock = fromBlock + span - 1
+	}
+
+	for {
+		events, err := s.events(ctx, trigger, source, fromBlock, toBlock)
+		if err != nil {
+			return nil, toBlock, err
+		}
+
+		span = toBlock - fromBlock + 1
+		if len(events) <= s.maxEventsPerFetch || span == 1 {
+			if len(events) <= s.maxEventsPerFetch/2 && span < s.maxBlocksForEvents {
+				// Few enough events that the next fetch can cover more blocks.
+				span = min(span*2, s.maxBlocksForEvents)
+			}
+			s.eventSpansMu.Lock()
+			s.eventSpans[name] = span
+			s.eventSpansMu.Unlock()
+
+			return events, toBlock, nil
+		}
+
+		s.log.Trace().
+			Str("trigger", name).
+			Uint64("from_block", fromBlock).
+			Uint64("to_block", toBlock).
+			Int("events", len(events)).
+			Msg("Too many events; narrowing range")
+		monitorEventsFetchNarrowed()
+		toBlock = fromBlock + span/2 - 1
+	}
+}
+
+// events obtains the events for a trigger in the given range.
+// The events filter takes a single address, so if the trigger has an address set but no source
+// then the events for each address in the set are obtained separately and merged, rather than
+// obtaining every event in the range and filtering them locally.
+func (s *Service) events(ctx context.Context,
+	trigger *handlers.EventTrigger,
+	source *types.Address,
+	fromBlock uint64,
+	toBlock uint64,
+) (
+	[]*spec.BerlinTransactionEvent,
+	error,
+) {
+	if source != nil || trigger.SourceSet == nil {
+		return s.eventsProvider.Events(ctx, eventsFilter(trigger, source, fromBlock, toBlock))
+	}
+
+	res := make([]*spec.BerlinTransactionEvent, 0)
+	for _, address := range trigger.SourceSet.Addresses() {
+		events, err := s.eventsProvider.Events(ctx, eventsFilter(trigger, &address, fromBlock, toBlock))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, events...)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].BlockNumber != res[j].BlockNumber {
+			return res[i].BlockNumber < res[j].BlockNumber
+		}
+
+		return res[i].Index < res[j].Index
+	})
+
+	return res, nil
+}
@@ -0,0 +1,246 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/cockroachdb/pebble"
+)
+
+var (
+	eventIndexEventsPrefix    = []byte("listener.ethclient.eventindex.events.")
+	eventIndexAddressesPrefix = []byte("listener.ethclient.eventindex.addresses.")
+	eventIndexTimesPrefix     = []byte("listener.ethclient.eventindex.times.")
+)
+
+// IndexedEvent is an event stored in the embedded event index.
+type IndexedEvent struct {
+	// Trigger is the name of the trigger that matched the event.
+	Trigger string `json:"trigger"`
+	// Event is the event.
+	Event *spec.BerlinTransactionEvent `json:"event"`
+	// Indexed is the time at which the event was indexed.
+	Indexed time.Time `json:"indexed"`
+}
+
+// eventPositionLength is the length of the position of an event.
+const eventPositionLength = 16
+
+// eventPosition returns the position of an event in the chain, used as the suffix of index keys.
+func eventPosition(event *spec.BerlinTransactionEvent) []byte {
+	position := make([]byte, eventPositionLength)
+	binary.BigEndian.PutUint64(position[0:8], uint64(event.BlockNumber))
+	binary.BigEndian.PutUint32(position[8:12], event.TransactionIndex)
+	binary.BigEndian.PutUint32(position[12:16], event.Index)
+
+	return position
+}
+
+// blockKey returns the key for the start of the given block under the given prefix.
+func blockKey(prefix []byte, height uint64) []byte {
+	key := make([]byte, len(prefix)+8)
+	copy(key, prefix)
+	binary.BigEndian.PutUint64(key[len(prefix):], height)
+
+	return key
+}
+
+// indexEvent writes an event matched by a trigger to the embedded event index.
+func (s *Service) indexEvent(_ context.Context, trigger string, event *spec.BerlinTransactionEvent) error {
+	s.metadataDBMu.Lock()
+	defer s.metadataDBMu.Unlock()
+	if !s.metadataDBOpen.Load() {
+		return errors.New("database closed")
+	}
+
+	indexed := &IndexedEvent{
+		Trigger: trigger,
+		Event:   event,
+		Indexed: time.Now(),
+	}
+	data, err := s.marshalValue(indexed)
+	if err != nil {
+		return errors.Join(errors.New("failed to marshal indexed event"), err)
+	}
+
+	position := eventPosition(event)
+	batch := s.metadataDB.NewBatch()
+	defer batch.Close()
+	// If the event is being re-indexed then its entry in the time index is replaced.
+	existing, err := s.indexedEvent(position)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		if err := batch.Delete(eventIndexTimeKey(existing.Indexed, position), nil); err != nil {
+			return errors.Join(errors.New("failed to remove previous index time"), err)
+		}
+	}
+	if err := batch.Set(eventIndexEventKey(position), data, nil); err != nil {
+		return errors.Join(errors.New("failed to index event"), err)
+	}
+	if err := batch.Set(eventIndexAddressKey(event.Address, position), nil, nil); err != nil {
+		return errors.Join(errors.New("failed to index event address"), err)
+	}
+	if err := batch.Set(eventIndexTimeKey(indexed.Indexed, position), nil, nil); err != nil {
+		return errors.Join(errors.New("failed to index event time"), err)
+	}
+	started := time.Now()
+	err = batch.Commit(pebble.Sync)
+	monitorMetadataDBOperation("write", time.Since(started))
+	if err != nil {
+		return errors.Join(errors.New("failed to commit indexed event"), err)
+	}
+
+	return nil
+}
+
+// EventsInRange returns the indexed events between the given blocks inclusive, in chain order.
+// It requires the event index to be enabled with WithEventIndex().
+func (s *Service) EventsInRange(_ context.Context, from uint64, to uint64) ([]*IndexedEvent, error) {
+	if !s.eventIndex {
+		return nil, errors.New("event index not enabled")
+	}
+	if from > to {
+		return nil, errors.New("from block after to block")
+	}
+
+	s.metadataDBMu.Lock()
+	defer s.metadataDBMu.Unlock()
+	if !s.metadataDBOpen.Load() {
+		return nil, errors.New("database closed")
+	}
+
+	upperBound := blockKey(eventIndexEventsPrefix, to+1)
+	if to == maxUint64 {
+		upperBound = prefixEnd(eventIndexEventsPrefix)
+	}
+	iter, err := s.metadataDB.NewIter(&pebble.IterOptions{
+		LowerBound: blockKey(eventIndexEventsPrefix, from),
+		UpperBound: upperBound,
+	})
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to create iterator"), err)
+	}
+	defer iter.Close()
+
+	res := make([]*IndexedEvent, 0)
+	for iter.First(); iter.Valid(); iter.Next() {
+		event := &IndexedEvent{}
+		if err := s.unmarshalValue(iter.Value(), event); err != nil {
+			return nil, errors.Join(errors.New("failed to unmarshal indexed event"), err)
+		}
+		res = append(res, event)
+	}
+
+	return res, iter.Error()
+}
+
+// EventsByAddress returns the indexed events emitted by the given address, in chain order.
+// It requires the event index to be enabled with WithEventIndex().
+func (s *Service) EventsByAddress(_ context.Context, address types.Address) ([]*IndexedEvent, error) {
+	if !s.eventIndex {
+		return nil, errors.New("event index not enabled")
+	}
+
+	s.metadataDBMu.Lock()
+	defer s.metadataDBMu.Unlock()
+	if !s.metadataDBOpen.Load() {
+		return nil, errors.New("database closed")
+	}
+
+	prefix := eventIndexAddressKey(address, nil)
+	iter, err := s.metadataDB.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: prefixEnd(prefix),
+	})
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to create iterator"), err)
+	}
+	defer iter.Close()
+
+	res := make([]*IndexedEvent, 0)
+	for iter.First(); iter.Valid(); iter.Next() {
+		event, err := s.indexedEvent(iter.Key()[len(prefix):])
+		if err != nil {
+			return nil, err
+		}
+		if event == nil {
+			// The event has been removed from the index.
+			continue
+		}
+		res = append(res, event)
+	}
+
+	return res, iter.Error()
+}
+
+// indexedEvent returns the indexed event at the given position, or nil if there is no such event.
+// The metadata database lock must be held by the caller.
+func (s *Service) indexedEvent(position []byte) (*IndexedEvent, error) {
+	data, closer, err := s.metadataDB.Get(eventIndexEventKey(position))
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, nil
+		}
+
+		return nil, errors.Join(errors.New("failed to get indexed event"), err)
+	}
+	defer closer.Close()
+
+	event := &IndexedEvent{}
+	if err := s.unmarshalValue(data, event); err != nil {
+		return nil, errors.Join(errors.New("failed to unmarshal indexed event"), err)
+	}
+
+	return event, nil
+}
+
+// eventIndexEventKey returns the key of the event at the given position.
+func eventIndexEventKey(position []byte) []byte {
+	return append(append([]byte{}, eventIndexEventsPrefix...), position...)
+}
+
+// eventIndexAddressKey returns the key of the address index entry for the event at the given position.
+func eventIndexAddressKey(address types.Address, position []byte) []byte {
+	return append(append(append([]byte{}, eventIndexAddressesPrefix...), address[:]...), position...)
+}
+
+// eventIndexTimeKey returns the key of the time index entry for the event at the given position.
+func eventIndexTimeKey(indexed time.Time, position []byte) []byte {
+	key := make([]byte, len(eventIndexTimesPrefix)+8, len(eventIndexTimesPrefix)+8+len(position))
+	copy(key, eventIndexTimesPrefix)
+	binary.BigEndian.PutUint64(key[len(eventIndexTimesPrefix):], uint64(indexed.Unix()))
+
+	return append(key, position...)
+}
+
+// prefixEnd returns the smallest key that is greater than all keys with the given prefix.
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+
+	return nil
+}
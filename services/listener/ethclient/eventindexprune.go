@@ -0,0 +1,181 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+)
+
+// maxPruneBatch is the maximum number of events removed from the index in a single batch.
+const maxPruneBatch = 1000
+
+// eventIndexPruner periodically removes events from the index that are outside the retention policy.
+func (s *Service) eventIndexPruner(ctx context.Context) {
+	ticker := time.NewTicker(s.eventIndexPrune)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopping:
+			return
+		case <-ticker.C:
+			pruned, err := s.pruneEventIndex(ctx)
+			if err != nil {
+				s.log.Warn().Err(err).Msg("Failed to prune event index")
+
+				continue
+			}
+			if pruned > 0 {
+				s.log.Debug().Int("pruned", pruned).Msg("Pruned event index")
+			}
+		}
+	}
+}
+
+// pruneEventIndex removes events from the index that are outside the retention policy,
+// returning the number of events removed.
+func (s *Service) pruneEventIndex(ctx context.Context) (int, error) {
+	total := 0
+
+	if s.eventIndexBlocks > 0 {
+		chainHead := s.chainHead.Load()
+		if chainHead > s.eventIndexBlocks {
+			pruned, err := s.pruneEventIndexRange(ctx,
+				eventIndexEventsPrefix,
+				blockKey(eventIndexEventsPrefix, chainHead-s.eventIndexBlocks),
+			)
+			total += pruned
+			if err != nil {
+				return total, err
+			}
+		}
+	}
+
+	if s.eventIndexPeriod > 0 {
+		pruned, err := s.pruneEventIndexRange(ctx,
+			eventIndexTimesPrefix,
+			eventIndexTimeKey(time.Now().Add(-s.eventIndexPeriod), nil),
+		)
+		total += pruned
+		if err != nil {
+			return total, err
+		}
+	}
+
+	if total > 0 {
+		if err := s.compactEventIndex(); err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+// pruneEventIndexRange removes the events whose keys under the given prefix are before the given key.
+func (s *Service) pruneEventIndexRange(ctx context.Context, prefix []byte, before []byte) (int, error) {
+	total := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		pruned, err := s.pruneEventIndexBatch(prefix, before)
+		total += pruned
+		if err != nil {
+			return total, err
+		}
+		if pruned < maxPruneBatch {
+			return total, nil
+		}
+	}
+}
+
+// pruneEventIndexBatch removes up to maxPruneBatch events whose keys under the given prefix
+// are before the given key.
+func (s *Service) pruneEventIndexBatch(prefix []byte, before []byte) (int, error) {
+	s.metadataDBMu.Lock()
+	defer s.metadataDBMu.Unlock()
+	if !s.metadataDBOpen.Load() {
+		return 0, errors.New("database closed")
+	}
+
+	iter, err := s.metadataDB.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: before,
+	})
+	if err != nil {
+		return 0, errors.Join(errors.New("failed to create iterator"), err)
+	}
+	defer iter.Close()
+
+	batch := s.metadataDB.NewBatch()
+	defer batch.Close()
+	pruned := 0
+	for iter.First(); iter.Valid() && pruned < maxPruneBatch; iter.Next() {
+		// Both the events and the time index have the position of the event at the end of their keys.
+		key := iter.Key()
+		position := key[len(key)-eventPositionLength:]
+		event, err := s.indexedEvent(position)
+		if err != nil {
+			return 0, err
+		}
+		if err := batch.Delete(key, nil); err != nil {
+			return 0, errors.Join(errors.New("failed to remove index entry"), err)
+		}
+		if event != nil {
+			for _, key := range [][]byte{
+				eventIndexEventKey(position),
+				eventIndexAddressKey(event.Event.Address, position),
+				eventIndexTimeKey(event.Indexed, position),
+			} {
+				if err := batch.Delete(key, nil); err != nil {
+					return 0, errors.Join(errors.New("failed to remove indexed event"), err)
+				}
+			}
+		}
+		pruned++
+	}
+	if err := iter.Error(); err != nil {
+		return 0, errors.Join(errors.New("failed to iterate over index"), err)
+	}
+	if pruned == 0 {
+		return 0, nil
+	}
+
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return 0, errors.Join(errors.New("failed to commit pruned events"), err)
+	}
+
+	return pruned, nil
+}
+
+// compactEventIndex compacts the event index, reclaiming the space used by pruned events.
+func (s *Service) compactEventIndex() error {
+	s.metadataDBMu.Lock()
+	defer s.metadataDBMu.Unlock()
+	if !s.metadataDBOpen.Load() {
+		return errors.New("database closed")
+	}
+
+	for _, prefix := range [][]byte{eventIndexEventsPrefix, eventIndexAddressesPrefix, eventIndexTimesPrefix} {
+		if err := s.metadataDB.Compact(prefix, prefixEnd(prefix), true); err != nil {
+			return errors.Join(errors.New("failed to compact event index"), err)
+		}
+	}
+
+	return nil
+}
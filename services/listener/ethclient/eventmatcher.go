@@ -0,0 +1,133 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// eventMatcher matches events against the criteria of an event trigger.  It is compiled once
+// for each trigger, so that the checks for each event compare fixed-size values in place and
+// skip criteria that cannot reject the event.
+type eventMatcher struct {
+	// source is the static source, if it is not applied by the events filter.
+	source *types.Address
+	// topics are the static topics, if they are not applied by the events filter.
+	topics    []types.Hash
+	sourceSet handlers.AddressSet
+	topicSets []positionalTopicSet
+	// minTopics is the number of topics an event requires to be able to match.
+	minTopics int
+}
+
+// positionalTopicSet is a topic set along with the position of the topic it checks.
+type positionalTopicSet struct {
+	position int
+	set      handlers.TopicSet
+}
+
+// maxEventTopics is the maximum number of topics that an event can have.
+const maxEventTopics = 4
+
+// checkEventTopics checks that the topic filters of the trigger are able to match an event.
+func checkEventTopics(trigger *handlers.EventTrigger) error {
+	if len(trigger.Topics) > maxEventTopics {
+		return fmt.Errorf("%d topics specified but events have at most %d", len(trigger.Topics), maxEventTopics)
+	}
+	if len(trigger.TopicSets) > maxEventTopics {
+		return fmt.Errorf("%d topic sets specified but events have at most %d topics", len(trigger.TopicSets), maxEventTopics)
+	}
+
+	return nil
+}
+
+// newEventMatcher compiles a matcher for the trigger.  If the events to be matched have
+// already been filtered by the trigger's source and topics then they are not checked again.
+func newEventMatcher(trigger *handlers.EventTrigger, filtered bool) *eventMatcher {
+	m := &eventMatcher{
+		sourceSet: trigger.SourceSet,
+		topicSets: make([]positionalTopicSet, 0, len(trigger.TopicSets)),
+	}
+	if !filtered {
+		m.source = trigger.Source
+		m.topics = trigger.Topics
+		m.minTopics = len(trigger.Topics)
+	}
+	for i, topicSet := range trigger.TopicSets {
+		if topicSet == nil {
+			continue
+		}
+		m.topicSets = append(m.topicSets, positionalTopicSet{
+			position: i,
+			set:      topicSet,
+		})
+		if i+1 > m.minTopics {
+			m.minTopics = i + 1
+		}
+	}
+
+	return m
+}
+
+// matches returns true if the event matches the trigger.
+func (m *eventMatcher) matches(event *spec.BerlinTransactionEvent) bool {
+	if len(event.Topics) < m.minTopics {
+		return false
+	}
+	if m.source != nil && *m.source != event.Address {
+		return false
+	}
+	for i := range m.topics {
+		if m.topics[i] != event.Topics[i] {
+			return false
+		}
+	}
+	if m.sourceSet != nil && !m.sourceSet.Contains(event.Address) {
+		return false
+	}
+	for i := range m.topicSets {
+		if !m.topicSets[i].set.ContainsTopic(event.Topics[m.topicSets[i].position]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newEventMatchers compiles matchers for the triggers, whose events are obtained with the events filter.
+func newEventMatchers(triggers []*handlers.EventTrigger) map[*handlers.EventTrigger]*eventMatcher {
+	matchers := make(map[*handlers.EventTrigger]*eventMatcher, len(triggers))
+	for _, trigger := range triggers {
+		matchers[trigger] = newEventMatcher(trigger, true)
+	}
+
+	return matchers
+}
+
+// eventMatcherFor returns the matcher for a trigger whose events are obtained with the events filter.
+func (s *Service) eventMatcherFor(trigger *handlers.EventTrigger) *eventMatcher {
+	s.triggersMu.RLock()
+	matcher, exists := s.eventMatchers[trigger]
+	s.triggersMu.RUnlock()
+	if exists {
+		return matcher
+	}
+
+	// The trigger is not registered with the listener, for example a replay.
+	return newEventMatcher(trigger, true)
+}
@@ -0,0 +1,164 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	execclient "github.com/attestantio/go-execution-client"
+	"github.com/attestantio/go-execution-client/api"
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	"github.com/wealdtech/go-eth-listener/services/failover"
+	"github.com/wealdtech/go-eth-listener/services/quorum"
+)
+
+// connectFailoverProvider connects to the primary and secondary Ethereum clients, returning a
+// provider that fails over between them.
+func connectFailoverProvider(ctx context.Context, log zerolog.Logger, parameters *parameters) (quorum.Provider, error) {
+	addresses := append([]string{parameters.address}, parameters.addresses...)
+	providers := make([]failover.Provider, len(addresses))
+	for i, address := range addresses {
+		provider, err := connectProvider(ctx, parameters, address)
+		if err != nil {
+			// The client may be temporarily unavailable, so connect when it is next required.
+			log.Warn().Int("provider", i).Err(err).Msg("Failed to connect to Ethereum client; will retry when required")
+			providers[i] = newLazyProvider(ctx, parameters, address)
+
+			continue
+		}
+		providers[i] = provider
+	}
+
+	provider, err := failover.New(ctx,
+		failover.WithLogLevel(parameters.logLevel),
+		failover.WithMonitor(parameters.monitor),
+		failover.WithProviders(providers),
+	)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to create failover provider"), err)
+	}
+
+	return provider, nil
+}
+
+// lazyProvider is a provider that connects to its Ethereum client when it is first used.
+type lazyProvider struct {
+	ctx        context.Context
+	parameters *parameters
+	address    string
+	mu         sync.Mutex
+	provider   quorum.Provider
+}
+
+func newLazyProvider(ctx context.Context, parameters *parameters, address string) *lazyProvider {
+	return &lazyProvider{
+		ctx:        ctx,
+		parameters: parameters,
+		address:    address,
+	}
+}
+
+// connect returns the underlying provider, connecting to the client if not already connected.
+func (p *lazyProvider) connect() (quorum.Provider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.provider == nil {
+		// The connection is tied to the lifetime of the service rather than that of the request.
+		provider, err := connectProvider(p.ctx, p.parameters, p.address)
+		if err != nil {
+			return nil, err
+		}
+		p.provider = provider
+	}
+
+	return p.provider, nil
+}
+
+// ChainHeight returns the height of the chain.
+func (p *lazyProvider) ChainHeight(ctx context.Context) (uint32, error) {
+	provider, err := p.connect()
+	if err != nil {
+		return 0, err
+	}
+
+	return provider.ChainHeight(ctx)
+}
+
+// ChainID returns the chain ID.
+func (p *lazyProvider) ChainID(ctx context.Context) (uint64, error) {
+	provider, err := p.connect()
+	if err != nil {
+		return 0, err
+	}
+	chainIDProvider, isProvider := provider.(execclient.ChainIDProvider)
+	if !isProvider {
+		return 0, errors.New("client does not provide chain ID")
+	}
+
+	return chainIDProvider.ChainID(ctx)
+}
+
+// Block returns the block with the given ID.
+func (p *lazyProvider) Block(ctx context.Context, blockID string) (*spec.Block, error) {
+	provider, err := p.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.Block(ctx, blockID)
+}
+
+// RawBlock returns the JSON of the block with the given ID.
+func (p *lazyProvider) RawBlock(ctx context.Context, blockID string) (json.RawMessage, error) {
+	provider, err := p.connect()
+	if err != nil {
+		return nil, err
+	}
+	rawBlocksProvider, isProvider := provider.(RawBlocksProvider)
+	if !isProvider {
+		return nil, errors.New("client does not provide raw blocks")
+	}
+
+	return rawBlocksProvider.RawBlock(ctx, blockID)
+}
+
+// Events returns the events matching the filter.
+func (p *lazyProvider) Events(ctx context.Context, filter *api.EventsFilter) ([]*spec.BerlinTransactionEvent, error) {
+	provider, err := p.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.Events(ctx, filter)
+}
+
+// TransactionReceipt returns the transaction receipt for the given transaction hash.
+func (p *lazyProvider) TransactionReceipt(ctx context.Context, hash types.Hash) (*spec.TransactionReceipt, error) {
+	provider, err := p.connect()
+	if err != nil {
+		return nil, err
+	}
+	receiptsProvider, isProvider := provider.(execclient.TransactionReceiptsProvider)
+	if !isProvider {
+		return nil, errors.New("client does not provide transaction receipts")
+	}
+
+	return receiptsProvider.TransactionReceipt(ctx, hash)
+}
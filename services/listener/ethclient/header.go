@@ -0,0 +1,59 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// BlockHeader is the header of a block.
+type BlockHeader struct {
+	// Number is the number of the block.
+	Number uint64
+	// Hash is the hash of the block.
+	Hash types.Hash
+	// ParentHash is the hash of the parent of the block.
+	ParentHash types.Hash
+	// Timestamp is the timestamp of the block.
+	Timestamp time.Time
+}
+
+// LatestProcessedHeader returns the header of the most recent block fully processed by all triggers,
+// or nil if no poll has yet succeeded.
+func (s *Service) LatestProcessedHeader() *BlockHeader {
+	return s.latestHeader.Load()
+}
+
+// recordLatestProcessedHeader records the header of the block fully processed by a poll.
+// The block is generally in the poll's cache, so this rarely requires a call to the client.
+func (s *Service) recordLatestProcessedHeader(ctx context.Context, height uint64) {
+	s.confirmPreviousHeader(ctx, height)
+
+	block, err := s.block(ctx, height)
+	if err != nil {
+		s.log.Debug().Uint64("height", height).Err(err).Msg("Failed to obtain latest processed block header")
+
+		return
+	}
+
+	s.latestHeader.Store(&BlockHeader{
+		Number:     uint64(block.Number()),
+		Hash:       block.Hash(),
+		ParentHash: block.ParentHash(),
+		Timestamp:  block.Timestamp(),
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrHistoryUnavailable is returned when the Ethereum client cannot serve the requested
+// historical blocks or events, for example because it is not an archive node or has
+// expired its history.
+type ErrHistoryUnavailable struct {
+	// Block is the block that was requested.
+	Block uint64
+	// EarliestAvailableBlock is the earliest block that the client can serve, if known.
+	EarliestAvailableBlock *uint64
+	// Err is the error returned by the client.
+	Err error
+}
+
+// Error implements error.
+func (e *ErrHistoryUnavailable) Error() string {
+	if e.EarliestAvailableBlock != nil {
+		return fmt.Sprintf("history unavailable for block %d; earliest available block is %d", e.Block, *e.EarliestAvailableBlock)
+	}
+
+	return fmt.Sprintf("history unavailable for block %d", e.Block)
+}
+
+// Unwrap returns the error returned by the client.
+func (e *ErrHistoryUnavailable) Unwrap() error {
+	return e.Err
+}
+
+// historyUnavailablePatterns are fragments of the errors returned by clients when history is unavailable.
+var historyUnavailablePatterns = []string{
+	"pruned history unavailable",
+	"history has been pruned",
+	"historical state not available",
+	"history not available",
+	"missing trie node",
+	"header not found",
+	"block not found",
+	"requested data is no longer available",
+	"no longer available",
+	"before the earliest",
+	"older than",
+}
+
+// earliestBlockPattern extracts the earliest available block from client errors that include it.
+var earliestBlockPattern = regexp.MustCompile(`(?i)(?:earliest|oldest|first available)[^0-9]{0,32}(0x[0-9a-f]+|[0-9]+)`)
+
+// historyError returns an ErrHistoryUnavailable if the error from the client indicates that
+// history is unavailable, otherwise the original error.
+func (s *Service) historyError(err error, block uint64) error {
+	if err == nil {
+		return nil
+	}
+
+	msg := strings.ToLower(err.Error())
+	matched := false
+	for _, pattern := range historyUnavailablePatterns {
+		if strings.Contains(msg, pattern) {
+			matched = true
+
+			break
+		}
+	}
+	if !matched {
+		return err
+	}
+
+	historyErr := &ErrHistoryUnavailable{
+		Block: block,
+		Err:   err,
+	}
+	if match := earliestBlockPattern.FindStringSubmatch(msg); match != nil {
+		if earliest, err := strconv.ParseUint(match[1], 0, 32); err == nil {
+			earliestBlock := uint64(earliest)
+			historyErr.EarliestAvailableBlock = &earliestBlock
+		}
+	}
+	if historyErr.EarliestAvailableBlock == nil {
+		if earliest, known := s.earliestAvailableBlock(); known {
+			historyErr.EarliestAvailableBlock = &earliest
+		}
+	}
+
+	return historyErr
+}
+
+// earliestAvailableBlock returns the earliest block known to be available from the client.
+func (s *Service) earliestAvailableBlock() (uint64, bool) {
+	earliest := s.earliestAvailable.Load()
+	if earliest < 0 {
+		return 0, false
+	}
+
+	return uint64(earliest), true
+}
+
+// logPollError logs an error from a poll.  History errors are logged once, as they
+// will recur on every poll until the configuration is changed.
+func (s *Service) logPollError(err error, msg string) {
+	var historyErr *ErrHistoryUnavailable
+	if errors.As(err, &historyErr) {
+		if s.historyLogged.CompareAndSwap(false, true) {
+			s.log.Error().Err(err).Msg("Ethereum client cannot serve requested history; configure an earlier-starting client or a later earliest block")
+		}
+		monitorFailure()
+
+		return
+	}
+	s.logSampledError(ErrorClassPoll, err, msg)
+	monitorFailure()
+}
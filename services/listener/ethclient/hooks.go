@@ -0,0 +1,48 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+)
+
+// HandlerContextDecorator returns a context derived from the supplied context, for example
+// with additional request-scoped values.  It is called for every handler invocation.
+type HandlerContextDecorator func(ctx context.Context) context.Context
+
+// PollInfo contains information about a poll.
+type PollInfo struct {
+	// From is the first block that is new in this poll, being one higher than the highest block of the previous poll.
+	From uint64
+	// To is the highest block of this poll.
+	To uint64
+	// ChainHead is the chain head at the time of this poll.
+	ChainHead uint64
+	// Stats are the statistics for the poll.  They are only set for post-poll hooks.
+	Stats *PollStats
+	// Err is the outcome of the poll.  It is only set for post-poll hooks.
+	Err error
+	// Skipped is true if the poll found nothing new to process.  It is only set for post-poll hooks.
+	Skipped bool
+}
+
+// PollHook is a function that is called before or after a poll.
+type PollHook func(ctx context.Context, info *PollInfo)
+
+// runPollHooks runs the supplied hooks.
+func runPollHooks(ctx context.Context, hooks []PollHook, info *PollInfo) {
+	for _, hook := range hooks {
+		hook(ctx, info)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+
+	execclient "github.com/attestantio/go-execution-client"
+)
+
+// obtainChainID returns the configured chain ID, or obtains it from the provider if not configured.
+func obtainChainID(ctx context.Context, chainID uint64, provider execclient.ChainHeightProvider) (uint64, error) {
+	if chainID != 0 {
+		return chainID, nil
+	}
+
+	chainIDProvider, isProvider := provider.(execclient.ChainIDProvider)
+	if !isProvider {
+		return 0, errors.New("client does not provide chain ID; it must be configured")
+	}
+	chainID, err := chainIDProvider.ChainID(ctx)
+	if err != nil {
+		return 0, errors.Join(errors.New("failed to obtain chain ID"), err)
+	}
+
+	return chainID, nil
+}
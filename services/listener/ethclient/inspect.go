@@ -0,0 +1,228 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/rs/zerolog"
+)
+
+// Progress is the progress of triggers as stored in a metadata database.
+type Progress struct {
+	// Blocks is the latest block processed by each block trigger.
+	Blocks map[string]int64 `json:"blocks"`
+	// Transactions is the latest block processed by each transaction trigger.
+	Transactions map[string]int64 `json:"transactions"`
+	// Events is the progress of each event trigger.
+	Events map[string]*EventProgress `json:"events"`
+}
+
+// EventProgress is the progress of an event trigger.
+type EventProgress struct {
+	// NextBlock is the block from which events will next be fetched.
+	NextBlock uint64 `json:"next_block"`
+	// LatestEventIndex is the index of the latest event processed in the next block, or -1 if none.
+	LatestEventIndex int32 `json:"latest_event_index"`
+}
+
+// ReadProgress reads the progress of triggers from the metadata database at the given path.
+// The cipher is required if the database is encrypted.
+// The database must not be in use by a running listener.
+func ReadProgress(ctx context.Context, path string, cipher ValueCipher) (*Progress, error) {
+	s, err := openInspection(path, cipher, true)
+	if err != nil {
+		return nil, err
+	}
+	defer s.closeMetadataDB()
+
+	return s.Progress(ctx)
+}
+
+// Progress returns the progress of triggers as currently stored by the listener.
+func (s *Service) Progress(ctx context.Context) (*Progress, error) {
+	blocksMD, err := s.getBlocksMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	txsMD, err := s.getTransactionsMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	eventsMD, err := s.getEventsMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &Progress{
+		Blocks:       blocksMD.LatestBlocks,
+		Transactions: txsMD.LatestBlocks,
+		Events:       make(map[string]*EventProgress, len(eventsMD.Entries)),
+	}
+	for name, entry := range eventsMD.Entries {
+		progress.Events[name] = &EventProgress{
+			NextBlock:        entry.LatestBlock,
+			LatestEventIndex: entry.LatestEventIndex,
+		}
+	}
+
+	return progress, nil
+}
+
+// WriteProgress writes the progress of triggers to the metadata database at the given path,
+// replacing the existing progress.
+// The cipher is required if the database is encrypted.
+// The database must not be in use by a running listener.
+// The changes are recorded in the audit log against the actor in the context, if any.
+func WriteProgress(ctx context.Context, path string, progress *Progress, cipher ValueCipher) error {
+	if progress == nil {
+		return errors.New("no progress supplied")
+	}
+
+	s, err := openInspection(path, cipher, false)
+	if err != nil {
+		return err
+	}
+	defer s.closeMetadataDB()
+
+	previous, err := s.Progress(ctx)
+	if err != nil {
+		return err
+	}
+
+	blocksMD := &blocksMetadata{
+		LatestBlocks: progress.Blocks,
+	}
+	if blocksMD.LatestBlocks == nil {
+		blocksMD.LatestBlocks = map[string]int64{}
+	}
+	if err := s.setBlocksMetadata(ctx, blocksMD); err != nil {
+		return err
+	}
+
+	txsMD := &transactionsMetadata{
+		LatestBlocks: progress.Transactions,
+	}
+	if txsMD.LatestBlocks == nil {
+		txsMD.LatestBlocks = map[string]int64{}
+	}
+	if err := s.setTransactionsMetadata(ctx, txsMD); err != nil {
+		return err
+	}
+
+	eventsMD := &eventsMetadata{
+		Entries: make(map[string]*eventsEntryMetadata, len(progress.Events)),
+	}
+	for name, entry := range progress.Events {
+		eventsMD.Entries[name] = &eventsEntryMetadata{
+			LatestBlock:      entry.NextBlock,
+			LatestEventIndex: entry.LatestEventIndex,
+		}
+	}
+
+	if err := s.setEventsMetadata(ctx, eventsMD); err != nil {
+		return err
+	}
+
+	return s.writeAuditEntry(&AuditEntry{
+		Time:    time.Now().UTC(),
+		Actor:   ActorFromContext(ctx),
+		Action:  AuditActionWriteProgress,
+		Details: DiffProgress(previous, progress),
+	})
+}
+
+// DiffProgress returns human-readable descriptions of the differences between two sets of progress.
+func DiffProgress(a *Progress, b *Progress) []string {
+	diffs := make([]string, 0)
+
+	diffs = append(diffs, diffLatestBlocks("block", a.Blocks, b.Blocks)...)
+	diffs = append(diffs, diffLatestBlocks("transaction", a.Transactions, b.Transactions)...)
+
+	for _, name := range unionKeys(a.Events, b.Events) {
+		aEntry, aExists := a.Events[name]
+		bEntry, bExists := b.Events[name]
+		switch {
+		case !aExists:
+			diffs = append(diffs, fmt.Sprintf("event trigger %q: absent -> %d/%d", name, bEntry.NextBlock, bEntry.LatestEventIndex))
+		case !bExists:
+			diffs = append(diffs, fmt.Sprintf("event trigger %q: %d/%d -> absent", name, aEntry.NextBlock, aEntry.LatestEventIndex))
+		case *aEntry != *bEntry:
+			diffs = append(diffs, fmt.Sprintf("event trigger %q: %d/%d -> %d/%d", name, aEntry.NextBlock, aEntry.LatestEventIndex, bEntry.NextBlock, bEntry.LatestEventIndex))
+		}
+	}
+
+	return diffs
+}
+
+// diffLatestBlocks returns human-readable descriptions of the differences between the latest
+// blocks processed by triggers of the given kind.
+func diffLatestBlocks(kind string, a map[string]int64, b map[string]int64) []string {
+	diffs := make([]string, 0)
+	for _, name := range unionKeys(a, b) {
+		aLatest, aExists := a[name]
+		bLatest, bExists := b[name]
+		switch {
+		case !aExists:
+			diffs = append(diffs, fmt.Sprintf("%s trigger %q: absent -> %d", kind, name, bLatest))
+		case !bExists:
+			diffs = append(diffs, fmt.Sprintf("%s trigger %q: %d -> absent", kind, name, aLatest))
+		case aLatest != bLatest:
+			diffs = append(diffs, fmt.Sprintf("%s trigger %q: %d -> %d", kind, name, aLatest, bLatest))
+		}
+	}
+
+	return diffs
+}
+
+// openInspection opens a metadata database for inspection outside of a running listener.
+func openInspection(path string, cipher ValueCipher, readOnly bool) (*Service, error) {
+	db, err := pebble.Open(path, &pebble.Options{
+		ReadOnly:         readOnly,
+		ErrorIfNotExists: true,
+	})
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to open metadata database"), err)
+	}
+
+	s := &Service{
+		log:            zerolog.Nop(),
+		metadataDB:     db,
+		metadataCipher: cipher,
+	}
+	s.metadataDBOpen.Store(true)
+
+	return s, nil
+}
+
+// unionKeys returns the sorted union of the keys of two maps.
+func unionKeys[T any](a map[string]T, b map[string]T) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, exists := a[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,259 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// sharedBlocks provides blocks to isolated triggers, fetching each block once however many
+// triggers handle it.  No trigger runs more than maxCachedBlocks blocks ahead of the slowest
+// trigger, so that the number of blocks held is bounded.
+type sharedBlocks struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	blocks map[uint64]*sharedBlock
+	// cursors are the next block for each trigger, or maxUint64 once the trigger has finished.
+	cursors []uint64
+	fetched int
+}
+
+// sharedBlock is a block fetched on behalf of all triggers.
+type sharedBlock struct {
+	once  sync.Once
+	block *spec.Block
+	err   error
+}
+
+func newSharedBlocks(triggers int, from uint64) *sharedBlocks {
+	b := &sharedBlocks{
+		blocks:  make(map[uint64]*sharedBlock),
+		cursors: make([]uint64, triggers),
+	}
+	b.cond = sync.NewCond(&b.mu)
+	for i := range b.cursors {
+		b.cursors[i] = from
+	}
+
+	return b
+}
+
+// get returns the block at the given height for the given trigger, waiting if the trigger is too
+// far ahead of the slowest trigger.
+func (b *sharedBlocks) get(ctx context.Context, s *Service, trigger int, height uint64) (*spec.Block, error) {
+	b.mu.Lock()
+	b.cursors[trigger] = height
+	b.release()
+	for height >= b.lowest()+maxCachedBlocks && ctx.Err() == nil {
+		b.cond.Wait()
+	}
+	if err := ctx.Err(); err != nil {
+		b.mu.Unlock()
+
+		return nil, err
+	}
+	entry, exists := b.blocks[height]
+	if !exists {
+		entry = &sharedBlock{}
+		b.blocks[height] = entry
+		b.fetched++
+	}
+	b.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.block, entry.err = s.block(ctx, height)
+	})
+
+	return entry.block, entry.err
+}
+
+// done notes that the given trigger has finished with blocks.
+func (b *sharedBlocks) done(trigger int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.cursors[trigger] = maxUint64
+	b.release()
+}
+
+// release drops blocks that all triggers have passed, and wakes triggers waiting for the slowest.
+// This assumes that the lock is held.
+func (b *sharedBlocks) release() {
+	lowest := b.lowest()
+	for height := range b.blocks {
+		if height < lowest {
+			delete(b.blocks, height)
+		}
+	}
+	b.cond.Broadcast()
+}
+
+// lowest returns the next block of the slowest trigger.
+// This assumes that the lock is held.
+func (b *sharedBlocks) lowest() uint64 {
+	lowest := maxUint64
+	for _, cursor := range b.cursors {
+		lowest = min(lowest, cursor)
+	}
+
+	return lowest
+}
+
+// pollBlocksIsolated polls blocks with each trigger processed in its own goroutine with its own
+// cursor, so that a failing trigger does not hold back the progress of the others.  Blocks are
+// fetched once and shared between the triggers.  The poll completes when all triggers have
+// completed, so a slow trigger delays the next poll and with it the other triggers.
+func (s *Service) pollBlocksIsolated(ctx context.Context,
+	from uint64,
+	to uint64,
+	triggers []*handlers.BlockTrigger,
+	md *blocksMetadata,
+	caps map[string]int64,
+) error {
+	// mdMu protects the metadata.
+	var mdMu sync.Mutex
+	blocks := newSharedBlocks(len(triggers), from)
+	// Triggers waiting for the slowest trigger must wake if the poll is cancelled.
+	stop := context.AfterFunc(ctx, func() {
+		blocks.mu.Lock()
+		blocks.cond.Broadcast()
+		blocks.mu.Unlock()
+	})
+	defer stop()
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(triggers))
+	for i, trigger := range triggers {
+		wg.Add(1)
+		go func(i int, trigger *handlers.BlockTrigger) {
+			defer wg.Done()
+			defer blocks.done(i)
+			errs[i] = s.pollBlocksForTrigger(ctx, from, to, trigger, caps[trigger.QualifiedName()], md, &mdMu, blocks, i)
+		}(i, trigger)
+	}
+	wg.Wait()
+
+	s.pollStatsMu.Lock()
+	s.pollStats.Blocks += blocks.fetched
+	s.pollStatsMu.Unlock()
+
+	return errors.Join(errs...)
+}
+
+// pollBlocksForTrigger polls blocks for a single trigger, stopping at the first failure.
+func (s *Service) pollBlocksForTrigger(ctx context.Context,
+	from uint64,
+	to uint64,
+	trigger *handlers.BlockTrigger,
+	limit int64,
+	md *blocksMetadata,
+	mdMu *sync.Mutex,
+	blocks *sharedBlocks,
+	index int,
+) error {
+	name := trigger.QualifiedName()
+	mdMu.Lock()
+	latest, exists := md.LatestBlocks[name]
+	mdMu.Unlock()
+	if exists && latest >= int64(from) {
+		// The trigger has already successfully processed some of the blocks.
+		from = uint64(latest + 1)
+	}
+	if int64(to) > limit {
+		// The trigger cannot advance past its dependencies.
+		if limit < int64(from) {
+			return nil
+		}
+		to = uint64(limit)
+	}
+
+	for height := from; height <= to; height++ {
+		if s.NamespacePaused(trigger.Namespace) {
+			return nil
+		}
+		block, err := blocks.get(ctx, s, index, height)
+		if err != nil {
+			return errors.Join(errors.New("failed to obtain block"), s.historyError(err, height))
+		}
+
+		state := s.triggerStateFor(blockTriggerState, name)
+		handlerCtx := handlers.WithTriggerState(s.handlerContext(ctx, height, block.FeeRecipient()), state)
+		handlerCtx = handlers.WithIdempotencyKey(handlerCtx, handlers.BlockIdempotencyKey(s.chainID, block.Hash()))
+		if err := trigger.Handler.HandleBlock(handlerCtx, block, trigger); err != nil {
+			state.discard()
+			log := s.triggerLog(name, trigger.Labels)
+			log.Debug().Uint64("block", height).Err(err).Msg("Trigger failed to handle block")
+			// The trigger has reported a failure.  We stop here for this trigger and don't update its metadata.
+			return nil
+		}
+		monitorHandled(trigger.Namespace, "block")
+
+		if err := s.setTriggerBlocksMetadata(ctx, md, mdMu, name, state, height); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setTriggerBlocksMetadata records that a trigger has handled a block, accepting its state and
+// storing the metadata while holding the metadata lock.
+func (s *Service) setTriggerBlocksMetadata(ctx context.Context,
+	md *blocksMetadata,
+	mdMu *sync.Mutex,
+	name string,
+	state *triggerState,
+	height uint64,
+) error {
+	mdMu.Lock()
+	defer mdMu.Unlock()
+
+	// State is accepted along with the progress it relates to, so that metadata committed
+	// for another trigger cannot hold state ahead of this trigger's progress.
+	state.accept()
+	md.LatestBlocks[name] = int64(height)
+	if err := s.setBlocksMetadata(ctx, md); err != nil {
+		return errors.Join(errors.New("failed to set metadata after block poll"), err)
+	}
+	s.recordBlocksProgress(md)
+
+	return nil
+}
+
+// pollEventsIsolated polls events with each trigger processed in its own goroutine, so that a
+// failing trigger does not hold back the progress of the others.  The poll completes when all
+// triggers have completed, so a slow trigger delays the next poll and with it the other triggers.
+func (s *Service) pollEventsIsolated(ctx context.Context,
+	toBlock uint64,
+	md *eventsMetadata,
+	mdMu *sync.Mutex,
+) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(s.eventTriggers))
+	for i, trigger := range s.eventTriggers {
+		wg.Add(1)
+		go func(i int, trigger *handlers.EventTrigger) {
+			defer wg.Done()
+			errs[i] = s.pollEventsTrigger(ctx, trigger, toBlock, md, mdMu)
+		}(i, trigger)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
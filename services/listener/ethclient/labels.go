@@ -0,0 +1,44 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"github.com/rs/zerolog"
+)
+
+// triggerLog returns a logger for the named trigger, including the trigger's labels.
+func (s *Service) triggerLog(name string, labels map[string]string) zerolog.Logger {
+	log := s.log.With().Str("trigger", name)
+	if len(labels) > 0 {
+		log = log.Interface("labels", labels)
+	}
+
+	return log.Logger()
+}
+
+// recordTriggerLabels records the labels of all triggers as metrics.
+func (s *Service) recordTriggerLabels(parameters *parameters) {
+	for _, trigger := range parameters.blockTriggers {
+		monitorTriggerLabels("block", trigger.QualifiedName(), trigger.Labels)
+	}
+	for _, trigger := range parameters.txTriggers {
+		monitorTriggerLabels("transaction", trigger.QualifiedName(), trigger.Labels)
+	}
+	for _, trigger := range parameters.eventTriggers {
+		monitorTriggerLabels("event", trigger.QualifiedName(), trigger.Labels)
+	}
+	for _, trigger := range parameters.scheduleTriggers {
+		monitorTriggerLabels("schedule", trigger.Name, trigger.Labels)
+	}
+}
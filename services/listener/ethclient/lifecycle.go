@@ -0,0 +1,114 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+)
+
+// LifecycleHandler is a function that is called when the listener starts or stops.
+type LifecycleHandler func(ctx context.Context) error
+
+// shutdown waits for the listener to be stopping, either through Stop or the context being done,
+// then waits for in-flight work, closes the metadata database and runs the shutdown handlers.
+func (s *Service) shutdown(ctx context.Context, handlers []LifecycleHandler) {
+	<-s.stopping
+
+	// Wait for any in-progress work to complete, so that its progress is recorded.
+	s.workers.Wait()
+	s.pollMu.Lock()
+	if err := s.flushMetadataDB(); err != nil {
+		s.log.Warn().Err(err).Msg("Failed to flush metadata database")
+	}
+	s.closeMetadataDB()
+	s.pollMu.Unlock()
+
+	// The service context is done, so handlers are given a context that is not.
+	handlerCtx := context.WithoutCancel(ctx)
+	for _, handler := range handlers {
+		if err := handler(handlerCtx); err != nil {
+			s.log.Warn().Err(err).Msg("Shutdown handler failed")
+		}
+	}
+	s.log.Debug().Msg("Listener stopped")
+	s.cancel()
+	close(s.stopped)
+}
+
+// startWorker starts a function in the background, which must return once the listener is stopping.
+func (s *Service) startWorker(worker func()) {
+	s.workers.Add(1)
+	go func() {
+		defer s.workers.Done()
+		worker()
+	}()
+}
+
+// Stop stops the listener.  No further polls or scheduled handlers are started, and in-flight
+// work is allowed to complete before the metadata database is flushed and closed and the
+// shutdown handlers run.
+// If the context is done before the listener has stopped then in-flight work is cancelled,
+// and the context's error is returned once the listener has stopped.
+func (s *Service) Stop(ctx context.Context) error {
+	s.stop()
+
+	select {
+	case <-s.stopped:
+		return nil
+	case <-ctx.Done():
+		s.log.Warn().Msg("Listener did not stop in time; cancelling in-flight work")
+		s.cancel()
+		<-s.stopped
+
+		return ctx.Err()
+	}
+}
+
+// Close stops the listener, waiting for in-flight work to complete.
+func (s *Service) Close() error {
+	return s.Stop(context.Background())
+}
+
+// flushMetadataDB flushes the metadata database to disk, so that it opens quickly on restart.
+func (s *Service) flushMetadataDB() error {
+	s.metadataDBMu.Lock()
+	defer s.metadataDBMu.Unlock()
+
+	if !s.metadataDBOpen.Load() {
+		return nil
+	}
+
+	return s.metadataDB.Flush()
+}
+
+// closeMetadataDB closes the metadata database.
+func (s *Service) closeMetadataDB() {
+	s.metadataDBMu.Lock()
+	defer s.metadataDBMu.Unlock()
+
+	if !s.metadataDBOpen.Load() {
+		return
+	}
+	err := s.metadataDB.Close()
+	s.metadataDBOpen.Store(false)
+	if err != nil {
+		s.log.Warn().Err(err).Msg("Failed to close pebble")
+	}
+}
+
+// Stopped returns a channel that is closed when the listener has stopped, after it has been
+// stopped or its context is done, and its shutdown handlers have run.
+func (s *Service) Stopped() <-chan struct{} {
+	return s.stopped
+}
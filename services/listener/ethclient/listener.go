@@ -14,10 +14,11 @@
 package ethclient
 
 import (
-	"bytes"
 	"context"
 	"errors"
-	"fmt"
+	"math"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/attestantio/go-execution-client/api"
@@ -27,244 +28,669 @@ import (
 	"github.com/wealdtech/go-eth-listener/handlers"
 )
 
-// Maximum number of blocks to fetch for events.
-const maxBlocksForEvents = uint32(100)
+// Default maximum number of blocks to fetch for events in a poll.
+const defaultMaxBlocksForEvents = uint64(100)
 
 func (s *Service) listener(ctx context.Context,
 ) {
-	// Start with a poll.
-	s.poll(ctx)
-
-	// Now loop until context is cancelled.
+	failures := 0
 	for {
+		// Errors are logged by the poll itself.
+		err := s.poll(ctx)
+
+		wait := s.pollInterval()
+		switch {
+		case err != nil && !errors.Is(err, ErrPollInProgress) && (s.lastPollAdvanced.Load() || s.lastPollOverran.Load()):
+			// The poll either moved triggers forward or ran out of time in which to do so, neither
+			// of which suggests a problem with the client, so poll again at the usual interval.
+			s.log.Trace().Err(err).Msg("Poll incomplete; not backing off")
+			failures = 0
+		case err != nil && !errors.Is(err, ErrPollInProgress):
+			failures++
+			wait = s.failureBackoff(failures)
+			s.log.Trace().Int("failures", failures).Dur("wait", wait).Msg("Poll failed; backing off")
+		case err == nil && failures > 0:
+			// Recovered, so catch up immediately.
+			s.log.Debug().Int("failures", failures).Msg("Poll recovered")
+			failures = 0
+			wait = 0
+		}
+
 		select {
-		case <-time.After(s.interval):
-			s.poll(ctx)
-		case <-ctx.Done():
-			s.log.Debug().Msg("Context done")
+		case <-time.After(wait):
+		case <-s.stopping:
+			s.log.Debug().Msg("Stopping")
 			return
 		}
 	}
 }
 
-func (s *Service) selectHighestBlock(ctx context.Context) (uint32, error) {
-	var to uint32
+// failureBackoff returns the time to wait after the given number of consecutive failed polls,
+// doubling the interval for each failure up to the maximum backoff.
+func (s *Service) failureBackoff(failures int) time.Duration {
+	wait := s.pollInterval()
+	maxBackoff := time.Duration(s.maxBackoff.Load())
+	for i := 1; i < failures && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > maxBackoff {
+		wait = maxBackoff
+	}
+
+	return wait
+}
+
+// chainHeight returns the height of the chain as reported by the client.
+func (s *Service) chainHeight(ctx context.Context) (uint64, error) {
+	height, err := s.chainHeightProvider.ChainHeight(ctx)
+
+	return uint64(height), err
+}
+
+func (s *Service) selectHighestBlock(ctx context.Context) (uint64, error) {
+	var to uint64
 	// Select the highest block with which to work, based on the specifier or the block delay.
-	if s.blockSpecifier != "" {
+	switch {
+	case strings.EqualFold(s.blockSpecifier, "latest"):
+		// The latest block is the chain height, so there is no need to fetch the block.
+		chainHeight, err := s.chainHeight(ctx)
+		if err != nil {
+			return 0, errors.Join(errors.New("failed to get chain height"), err)
+		}
+		s.chainHead.Store(chainHeight)
+		to = chainHeight
+		s.log.Trace().Str("specifier", s.blockSpecifier).Uint64("height", to).Msg("Obtained chain height with specifier")
+	case s.blockSpecifier != "":
 		block, err := s.blocksProvider.Block(ctx, s.blockSpecifier)
 		if err != nil {
 			return 0, errors.Join(errors.New("failed to obtain block"), err)
 		}
-		to = block.Number()
-		s.log.Trace().Str("specifier", s.blockSpecifier).Uint32("height", to).Msg("Obtained chain height with specifier")
-	} else {
-		chainHeight, err := s.chainHeightProvider.ChainHeight(ctx)
+		to = uint64(block.Number())
+		// The block is likely to be required by triggers in this poll.
+		s.blockCache.put(block)
+		s.log.Trace().Str("specifier", s.blockSpecifier).Uint64("height", to).Msg("Obtained chain height with specifier")
+		chainHeight, err := s.chainHeight(ctx)
+		if err != nil {
+			return 0, errors.Join(errors.New("failed to get chain height"), err)
+		}
+		s.chainHead.Store(chainHeight)
+	default:
+		chainHeight, err := s.chainHeight(ctx)
 		if err != nil {
 			return 0, errors.Join(errors.New("failed to get chain height for event poll"), err)
 		}
-		to = chainHeight - s.blockDelay
-		s.log.Trace().Uint32("block_delay", s.blockDelay).Uint32("height", to).Msg("Obtained chain height with delay")
+		s.chainHead.Store(chainHeight)
+		var available bool
+		to, available = s.delayedHeight(chainHeight)
+		if !available {
+			return 0, errChainBelowDelay
+		}
+		s.log.Trace().Uint64("block_delay", s.blockDelay).Uint64("height", to).Msg("Obtained chain height with delay")
 	}
 
-	s.log.Trace().Uint32("height", to).Msg("Selected highest block")
+	s.log.Trace().Uint64("height", to).Msg("Selected highest block")
 
 	return to, nil
 }
 
-func (s *Service) poll(ctx context.Context) {
+// delayedHeight returns the highest block to process for the given chain height, allowing for
+// the block delay.  It returns false if the chain is not yet higher than the delay.
+func (s *Service) delayedHeight(chainHeight uint64) (uint64, bool) {
+	if chainHeight < s.blockDelay {
+		return 0, false
+	}
+
+	return chainHeight - s.blockDelay, true
+}
+
+// errChainBelowDelay is returned when the chain is not yet higher than the block delay.
+var errChainBelowDelay = errors.New("chain height below block delay")
+
+// ErrPollInProgress is returned when a poll is requested while another poll is running.
+var ErrPollInProgress = errors.New("poll already in progress")
+
+// ErrStopped is returned when a poll is requested after the listener has been stopped.
+var ErrStopped = errors.New("listener stopped")
+
+// PollOnce carries out a single poll, handling all blocks, transactions and events up to the
+// highest block.  It allows the listener to be driven by an external scheduler, in which case the
+// service should be created with an interval of 0 to disable its own polling.
+// If another poll is running the poll is skipped and ErrPollInProgress is returned.
+func (s *Service) PollOnce(ctx context.Context) error {
+	return s.poll(ctx)
+}
+
+func (s *Service) poll(ctx context.Context) error {
+	if !s.pollMu.TryLock() {
+		// A poll is already running; running another after it would compound any slowness.
+		s.log.Debug().Msg("Poll already in progress; skipping")
+		monitorPollSkipped()
+
+		return ErrPollInProgress
+	}
+	// Reorgs detected during the poll are notified once it has completed, so that the reorg
+	// handler can act on the listener.
+	defer s.notifyReorgs(ctx)
+	defer s.pollMu.Unlock()
+	select {
+	case <-s.stopping:
+		return ErrStopped
+	default:
+	}
+	// Blocks are only cached within a poll.
+	defer s.blockCache.clear()
+	s.lastPollAdvanced.Store(false)
+	s.lastPollOverran.Store(false)
+
+	if pollTimeout := time.Duration(s.pollTimeout.Load()); pollTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, pollTimeout)
+		defer func(ctx context.Context) {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				s.log.Warn().Dur("timeout", pollTimeout).Msg("Poll exceeded its deadline")
+				monitorPollOverrun()
+				s.lastPollOverran.Store(true)
+			}
+			cancel()
+		}(ctx)
+	}
+
+	started := time.Now()
+	s.pollStats = &PollStats{}
+	info := &PollInfo{
+		From: s.lastPollTo,
+	}
 	to, err := s.selectHighestBlock(ctx)
-	if err != nil && ctx.Err() == nil {
-		s.log.Error().Err(err).Msg("Failed to select highest block")
-		monitorFailure()
+	if errors.Is(err, errChainBelowDelay) {
+		// Nothing is old enough to process yet.
+		s.log.Trace().Uint64("block_delay", s.blockDelay).Msg("Chain height below block delay; skipping poll")
+		s.completeSkippedPoll(ctx, info, started)
+
+		return nil
+	}
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logSampledError(ErrorClassChainHeight, err, "Failed to select highest block")
+			monitorFailure()
+		}
+		info.Err = err
+		runPollHooks(ctx, s.postPollHooks, info)
+
+		return err
+	}
+	s.logRecovered(ErrorClassChainHeight)
+	s.pollTarget.Store(to)
+	if s.lastPollSucceeded && to+1 == s.lastPollTo && s.triggersReached(ctx, to) {
+		// The head has not advanced since the last successful poll, and all triggers have caught up
+		// with it, so there is nothing new to process.
+		s.log.Trace().Uint64("height", to).Msg("Highest block unchanged; skipping poll")
+		info.To = to
+		info.ChainHead = s.chainHead.Load()
+		s.completeSkippedPoll(ctx, info, started)
+
+		return nil
+	}
+	info.To = to
+	info.ChainHead = s.chainHead.Load()
+	s.startRun()
+	runPollHooks(ctx, s.prePollHooks, info)
+
+	progress := s.progressSnapshot()
+	pollErr := s.pollTo(ctx, to)
+	s.lastPollAdvanced.Store(s.progressAdvanced(progress))
+	if pollErr == nil {
+		s.logRecovered(ErrorClassPoll)
+	}
+
+	trackingStarted := time.Now()
+	if err := s.pollTracked(ctx); err != nil {
+		if ctx.Err() == nil {
+			s.logSampledError(ErrorClassTracking, err, "Tracked transaction poll failed")
+			monitorFailure()
+		}
+		pollErr = errors.Join(pollErr, err)
+	} else {
+		s.logRecovered(ErrorClassTracking)
+	}
+	s.pollStats.TrackingDuration = time.Since(trackingStarted)
+	s.updateCatchingUp()
+	s.sampleProgress()
+	s.recordMetadataDBMetrics()
+
+	if pollErr == nil {
+		s.recordLatestProcessedHeader(ctx, to)
+	}
+
+	s.lastPollTo = to + 1
+	s.lastPollSucceeded = pollErr == nil
+	s.pollStats.Duration = time.Since(started)
+	s.recordPollStats(s.pollStats)
+	info.Stats = s.pollStats
+	info.Err = pollErr
+	runPollHooks(ctx, s.postPollHooks, info)
+
+	return pollErr
+}
+
+// completeSkippedPoll records the statistics of a poll that found nothing new to process, and
+// runs the post-poll hooks.
+func (s *Service) completeSkippedPoll(ctx context.Context, info *PollInfo, started time.Time) {
+	s.pollStats.Duration = time.Since(started)
+	s.recordPollStats(s.pollStats)
+	info.Stats = s.pollStats
+	info.Skipped = true
+	runPollHooks(ctx, s.postPollHooks, info)
+}
+
+// triggersReached returns true if all block, transaction and event triggers that are not paused
+// have processed everything up to and including the given block.  Triggers whose handlers failed,
+// or that were limited in how far they could advance, have not.
+func (s *Service) triggersReached(ctx context.Context, to uint64) bool {
+	if len(s.blockTriggers) > 0 {
+		md, err := s.getBlocksMetadata(ctx)
+		if err != nil {
+			return false
+		}
+		for _, trigger := range s.blockTriggers {
+			if s.NamespacePaused(trigger.Namespace) {
+				continue
+			}
+			if latest, exists := md.LatestBlocks[trigger.QualifiedName()]; !exists || latest < int64(to) {
+				return false
+			}
+		}
+	}
+
+	if len(s.txTriggers) > 0 {
+		md, err := s.getTransactionsMetadata(ctx)
+		if err != nil {
+			return false
+		}
+		for _, trigger := range s.activeTxTriggers() {
+			if md.latestBlock(trigger) < int64(to) {
+				return false
+			}
+		}
+	}
 
-		return
+	if len(s.eventTriggers) > 0 {
+		md, err := s.getEventsMetadata(ctx)
+		if err != nil {
+			return false
+		}
+		for _, trigger := range s.eventTriggers {
+			if s.NamespacePaused(trigger.Namespace) || trigger.EarliestBlock > to {
+				continue
+			}
+			// The latest block in the metadata is the next block to process.
+			if entry, exists := md.Entries[trigger.QualifiedName()]; !exists || entry.LatestBlock <= to {
+				return false
+			}
+		}
 	}
 
-	s.pollTo(ctx, to)
+	return true
 }
 
-func (s *Service) pollTo(ctx context.Context, to uint32) {
-	s.pollBlocksTo(ctx, to)
-	s.pollTxsTo(ctx, to)
-	s.pollEventsTo(ctx, to)
+func (s *Service) pollTo(ctx context.Context, to uint64) error {
+	started := time.Now()
+	var blocksErr error
+	if len(s.blockTriggers) > 0 {
+		blocksErr = s.pollFastPathBlocks(ctx, to)
+		if blocksErr != nil && ctx.Err() == nil {
+			s.logPollError(blocksErr, "Block poll failed")
+		}
+	}
+	s.pollStats.BlocksDuration = time.Since(started)
+
+	// Blocks and transactions are polled in chunks that fit in the block cache, so that each
+	// block is fetched at most once per poll even when catching up.  A failure stops further
+	// chunks for the failed kind of trigger only.
+	var txsErr error
+	for chunkFrom := s.cachedPollFrom(ctx, to); ; chunkFrom += maxCachedBlocks {
+		chunkTo := to
+		if to-chunkFrom >= maxCachedBlocks {
+			chunkTo = chunkFrom + maxCachedBlocks - 1
+		}
+
+		if blocksErr == nil {
+			started = time.Now()
+			blocksErr = s.pollBlocksTo(ctx, chunkTo)
+			s.pollStats.BlocksDuration += time.Since(started)
+		}
+		if txsErr == nil {
+			started = time.Now()
+			txsErr = s.pollTxsTo(ctx, chunkTo)
+			s.pollStats.TransactionsDuration += time.Since(started)
+		}
+
+		if chunkTo == to || (blocksErr != nil && txsErr != nil) || ctx.Err() != nil {
+			break
+		}
+	}
+
+	started = time.Now()
+	eventsErr := s.pollEventsTo(ctx, to)
+	s.pollStats.EventsDuration = time.Since(started)
+
 	monitorLatestBlock(to)
+
+	return errors.Join(blocksErr, txsErr, eventsErr)
 }
 
-func (s *Service) pollBlocksTo(ctx context.Context, to uint32) {
+func (s *Service) pollBlocksTo(ctx context.Context, to uint64) error {
 	if len(s.blockTriggers) > 0 {
 		s.log.Trace().Msg("Polling blocks")
 		err := s.pollBlocks(ctx, to)
 		if err != nil && ctx.Err() == nil {
-			s.log.Error().Err(err).Msg("Block poll failed")
-			monitorFailure()
+			s.logPollError(err, "Block poll failed")
 		}
+
+		return err
 	}
+
+	return nil
 }
 
-func (s *Service) pollTxsTo(ctx context.Context, to uint32) {
+func (s *Service) pollTxsTo(ctx context.Context, to uint64) error {
 	if len(s.txTriggers) > 0 {
 		s.log.Trace().Msg("Polling blocks for transactions")
 		err := s.pollTxs(ctx, to)
 		if err != nil && ctx.Err() == nil {
-			s.log.Error().Err(err).Msg("Transaction poll failed")
-			monitorFailure()
+			s.logPollError(err, "Transaction poll failed")
 		}
+
+		return err
 	}
+
+	return nil
 }
 
-func (s *Service) pollEventsTo(ctx context.Context, to uint32) {
+func (s *Service) pollEventsTo(ctx context.Context, to uint64) error {
 	if len(s.eventTriggers) > 0 {
 		s.log.Trace().Msg("Polling events")
 		err := s.pollEvents(ctx, to)
 		if err != nil && ctx.Err() == nil {
-			s.log.Error().Err(err).Msg("Event poll failed")
-			monitorFailure()
+			s.logPollError(err, "Event poll failed")
 		}
+
+		return err
 	}
+
+	return nil
 }
 
 func (s *Service) pollBlocks(ctx context.Context,
-	to uint32,
+	to uint64,
+) error {
+	return s.pollBlocksFor(ctx, to, s.blockTriggers)
+}
+
+// pollBlocksFor polls blocks for the given triggers.
+// If the triggers are a subset of the block triggers then the range is calculated from their
+// progress alone, and nothing is done if any of them has no progress recorded.
+func (s *Service) pollBlocksFor(ctx context.Context,
+	to uint64,
+	triggers []*handlers.BlockTrigger,
 ) error {
 	md, err := s.getBlocksMetadata(ctx)
 	if err != nil {
 		return errors.Join(errors.New("failed to get metadata for block poll"), err)
 	}
+	s.applyInitialBlocksProgress(md)
+	s.recordBlocksProgress(md)
 
-	from := s.calculateBlocksFrom(ctx, md)
-	s.log.Trace().Uint32("from", from).Uint32("to", to).Msg("Polling blocks in range")
+	var from uint64
+	if len(triggers) == len(s.blockTriggers) {
+		from = s.calculateBlocksFrom(ctx, md)
+	} else {
+		var ok bool
+		from, ok = s.fastPathBlocksFrom(md, triggers)
+		if !ok {
+			return nil
+		}
+	}
+	s.log.Trace().Uint64("from", from).Uint64("to", to).Msg("Polling blocks in range")
 	if from > to {
 		return nil
 	}
 
+	ctx = s.runContext(ctx, from, to)
+	caps := make(map[string]int64, len(triggers))
+	for _, trigger := range triggers {
+		caps[trigger.QualifiedName()] = s.dependencyCap(trigger.DependsOn)
+	}
+	if s.isolateTriggers && len(triggers) > 1 {
+		return s.pollBlocksIsolated(ctx, from, to, triggers, md, caps)
+	}
 	failed := make(map[string]bool)
 	for height := from; height <= to; height++ {
-		s.log.Trace().Uint32("block", height).Msg("Handling block")
-		block, err := s.blocksProvider.Block(ctx, fmt.Sprintf("%d", height))
+		s.log.Trace().Uint64("block", height).Msg("Handling block")
+		block, err := s.block(ctx, height)
 		if err != nil {
-			return errors.Join(errors.New("failed to obtain block"), err)
+			return errors.Join(errors.New("failed to obtain block"), s.historyError(err, height))
 		}
+		s.pollStats.Blocks++
 
-		for _, trigger := range s.blockTriggers {
-			if failed[trigger.Name] {
+		for _, trigger := range triggers {
+			if failed[trigger.QualifiedName()] {
 				// The trigger already reported a failure in this run, so don't run for future blocks.
 				continue
 			}
-			if md.LatestBlocks[trigger.Name] >= int32(height) {
+			if s.NamespacePaused(trigger.Namespace) {
+				continue
+			}
+			if md.LatestBlocks[trigger.QualifiedName()] >= int64(height) {
 				// The trigger has already successfully processed this block.
 				continue
 			}
-			if err := trigger.Handler.HandleBlock(ctx, block, trigger); err != nil {
-				s.log.Debug().Str("trigger", trigger.Name).Uint32("block", height).Err(err).Msg("Trigger failed to handle block")
+			if int64(height) > caps[trigger.QualifiedName()] {
+				// The trigger cannot advance past its dependencies.
+				continue
+			}
+			state := s.triggerStateFor(blockTriggerState, trigger.QualifiedName())
+			handlerCtx := handlers.WithTriggerState(s.handlerContext(ctx, height, block.FeeRecipient()), state)
+			handlerCtx = handlers.WithIdempotencyKey(handlerCtx, handlers.BlockIdempotencyKey(s.chainID, block.Hash()))
+			if err := trigger.Handler.HandleBlock(handlerCtx, block, trigger); err != nil {
+				state.discard()
+				log := s.triggerLog(trigger.QualifiedName(), trigger.Labels)
+				log.Debug().Uint64("block", height).Err(err).Msg("Trigger failed to handle block")
 				// The trigger has reported a failure.  We stop here for this trigger and don't update its metadata.
-				failed[trigger.Name] = true
+				failed[trigger.QualifiedName()] = true
 
 				continue
 			}
-			md.LatestBlocks[trigger.Name] = int32(height)
+			state.accept()
+			md.LatestBlocks[trigger.QualifiedName()] = int64(height)
+			monitorHandled(trigger.Namespace, "block")
 		}
 
 		if err := s.setBlocksMetadata(ctx, md); err != nil {
 			return errors.Join(errors.New("failed to set metadata after block poll"), err)
 		}
+		s.recordBlocksProgress(md)
 	}
 
 	return nil
 }
 
-const maxUint32 = uint32(0xffffffff)
+// handlerContext returns the context to pass to handlers for an item in the given block
+// involving the given addresses.
+func (s *Service) handlerContext(ctx context.Context, height uint64, addresses ...types.Address) context.Context {
+	for _, decorator := range s.contextDecorators {
+		ctx = decorator(ctx)
+	}
+
+	confirmations := uint32(0)
+	if chainHead := s.chainHead.Load(); chainHead > height {
+		confirmations = uint32(min(chainHead-height, math.MaxUint32))
+	}
+	ctx = handlers.WithConfirmations(ctx, confirmations)
+
+	// The block is only used if it has already been fetched, to avoid a call per event.
+	if block, exists := s.blockCache.get(height); exists {
+		ctx = handlers.WithBlockTimestamp(ctx, block.Timestamp())
+	}
+
+	if labels := s.addressLabels(ctx, addresses); len(labels) > 0 {
+		ctx = handlers.WithAddressLabels(ctx, labels)
+	}
+
+	return ctx
+}
+
+const maxUint64 = uint64(math.MaxUint64)
 
 // calculateBlocksFrom calculates the earliest block which we need to fetch.
-func (s *Service) calculateBlocksFrom(_ context.Context, md *blocksMetadata) uint32 {
-	var from uint32
+func (s *Service) calculateBlocksFrom(_ context.Context, md *blocksMetadata) uint64 {
+	var from uint64
 
 	switch {
 	case s.earliestBlock > -1:
 		// There is a hard-coded earliest block passed to us in configuration, so we must start there.
 		// We have to reset the metadata, otherwise blocks won't be reprocessed.
-		from = uint32(s.earliestBlock)
+		from = uint64(s.earliestBlock)
 		for name := range md.LatestBlocks {
 			md.LatestBlocks[name] = s.earliestBlock - 1
 		}
 		s.earliestBlock = -1
 	case len(md.LatestBlocks) > 0:
 		// Work out the earliest block from our existing metadata.
-		from = maxUint32
-		for _, latest := range md.LatestBlocks {
-			if from > uint32(latest+1) {
-				from = uint32(latest + 1)
-			}
-		}
+		from = s.blocksProgressFrom(md)
 	default:
 		// Means that there is no metadata or hard-coded block, so start from the beginning.
 		from = 0
 	}
 
+	return s.availableFrom(from)
+}
+
+// blocksProgressFrom returns the block after the earliest progress of block triggers that are
+// not paused.  Progress of triggers that are no longer configured does not hold back those that
+// are, and if there is no progress for configured triggers then this starts from the beginning.
+func (s *Service) blocksProgressFrom(md *blocksMetadata) uint64 {
+	configured := false
+	from := maxUint64
+	for name, latest := range md.LatestBlocks {
+		trigger := s.blockTriggerNamed(name)
+		if trigger == nil {
+			continue
+		}
+		configured = true
+		if s.NamespacePaused(trigger.Namespace) {
+			continue
+		}
+		from = min(from, uint64(latest+1))
+	}
+	if !configured {
+		return 0
+	}
+
 	return from
 }
 
 func (s *Service) pollTxs(ctx context.Context,
-	to uint32,
+	to uint64,
 ) error {
 	md, err := s.getTransactionsMetadata(ctx)
 	if err != nil {
 		return errors.Join(errors.New("failed to get metadata for transaction poll"), err)
 	}
+	if s.claimSharedTransactionsProgress(md, s.txTriggers) {
+		if err := s.setTransactionsMetadata(ctx, md); err != nil {
+			return errors.Join(errors.New("failed to set metadata after claiming shared progress"), err)
+		}
+	}
+	s.applyInitialTransactionsProgress(md)
+	s.recordTransactionsProgress(md)
 
-	from := uint32(md.LatestBlock + 1)
+	triggers := s.activeTxTriggers()
+	if len(triggers) == 0 {
+		return nil
+	}
+	from := maxUint64
+	for _, trigger := range triggers {
+		from = min(from, uint64(md.latestBlock(trigger)+1))
+	}
 	if s.earliestBlock != -1 {
-		from = uint32(s.earliestBlock)
+		from = uint64(s.earliestBlock)
+		for _, trigger := range triggers {
+			md.LatestBlocks[trigger.QualifiedName()] = s.earliestBlock - 1
+		}
 		s.earliestBlock = -1
 	}
+	from = s.availableFrom(from)
+
+	caps := make(map[string]int64, len(triggers))
+	highest := int64(-1)
+	for _, trigger := range triggers {
+		caps[trigger.QualifiedName()] = s.dependencyCap(trigger.DependsOn)
+		highest = max(highest, caps[trigger.QualifiedName()])
+	}
+	if highest < int64(to) {
+		if highest < 0 {
+			s.log.Trace().Msg("Transaction triggers are waiting for their dependencies")
+			return nil
+		}
+		to = uint64(highest)
+	}
 
 	if from > to {
-		s.log.Trace().Uint32("from", from).Uint32("to", to).Msg("Not fetching blocks for transactions")
+		s.log.Trace().Uint64("from", from).Uint64("to", to).Msg("Not fetching blocks for transactions")
 		return nil
 	}
 
+	s.log.Trace().Uint64("from", from).Uint64("to", to).Msg("Polling blocks for transactions in range")
+	ctx = s.runContext(ctx, from, to)
 	for height := from; height <= to; height++ {
-		if err := s.pollBlockTxs(ctx, height); err != nil {
+		if err := s.pollBlockTxs(ctx, height, md, caps); err != nil {
 			return err
 		}
 
-		md.LatestBlock = int32(height)
+		for _, trigger := range triggers {
+			if md.latestBlock(trigger) < int64(height) && caps[trigger.QualifiedName()] >= int64(height) {
+				md.LatestBlocks[trigger.QualifiedName()] = int64(height)
+			}
+		}
 		if err := s.setTransactionsMetadata(ctx, md); err != nil {
 			return errors.Join(errors.New("failed to set metadata after trasaction poll"), err)
 		}
+		s.recordTransactionsProgress(md)
 	}
 
 	return nil
 }
 
-func (s *Service) pollBlockTxs(ctx context.Context, height uint32) error {
-	block, err := s.blocksProvider.Block(ctx, fmt.Sprintf("%d", height))
+func (s *Service) pollBlockTxs(ctx context.Context, height uint64, md *transactionsMetadata, caps map[string]int64) error {
+	block, err := s.block(ctx, height)
 	if err != nil {
-		return errors.Join(errors.New("failed to obtain block for transactions"), err)
+		return errors.Join(errors.New("failed to obtain block for transactions"), s.historyError(err, height))
 	}
+	s.pollStats.Transactions += len(block.Transactions())
 
-	log := s.log.With().Uint32("block_height", block.Number()).Logger()
-	for _, trigger := range s.txTriggers {
-		log := log.With().Str("trigger", trigger.Name).Logger()
-		if block.Number() < trigger.EarliestBlock {
-			log.Trace().Msg("Block too early; ignoring")
-			continue
-		}
-		for i, tx := range block.Transactions() {
-			if trigger.From != nil {
-				txFrom := tx.From()
-				if !bytes.Equal(trigger.From[:], txFrom[:]) {
-					log.Trace().Int("index", i).Msg("From does not match; ignoring")
-					continue
-				}
+	log := s.log.With().Uint64("block_height", height).Logger()
+	for i, tx := range block.Transactions() {
+		for _, trigger := range s.txMatcher.candidates(tx) {
+			if height < trigger.EarliestBlock {
+				continue
 			}
-			if trigger.To != nil {
-				txTo := tx.To()
-				if !bytes.Equal(trigger.To[:], txTo[:]) {
-					log.Trace().Int("index", i).Msg("To does not match; ignoring")
-					continue
-				}
+			if md.latestBlock(trigger) >= int64(height) || s.NamespacePaused(trigger.Namespace) {
+				// The trigger has already processed this block, or is paused.
+				continue
 			}
-			trigger.Handler.HandleTx(ctx, tx, trigger)
+			if caps[trigger.QualifiedName()] < int64(height) {
+				// The trigger cannot advance past its dependencies.
+				continue
+			}
+			if !txMatchesTrigger(trigger, tx) || !s.setCodeMatchesTrigger(trigger, tx) {
+				log.Trace().Str("trigger", trigger.QualifiedName()).Int("index", i).Msg("Transaction does not match; ignoring")
+				continue
+			}
+			state := s.triggerStateFor(txTriggerState, trigger.QualifiedName())
+			trigger.Handler.HandleTx(handlers.WithTriggerState(s.txContext(ctx, height, tx), state), tx, trigger)
+			// Transaction handlers cannot fail, so their state is always accepted.
+			state.accept()
+			monitorHandled(trigger.Namespace, "transaction")
 		}
 	}
 
@@ -272,127 +698,247 @@ func (s *Service) pollBlockTxs(ctx context.Context, height uint32) error {
 }
 
 func (s *Service) pollEvents(ctx context.Context,
-	toBlock uint32,
+	toBlock uint64,
 ) error {
 	md, err := s.getEventsMetadata(ctx)
 	if err != nil {
 		return errors.Join(errors.New("failed to get metadata for event poll"), err)
 	}
+	s.applyInitialEventsProgress(md)
+	s.recordEventsProgress(md)
 
-	// Need to run each trigger separately.
+	// Entries are created up front, as the metadata is shared by triggers polled concurrently.
 	for _, trigger := range s.eventTriggers {
-		// Obtain the last block and transaction we examined for this trigger, or use the earliest block as defined in the trigger.
-		fromBlock := trigger.EarliestBlock
-		fromEventIndex := int32(-1)
-		if entry, exists := md.Entries[trigger.Name]; exists {
-			if entry.LatestBlock >= fromBlock {
-				fromBlock = entry.LatestBlock
-				fromEventIndex = entry.LatestEventIndex
+		if s.NamespacePaused(trigger.Namespace) {
+			continue
+		}
+		if _, exists := md.Entries[trigger.QualifiedName()]; !exists {
+			md.Entries[trigger.QualifiedName()] = &eventsEntryMetadata{
+				LatestBlock:      trigger.EarliestBlock,
+				LatestEventIndex: -1,
 			}
-		} else {
-			md.Entries[trigger.Name] = &eventsEntryMetadata{
-				LatestBlock:      fromBlock,
-				LatestEventIndex: fromEventIndex,
+		}
+	}
+
+	var mdMu sync.Mutex
+	if s.isolateTriggers {
+		return s.pollEventsIsolated(ctx, toBlock, md, &mdMu)
+	}
+
+	var historyErrs error
+	// Need to run each trigger separately.
+	for _, trigger := range s.eventTriggers {
+		if err := s.pollEventsTrigger(ctx, trigger, toBlock, md, &mdMu); err != nil {
+			var historyErr *ErrHistoryUnavailable
+			if !errors.As(err, &historyErr) {
+				return err
 			}
+			historyErrs = errors.Join(historyErrs, err)
 		}
-		if fromBlock > toBlock {
-			s.log.Trace().
-				Str("trigger", trigger.Name).
-				Uint32("from_block", fromBlock).
-				Int32("from_event_index", fromEventIndex).
-				Uint32("to_block", toBlock).
-				Msg("Not fetching events")
+	}
 
+	return historyErrs
+}
+
+// pollEventsTrigger polls events for a single trigger, updating its entry in the metadata.
+// Errors for unavailable history are returned to be surfaced to the caller, as are failures
+// to store the metadata.  The metadata lock protects the metadata from triggers polled concurrently.
+func (s *Service) pollEventsTrigger(ctx context.Context,
+	trigger *handlers.EventTrigger,
+	toBlock uint64,
+	md *eventsMetadata,
+	mdMu *sync.Mutex,
+) error {
+	if s.NamespacePaused(trigger.Namespace) {
+		return nil
+	}
+	// Obtain the last block and transaction we examined for this trigger, or use the earliest block as defined in the trigger.
+	mdMu.Lock()
+	entry := md.Entries[trigger.QualifiedName()]
+	fromBlock := trigger.EarliestBlock
+	fromEventIndex := int32(-1)
+	if entry.LatestBlock >= fromBlock {
+		fromBlock = entry.LatestBlock
+		fromEventIndex = entry.LatestEventIndex
+	}
+	mdMu.Unlock()
+	triggerTo := toBlock
+	if limit := s.dependencyCap(trigger.DependsOn); limit < int64(triggerTo) {
+		// The trigger cannot advance past its dependencies.
+		if limit < int64(fromBlock) {
 			return nil
 		}
+		triggerTo = uint64(limit)
+	}
+	if fromBlock > triggerTo {
+		log := s.triggerLog(trigger.QualifiedName(), trigger.Labels)
+		log.Trace().
+			Uint64("from_block", fromBlock).
+			Int32("from_event_index", fromEventIndex).
+			Uint64("to_block", triggerTo).
+			Msg("Not fetching events")
 
-		if toBlock+1-fromBlock > maxBlocksForEvents {
-			toBlock = fromBlock + maxBlocksForEvents - 1
-		}
+		return nil
+	}
 
-		latestBlock, latestEventIndex, err := s.pollEventsForTrigger(ctx, trigger, fromBlock, fromEventIndex, toBlock)
-		if err != nil {
-			s.log.Debug().
-				Str("trigger", trigger.Name).
-				Uint32("latest_block", latestBlock).
-				Int32("latest_event_index", latestEventIndex).
-				Err(err).
-				Msg("Poll errored")
-		}
-		md.Entries[trigger.Name].LatestBlock = latestBlock
-		md.Entries[trigger.Name].LatestEventIndex = latestEventIndex
+	if triggerTo+1-fromBlock > s.maxBlocksForEvents {
+		triggerTo = fromBlock + s.maxBlocksForEvents - 1
+	}
+
+	// State is accepted along with the progress it relates to, so that metadata committed
+	// for another trigger cannot hold state ahead of this trigger's progress.
+	accept := func(state *triggerState, latestBlock uint64, latestEventIndex int32) {
+		mdMu.Lock()
+		defer mdMu.Unlock()
+		state.accept()
+		entry.LatestBlock = latestBlock
+		entry.LatestEventIndex = latestEventIndex
+	}
 
-		if err := s.setEventsMetadata(ctx, md); err != nil {
-			return errors.Join(errors.New("failed to set metadata after event poll"), err)
+	var historyErr error
+	latestBlock, latestEventIndex, err := s.pollEventsForTrigger(ctx, trigger, fromBlock, fromEventIndex, triggerTo, accept)
+	if err != nil {
+		log := s.triggerLog(trigger.QualifiedName(), trigger.Labels)
+		log.Debug().
+			Uint64("latest_block", latestBlock).
+			Int32("latest_event_index", latestEventIndex).
+			Err(err).
+			Msg("Poll errored")
+		var unavailable *ErrHistoryUnavailable
+		if errors.As(err, &unavailable) {
+			// Unavailable history will not resolve itself, so is surfaced to the caller.
+			historyErr = err
 		}
 	}
 
-	return nil
+	mdMu.Lock()
+	defer mdMu.Unlock()
+	entry.LatestBlock = latestBlock
+	entry.LatestEventIndex = latestEventIndex
+	if err := s.setEventsMetadata(ctx, md); err != nil {
+		return errors.Join(errors.New("failed to set metadata after event poll"), err)
+	}
+	s.recordEventsProgress(md)
+
+	return historyErr
 }
 
 func (s *Service) pollEventsForTrigger(ctx context.Context,
 	trigger *handlers.EventTrigger,
-	fromBlock uint32,
+	fromBlock uint64,
 	fromEventIndex int32,
-	toBlock uint32,
+	toBlock uint64,
+	accept func(state *triggerState, latestBlock uint64, latestEventIndex int32),
 ) (
-	uint32,
+	uint64,
 	int32,
 	error,
 ) {
-	log := s.log.With().Str("trigger", trigger.Name).Logger()
+	log := s.triggerLog(trigger.QualifiedName(), trigger.Labels)
 
 	source, err := s.resolveSourceFromTrigger(ctx, trigger)
 	if err != nil {
 		return fromBlock, fromEventIndex, err
 	}
 
-	log.Trace().Uint32("from_block", fromBlock).Int32("from_event", fromEventIndex).Uint32("to", toBlock).Msg("Fetching events")
+	log.Trace().Uint64("from_block", fromBlock).Int32("from_event", fromEventIndex).Uint64("to", toBlock).Msg("Fetching events")
 
-	filter := &api.EventsFilter{
-		FromBlock: executil.MarshalUint32(fromBlock),
-		ToBlock:   executil.MarshalUint32(toBlock),
-	}
-	if source != nil {
-		filter.Address = source
-	}
-	if len(trigger.Topics) > 0 {
-		filter.Topics = trigger.Topics
-	}
-
-	events, err := s.eventsProvider.Events(ctx, filter)
+	events, toBlock, err := s.fetchEvents(ctx, trigger, source, fromBlock, toBlock)
 	if err != nil {
-		return fromBlock, fromEventIndex, errors.Join(errors.New("failed to obtain events"), err)
+		return fromBlock, fromEventIndex, errors.Join(errors.New("failed to obtain events"), s.historyError(err, fromBlock))
 	}
+	s.pollStatsMu.Lock()
+	s.pollStats.Events += len(events)
+	s.pollStatsMu.Unlock()
 
+	ctx = s.runContext(ctx, fromBlock, toBlock)
 	latestBlock := fromBlock
 	latestEventIndex := fromEventIndex
+	matcher := s.eventMatcherFor(trigger)
 	for _, event := range events {
+		if uint64(event.BlockNumber) == fromBlock && int32(event.Index) <= fromEventIndex {
+			// This event has already been handled.
+			continue
+		}
+		if !matcher.matches(event) {
+			continue
+		}
+
+		// The logger is only created for events that are handled, as most events may not match.
 		log := log.With().
 			Uint32("block_number", event.BlockNumber).
 			Stringer("tx", event.TransactionHash).
 			Uint32("event_index", event.Index).
 			Logger()
+		if err := s.checkCanonicalEvent(ctx, event); err != nil {
+			log.Debug().Err(err).Msg("Event failed canonical chain check")
 
-		if event.BlockNumber == fromBlock && int32(event.Index) <= fromEventIndex {
-			// This event has already been handled.
-			continue
+			return latestBlock, latestEventIndex, err
 		}
-		if err := trigger.Handler.HandleEvent(ctx, event, trigger); err != nil {
+		if s.receiptsProvider != nil {
+			if err := s.verifyEvent(ctx, event); err != nil {
+				log.Warn().Err(err).Msg("Event failed receipts verification")
+				monitorFailure()
+
+				return latestBlock, latestEventIndex, errors.Join(errors.New("event failed receipts verification"), err)
+			}
+		}
+		state := s.triggerStateFor(eventTriggerState, trigger.QualifiedName())
+		handlerCtx := handlers.WithTriggerState(s.eventContext(ctx, event), state)
+		decoded := s.decodeEvent(trigger, event)
+		if decoded != nil {
+			handlerCtx = handlers.WithDecodedEvent(handlerCtx, decoded)
+		}
+		if s.receiptProofs {
+			proof, err := s.receiptProof(ctx, event)
+			if err != nil {
+				log.Debug().Err(err).Msg("Failed to generate receipt proof")
+
+				return latestBlock, latestEventIndex, errors.Join(errors.New("failed to generate receipt proof"), err)
+			}
+			handlerCtx = handlers.WithReceiptProof(handlerCtx, proof)
+		}
+		// Indexing is idempotent, so the event is indexed before it is handled in case the handler must be retried.
+		if s.eventIndex {
+			if err := s.indexEvent(ctx, trigger.QualifiedName(), event); err != nil {
+				log.Debug().Err(err).Msg("Failed to index event")
+
+				return latestBlock, latestEventIndex, errors.Join(errors.New("failed to index event"), err)
+			}
+		}
+		if err := handleEvent(handlerCtx, trigger, event, decoded); err != nil {
+			state.discard()
 			log.Debug().Err(err).Msg("Handler errored")
 
 			return latestBlock, latestEventIndex, errors.Join(errors.New("handler errored"), err)
 		}
 		log.Trace().Msg("Handler succeeded")
-
-		latestBlock = event.BlockNumber
+		latestBlock = uint64(event.BlockNumber)
 		latestEventIndex = int32(event.Index)
+		accept(state, latestBlock, latestEventIndex)
+		monitorHandled(trigger.Namespace, "event")
 	}
 
 	// We have processed all of the events for the blocks.
 	return toBlock + 1, -1, nil
 }
 
+// eventsFilter returns the filter to obtain events for a trigger in the given range.
+func eventsFilter(trigger *handlers.EventTrigger, source *types.Address, fromBlock uint64, toBlock uint64) *api.EventsFilter {
+	filter := &api.EventsFilter{
+		FromBlock: executil.MarshalUint64(fromBlock),
+		ToBlock:   executil.MarshalUint64(toBlock),
+	}
+	if source != nil {
+		filter.Address = source
+	}
+	if len(trigger.Topics) > 0 {
+		filter.Topics = trigger.Topics
+	}
+
+	return filter
+}
+
 func (s *Service) resolveSourceFromTrigger(ctx context.Context,
 	trigger *handlers.EventTrigger,
 ) (
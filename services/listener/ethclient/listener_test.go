@@ -0,0 +1,348 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/cockroachdb/pebble"
+	"github.com/wealdtech/go-eth-listener/handlers"
+	"github.com/wealdtech/go-eth-listener/services/listener/ethclient"
+	"github.com/wealdtech/go-eth-listener/services/listener/ethclient/ethclienttest"
+)
+
+func TestPollRetriesFailedTriggerWithoutNewBlock(t *testing.T) {
+	ctx := context.Background()
+	handler := ethclienttest.NewHandler().FailBlock(5, 1)
+	h, err := ethclienttest.New(ctx,
+		ethclienttest.WithChain(ethclienttest.NewChain(10)),
+		ethclienttest.WithListenerParameters(ethclient.WithBlockTriggers([]*handlers.BlockTrigger{
+			{Name: "test", Handler: handler},
+		})),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := h.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if err := h.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.CheckBlockProgress(ctx, "test", 4); err != nil {
+		t.Fatal(err)
+	}
+
+	// The chain has not advanced, but the failed block must be retried.
+	if err := h.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if attempts := handler.BlockAttempts("test", 5); attempts != 2 {
+		t.Fatalf("expected 2 attempts for block 5, found %d", attempts)
+	}
+	if err := h.CheckBlockProgress(ctx, "test", 10); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReconfigureDuringReplay(t *testing.T) {
+	ctx := context.Background()
+	chain := ethclienttest.NewChain(10)
+	for height := uint32(1); height <= 10; height++ {
+		if _, err := chain.AddEvent(height, types.Address{0x01}, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	trigger := &handlers.EventTrigger{Name: "test", Handler: ethclienttest.NewHandler()}
+	h, err := ethclienttest.New(ctx,
+		ethclienttest.WithChain(chain),
+		ethclienttest.WithListenerParameters(ethclient.WithEventTriggers([]*handlers.EventTrigger{trigger})),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := h.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	// Replays are not serialised with polls, so must be safe against reconfiguration.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 20 {
+			if err := h.Listener().Reconfigure(ctx, &ethclient.Configuration{
+				EventTriggers: []*handlers.EventTrigger{trigger},
+			}); err != nil {
+				t.Error(err)
+
+				return
+			}
+		}
+	}()
+	for range 20 {
+		events, err := h.Listener().ReplayEvents(ctx, trigger, 1, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := 0
+		for events.Next(ctx) {
+			found++
+		}
+		if err := events.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if found != 10 {
+			t.Fatalf("expected 10 events, found %d", found)
+		}
+	}
+	wg.Wait()
+}
+
+func TestIsolatedTriggerFailureDoesNotHoldBackOthers(t *testing.T) {
+	ctx := context.Background()
+	failing := ethclienttest.NewHandler().FailBlock(5, 1)
+	h, err := ethclienttest.New(ctx,
+		ethclienttest.WithChain(ethclienttest.NewChain(200)),
+		ethclienttest.WithListenerParameters(
+			ethclient.WithTriggerIsolation(true),
+			ethclient.WithBlockTriggers([]*handlers.BlockTrigger{
+				{Name: "failing", Handler: failing},
+				{Name: "test", Handler: ethclienttest.NewHandler()},
+			}),
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := h.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if err := h.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.CheckBlockProgress(ctx, "failing", 4); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.CheckBlockProgress(ctx, "test", 200); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTriggerLagAllowsForBlockDelay(t *testing.T) {
+	ctx := context.Background()
+	h, err := ethclienttest.New(ctx,
+		ethclienttest.WithChain(ethclienttest.NewChain(10)),
+		ethclienttest.WithListenerParameters(
+			ethclient.WithBlockDelay(3),
+			ethclient.WithBlockTriggers([]*handlers.BlockTrigger{
+				{Name: "test", Handler: ethclienttest.NewHandler()},
+			}),
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := h.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if err := h.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.CheckBlockProgress(ctx, "test", 7); err != nil {
+		t.Fatal(err)
+	}
+	lag, err := h.Listener().TriggerLag("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lag != 0 {
+		t.Fatalf("expected no lag, found %d", lag)
+	}
+	if chainHead := h.Listener().ChainHead(); chainHead != 10 {
+		t.Fatalf("expected chain head 10, found %d", chainHead)
+	}
+}
+
+// addressSet is a fixed set of addresses.
+type addressSet []types.Address
+
+func (s addressSet) Contains(address types.Address) bool {
+	for _, member := range s {
+		if member == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s addressSet) Addresses() []types.Address {
+	return s
+}
+
+func TestEventSourceSetDeliversInChainOrder(t *testing.T) {
+	ctx := context.Background()
+	chain := ethclienttest.NewChain(10)
+	for height := uint32(1); height <= 10; height++ {
+		for _, address := range []types.Address{{0x01}, {0x02}, {0x03}} {
+			if _, err := chain.AddEvent(height, address, nil, nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	handler := ethclienttest.NewHandler()
+	h, err := ethclienttest.New(ctx,
+		ethclienttest.WithChain(chain),
+		ethclienttest.WithListenerParameters(ethclient.WithEventTriggers([]*handlers.EventTrigger{
+			{Name: "test", SourceSet: addressSet{{0x03}, {0x01}}, Handler: handler},
+		})),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := h.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if err := h.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	deliveries := handler.Deliveries()
+	if len(deliveries) != 20 {
+		t.Fatalf("expected 20 deliveries, found %d", len(deliveries))
+	}
+	for i, delivery := range deliveries {
+		// Events from 0x01 and 0x03 are at indices 0 and 2 of each block.
+		block := uint32(i/2) + 1
+		index := uint32(i%2) * 2
+		if delivery.Block != block || delivery.Index != index {
+			t.Fatalf("expected delivery %d to be event %d/%d, found %d/%d", i, block, index, delivery.Block, delivery.Index)
+		}
+	}
+}
+
+// txHandler is a transaction handler that does nothing.
+type txHandler struct{}
+
+func (txHandler) HandleTx(_ context.Context, _ *spec.Transaction, _ *handlers.TxTrigger) {}
+
+func TestSharedTransactionProgressRetainedUntilClaimed(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+
+	// Metadata from before each transaction trigger had its own progress.
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Set([]byte("listener.ethclient.version"), []byte(`{"version":2}`), pebble.Sync); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Set([]byte("listener.ethclient.transactions"), []byte(`{"latest_block":5,"namespaces":{"other":7}}`), pebble.Sync); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The shared progress is above the chain height, so is unchanged by polling.
+	chain := ethclienttest.NewChain(3)
+	for _, trigger := range []*handlers.TxTrigger{
+		{Name: "test", Handler: txHandler{}},
+		// The second trigger is in a namespace that was not configured when the metadata was migrated.
+		{Name: "test", Namespace: "other", Handler: txHandler{}},
+	} {
+		h, err := ethclienttest.New(ctx,
+			ethclienttest.WithChain(chain),
+			ethclienttest.WithMetadataDBPath(path),
+			ethclienttest.WithListenerParameters(ethclient.WithTxTriggers([]*handlers.TxTrigger{trigger})),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := h.Poll(ctx); err != nil {
+			t.Fatal(err)
+		}
+		progress, err := h.Progress(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := h.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := map[string]int64{"test": 5, "other/test": 7}[trigger.QualifiedName()]
+		if latest, exists := progress.Transactions[trigger.QualifiedName()]; !exists || latest != expected {
+			t.Fatalf("expected trigger %s to have latest block %d, found %d", trigger.QualifiedName(), expected, latest)
+		}
+	}
+}
+
+func TestPostPollHookRunsForSkippedPoll(t *testing.T) {
+	ctx := context.Background()
+	infos := make([]*ethclient.PollInfo, 0)
+	h, err := ethclienttest.New(ctx,
+		ethclienttest.WithChain(ethclienttest.NewChain(10)),
+		ethclienttest.WithListenerParameters(
+			ethclient.WithBlockTriggers([]*handlers.BlockTrigger{
+				{Name: "test", Handler: ethclienttest.NewHandler()},
+			}),
+			ethclient.WithPostPollHook(func(_ context.Context, info *ethclient.PollInfo) {
+				infos = append(infos, info)
+			}),
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := h.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	// The second poll has nothing new to process.
+	for range 2 {
+		if err := h.Poll(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(infos) != 2 {
+		t.Fatalf("expected 2 post-poll hook calls, found %d", len(infos))
+	}
+	if infos[0].Skipped || !infos[1].Skipped {
+		t.Fatalf("expected only the second poll to be skipped, found %t and %t", infos[0].Skipped, infos[1].Skipped)
+	}
+	if infos[1].To != 10 || infos[1].Stats == nil {
+		t.Fatalf("expected skipped poll to report block 10 and statistics")
+	}
+}
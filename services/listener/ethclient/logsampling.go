@@ -0,0 +1,117 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"sync"
+)
+
+// ErrorClass is a class of error logged by the listener, to which log sampling can be applied.
+type ErrorClass string
+
+const (
+	// ErrorClassChainHeight is failure to obtain the highest block at the start of a poll.
+	ErrorClassChainHeight ErrorClass = "chain_height"
+	// ErrorClassPoll is failure of the blocks, transactions or events part of a poll.
+	ErrorClassPoll ErrorClass = "poll"
+	// ErrorClassTracking is failure to poll tracked transactions.
+	ErrorClassTracking ErrorClass = "tracking"
+)
+
+// errorClasses are the classes of error to which log sampling can be applied.
+var errorClasses = map[ErrorClass]bool{
+	ErrorClassChainHeight: true,
+	ErrorClassPoll:        true,
+	ErrorClassTracking:    true,
+}
+
+// logSampleKey identifies a repeated error.
+type logSampleKey struct {
+	class ErrorClass
+	msg   string
+}
+
+// logSampler samples the logging of repeated errors, logging the first of a run of consecutive
+// occurrences and every nth thereafter.
+type logSampler struct {
+	every       map[ErrorClass]int
+	mu          sync.Mutex
+	occurrences map[logSampleKey]int
+}
+
+// newLogSampler creates a log sampler with the given sampling for each class of error.
+func newLogSampler(every map[ErrorClass]int) *logSampler {
+	return &logSampler{
+		every:       every,
+		occurrences: make(map[logSampleKey]int),
+	}
+}
+
+// sample records an occurrence of an error, returning the number of consecutive occurrences
+// and true if this occurrence should be logged.
+func (l *logSampler) sample(class ErrorClass, msg string) (int, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := logSampleKey{
+		class: class,
+		msg:   msg,
+	}
+	l.occurrences[key]++
+	occurrences := l.occurrences[key]
+	every := l.every[class]
+
+	return occurrences, every <= 1 || occurrences == 1 || occurrences%every == 0
+}
+
+// recovered records recovery from errors of the given class, returning the number of consecutive
+// occurrences of each error for which logging was sampled.
+func (l *logSampler) recovered(class ErrorClass) map[string]int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	res := make(map[string]int)
+	for key, occurrences := range l.occurrences {
+		if key.class != class {
+			continue
+		}
+		if l.every[class] > 1 {
+			res[key.msg] = occurrences
+		}
+		delete(l.occurrences, key)
+	}
+
+	return res
+}
+
+// logSampledError logs an error of the given class, subject to the sampling for the class.
+func (s *Service) logSampledError(class ErrorClass, err error, msg string) {
+	occurrences, log := s.logSampler.sample(class, msg)
+	if !log {
+		return
+	}
+
+	e := s.log.Error().Err(err)
+	if occurrences > 1 {
+		e = e.Int("occurrences", occurrences)
+	}
+	e.Msg(msg)
+}
+
+// logRecovered logs recovery from sampled errors of the given class.
+func (s *Service) logRecovered(class ErrorClass) {
+	for msg, occurrences := range s.logSampler.recovered(class) {
+		s.log.Info().Str("error", msg).Int("occurrences", occurrences).Msg("Recovered from repeated error")
+	}
+}
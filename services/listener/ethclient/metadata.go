@@ -15,10 +15,11 @@ package ethclient
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/cockroachdb/pebble"
+	"github.com/wealdtech/go-eth-listener/handlers"
 )
 
 var (
@@ -28,21 +29,34 @@ var (
 )
 
 type blocksMetadata struct {
-	LatestBlocks map[string]int32 `json:"latest_blocks"`
+	LatestBlocks map[string]int64 `json:"latest_blocks"`
 }
 
 type transactionsMetadata struct {
-	LatestBlock int32 `json:"latest_block"`
+	LatestBlocks map[string]int64 `json:"latest_blocks"`
+	// LatestBlock and Namespaces are deprecated.
+	LatestBlock *int64           `json:"latest_block,omitempty"`
+	Namespaces  map[string]int64 `json:"namespaces,omitempty"`
+}
+
+// latestBlock returns the latest block processed by the transaction trigger.
+// Triggers without progress start from their earliest block.
+func (md *transactionsMetadata) latestBlock(trigger *handlers.TxTrigger) int64 {
+	if latest, exists := md.LatestBlocks[trigger.QualifiedName()]; exists {
+		return latest
+	}
+
+	return int64(trigger.EarliestBlock) - 1
 }
 
 type eventsMetadata struct {
 	// LatestBlocks is deprecated.
-	LatestBlocks map[string]uint32               `json:"latest_blocks,omitempty"`
+	LatestBlocks map[string]uint64               `json:"latest_blocks,omitempty"`
 	Entries      map[string]*eventsEntryMetadata `json:"entries"`
 }
 
 type eventsEntryMetadata struct {
-	LatestBlock      uint32 `json:"latest_block"`
+	LatestBlock      uint64 `json:"latest_block"`
 	LatestEventIndex int32  `json:"latest_event_index"`
 }
 
@@ -54,10 +68,12 @@ func (s *Service) getBlocksMetadata(_ context.Context) (*blocksMetadata, error)
 	}
 
 	res := &blocksMetadata{
-		LatestBlocks: map[string]int32{},
+		LatestBlocks: map[string]int64{},
 	}
 
+	started := time.Now()
 	data, closer, err := s.metadataDB.Get(blocksMetadataKey)
+	monitorMetadataDBOperation("read", time.Since(started))
 	if err != nil {
 		if errors.Is(err, pebble.ErrNotFound) {
 			return res, nil
@@ -70,7 +86,7 @@ func (s *Service) getBlocksMetadata(_ context.Context) (*blocksMetadata, error)
 		return nil, errors.Join(errors.New("failed to close blocks metadata"), err)
 	}
 
-	if err := json.Unmarshal(data, res); err != nil {
+	if err := s.unmarshalValue(data, res); err != nil {
 		return nil, errors.Join(errors.New("failed to unmarshal blocks metadata"), err)
 	}
 
@@ -84,12 +100,15 @@ func (s *Service) setBlocksMetadata(_ context.Context, md *blocksMetadata) error
 		return errors.New("database closed")
 	}
 
-	data, err := json.Marshal(md)
+	data, err := s.marshalValue(md)
 	if err != nil {
 		return errors.Join(errors.New("failed to marshal blocks metadata"), err)
 	}
 
-	if err := s.metadataDB.Set(blocksMetadataKey, data, pebble.Sync); err != nil {
+	started := time.Now()
+	err = s.commitMetadata(blocksMetadataKey, data, blockTriggerState)
+	monitorMetadataDBOperation("write", time.Since(started))
+	if err != nil {
 		return errors.Join(errors.New("failed to set blocks metadata"), err)
 	}
 
@@ -103,11 +122,13 @@ func (s *Service) getTransactionsMetadata(_ context.Context) (*transactionsMetad
 		return nil, errors.New("database closed")
 	}
 
+	started := time.Now()
 	data, closer, err := s.metadataDB.Get(transactionsMetadataKey)
+	monitorMetadataDBOperation("read", time.Since(started))
 	if err != nil {
 		if errors.Is(err, pebble.ErrNotFound) {
 			return &transactionsMetadata{
-				LatestBlock: -1,
+				LatestBlocks: map[string]int64{},
 			}, nil
 		}
 
@@ -119,9 +140,13 @@ func (s *Service) getTransactionsMetadata(_ context.Context) (*transactionsMetad
 	}
 
 	res := &transactionsMetadata{}
-	if err := json.Unmarshal(data, res); err != nil {
+	if err := s.unmarshalValue(data, res); err != nil {
 		return nil, errors.Join(errors.New("failed to unmarshal transactions metadata"), err)
 	}
+	if res.LatestBlocks == nil {
+		// Metadata from before schema version 3; the conversion is carried out by its migration.
+		res.LatestBlocks = map[string]int64{}
+	}
 
 	return res, nil
 }
@@ -133,12 +158,15 @@ func (s *Service) setTransactionsMetadata(_ context.Context, md *transactionsMet
 		return errors.New("database closed")
 	}
 
-	data, err := json.Marshal(md)
+	data, err := s.marshalValue(md)
 	if err != nil {
 		return errors.Join(errors.New("failed to marshal transactions metadata"), err)
 	}
 
-	if err := s.metadataDB.Set(transactionsMetadataKey, data, pebble.Sync); err != nil {
+	started := time.Now()
+	err = s.commitMetadata(transactionsMetadataKey, data, txTriggerState)
+	monitorMetadataDBOperation("write", time.Since(started))
+	if err != nil {
 		return errors.Join(errors.New("failed to set transactions metadata"), err)
 	}
 
@@ -152,7 +180,9 @@ func (s *Service) getEventsMetadata(_ context.Context) (*eventsMetadata, error)
 		return nil, errors.New("database closed")
 	}
 
+	started := time.Now()
 	data, closer, err := s.metadataDB.Get(eventsMetadataKey)
+	monitorMetadataDBOperation("read", time.Since(started))
 	if err != nil {
 		if errors.Is(err, pebble.ErrNotFound) {
 			return &eventsMetadata{
@@ -168,11 +198,12 @@ func (s *Service) getEventsMetadata(_ context.Context) (*eventsMetadata, error)
 	}
 
 	res := &eventsMetadata{}
-	if err := json.Unmarshal(data, res); err != nil {
+	if err := s.unmarshalValue(data, res); err != nil {
 		return nil, errors.Join(errors.New("failed to unmarshal events metadata"), err)
 	}
 
 	if res.Entries == nil {
+		// Metadata from before schema version 1; the conversion is persisted by its migration.
 		res.Entries = map[string]*eventsEntryMetadata{}
 		for k, v := range res.LatestBlocks {
 			res.Entries[k] = &eventsEntryMetadata{
@@ -193,14 +224,28 @@ func (s *Service) setEventsMetadata(_ context.Context, md *eventsMetadata) error
 		return errors.New("database closed")
 	}
 
-	data, err := json.Marshal(md)
+	data, err := s.marshalValue(md)
 	if err != nil {
 		return errors.Join(errors.New("failed to marshal events metadata"), err)
 	}
 
-	if err := s.metadataDB.Set(eventsMetadataKey, data, pebble.Sync); err != nil {
+	started := time.Now()
+	err = s.commitMetadata(eventsMetadataKey, data, eventTriggerState)
+	monitorMetadataDBOperation("write", time.Since(started))
+	if err != nil {
 		return errors.Join(errors.New("failed to set events metadata"), err)
 	}
 
 	return nil
 }
+
+// recordMetadataDBMetrics records the size and health of the metadata database.
+func (s *Service) recordMetadataDBMetrics() {
+	s.metadataDBMu.Lock()
+	defer s.metadataDBMu.Unlock()
+	if !s.metadataDBOpen.Load() {
+		return
+	}
+
+	monitorMetadataDB(s.metadataDB.Metrics())
+}
@@ -16,7 +16,9 @@ package ethclient
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/cockroachdb/pebble"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/wealdtech/go-eth-listener/services/metrics"
 )
@@ -24,8 +26,24 @@ import (
 var metricsNamespace = "eth_listener"
 
 var (
-	latestBlockMetric prometheus.Gauge
-	failuresMetric    prometheus.Counter
+	latestBlockMetric  prometheus.Gauge
+	failuresMetric     prometheus.Counter
+	pollOverrunsMetric prometheus.Counter
+	pollsSkippedMetric prometheus.Counter
+	narrowedMetric     prometheus.Counter
+	compressedMetric   *prometheus.CounterVec
+	itemsMetric        *prometheus.CounterVec
+	pollPhaseMetric    *prometheus.HistogramVec
+	catchingUpMetric   prometheus.Gauge
+	dbSizeMetric       prometheus.Gauge
+	dbCompactionMetric *prometheus.GaugeVec
+	dbReadAmpMetric    prometheus.Gauge
+	dbOperationMetric  *prometheus.HistogramVec
+	handledMetric      *prometheus.CounterVec
+	triggerLabelMetric *prometheus.GaugeVec
+	triggerRateMetric  *prometheus.GaugeVec
+	triggerETAMetric   *prometheus.GaugeVec
+	reorgsMetric       prometheus.Counter
 )
 
 func registerMetrics(_ context.Context, monitor metrics.Service) error {
@@ -65,10 +83,176 @@ func registerPrometheusMetrics() error {
 		return errors.Join(errors.New("failed to register total failures"), err)
 	}
 
+	pollOverrunsMetric = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "ethclient",
+		Name:      "poll_overruns_total",
+		Help:      "The number of polls that exceeded their deadline.",
+	})
+	if err := prometheus.Register(pollOverrunsMetric); err != nil {
+		return errors.Join(errors.New("failed to register total poll overruns"), err)
+	}
+
+	pollsSkippedMetric = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "ethclient",
+		Name:      "polls_skipped_total",
+		Help:      "The number of polls skipped because a previous poll was still running.",
+	})
+	if err := prometheus.Register(pollsSkippedMetric); err != nil {
+		return errors.Join(errors.New("failed to register total skipped polls"), err)
+	}
+
+	narrowedMetric = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "ethclient",
+		Name:      "events_fetches_narrowed_total",
+		Help:      "The number of event fetches repeated over fewer blocks because they returned too many events.",
+	})
+	if err := prometheus.Register(narrowedMetric); err != nil {
+		return errors.Join(errors.New("failed to register total narrowed event fetches"), err)
+	}
+
+	compressedMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "ethclient",
+		Name:      "metadata_compressed_bytes_total",
+		Help:      "The number of bytes of metadata values compressed, before and after compression.",
+	}, []string{"size"})
+	if err := prometheus.Register(compressedMetric); err != nil {
+		return errors.Join(errors.New("failed to register total metadata compressed bytes"), err)
+	}
+
+	itemsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "ethclient",
+		Name:      "items_processed_total",
+		Help:      "The number of items processed, by type.",
+	}, []string{"type"})
+	if err := prometheus.Register(itemsMetric); err != nil {
+		return errors.Join(errors.New("failed to register total items processed"), err)
+	}
+
+	pollPhaseMetric = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "ethclient",
+		Name:      "poll_phase_duration_seconds",
+		Help:      "The time taken by each phase of a poll.",
+		Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 300},
+	}, []string{"phase"})
+	if err := prometheus.Register(pollPhaseMetric); err != nil {
+		return errors.Join(errors.New("failed to register poll phase duration"), err)
+	}
+
+	catchingUpMetric = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "ethclient",
+		Name:      "catching_up",
+		Help:      "1 if the listener is catching up with the chain, otherwise 0.",
+	})
+	if err := prometheus.Register(catchingUpMetric); err != nil {
+		return errors.Join(errors.New("failed to register catching up"), err)
+	}
+
+	reorgsMetric = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "ethclient",
+		Name:      "reorgs_total",
+		Help:      "The number of reorgs detected by the canonical chain cache.",
+	})
+	if err := prometheus.Register(reorgsMetric); err != nil {
+		return errors.Join(errors.New("failed to register reorgs"), err)
+	}
+
+	handledMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "ethclient",
+		Name:      "handled_total",
+		Help:      "The number of items passed to handlers, by trigger namespace and type.",
+	}, []string{"namespace", "type"})
+	if err := prometheus.Register(handledMetric); err != nil {
+		return errors.Join(errors.New("failed to register total items handled"), err)
+	}
+
+	triggerLabelMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "ethclient",
+		Name:      "trigger_label",
+		Help:      "Labels of triggers, with a value of 1 for each label, for joining with other metrics.",
+	}, []string{"type", "trigger", "key", "value"})
+	if err := prometheus.Register(triggerLabelMetric); err != nil {
+		return errors.Join(errors.New("failed to register trigger labels"), err)
+	}
+
+	triggerRateMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "ethclient",
+		Name:      "trigger_rate_blocks_per_second",
+		Help:      "The rate at which the trigger has progressed over recent polls.",
+	}, []string{"type", "trigger"})
+	if err := prometheus.Register(triggerRateMetric); err != nil {
+		return errors.Join(errors.New("failed to register trigger rate"), err)
+	}
+
+	triggerETAMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "ethclient",
+		Name:      "trigger_eta_seconds",
+		Help:      "The estimated time for the trigger to catch up with the highest block to process; -1 if unknown.",
+	}, []string{"type", "trigger"})
+	if err := prometheus.Register(triggerETAMetric); err != nil {
+		return errors.Join(errors.New("failed to register trigger ETA"), err)
+	}
+
+	return registerMetadataDBMetrics()
+}
+
+func registerMetadataDBMetrics() error {
+	dbSizeMetric = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "ethclient",
+		Name:      "metadata_db_size_bytes",
+		Help:      "The size of the metadata database on disk.",
+	})
+	if err := prometheus.Register(dbSizeMetric); err != nil {
+		return errors.Join(errors.New("failed to register metadata database size"), err)
+	}
+
+	dbCompactionMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "ethclient",
+		Name:      "metadata_db_compactions",
+		Help:      "Compaction statistics for the metadata database.",
+	}, []string{"stat"})
+	if err := prometheus.Register(dbCompactionMetric); err != nil {
+		return errors.Join(errors.New("failed to register metadata database compactions"), err)
+	}
+
+	dbReadAmpMetric = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "ethclient",
+		Name:      "metadata_db_read_amplification",
+		Help:      "The read amplification of the metadata database.",
+	})
+	if err := prometheus.Register(dbReadAmpMetric); err != nil {
+		return errors.Join(errors.New("failed to register metadata database read amplification"), err)
+	}
+
+	dbOperationMetric = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "ethclient",
+		Name:      "metadata_db_operation_duration_seconds",
+		Help:      "The time taken by reads and writes of the metadata database.",
+		Buckets:   []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1},
+	}, []string{"operation"})
+	if err := prometheus.Register(dbOperationMetric); err != nil {
+		return errors.Join(errors.New("failed to register metadata database operation duration"), err)
+	}
+
 	return nil
 }
 
-func monitorLatestBlock(block uint32) {
+func monitorLatestBlock(block uint64) {
 	if latestBlockMetric != nil {
 		latestBlockMetric.Set(float64(block))
 	}
@@ -79,3 +263,106 @@ func monitorFailure() {
 		failuresMetric.Inc()
 	}
 }
+
+func monitorPollOverrun() {
+	if pollOverrunsMetric != nil {
+		pollOverrunsMetric.Inc()
+	}
+}
+
+func monitorPollSkipped() {
+	if pollsSkippedMetric != nil {
+		pollsSkippedMetric.Inc()
+	}
+}
+
+func monitorEventsFetchNarrowed() {
+	if narrowedMetric != nil {
+		narrowedMetric.Inc()
+	}
+}
+
+func monitorMetadataCompressed(uncompressed int, compressed int) {
+	if compressedMetric != nil {
+		compressedMetric.WithLabelValues("uncompressed").Add(float64(uncompressed))
+		compressedMetric.WithLabelValues("compressed").Add(float64(compressed))
+	}
+}
+
+func monitorPollStats(stats *PollStats) {
+	if itemsMetric != nil {
+		itemsMetric.WithLabelValues("block").Add(float64(stats.Blocks))
+		itemsMetric.WithLabelValues("transaction").Add(float64(stats.Transactions))
+		itemsMetric.WithLabelValues("event").Add(float64(stats.Events))
+	}
+	if pollPhaseMetric != nil {
+		pollPhaseMetric.WithLabelValues("blocks").Observe(stats.BlocksDuration.Seconds())
+		pollPhaseMetric.WithLabelValues("transactions").Observe(stats.TransactionsDuration.Seconds())
+		pollPhaseMetric.WithLabelValues("events").Observe(stats.EventsDuration.Seconds())
+		pollPhaseMetric.WithLabelValues("tracking").Observe(stats.TrackingDuration.Seconds())
+		pollPhaseMetric.WithLabelValues("total").Observe(stats.Duration.Seconds())
+	}
+}
+
+func monitorReorg() {
+	if reorgsMetric != nil {
+		reorgsMetric.Inc()
+	}
+}
+
+func monitorCatchingUp(catchingUp bool) {
+	if catchingUpMetric != nil {
+		if catchingUp {
+			catchingUpMetric.Set(1)
+		} else {
+			catchingUpMetric.Set(0)
+		}
+	}
+}
+
+func monitorMetadataDB(metrics *pebble.Metrics) {
+	if dbSizeMetric != nil {
+		dbSizeMetric.Set(float64(metrics.DiskSpaceUsage()))
+	}
+	if dbCompactionMetric != nil {
+		dbCompactionMetric.WithLabelValues("count").Set(float64(metrics.Compact.Count))
+		dbCompactionMetric.WithLabelValues("in_progress").Set(float64(metrics.Compact.NumInProgress))
+		dbCompactionMetric.WithLabelValues("debt_bytes").Set(float64(metrics.Compact.EstimatedDebt))
+	}
+	if dbReadAmpMetric != nil {
+		dbReadAmpMetric.Set(float64(metrics.ReadAmp()))
+	}
+}
+
+func monitorMetadataDBOperation(operation string, duration time.Duration) {
+	if dbOperationMetric != nil {
+		dbOperationMetric.WithLabelValues(operation).Observe(duration.Seconds())
+	}
+}
+
+func monitorHandled(namespace string, itemType string) {
+	if handledMetric != nil {
+		handledMetric.WithLabelValues(namespace, itemType).Inc()
+	}
+}
+
+func monitorTriggerLabels(triggerType string, trigger string, labels map[string]string) {
+	if triggerLabelMetric != nil {
+		for key, value := range labels {
+			triggerLabelMetric.WithLabelValues(triggerType, trigger, key, value).Set(1)
+		}
+	}
+}
+
+func monitorTriggerRate(triggerType string, trigger string, rate float64, eta time.Duration) {
+	if triggerRateMetric != nil {
+		triggerRateMetric.WithLabelValues(triggerType, trigger).Set(rate)
+	}
+	if triggerETAMetric != nil {
+		if eta < 0 {
+			triggerETAMetric.WithLabelValues(triggerType, trigger).Set(-1)
+		} else {
+			triggerETAMetric.WithLabelValues(triggerType, trigger).Set(eta.Seconds())
+		}
+	}
+}
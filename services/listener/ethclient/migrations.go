@@ -0,0 +1,333 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/cockroachdb/pebble"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+var schemaVersionKey = []byte("listener.ethclient.version")
+
+type schemaVersion struct {
+	Version int `json:"version"`
+}
+
+// migration is a change to the format of the metadata database.
+type migration struct {
+	// version is the schema version after the migration has been applied.
+	version     int
+	description string
+	migrate     func(ctx context.Context, s *Service) error
+}
+
+// migrations are the migrations of the metadata database, in order.
+// Databases created before schema versions were introduced have version 0.
+var migrations = []*migration{
+	{
+		version:     1,
+		description: "move event trigger progress from latest blocks to entries",
+		migrate: func(ctx context.Context, s *Service) error {
+			// Reading the events metadata converts it to the current format.
+			md, err := s.getEventsMetadata(ctx)
+			if err != nil {
+				return err
+			}
+
+			return s.setEventsMetadata(ctx, md)
+		},
+	},
+	{
+		version:     2,
+		description: "widen block heights in keys from 32 to 64 bits",
+		migrate: func(_ context.Context, s *Service) error {
+			return s.widenHeightKeys()
+		},
+	},
+	{
+		version:     3,
+		description: "give each transaction trigger its own progress",
+		migrate: func(ctx context.Context, s *Service) error {
+			return s.splitTransactionsProgress(ctx)
+		},
+	},
+}
+
+// maxMigrationBatch is the maximum number of keys rewritten in a single batch by a migration.
+const maxMigrationBatch = 1000
+
+// currentSchemaVersion is the schema version of the metadata database after all migrations.
+var currentSchemaVersion = migrations[len(migrations)-1].version
+
+// migrateMetadata brings the metadata database up to the current schema version.
+func (s *Service) migrateMetadata(ctx context.Context) error {
+	version, err := s.getSchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if version > currentSchemaVersion {
+		return fmt.Errorf("metadata database has schema version %d but only versions up to %d are supported", version, currentSchemaVersion)
+	}
+
+	for _, migration := range migrations {
+		if migration.version <= version {
+			continue
+		}
+		s.log.Info().Int("version", migration.version).Str("description", migration.description).Msg("Migrating metadata database")
+		if err := migration.migrate(ctx, s); err != nil {
+			return errors.Join(fmt.Errorf("failed to migrate metadata database to version %d", migration.version), err)
+		}
+		if err := s.setSchemaVersion(ctx, migration.version); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// heightKeys are the keys that contain block heights.
+var heightKeys = []struct {
+	prefix []byte
+	// offset is the position of the height after the prefix.
+	offset int
+	// length is the length of the key after the prefix when the height is 32 bits.
+	length int
+}{
+	{prefix: canonicalPrefix, offset: 0, length: 4},
+	{prefix: eventIndexEventsPrefix, offset: 0, length: 12},
+	{prefix: eventIndexAddressesPrefix, offset: len(types.Address{}), length: len(types.Address{}) + 12},
+	{prefix: eventIndexTimesPrefix, offset: 8, length: 20},
+}
+
+// widenHeightKeys rewrites keys that contain 32-bit block heights to contain 64-bit block heights.
+// Keys that have already been rewritten are left alone, so this can be rerun if interrupted.
+func (s *Service) widenHeightKeys() error {
+	s.metadataDBMu.Lock()
+	defer s.metadataDBMu.Unlock()
+	if !s.metadataDBOpen.Load() {
+		return errors.New("database closed")
+	}
+
+	for _, keys := range heightKeys {
+		if err := s.widenHeightKeysWithPrefix(keys.prefix, keys.offset, keys.length); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// widenHeightKeysWithPrefix rewrites the keys with the given prefix that contain 32-bit block heights.
+// The metadata database lock must be held by the caller.
+func (s *Service) widenHeightKeysWithPrefix(prefix []byte, offset int, length int) error {
+	iter, err := s.metadataDB.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: prefixEnd(prefix),
+	})
+	if err != nil {
+		return errors.Join(errors.New("failed to create iterator"), err)
+	}
+	defer iter.Close()
+
+	batch := s.metadataDB.NewBatch()
+	for iter.First(); iter.Valid(); iter.Next() {
+		key := iter.Key()
+		if len(key) != len(prefix)+length {
+			continue
+		}
+		// A 32-bit big-endian height is widened by prefixing it with zeros.
+		heightStart := len(prefix) + offset
+		widened := make([]byte, 0, len(key)+4)
+		widened = append(widened, key[:heightStart]...)
+		widened = append(widened, 0, 0, 0, 0)
+		widened = append(widened, key[heightStart:]...)
+		if err := batch.Set(widened, iter.Value(), nil); err != nil {
+			batch.Close()
+
+			return errors.Join(errors.New("failed to set widened key"), err)
+		}
+		if err := batch.Delete(key, nil); err != nil {
+			batch.Close()
+
+			return errors.Join(errors.New("failed to remove narrow key"), err)
+		}
+		if batch.Count() >= maxMigrationBatch {
+			err := batch.Commit(pebble.Sync)
+			batch.Close()
+			if err != nil {
+				return errors.Join(errors.New("failed to commit widened keys"), err)
+			}
+			batch = s.metadataDB.NewBatch()
+		}
+	}
+	defer batch.Close()
+	if err := iter.Error(); err != nil {
+		return errors.Join(errors.New("failed to iterate over keys"), err)
+	}
+
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return errors.Join(errors.New("failed to commit widened keys"), err)
+	}
+
+	return nil
+}
+
+// getSchemaVersion returns the schema version of the metadata database.
+func (s *Service) getSchemaVersion(_ context.Context) (int, error) {
+	s.metadataDBMu.Lock()
+	defer s.metadataDBMu.Unlock()
+	if !s.metadataDBOpen.Load() {
+		return 0, errors.New("database closed")
+	}
+
+	started := time.Now()
+	data, closer, err := s.metadataDB.Get(schemaVersionKey)
+	monitorMetadataDBOperation("read", time.Since(started))
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return s.unversionedSchemaVersion()
+		}
+
+		return 0, errors.Join(errors.New("failed to get schema version"), err)
+	}
+	defer closer.Close()
+
+	res := &schemaVersion{}
+	if err := s.unmarshalValue(data, res); err != nil {
+		return 0, errors.Join(errors.New("failed to unmarshal schema version"), err)
+	}
+
+	return res.Version, nil
+}
+
+// unversionedSchemaVersion returns the schema version of a database without a version key,
+// which is the current version if the database is empty and otherwise 0.
+// The metadata database lock must be held by the caller.
+func (s *Service) unversionedSchemaVersion() (int, error) {
+	iter, err := s.metadataDB.NewIter(nil)
+	if err != nil {
+		return 0, errors.Join(errors.New("failed to create iterator"), err)
+	}
+	defer iter.Close()
+
+	if iter.First() {
+		return 0, nil
+	}
+	if err := iter.Error(); err != nil {
+		return 0, errors.Join(errors.New("failed to check for existing metadata"), err)
+	}
+
+	return currentSchemaVersion, nil
+}
+
+// setSchemaVersion sets the schema version of the metadata database.
+func (s *Service) setSchemaVersion(_ context.Context, version int) error {
+	s.metadataDBMu.Lock()
+	defer s.metadataDBMu.Unlock()
+	if !s.metadataDBOpen.Load() {
+		return errors.New("database closed")
+	}
+
+	data, err := s.marshalValue(&schemaVersion{
+		Version: version,
+	})
+	if err != nil {
+		return errors.Join(errors.New("failed to marshal schema version"), err)
+	}
+
+	started := time.Now()
+	err = s.metadataDB.Set(schemaVersionKey, data, pebble.Sync)
+	monitorMetadataDBOperation("write", time.Since(started))
+	if err != nil {
+		return errors.Join(errors.New("failed to set schema version"), err)
+	}
+
+	return nil
+}
+
+// splitTransactionsProgress gives each transaction trigger the progress that it previously shared
+// with the other transaction triggers in its namespace.
+func (s *Service) splitTransactionsProgress(ctx context.Context) error {
+	md, err := s.getTransactionsMetadata(ctx)
+	if err != nil {
+		return err
+	}
+	if !s.claimSharedTransactionsProgress(md, s.txTriggers) {
+		return nil
+	}
+	if md.LatestBlock != nil || len(md.Namespaces) > 0 {
+		s.log.Info().Msg("Retaining shared transaction progress for namespaces without transaction triggers")
+	}
+
+	return s.setTransactionsMetadata(ctx, md)
+}
+
+// claimSharedTransactionsProgress gives transaction triggers without progress of their own the
+// progress that they previously shared with the other transaction triggers in their namespace.
+// Shared progress is removed once claimed by a trigger in its namespace; until then it is retained,
+// so that triggers in namespaces that are not currently configured can claim it later.
+// It returns true if the metadata was changed.
+func (s *Service) claimSharedTransactionsProgress(md *transactionsMetadata, triggers []*handlers.TxTrigger) bool {
+	if md.LatestBlock == nil && len(md.Namespaces) == 0 {
+		return false
+	}
+
+	// Namespaces without progress of their own shared the progress of the default namespace, so
+	// this returns the namespace whose progress was shared along with the progress.
+	shared := func(namespace string) (string, int64, bool) {
+		if latest, exists := md.Namespaces[namespace]; exists && namespace != "" {
+			return namespace, latest, true
+		}
+		if md.LatestBlock != nil {
+			return "", *md.LatestBlock, true
+		}
+
+		return "", 0, false
+	}
+
+	changed := false
+	claimed := make(map[string]bool)
+	for _, trigger := range triggers {
+		namespace, latest, exists := shared(trigger.Namespace)
+		if !exists {
+			continue
+		}
+		if _, exists := md.LatestBlocks[trigger.QualifiedName()]; !exists && latest >= 0 {
+			s.log.Debug().Str("trigger", trigger.QualifiedName()).Int64("latest_block", latest).Msg("Claiming shared transaction progress")
+			md.LatestBlocks[trigger.QualifiedName()] = latest
+			changed = true
+		}
+		if trigger.Namespace == namespace {
+			claimed[namespace] = true
+		}
+	}
+	for namespace := range claimed {
+		if namespace == "" {
+			md.LatestBlock = nil
+		} else {
+			delete(md.Namespaces, namespace)
+		}
+	}
+	if len(md.Namespaces) == 0 {
+		md.Namespaces = nil
+	}
+
+	return changed || len(claimed) > 0
+}
@@ -0,0 +1,136 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// PauseNamespace pauses the triggers in the given namespace.  Paused triggers are not passed
+// items and do not make progress until they are resumed.
+// The action is recorded in the audit log against the actor in the context, if any.
+func (s *Service) PauseNamespace(ctx context.Context, namespace string) {
+	s.pausedMu.Lock()
+	s.paused[namespace] = true
+	s.pausedMu.Unlock()
+	s.log.Info().Str("namespace", namespace).Str("actor", ActorFromContext(ctx)).Msg("Paused namespace")
+	s.audit(ctx, AuditActionPause, namespace)
+}
+
+// ResumeNamespace resumes the triggers in the given namespace.
+// The action is recorded in the audit log against the actor in the context, if any.
+func (s *Service) ResumeNamespace(ctx context.Context, namespace string) {
+	s.pausedMu.Lock()
+	delete(s.paused, namespace)
+	s.pausedMu.Unlock()
+	s.log.Info().Str("namespace", namespace).Str("actor", ActorFromContext(ctx)).Msg("Resumed namespace")
+	s.audit(ctx, AuditActionResume, namespace)
+}
+
+// NamespacePaused returns true if the triggers in the given namespace are paused.
+func (s *Service) NamespacePaused(namespace string) bool {
+	s.pausedMu.RLock()
+	defer s.pausedMu.RUnlock()
+
+	return s.paused[namespace]
+}
+
+// RewindNamespace sets the progress of all triggers in the given namespace so that they are
+// next passed items from the given block.  It waits for any running poll to complete.
+// The action is recorded in the audit log against the actor in the context, if any.
+func (s *Service) RewindNamespace(ctx context.Context, namespace string, block uint64) error {
+	s.pollMu.Lock()
+	defer s.pollMu.Unlock()
+
+	latest := int64(block) - 1
+
+	blocksMD, err := s.getBlocksMetadata(ctx)
+	if err != nil {
+		return errors.Join(errors.New("failed to get metadata for block rewind"), err)
+	}
+	for _, trigger := range s.blockTriggers {
+		if trigger.Namespace == namespace {
+			blocksMD.LatestBlocks[trigger.QualifiedName()] = latest
+		}
+	}
+	if err := s.setBlocksMetadata(ctx, blocksMD); err != nil {
+		return errors.Join(errors.New("failed to set metadata after block rewind"), err)
+	}
+	s.recordBlocksProgress(blocksMD)
+
+	txsMD, err := s.getTransactionsMetadata(ctx)
+	if err != nil {
+		return errors.Join(errors.New("failed to get metadata for transaction rewind"), err)
+	}
+	for _, trigger := range s.txTriggers {
+		if trigger.Namespace == namespace {
+			txsMD.LatestBlocks[trigger.QualifiedName()] = latest
+		}
+	}
+	if err := s.setTransactionsMetadata(ctx, txsMD); err != nil {
+		return errors.Join(errors.New("failed to set metadata after transaction rewind"), err)
+	}
+	s.recordTransactionsProgress(txsMD)
+
+	eventsMD, err := s.getEventsMetadata(ctx)
+	if err != nil {
+		return errors.Join(errors.New("failed to get metadata for event rewind"), err)
+	}
+	for _, trigger := range s.eventTriggers {
+		if trigger.Namespace == namespace {
+			eventsMD.Entries[trigger.QualifiedName()] = &eventsEntryMetadata{
+				LatestBlock:      block,
+				LatestEventIndex: -1,
+			}
+		}
+	}
+	if err := s.setEventsMetadata(ctx, eventsMD); err != nil {
+		return errors.Join(errors.New("failed to set metadata after event rewind"), err)
+	}
+	s.recordEventsProgress(eventsMD)
+
+	// Ensure that the next poll runs even if the chain has not advanced.
+	s.lastPollSucceeded = false
+	s.log.Info().Str("namespace", namespace).Uint64("block", block).Str("actor", ActorFromContext(ctx)).Msg("Rewound namespace")
+	s.audit(ctx, AuditActionRewind, namespace, fmt.Sprintf("block %d", block))
+
+	return nil
+}
+
+// activeTxTriggers returns the transaction triggers that are not paused.
+func (s *Service) activeTxTriggers() []*handlers.TxTrigger {
+	triggers := make([]*handlers.TxTrigger, 0, len(s.txTriggers))
+	for _, trigger := range s.txTriggers {
+		if !s.NamespacePaused(trigger.Namespace) {
+			triggers = append(triggers, trigger)
+		}
+	}
+
+	return triggers
+}
+
+// blockTriggerNamed returns the block trigger with the given qualified name, or nil if there is none.
+func (s *Service) blockTriggerNamed(name string) *handlers.BlockTrigger {
+	for _, trigger := range s.blockTriggers {
+		if trigger.QualifiedName() == name {
+			return trigger
+		}
+	}
+
+	return nil
+}
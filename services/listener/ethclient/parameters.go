@@ -24,22 +24,72 @@ import (
 	"github.com/wealdtech/go-eth-listener/handlers"
 	"github.com/wealdtech/go-eth-listener/services/metrics"
 	nullmetrics "github.com/wealdtech/go-eth-listener/services/metrics/null"
+	"github.com/wealdtech/go-eth-listener/services/quorum"
 )
 
+// defaultMaxBackoffIntervals is the number of intervals in the maximum backoff if it is not supplied.
+const defaultMaxBackoffIntervals = 10
+
 type parameters struct {
-	logLevel       zerolog.Level
-	clientLogLevel zerolog.Level
-	monitor        metrics.Service
-	metadataDBPath string
-	address        string
-	timeout        time.Duration
-	blockDelay     uint32
-	blockSpecifier string
-	earliestBlock  int32
-	blockTriggers  []*handlers.BlockTrigger
-	txTriggers     []*handlers.TxTrigger
-	eventTriggers  []*handlers.EventTrigger
-	interval       time.Duration
+	logLevel              zerolog.Level
+	clientLogLevel        zerolog.Level
+	monitor               metrics.Service
+	metadataDBPath        string
+	address               string
+	addresses             []string
+	provider              quorum.Provider
+	chainID               uint64
+	timeout               time.Duration
+	blockDelay            uint64
+	blockSpecifier        string
+	earliestBlock         int64
+	blockTriggers         []*handlers.BlockTrigger
+	txTriggers            []*handlers.TxTrigger
+	compositeTriggers     []*handlers.CompositeTrigger
+	windowTriggers        []*handlers.WindowTrigger
+	eventTriggers         []*handlers.EventTrigger
+	scheduleTriggers      []*handlers.ScheduleTrigger
+	interval              time.Duration
+	trackingTimeout       uint64
+	prePollHooks          []PollHook
+	postPollHooks         []PollHook
+	addressLabeler        handlers.AddressLabeler
+	tokenMetadata         handlers.TokenMetadataProvider
+	signatureLookup       handlers.SignatureLookup
+	reorgHandler          handlers.ReorgHandler
+	abis                  [][]byte
+	verificationAddresses []string
+	quorum                int
+	validateResponses     bool
+	verifyReceipts        bool
+	receiptProofs         bool
+	isolateTriggers       bool
+	blockMemoryBudget     uint64
+	maxEventsPerFetch     int
+	maxBlocksForEvents    uint64
+	discoverEarliestBlock bool
+	clampEarliestBlock    bool
+	startupHandlers       []LifecycleHandler
+	shutdownHandlers      []LifecycleHandler
+	pollTimeout           time.Duration
+	pollTimeoutDefaulted  bool
+	maxBackoff            time.Duration
+	maxBackoffDefaulted   bool
+	errorLogSampling      map[ErrorClass]int
+	initialProgress       map[string]uint64
+	contextDecorators     []HandlerContextDecorator
+	catchUpThreshold      uint64
+	eventIndex            bool
+	eventIndexBlocks      uint64
+	eventIndexPeriod      time.Duration
+	eventIndexPrune       time.Duration
+	metadataCipher        ValueCipher
+	compressionThreshold  int
+	backupStore           BackupStore
+	backupInterval        time.Duration
+	fastPathPriority      *int
+	configurationLoader   ConfigurationLoader
+	reloadOnHUP           bool
 }
 
 // Parameter is the interface for service parameters.
@@ -88,6 +138,268 @@ func WithAddress(address string) Parameter {
 	})
 }
 
+// WithAddresses sets the addresses of Ethereum clients in order of priority, following any address
+// set with WithAddress.  Requests go to the first healthy client, failing over to the next if it
+// becomes unreachable or stops advancing, and failing back to the primary once it recovers.
+func WithAddresses(addresses []string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.addresses = addresses
+	})
+}
+
+// WithProvider sets a provider of chain data to use in place of an Ethereum client at an address,
+// for example a simulated chain in tests.
+func WithProvider(provider quorum.Provider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.provider = provider
+	})
+}
+
+// WithChainID sets the chain ID of the Ethereum client, used in the idempotency keys of items.
+// If not supplied it is obtained from the client.
+func WithChainID(chainID uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainID = chainID
+	})
+}
+
+// WithVerificationAddresses sets the addresses of additional Ethereum clients against which
+// chain data is cross-verified.  If supplied, blocks and events are only passed to handlers
+// when a quorum of clients agree on them.
+func WithVerificationAddresses(addresses []string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.verificationAddresses = addresses
+	})
+}
+
+// WithQuorum sets the number of Ethereum clients that must agree on chain data when
+// verification addresses are supplied.  If not supplied a majority is required.
+func WithQuorum(quorum int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.quorum = quorum
+	})
+}
+
+// WithValidateResponses sets whether responses from Ethereum clients are sanity-checked before
+// use, with anomalies such as events outside of the requested range retried rather than being
+// passed to handlers.
+func WithValidateResponses(validate bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.validateResponses = validate
+	})
+}
+
+// WithVerifyReceipts sets whether events are verified against the receipts root of their block
+// before being passed to handlers.  This requires fetching the receipts for every transaction
+// in each block containing an event, so is expensive.
+func WithVerifyReceipts(verify bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.verifyReceipts = verify
+	})
+}
+
+// WithReceiptProofs sets whether proofs of inclusion of their receipts are attached to events
+// passed to handlers.  Events are verified against the receipts root of their block in the
+// process, so this implies receipts verification.
+func WithReceiptProofs(proofs bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.receiptProofs = proofs
+	})
+}
+
+// WithTriggerIsolation sets whether block and event triggers are processed concurrently within
+// a poll, each with its own cursor, so that a failing trigger does not hold back the progress of
+// the others.  A poll completes when all of its triggers have completed, so a slow trigger still
+// delays the next poll.  Handlers shared between triggers must be safe for concurrent use.
+func WithTriggerIsolation(isolate bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.isolateTriggers = isolate
+	})
+}
+
+// WithBlockMemoryBudget sets the approximate memory, in bytes, that blocks may occupy in the cache
+// held during a poll.  Older blocks are evicted to stay within the budget, and a block larger than
+// the budget is not cached at all, at the cost of fetching blocks more than once.
+// The default of 0 limits the cache by number of blocks alone.
+func WithBlockMemoryBudget(bytes uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.blockMemoryBudget = bytes
+	})
+}
+
+// WithMaxEventsPerFetch sets the maximum number of events to obtain in a single request.  If a
+// request returns more events then the range of blocks requested for the trigger is narrowed,
+// and widened again as the number of events falls.  A single block is always fetched in full.
+// The default of 0 does not limit the number of events.
+func WithMaxEventsPerFetch(events int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxEventsPerFetch = events
+	})
+}
+
+// WithMaxBlocksPerEventPoll sets the maximum number of blocks for which each event trigger fetches
+// events in a poll.  Larger values allow faster backfills from clients that serve large ranges,
+// and smaller values suit clients that limit the range of a request.  The default is 100.
+func WithMaxBlocksPerEventPoll(blocks uint32) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxBlocksForEvents = uint64(blocks)
+	})
+}
+
+// WithDiscoverEarliestBlock sets whether the earliest block available from the Ethereum client
+// is discovered at startup.  This allows errors for unavailable history to report it.
+func WithDiscoverEarliestBlock(discover bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.discoverEarliestBlock = discover
+	})
+}
+
+// WithClampEarliestBlock sets whether earliest blocks of triggers that are before the earliest
+// block available from the Ethereum client are moved forward to it, rather than resulting in
+// repeated failures to obtain unavailable history.  This implies discovery of the earliest block.
+func WithClampEarliestBlock(clamp bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.clampEarliestBlock = clamp
+	})
+}
+
+// WithInitialProgress sets the first block to process for each named trigger that has no
+// recorded progress, for example a contract's deployment block or the cursor of a previous
+// indexer.  Triggers with recorded progress are unaffected.
+func WithInitialProgress(progress map[string]uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.initialProgress = progress
+	})
+}
+
+// WithStartupHandler adds a handler that is called when the listener starts, before the first poll.
+// If the handler returns an error the listener is not started.
+func WithStartupHandler(handler LifecycleHandler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.startupHandlers = append(p.startupHandlers, handler)
+	})
+}
+
+// WithShutdownHandler adds a handler that is called when the listener stops, after any
+// in-progress poll has completed and its progress has been recorded.
+func WithShutdownHandler(handler LifecycleHandler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.shutdownHandlers = append(p.shutdownHandlers, handler)
+	})
+}
+
+// WithPollTimeout sets the deadline for each poll, after which the poll is cancelled.
+// If not supplied this defaults to the interval, and follows the interval if it is reconfigured.
+func WithPollTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.pollTimeout = timeout
+	})
+}
+
+// WithMaxBackoff sets the maximum time to wait between polls after repeated failures.
+// The wait doubles from the interval with each consecutive failure, up to this value.
+// If not supplied this defaults to ten times the interval, and follows the interval if it is reconfigured.
+func WithMaxBackoff(backoff time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxBackoff = backoff
+	})
+}
+
+// WithErrorLogSampling sets the sampling of logs for repeated errors of the given class.  Of a run
+// of consecutive occurrences of an error only the first and every nth thereafter are logged, along
+// with the number of occurrences, and recovery from the error is logged.  If not supplied, or if
+// the value is 0 or 1, every occurrence is logged.
+func WithErrorLogSampling(class ErrorClass, every int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.errorLogSampling[class] = every
+	})
+}
+
+// WithCatchUpThreshold sets the number of blocks by which processing can trail the chain head
+// before the listener is considered to be catching up rather than live.
+// If not supplied this defaults to 32 blocks.
+func WithCatchUpThreshold(threshold uint32) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.catchUpThreshold = uint64(threshold)
+	})
+}
+
+// WithMetadataCipher sets the cipher used to encrypt values written to the metadata database.
+// Keys are not encrypted.  Encryption cannot be enabled or disabled for an existing database.
+func WithMetadataCipher(cipher ValueCipher) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.metadataCipher = cipher
+	})
+}
+
+// WithMetadataCompressionThreshold sets the size in bytes above which values written to the metadata
+// database are compressed, or 0 to disable compression.  Compressed values are flagged, so values
+// written with any threshold can be read regardless of the current threshold.
+func WithMetadataCompressionThreshold(bytes int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.compressionThreshold = bytes
+	})
+}
+
+// WithBackupStore sets the store to which the metadata database is periodically backed up.
+// If the metadata database is missing or corrupt at startup it is restored from the store.
+func WithBackupStore(store BackupStore) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.backupStore = store
+	})
+}
+
+// WithBackupInterval sets the interval at which the metadata database is backed up.
+// If not supplied this defaults to an hour.
+func WithBackupInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.backupInterval = interval
+	})
+}
+
+// WithEventIndex enables the embedded event index, in which events matched by event triggers
+// are stored in the metadata database.  Indexed events can be queried with EventsInRange()
+// and EventsByAddress().
+func WithEventIndex(enabled bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.eventIndex = enabled
+	})
+}
+
+// WithEventIndexRetentionBlocks sets the number of blocks behind the chain head for which
+// events are kept in the event index.  If not supplied events are not pruned by block.
+func WithEventIndexRetentionBlocks(blocks uint32) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.eventIndexBlocks = uint64(blocks)
+	})
+}
+
+// WithEventIndexRetentionPeriod sets the period for which events are kept in the event index
+// after they are indexed.  If not supplied events are not pruned by age.
+func WithEventIndexRetentionPeriod(period time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.eventIndexPeriod = period
+	})
+}
+
+// WithEventIndexPruneInterval sets the interval at which events outside the retention policy
+// are pruned from the event index.
+// If not supplied this defaults to an hour.
+func WithEventIndexPruneInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.eventIndexPrune = interval
+	})
+}
+
+// WithFastPathPriority enables a fast path for block triggers with at least the given priority.
+// Whilst the listener is catching up these triggers are brought up to date before other block
+// triggers are processed, rather than waiting for triggers that are further behind.
+func WithFastPathPriority(priority int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.fastPathPriority = &priority
+	})
+}
+
 // WithTimeout sets the timeout for requests made to the Ethereum client.
 func WithTimeout(timeout time.Duration) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -100,7 +412,7 @@ func WithTimeout(timeout time.Duration) Parameter {
 // Ignored if block specifier is provided.
 func WithBlockDelay(delay uint32) Parameter {
 	return parameterFunc(func(p *parameters) {
-		p.blockDelay = delay
+		p.blockDelay = uint64(delay)
 	})
 }
 
@@ -113,7 +425,7 @@ func WithBlockSpecifier(specifier string) Parameter {
 }
 
 // WithEarliestBlock sets the block number from which to start listening.
-func WithEarliestBlock(block int32) Parameter {
+func WithEarliestBlock(block int64) Parameter {
 	return parameterFunc(func(p *parameters) {
 		p.earliestBlock = block
 	})
@@ -133,6 +445,14 @@ func WithTxTriggers(triggers []*handlers.TxTrigger) Parameter {
 	})
 }
 
+// WithCompositeTriggers sets the composite triggers for the listener.
+// Composite triggers require transaction receipts from the client.
+func WithCompositeTriggers(triggers []*handlers.CompositeTrigger) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.compositeTriggers = triggers
+	})
+}
+
 // WithEventTriggers sets the event triggers for the listener.
 func WithEventTriggers(triggers []*handlers.EventTrigger) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -140,20 +460,130 @@ func WithEventTriggers(triggers []*handlers.EventTrigger) Parameter {
 	})
 }
 
+// WithWindowTriggers sets the windowed triggers for the listener.
+func WithWindowTriggers(triggers []*handlers.WindowTrigger) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.windowTriggers = triggers
+	})
+}
+
+// WithScheduleTriggers sets the schedule triggers for the listener.
+func WithScheduleTriggers(triggers []*handlers.ScheduleTrigger) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.scheduleTriggers = triggers
+	})
+}
+
 // WithInterval sets the interval between polls.
+// An interval of 0 means that the service never polls by itself, and polls must be
+// carried out by calling PollOnce.
 func WithInterval(interval time.Duration) Parameter {
 	return parameterFunc(func(p *parameters) {
 		p.interval = interval
 	})
 }
 
+// WithTrackingTimeout sets the number of blocks after which a tracked transaction
+// that has not been included is considered dropped.
+// If not supplied tracked transactions are never considered dropped.
+func WithTrackingTimeout(blocks uint32) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.trackingTimeout = uint64(blocks)
+	})
+}
+
+// WithPrePollHook adds a hook that is called before each poll, once the range of the poll is known.
+// It is not called for polls that find nothing new to process.
+func WithPrePollHook(hook PollHook) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.prePollHooks = append(p.prePollHooks, hook)
+	})
+}
+
+// WithPostPollHook adds a hook that is called after each poll with the outcome of the poll,
+// including polls that find nothing new to process.
+func WithPostPollHook(hook PollHook) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.postPollHooks = append(p.postPollHooks, hook)
+	})
+}
+
+// WithHandlerContextDecorator adds a decorator that is applied to the context passed to
+// every handler invocation.  Decorators are applied in the order in which they are supplied.
+func WithHandlerContextDecorator(decorator HandlerContextDecorator) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.contextDecorators = append(p.contextDecorators, decorator)
+	})
+}
+
+// WithAddressLabeler sets the labeler for addresses in items passed to handlers.
+// Labels are available to handlers with handlers.AddressLabelsFromContext().
+func WithAddressLabeler(labeler handlers.AddressLabeler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.addressLabeler = labeler
+	})
+}
+
+// WithTokenMetadataProvider sets the provider of token metadata, which is passed to
+// event handlers for events emitted by tokens.
+func WithTokenMetadataProvider(provider handlers.TokenMetadataProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.tokenMetadata = provider
+	})
+}
+
+// WithSignatureLookup sets the lookup used to annotate transactions and events with
+// probable human-readable signatures, which are passed to handlers.
+func WithSignatureLookup(lookup handlers.SignatureLookup) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.signatureLookup = lookup
+	})
+}
+
+// WithABIs sets JSON ABIs with which to decode events before they are handled.  Decoded events
+// are available to handlers with handlers.DecodedEventFromContext(), and are passed to handlers
+// that implement handlers.DecodedEventHandler.  Triggers can supply further ABIs of their own.
+func WithABIs(abis [][]byte) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.abis = abis
+	})
+}
+
+// WithReorgHandler sets the handler notified when the chain reorganizes below blocks seen by the listener.
+// When set, each poll also confirms that the block processed by the previous poll is still canonical.
+func WithReorgHandler(handler handlers.ReorgHandler) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.reorgHandler = handler
+	})
+}
+
+// WithConfigurationLoader sets the loader used to obtain the listener's triggers and interval when
+// the configuration is reloaded with Reload.
+func WithConfigurationLoader(loader ConfigurationLoader) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.configurationLoader = loader
+	})
+}
+
+// WithReloadOnHUP reloads the configuration when the process receives SIGHUP.
+// This requires a configuration loader.
+func WithReloadOnHUP(reload bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.reloadOnHUP = reload
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
-		logLevel:       zerolog.GlobalLevel(),
-		clientLogLevel: zerolog.GlobalLevel(),
-		monitor:        nullmetrics.New(),
-		earliestBlock:  -1,
+		logLevel:             zerolog.GlobalLevel(),
+		clientLogLevel:       zerolog.GlobalLevel(),
+		monitor:              nullmetrics.New(),
+		earliestBlock:        -1,
+		catchUpThreshold:     32,
+		compressionThreshold: 1024,
+		maxBlocksForEvents:   defaultMaxBlocksForEvents,
+		errorLogSampling:     make(map[ErrorClass]int),
 	}
 	for _, p := range params {
 		if p != nil {
@@ -167,17 +597,112 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.timeout == 0 {
 		return nil, errors.New("no timeout specified")
 	}
-	if parameters.address == "" {
-		return nil, errors.New("no address specified")
+	for _, address := range parameters.addresses {
+		if address == "" {
+			return nil, errors.New("empty address specified")
+		}
+	}
+	if parameters.address == "" && len(parameters.addresses) > 0 {
+		// The first of the addresses is the primary.
+		parameters.address = parameters.addresses[0]
+		parameters.addresses = parameters.addresses[1:]
+	}
+	if parameters.address == "" && parameters.provider == nil {
+		return nil, errors.New("no address or provider specified")
+	}
+	if parameters.compressionThreshold < 0 {
+		return nil, errors.New("metadata compression threshold cannot be negative")
+	}
+	if parameters.maxBlocksForEvents == 0 {
+		return nil, errors.New("max blocks per event poll must be greater than 0")
+	}
+	if parameters.maxEventsPerFetch < 0 {
+		return nil, errors.New("max events per fetch cannot be negative")
+	}
+	for class, every := range parameters.errorLogSampling {
+		if !errorClasses[class] {
+			return nil, fmt.Errorf("unknown error class %q for log sampling", class)
+		}
+		if every < 0 {
+			return nil, errors.New("error log sampling cannot be negative")
+		}
+	}
+	if parameters.maxBackoff < 0 {
+		return nil, errors.New("max backoff cannot be negative")
+	}
+	if parameters.maxBackoff == 0 {
+		parameters.maxBackoff = defaultMaxBackoffIntervals * parameters.interval
+		parameters.maxBackoffDefaulted = true
+	}
+	if parameters.maxBackoff < parameters.interval {
+		return nil, errors.New("max backoff cannot be less than interval")
+	}
+	if parameters.backupInterval < 0 {
+		return nil, errors.New("backup interval cannot be negative")
+	}
+	if parameters.backupInterval == 0 {
+		parameters.backupInterval = time.Hour
+	}
+	if parameters.eventIndexPeriod < 0 {
+		return nil, errors.New("event index retention period cannot be negative")
+	}
+	if parameters.eventIndexPrune < 0 {
+		return nil, errors.New("event index prune interval cannot be negative")
+	}
+	if parameters.eventIndexPrune == 0 {
+		parameters.eventIndexPrune = time.Hour
+	}
+	if parameters.pollTimeout < 0 {
+		return nil, errors.New("poll timeout cannot be negative")
+	}
+	if parameters.pollTimeout == 0 {
+		parameters.pollTimeout = parameters.interval
+		parameters.pollTimeoutDefaulted = true
+	}
+	if parameters.clampEarliestBlock {
+		parameters.discoverEarliestBlock = true
+	}
+	if parameters.reloadOnHUP && parameters.configurationLoader == nil {
+		return nil, errors.New("reload on SIGHUP requires a configuration loader")
+	}
+	for _, address := range parameters.verificationAddresses {
+		if address == "" {
+			return nil, errors.New("empty verification address specified")
+		}
 	}
 	if parameters.metadataDBPath == "" {
 		return nil, errors.New("no metadata db path specified")
 	}
+	for _, handlers := range [][]LifecycleHandler{parameters.startupHandlers, parameters.shutdownHandlers} {
+		for _, handler := range handlers {
+			if handler == nil {
+				return nil, errors.New("nil lifecycle handler specified")
+			}
+		}
+	}
+	for _, hooks := range [][]PollHook{parameters.prePollHooks, parameters.postPollHooks} {
+		for _, hook := range hooks {
+			if hook == nil {
+				return nil, errors.New("nil poll hook specified")
+			}
+		}
+	}
+	for _, decorator := range parameters.contextDecorators {
+		if decorator == nil {
+			return nil, errors.New("nil handler context decorator specified")
+		}
+	}
 	if err := checkTriggerParameters(&parameters); err != nil {
 		return nil, err
 	}
-	if parameters.interval == 0 {
-		return nil, errors.New("no interval specified")
+	if err := checkCompositeTriggerParameters(&parameters); err != nil {
+		return nil, err
+	}
+	if err := checkWindowTriggerParameters(&parameters); err != nil {
+		return nil, err
+	}
+	if err := checkTriggerDependencies(&parameters); err != nil {
+		return nil, err
 	}
 
 	validBlockSpecifiers := map[string]struct{}{
@@ -198,6 +723,9 @@ func checkTriggerParameters(parameters *parameters) error {
 		if blockTrigger.Name == "" {
 			return errors.New("no block trigger name specified")
 		}
+		if strings.Contains(blockTrigger.Namespace, handlers.NamespaceSeparator) {
+			return fmt.Errorf("block trigger namespace cannot contain %q", handlers.NamespaceSeparator)
+		}
 		if blockTrigger.Handler == nil {
 			return errors.New("no block trigger handler specified")
 		}
@@ -206,6 +734,9 @@ func checkTriggerParameters(parameters *parameters) error {
 		if txTrigger.Name == "" {
 			return errors.New("no transaction trigger name specified")
 		}
+		if strings.Contains(txTrigger.Namespace, handlers.NamespaceSeparator) {
+			return fmt.Errorf("transaction trigger namespace cannot contain %q", handlers.NamespaceSeparator)
+		}
 		if txTrigger.Handler == nil {
 			return errors.New("no transaction trigger handler specified")
 		}
@@ -214,9 +745,35 @@ func checkTriggerParameters(parameters *parameters) error {
 		if eventTrigger.Name == "" {
 			return errors.New("no event trigger name specified")
 		}
+		if strings.Contains(eventTrigger.Namespace, handlers.NamespaceSeparator) {
+			return fmt.Errorf("event trigger namespace cannot contain %q", handlers.NamespaceSeparator)
+		}
 		if eventTrigger.Handler == nil {
 			return errors.New("no event trigger handler specified")
 		}
+		if err := checkEventTopics(eventTrigger); err != nil {
+			return errors.Join(fmt.Errorf("invalid topics for event trigger %s", eventTrigger.QualifiedName()), err)
+		}
+	}
+	for _, scheduleTrigger := range parameters.scheduleTriggers {
+		if scheduleTrigger.Name == "" {
+			return errors.New("no schedule trigger name specified")
+		}
+		if scheduleTrigger.Handler == nil {
+			return errors.New("no schedule trigger handler specified")
+		}
+		switch {
+		case scheduleTrigger.Every == 0 && scheduleTrigger.Cron == "":
+			return errors.New("no schedule trigger interval or cron expression specified")
+		case scheduleTrigger.Every != 0 && scheduleTrigger.Cron != "":
+			return errors.New("only one of schedule trigger interval and cron expression can be specified")
+		case scheduleTrigger.Every < 0:
+			return errors.New("schedule trigger interval cannot be negative")
+		case scheduleTrigger.Cron != "":
+			if _, err := parseCron(scheduleTrigger.Cron); err != nil {
+				return errors.Join(fmt.Errorf("invalid cron expression for schedule trigger %s", scheduleTrigger.Name), err)
+			}
+		}
 	}
 
 	return nil
@@ -0,0 +1,88 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"sort"
+
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// sortByPriority returns copies of the triggers ordered by descending priority, retaining the
+// supplied order for triggers of equal priority.
+func sortByPriority(parameters *parameters) (
+	[]*handlers.BlockTrigger,
+	[]*handlers.TxTrigger,
+	[]*handlers.EventTrigger,
+) {
+	blockTriggers := append([]*handlers.BlockTrigger{}, parameters.blockTriggers...)
+	sort.SliceStable(blockTriggers, func(i, j int) bool {
+		return blockTriggers[i].Priority > blockTriggers[j].Priority
+	})
+	txTriggers := append([]*handlers.TxTrigger{}, parameters.txTriggers...)
+	sort.SliceStable(txTriggers, func(i, j int) bool {
+		return txTriggers[i].Priority > txTriggers[j].Priority
+	})
+	eventTriggers := append([]*handlers.EventTrigger{}, parameters.eventTriggers...)
+	sort.SliceStable(eventTriggers, func(i, j int) bool {
+		return eventTriggers[i].Priority > eventTriggers[j].Priority
+	})
+
+	return blockTriggers, txTriggers, eventTriggers
+}
+
+// pollFastPathBlocks brings block triggers at or above the fast path priority up to the given
+// block before other block triggers are processed, if the listener is catching up.  This stops
+// high priority triggers from waiting for lower priority triggers that are further behind.
+func (s *Service) pollFastPathBlocks(ctx context.Context, to uint64) error {
+	if s.fastPathPriority == nil || !s.catchingUp.Load() || s.earliestBlock > -1 {
+		return nil
+	}
+
+	triggers := make([]*handlers.BlockTrigger, 0)
+	for _, trigger := range s.blockTriggers {
+		if trigger.Priority >= *s.fastPathPriority {
+			triggers = append(triggers, trigger)
+		}
+	}
+	if len(triggers) == 0 || len(triggers) == len(s.blockTriggers) {
+		// There is nothing to gain from a separate pass.
+		return nil
+	}
+
+	s.log.Trace().Int("triggers", len(triggers)).Msg("Polling blocks for fast path triggers")
+
+	return s.pollBlocksFor(ctx, to, triggers)
+}
+
+// fastPathBlocksFrom calculates the earliest block required by the given triggers.
+// It returns false if any of the triggers has no progress recorded.
+func (s *Service) fastPathBlocksFrom(md *blocksMetadata, triggers []*handlers.BlockTrigger) (uint64, bool) {
+	from := maxUint64
+	for _, trigger := range triggers {
+		if s.NamespacePaused(trigger.Namespace) {
+			continue
+		}
+		latest, exists := md.LatestBlocks[trigger.QualifiedName()]
+		if !exists {
+			return 0, false
+		}
+		if uint64(latest+1) < from {
+			from = uint64(latest + 1)
+		}
+	}
+
+	return s.availableFrom(from), true
+}
@@ -0,0 +1,143 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"errors"
+	"maps"
+)
+
+// triggerType is the type of a trigger.
+type triggerType int
+
+const (
+	blockTriggerType triggerType = iota
+	txTriggerType
+	eventTriggerType
+)
+
+func (t triggerType) String() string {
+	switch t {
+	case blockTriggerType:
+		return "block"
+	case txTriggerType:
+		return "transaction"
+	case eventTriggerType:
+		return "event"
+	default:
+		return "unknown"
+	}
+}
+
+// progressKey identifies a trigger for progress.
+// Triggers of different types can share a name, so the type is part of the key.
+type progressKey struct {
+	triggerType triggerType
+	name        string
+}
+
+// ChainHead returns the chain head as of the last poll.
+func (s *Service) ChainHead() uint64 {
+	return s.chainHead.Load()
+}
+
+// TriggerLag returns the number of blocks by which the named trigger trails the highest block selected
+// by the last poll, which allows for the block delay or specifier, so a trigger that has caught up has no lag.
+// The chain head itself is returned by ChainHead.
+// Triggers in a namespace are named by their qualified name.
+// If triggers of more than one type share the name then the largest lag is returned.
+func (s *Service) TriggerLag(name string) (uint64, error) {
+	target := s.pollTarget.Load()
+
+	s.progressMu.RLock()
+	defer s.progressMu.RUnlock()
+
+	found := false
+	lag := uint64(0)
+	for _, triggerType := range []triggerType{blockTriggerType, txTriggerType, eventTriggerType} {
+		latest, exists := s.progress[progressKey{triggerType: triggerType, name: name}]
+		if !exists {
+			continue
+		}
+		found = true
+		if latest < target && target-latest > lag {
+			lag = target - latest
+		}
+	}
+	if !found {
+		return 0, errors.New("no progress for trigger")
+	}
+
+	return lag, nil
+}
+
+// recordProgress records the latest block processed by a trigger.
+func (s *Service) recordProgress(triggerType triggerType, name string, latest uint64) {
+	s.progressMu.Lock()
+	s.progress[progressKey{triggerType: triggerType, name: name}] = latest
+	s.progressMu.Unlock()
+}
+
+// progressSnapshot returns a copy of the latest block processed by each trigger.
+func (s *Service) progressSnapshot() map[progressKey]uint64 {
+	s.progressMu.RLock()
+	defer s.progressMu.RUnlock()
+
+	return maps.Clone(s.progress)
+}
+
+// progressAdvanced returns true if any trigger has processed blocks beyond those in the snapshot.
+func (s *Service) progressAdvanced(snapshot map[progressKey]uint64) bool {
+	s.progressMu.RLock()
+	defer s.progressMu.RUnlock()
+
+	for key, latest := range s.progress {
+		if previous, exists := snapshot[key]; !exists || latest > previous {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recordBlocksProgress records the progress of block triggers from their metadata.
+func (s *Service) recordBlocksProgress(md *blocksMetadata) {
+	blockTriggers, _, _ := s.triggers()
+	for _, trigger := range blockTriggers {
+		if latest, exists := md.LatestBlocks[trigger.QualifiedName()]; exists && latest >= 0 {
+			s.recordProgress(blockTriggerType, trigger.QualifiedName(), uint64(latest))
+		}
+	}
+}
+
+// recordTransactionsProgress records the progress of transaction triggers from their metadata.
+func (s *Service) recordTransactionsProgress(md *transactionsMetadata) {
+	_, txTriggers, _ := s.triggers()
+	for _, trigger := range txTriggers {
+		if latest, exists := md.LatestBlocks[trigger.QualifiedName()]; exists && latest >= 0 {
+			s.recordProgress(txTriggerType, trigger.QualifiedName(), uint64(latest))
+		}
+	}
+}
+
+// recordEventsProgress records the progress of event triggers from their metadata.
+func (s *Service) recordEventsProgress(md *eventsMetadata) {
+	_, _, eventTriggers := s.triggers()
+	for _, trigger := range eventTriggers {
+		// The latest block in the metadata is the next block to process.
+		if entry, exists := md.Entries[trigger.QualifiedName()]; exists && entry.LatestBlock > 0 {
+			s.recordProgress(eventTriggerType, trigger.QualifiedName(), entry.LatestBlock-1)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"errors"
+	"time"
+)
+
+// maxRateSamples is the number of recent polls over which the rate of progress is calculated.
+const maxRateSamples = 10
+
+// progressSample is the progress of a trigger at a point in time.
+type progressSample struct {
+	time   time.Time
+	latest uint64
+}
+
+// TriggerRate returns the rate, in blocks per second, at which the named trigger has progressed
+// over recent polls.
+// If triggers of more than one type share the name then the lowest rate is returned.
+func (s *Service) TriggerRate(name string) (float64, error) {
+	rate, _, err := s.triggerRateAndETA(name)
+
+	return rate, err
+}
+
+// TriggerETA returns the estimated time for the named trigger to catch up with the highest block
+// selected by the last poll, which allows for the block specifier or delay, based on its rate of
+// progress over recent polls.  It returns 0 if the trigger has caught up, and -1 if the trigger is
+// behind but not making progress.
+// If triggers of more than one type share the name then the longest time is returned.
+func (s *Service) TriggerETA(name string) (time.Duration, error) {
+	_, eta, err := s.triggerRateAndETA(name)
+
+	return eta, err
+}
+
+func (s *Service) triggerRateAndETA(name string) (float64, time.Duration, error) {
+	target := s.pollTarget.Load()
+
+	s.progressMu.RLock()
+	defer s.progressMu.RUnlock()
+
+	found := false
+	var rate float64
+	var eta time.Duration
+	for _, triggerType := range []triggerType{blockTriggerType, txTriggerType, eventTriggerType} {
+		key := progressKey{triggerType: triggerType, name: name}
+		samples, exists := s.progressSamples[key]
+		if !exists {
+			continue
+		}
+		keyRate, keyETA := rateAndETA(samples, target)
+		if !found || keyRate < rate {
+			rate = keyRate
+		}
+		if keyETA < 0 || (eta >= 0 && keyETA > eta) {
+			eta = keyETA
+		}
+		found = true
+	}
+	if !found {
+		return 0, 0, errors.New("no progress for trigger")
+	}
+
+	return rate, eta, nil
+}
+
+// sampleProgress records the current progress of each trigger, and updates rate metrics.
+func (s *Service) sampleProgress() {
+	now := time.Now()
+	target := s.pollTarget.Load()
+
+	s.progressMu.Lock()
+	defer s.progressMu.Unlock()
+
+	for key, latest := range s.progress {
+		samples := append(s.progressSamples[key], &progressSample{
+			time:   now,
+			latest: latest,
+		})
+		if len(samples) > maxRateSamples {
+			samples = samples[len(samples)-maxRateSamples:]
+		}
+		s.progressSamples[key] = samples
+
+		rate, eta := rateAndETA(samples, target)
+		monitorTriggerRate(key.triggerType.String(), key.name, rate, eta)
+	}
+}
+
+// rateAndETA calculates the rate of progress and the time to catch up with the target from samples.
+func rateAndETA(samples []*progressSample, target uint64) (float64, time.Duration) {
+	if len(samples) == 0 {
+		return 0, 0
+	}
+	first := samples[0]
+	last := samples[len(samples)-1]
+
+	var rate float64
+	if elapsed := last.time.Sub(first.time).Seconds(); elapsed > 0 && last.latest > first.latest {
+		rate = float64(last.latest-first.latest) / elapsed
+	}
+
+	if last.latest >= target {
+		return rate, 0
+	}
+	if rate == 0 {
+		// No progress is being made, so the time to catch up is unknown.
+		return rate, -1
+	}
+
+	return rate, time.Duration(float64(target-last.latest) / rate * float64(time.Second))
+}
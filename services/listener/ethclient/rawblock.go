@@ -0,0 +1,172 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	jsonrpcexecclient "github.com/attestantio/go-execution-client/jsonrpc"
+	"github.com/attestantio/go-execution-client/spec"
+)
+
+// ErrUnsupportedTransactionType is returned when a block contains transactions of a type that the
+// client library cannot decode, such as EIP-7702 set-code transactions.
+var ErrUnsupportedTransactionType = errors.New("block contains unsupported transaction type")
+
+// RawBlocksProvider is the interface for providing blocks as returned by the Ethereum client.
+// The listener uses it to obtain blocks for which a provider returns ErrUnsupportedTransactionType.
+// The failover, quorum and validation providers implement it if their underlying providers do.
+type RawBlocksProvider interface {
+	// RawBlock returns the JSON of the block with the given ID, including full transactions.
+	// It returns nil if the block is not found.
+	RawBlock(ctx context.Context, blockID string) (json.RawMessage, error)
+}
+
+// maxRawBlockSize is the maximum size of a block fetched directly from the client.
+const maxRawBlockSize = 256 * 1024 * 1024
+
+type rawBlockResponseJSON struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// jsonrpcProvider is a JSON-RPC Ethereum client that fetches blocks itself, so that it can
+// identify blocks the client library cannot decode and supply them as raw JSON.
+type jsonrpcProvider struct {
+	*jsonrpcexecclient.Service
+	address string
+	client  *http.Client
+}
+
+// Block returns the block with the given ID.
+// It returns ErrUnsupportedTransactionType if the block contains transactions that cannot be decoded.
+func (p *jsonrpcProvider) Block(ctx context.Context, blockID string) (*spec.Block, error) {
+	data, err := p.RawBlock(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	if err := checkTransactionTypes(data); err != nil {
+		return nil, err
+	}
+
+	block := &spec.Block{}
+	if err := json.Unmarshal(data, block); err != nil {
+		return nil, errors.Join(errors.New("failed to parse block"), err)
+	}
+
+	return block, nil
+}
+
+// RawBlock returns the JSON of the block with the given ID.
+func (p *jsonrpcProvider) RawBlock(ctx context.Context, blockID string) (json.RawMessage, error) {
+	method, param := blockRequest(blockID)
+	request, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  []any{param, true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.address, bytes.NewReader(request))
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to create block request"), err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to request block"), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("block request returned status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRawBlockSize))
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to read block response"), err)
+	}
+
+	response := &rawBlockResponseJSON{}
+	if err := json.Unmarshal(data, response); err != nil {
+		return nil, errors.Join(errors.New("failed to parse block response"), err)
+	}
+	if response.Error != nil {
+		return nil, fmt.Errorf("%s for %s failed: %s", method, param, response.Error.Message)
+	}
+	if len(response.Result) == 0 || bytes.Equal(response.Result, []byte("null")) {
+		return nil, nil
+	}
+
+	return response.Result, nil
+}
+
+// blockRequest returns the JSON-RPC method and parameter to request the block with the given ID.
+func blockRequest(blockID string) (string, string) {
+	switch {
+	case blockID == "":
+		return "eth_getBlockByNumber", "latest"
+	case strings.HasPrefix(blockID, "0x"):
+		return "eth_getBlockByHash", blockID
+	}
+
+	if height, err := strconv.ParseUint(blockID, 10, 64); err == nil {
+		return "eth_getBlockByNumber", fmt.Sprintf("0x%x", height)
+	}
+
+	// A named block such as "latest" or "finalized".
+	return "eth_getBlockByNumber", blockID
+}
+
+// checkTransactionTypes returns ErrUnsupportedTransactionType if the block contains transactions
+// of a type that the client library cannot decode.
+func checkTransactionTypes(data json.RawMessage) error {
+	txs, err := rawTransactions(data)
+	if err != nil {
+		return err
+	}
+	for _, tx := range txs {
+		var txType spec.TransactionType
+		if err := txType.UnmarshalJSON(tx["type"]); err != nil {
+			return errors.Join(ErrUnsupportedTransactionType, err)
+		}
+	}
+
+	return nil
+}
+
+// rawTransactions returns the transactions of a block as raw JSON fields.
+func rawTransactions(data json.RawMessage) ([]map[string]json.RawMessage, error) {
+	var block struct {
+		Transactions []map[string]json.RawMessage `json:"transactions"`
+	}
+	if err := json.Unmarshal(data, &block); err != nil {
+		return nil, errors.Join(errors.New("failed to parse block transactions"), err)
+	}
+
+	return block.Transactions, nil
+}
@@ -0,0 +1,338 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	executil "github.com/attestantio/go-execution-client/util"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// maxVerifiedBlocks is the maximum number of verified blocks to retain.
+const maxVerifiedBlocks = 1024
+
+// verifiedBlock contains the events of a block whose receipts have been verified
+// against its receipts root.
+type verifiedBlock struct {
+	hash         types.Hash
+	receiptsRoot types.Root
+	events       map[uint32]*spec.BerlinTransactionEvent
+	// txIndices maps event indices to the index of their transaction in the block.
+	txIndices map[uint32]int
+	// keys and values are the contents of the receipts trie.
+	keys   [][]byte
+	values [][]byte
+}
+
+// verifyEvent verifies that an event is present in the receipts of its block, and that
+// the receipts match the block's receipts root.
+func (s *Service) verifyEvent(ctx context.Context, event *spec.BerlinTransactionEvent) error {
+	verified, err := s.verifiedBlock(ctx, uint64(event.BlockNumber), event.BlockHash)
+	if err != nil {
+		return err
+	}
+	if verified.hash != event.BlockHash {
+		return fmt.Errorf("event block hash %#x does not match verified block hash %#x", event.BlockHash, verified.hash)
+	}
+
+	receiptEvent, exists := verified.events[event.Index]
+	if !exists {
+		return fmt.Errorf("event %d not present in verified receipts", event.Index)
+	}
+	if !eventsEqual(event, receiptEvent) {
+		return fmt.Errorf("event %d does not match verified receipts", event.Index)
+	}
+
+	return nil
+}
+
+// receiptProof returns the proof of inclusion of the receipt containing an event.
+func (s *Service) receiptProof(ctx context.Context, event *spec.BerlinTransactionEvent) (*handlers.ReceiptProof, error) {
+	verified, err := s.verifiedBlock(ctx, uint64(event.BlockNumber), event.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	txIndex, exists := verified.txIndices[event.Index]
+	if !exists {
+		return nil, fmt.Errorf("event %d not present in verified receipts", event.Index)
+	}
+
+	return &handlers.ReceiptProof{
+		BlockHash:        verified.hash,
+		ReceiptsRoot:     verified.receiptsRoot,
+		TransactionIndex: uint32(txIndex),
+		Key:              verified.keys[txIndex],
+		Receipt:          verified.values[txIndex],
+		Proof:            trieProof(verified.keys, verified.values, verified.keys[txIndex]),
+	}, nil
+}
+
+// verifiedBlock returns the verified events for a block, verifying them if required.
+func (s *Service) verifiedBlock(ctx context.Context, height uint64, hash types.Hash) (*verifiedBlock, error) {
+	s.verifiedMu.Lock()
+	verified, exists := s.verified[height]
+	s.verifiedMu.Unlock()
+	if exists && verified.hash == hash {
+		return verified, nil
+	}
+
+	if byzantium, exists := byzantiumBlocks[s.chainID]; exists && height < byzantium {
+		// Receipts before Byzantium contain a state root rather than a status, which the client library does not provide.
+		return nil, fmt.Errorf("receipts of pre-Byzantium block %d cannot be verified", height)
+	}
+
+	block, err := s.block(ctx, height)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to obtain block for receipts verification"), err)
+	}
+	// The receipts root is only trusted once the header containing it is known to hash to the block being processed.
+	receiptsRoot, err := s.verifiedReceiptsRoot(ctx, block, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([][]byte, 0, len(block.Transactions()))
+	values := make([][]byte, 0, len(block.Transactions()))
+	events := make(map[uint32]*spec.BerlinTransactionEvent)
+	txIndices := make(map[uint32]int)
+	for i, tx := range block.Transactions() {
+		receipt, err := s.receiptsProvider.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to obtain receipt for transaction %#x", tx.Hash()), err)
+		}
+		if receipt == nil {
+			return nil, fmt.Errorf("no receipt for transaction %#x", tx.Hash())
+		}
+		key := new(bytes.Buffer)
+		executil.RLPUint64(key, uint64(i))
+		keys = append(keys, key.Bytes())
+		values = append(values, encodeReceipt(receipt))
+		for _, event := range receipt.Logs() {
+			events[event.Index] = event
+			txIndices[event.Index] = i
+		}
+	}
+
+	root := trieRoot(keys, values)
+	if !bytes.Equal(root[:], receiptsRoot[:]) {
+		return nil, fmt.Errorf("calculated receipts root %#x does not match block %d receipts root %#x", root, height, receiptsRoot)
+	}
+	s.log.Trace().Uint64("block", height).Int("events", len(events)).Msg("Verified receipts root")
+
+	verified = &verifiedBlock{
+		hash:         hash,
+		receiptsRoot: receiptsRoot,
+		events:       events,
+		txIndices:    txIndices,
+		keys:         keys,
+		values:       values,
+	}
+	s.verifiedMu.Lock()
+	if len(s.verified) >= maxVerifiedBlocks {
+		s.verified = make(map[uint64]*verifiedBlock)
+	}
+	s.verified[height] = verified
+	s.verifiedMu.Unlock()
+
+	return verified, nil
+}
+
+// verifiedReceiptsRoot returns the receipts root of a block, having checked that the block's header
+// hashes to the given hash.
+// The header is obtained as returned by the client where possible, as the client library does not
+// hold all header fields of recent forks.
+func (s *Service) verifiedReceiptsRoot(ctx context.Context, block *spec.Block, hash types.Hash) (types.Root, error) {
+	var data []byte
+	var err error
+	if provider, isProvider := s.blocksProvider.(RawBlocksProvider); isProvider {
+		data, err = provider.RawBlock(ctx, fmt.Sprintf("%#x", hash))
+	} else {
+		data, err = json.Marshal(block)
+	}
+	if err != nil {
+		return types.Root{}, errors.Join(errors.New("failed to obtain block header for receipts verification"), err)
+	}
+	if data == nil {
+		return types.Root{}, fmt.Errorf("block %#x not found", hash)
+	}
+
+	header := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &header); err != nil {
+		return types.Root{}, errors.Join(errors.New("failed to parse block header"), err)
+	}
+	encoded, err := encodeHeader(header)
+	if err != nil {
+		return types.Root{}, err
+	}
+	if headerHash := keccak256(encoded); !bytes.Equal(headerHash[:], hash[:]) {
+		return types.Root{}, fmt.Errorf("calculated header hash %#x does not match block hash %#x", headerHash, hash)
+	}
+
+	receiptsRoot, err := headerBytes(header, "receiptsRoot")
+	if err != nil {
+		return types.Root{}, err
+	}
+	if len(receiptsRoot) != len(types.Root{}) {
+		return types.Root{}, fmt.Errorf("invalid receipts root length %d", len(receiptsRoot))
+	}
+
+	return types.Root(receiptsRoot), nil
+}
+
+// byzantiumBlocks are the first Byzantium blocks of chains that started before Byzantium, by chain ID.
+var byzantiumBlocks = map[uint64]uint64{
+	1: 4370000,
+}
+
+// headerField is a field of a block header.
+type headerField struct {
+	name    string
+	numeric bool
+}
+
+// headerFields are the fields of a block header, in the order in which they are encoded.
+var headerFields = []headerField{
+	{name: "parentHash"},
+	{name: "sha3Uncles"},
+	{name: "miner"},
+	{name: "stateRoot"},
+	{name: "transactionsRoot"},
+	{name: "receiptsRoot"},
+	{name: "logsBloom"},
+	{name: "difficulty", numeric: true},
+	{name: "number", numeric: true},
+	{name: "gasLimit", numeric: true},
+	{name: "gasUsed", numeric: true},
+	{name: "timestamp", numeric: true},
+	{name: "extraData"},
+	{name: "mixHash"},
+	{name: "nonce"},
+}
+
+// optionalHeaderFields are the fields added to block headers by later forks, in the order in which
+// they are encoded.  Each is present from the fork that introduced it onwards.
+var optionalHeaderFields = []headerField{
+	{name: "baseFeePerGas", numeric: true},
+	{name: "withdrawalsRoot"},
+	{name: "blobGasUsed", numeric: true},
+	{name: "excessBlobGas", numeric: true},
+	{name: "parentBeaconBlockRoot"},
+	{name: "requestsHash"},
+}
+
+// encodeHeader returns the consensus encoding of a block header from its JSON fields.
+func encodeHeader(header map[string]json.RawMessage) ([]byte, error) {
+	fields := headerFields
+	for _, field := range optionalHeaderFields {
+		if _, exists := header[field.name]; !exists {
+			break
+		}
+		fields = append(fields[:len(fields):len(fields)], field)
+	}
+
+	items := new(bytes.Buffer)
+	for _, field := range fields {
+		value, err := headerBytes(header, field.name)
+		if err != nil {
+			return nil, err
+		}
+		if field.numeric {
+			// Numbers are encoded without leading zeros.
+			value = new(big.Int).SetBytes(value).Bytes()
+		}
+		executil.RLPBytes(items, value)
+	}
+
+	buf := new(bytes.Buffer)
+	executil.RLPList(buf, items.Bytes())
+
+	return buf.Bytes(), nil
+}
+
+// headerBytes returns the value of a hex field of a block header.
+func headerBytes(header map[string]json.RawMessage, name string) ([]byte, error) {
+	var value string
+	if err := json.Unmarshal(header[name], &value); err != nil {
+		return nil, fmt.Errorf("block header field %s missing or invalid", name)
+	}
+	value = strings.TrimPrefix(value, "0x")
+	if len(value)%2 == 1 {
+		value = "0" + value
+	}
+	data, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("block header field %s invalid", name), err)
+	}
+
+	return data, nil
+}
+
+// encodeReceipt returns the consensus encoding of a receipt.
+// This is for a post-Byzantium receipt, containing a status rather than a state root; earlier
+// receipts are rejected before reaching here.
+func encodeReceipt(receipt *spec.TransactionReceipt) []byte {
+	logs := new(bytes.Buffer)
+	for _, event := range receipt.Logs() {
+		topics := new(bytes.Buffer)
+		for _, topic := range event.Topics {
+			executil.RLPBytes(topics, topic[:])
+		}
+		log := new(bytes.Buffer)
+		executil.RLPAddress(log, event.Address)
+		executil.RLPList(log, topics.Bytes())
+		executil.RLPBytes(log, event.Data)
+		executil.RLPList(logs, log.Bytes())
+	}
+
+	items := new(bytes.Buffer)
+	executil.RLPUint64(items, uint64(receipt.Status()))
+	executil.RLPUint64(items, uint64(receipt.CumulativeGasUsed()))
+	executil.RLPBytes(items, receipt.LogsBloom())
+	executil.RLPList(items, logs.Bytes())
+
+	buf := new(bytes.Buffer)
+	if receipt.Type() != spec.TransactionType0 {
+		buf.WriteByte(byte(receipt.Type()))
+	}
+	executil.RLPList(buf, items.Bytes())
+
+	return buf.Bytes()
+}
+
+// eventsEqual returns true if the two events have the same contents.
+func eventsEqual(a *spec.BerlinTransactionEvent, b *spec.BerlinTransactionEvent) bool {
+	if a.TransactionHash != b.TransactionHash ||
+		a.Address != b.Address ||
+		len(a.Topics) != len(b.Topics) ||
+		!bytes.Equal(a.Data, b.Data) {
+		return false
+	}
+	for i := range a.Topics {
+		if a.Topics[i] != b.Topics[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,200 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	executil "github.com/attestantio/go-execution-client/util"
+)
+
+// Block headers as returned by clients, with their hashes.
+const (
+	// mainnetGenesisHeader is the mainnet genesis block, with zero-valued number, gas used and timestamp.
+	mainnetGenesisHeader = `{"parentHash":"0x0000000000000000000000000000000000000000000000000000000000000000","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","miner":"0x0000000000000000000000000000000000000000","stateRoot":"0xd7f8974fb5ac78d9ac099b9ad5018bedc2ce0a72dad1827a1709da30580f0544","transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","difficulty":"0x400000000","number":"0x0","gasLimit":"0x1388","gasUsed":"0x0","timestamp":"0x0","extraData":"0x11bbe8db4e347b4e8c937c1c8370e4b5ed33adb3db69cbdb7a38e1e50b1b82fa","mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000","nonce":"0x0000000000000042"}`
+	mainnetGenesisHash   = "0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"
+	// mainnetBlock1Header is mainnet block 1.
+	mainnetBlock1Header = `{"parentHash":"0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","miner":"0x05a56e2d52c817161883f50c441c3228cfe54d9f","stateRoot":"0xd67e4d450343046425ae4271474353857ab860dbc0a1dde64b41b5cd3a532bf3","transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","difficulty":"0x3ff800000","number":"0x1","gasLimit":"0x1388","gasUsed":"0x0","timestamp":"0x55ba4224","extraData":"0x476574682f76312e302e302f6c696e75782f676f312e342e32","mixHash":"0x969b900de27b6ac6a67742365dd65f55a0526c41fd18e1b16f1a1215c2e66f59","nonce":"0x539bd4979fef1ec4"}`
+	mainnetBlock1Hash   = "0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6"
+	// mainnetLondonHeader is mainnet block 14430727, which has a base fee.
+	mainnetLondonHeader = `{"parentHash":"0xdc49656f3dc58120579f8b33624811cc029470ad872d777e0903065a78a2d34b","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","miner":"0x1ad91ee08f21be3de0ba2ba6918e714da6b45836","stateRoot":"0x127449324fa85284b46401a7162872bb2c19a0e0a4005234c17e15c60605178c","transactionsRoot":"0x526a2cb1c6bd30a154d3eae233621500cdffc6baf4f910ea003ff8ec79a42719","receiptsRoot":"0xaf4441e57f0061a7d5a787ac275327120b761d36f776007be09768fc29446413","logsBloom":"0x6ea00a06218969a910081683c9012433c1ef3430830104da232f2100241211139760011a50b0eb16840e7800681201023241c84a2828a800062347180c642690014406459c428a2a998625291734a32402202000095280012a831c6680004404a2222500a3210081290e9403300028df08c88e014809a41831882274409d00401483413e39201037354800000981700514a06087d158e1c91c206671885808220eba988b0022a80196cb6594acec00027269444a42b4034190f82408b11b22b0c1166463400385e0a090290a40065880040d6ab10a1b081a00802162082a660481313148640420028a0c544a0ee0988301780001f0c0504c301b4a32437258b6","difficulty":"0x2e6cfc89e63894","number":"0xdc3207","gasLimit":"0x1caa7ec","gasUsed":"0x8ff415","timestamp":"0x6238a4cb","extraData":"0x486976656f6e20686b","mixHash":"0x9cc6673f8d971f37ccd89f0f7296d213fc7e05bd6318695e5cfde210ce7e22e6","nonce":"0xa4c0db72ed33f298","baseFeePerGas":"0xbad4cab72"}`
+	mainnetLondonHash   = "0x88c0797ca5b6c8d68782c7910559f9dcd67f4598874cfd3b1a92430bc017054a"
+	// cancunHeader is a post-Cancun testnet block carrying blobs, with zero-valued difficulty and
+	// excess blob gas.
+	cancunHeader = `{"parentHash":"0x4bb5d8423fbdfe66d13db89bebc4097c2f74314b5329a53c894f36f5b5189a9b","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","miner":"0xf97e180c050e5ab072211ad2c213eb5aee4df134","stateRoot":"0xe2ee0557bb3f616b86824ac8f6b9c4237e194d82f540ec68053982f65796ece4","transactionsRoot":"0xc4af8ac4bbfee0ecd6900efdec157be8df5505b8b0a108aa7b299f3b92538294","receiptsRoot":"0xb4a350c5a8bf9e47b02683403ad5d8e1a85f2c965f953b626611af16a4dcd610","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","difficulty":"0x0","number":"0x50936","gasLimit":"0x1c9c380","gasUsed":"0x5c490","timestamp":"0x65a6d1c4","extraData":"0x9a726574682f76302e312e302d616c7068612e31342f6c696e7578","mixHash":"0x5854ff07a305068660b271d7661297e9703aab3388a0f3789c6348eef7b42e61","nonce":"0x0000000000000000","baseFeePerGas":"0x8","withdrawalsRoot":"0x68e83c7114fa8cd26a789927628a18985b83ed2c947ee8e6dbe46e8ad3259008","blobGasUsed":"0x40000","excessBlobGas":"0x0","parentBeaconBlockRoot":"0xde460880db8fe723aba6e3c5d13bf93860161c6f63a855c94bbb625af3cc6ebd"}`
+	cancunHash   = "0x4157a497c2d89b24eced0229efef28d256302e02c31680950d20920b6b25d83d"
+)
+
+func TestEncodeHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		hash   string
+	}{
+		{
+			name:   "Genesis",
+			header: mainnetGenesisHeader,
+			hash:   mainnetGenesisHash,
+		},
+		{
+			name:   "Legacy",
+			header: mainnetBlock1Header,
+			hash:   mainnetBlock1Hash,
+		},
+		{
+			name:   "London",
+			header: mainnetLondonHeader,
+			hash:   mainnetLondonHash,
+		},
+		{
+			// Optional fields after a missing field are not encoded.
+			name:   "LondonFieldAfterGap",
+			header: `{"parentHash":"0xdc49656f3dc58120579f8b33624811cc029470ad872d777e0903065a78a2d34b","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","miner":"0x1ad91ee08f21be3de0ba2ba6918e714da6b45836","stateRoot":"0x127449324fa85284b46401a7162872bb2c19a0e0a4005234c17e15c60605178c","transactionsRoot":"0x526a2cb1c6bd30a154d3eae233621500cdffc6baf4f910ea003ff8ec79a42719","receiptsRoot":"0xaf4441e57f0061a7d5a787ac275327120b761d36f776007be09768fc29446413","logsBloom":"0x6ea00a06218969a910081683c9012433c1ef3430830104da232f2100241211139760011a50b0eb16840e7800681201023241c84a2828a800062347180c642690014406459c428a2a998625291734a32402202000095280012a831c6680004404a2222500a3210081290e9403300028df08c88e014809a41831882274409d00401483413e39201037354800000981700514a06087d158e1c91c206671885808220eba988b0022a80196cb6594acec00027269444a42b4034190f82408b11b22b0c1166463400385e0a090290a40065880040d6ab10a1b081a00802162082a660481313148640420028a0c544a0ee0988301780001f0c0504c301b4a32437258b6","difficulty":"0x2e6cfc89e63894","number":"0xdc3207","gasLimit":"0x1caa7ec","gasUsed":"0x8ff415","timestamp":"0x6238a4cb","extraData":"0x486976656f6e20686b","mixHash":"0x9cc6673f8d971f37ccd89f0f7296d213fc7e05bd6318695e5cfde210ce7e22e6","nonce":"0xa4c0db72ed33f298","baseFeePerGas":"0xbad4cab72","blobGasUsed":"0x0"}`,
+			hash:   mainnetLondonHash,
+		},
+		{
+			name:   "Cancun",
+			header: cancunHeader,
+			hash:   cancunHash,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			header := make(map[string]json.RawMessage)
+			if err := json.Unmarshal([]byte(test.header), &header); err != nil {
+				t.Fatal(err)
+			}
+			encoded, err := encodeHeader(header)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hash := fmt.Sprintf("%#x", keccak256(encoded)); hash != test.hash {
+				t.Fatalf("expected hash %s, found %s", test.hash, hash)
+			}
+		})
+	}
+}
+
+func TestEncodeHeaderPrague(t *testing.T) {
+	cancun := make(map[string]json.RawMessage)
+	if err := json.Unmarshal([]byte(cancunHeader), &cancun); err != nil {
+		t.Fatal(err)
+	}
+	cancunEncoded, err := encodeHeader(cancun)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The requests hash follows the parent beacon block root.
+	requestsHash := bytes.Repeat([]byte{0xab}, 32)
+	prague := make(map[string]json.RawMessage, len(cancun)+1)
+	for k, v := range cancun {
+		prague[k] = v
+	}
+	prague["requestsHash"] = json.RawMessage(fmt.Sprintf(`"%#x"`, requestsHash))
+	encoded, err := encodeHeader(prague)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Strip the list header from the Cancun encoding to obtain its items.
+	items := new(bytes.Buffer)
+	items.Write(cancunEncoded[1+int(cancunEncoded[0]-0xf7):])
+	executil.RLPBytes(items, requestsHash)
+	expected := new(bytes.Buffer)
+	executil.RLPList(expected, items.Bytes())
+	if !bytes.Equal(encoded, expected.Bytes()) {
+		t.Fatalf("expected %#x, found %#x", expected.Bytes(), encoded)
+	}
+}
+
+func TestEncodeReceipt(t *testing.T) {
+	bloom := make([]byte, 256)
+	zeroBloom := strings.Repeat("00", 256)
+
+	tests := []struct {
+		name     string
+		receipt  *spec.BerlinTransactionReceipt
+		expected string
+	}{
+		{
+			name: "Legacy",
+			receipt: &spec.BerlinTransactionReceipt{
+				Type:              spec.TransactionType0,
+				Status:            1,
+				CumulativeGasUsed: 0x5208,
+				LogsBloom:         bloom,
+			},
+			// Legacy receipts have no type prefix.
+			expected: "f9010801825208b90100" + zeroBloom + "c0",
+		},
+		{
+			name: "AccessList",
+			receipt: &spec.BerlinTransactionReceipt{
+				Type:              spec.TransactionType1,
+				Status:            1,
+				CumulativeGasUsed: 0x5208,
+				LogsBloom:         bloom,
+			},
+			expected: "01f9010801825208b90100" + zeroBloom + "c0",
+		},
+		{
+			name: "DynamicFee",
+			receipt: &spec.BerlinTransactionReceipt{
+				Type:              spec.TransactionType2,
+				Status:            1,
+				CumulativeGasUsed: 0x1f3a4,
+				LogsBloom:         bloom,
+				Logs: []*spec.BerlinTransactionEvent{
+					{
+						Address: types.Address(bytes.Repeat([]byte{0x11}, 20)),
+						Topics:  []types.Hash{types.Hash(bytes.Repeat([]byte{0x22}, 32))},
+						Data:    []byte{0xbe, 0xef},
+					},
+				},
+			},
+			expected: "02f90146018301f3a4b90100" + zeroBloom +
+				"f83cf83a94" + strings.Repeat("11", 20) + "e1a0" + strings.Repeat("22", 32) + "82beef",
+		},
+		{
+			// Zero values are encoded as empty strings.
+			name: "BlobZeroValues",
+			receipt: &spec.BerlinTransactionReceipt{
+				Type:      spec.TransactionType3,
+				LogsBloom: bloom,
+			},
+			expected: "03f901068080b90100" + zeroBloom + "c0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			encoded := encodeReceipt(&spec.TransactionReceipt{
+				Fork:                     spec.ForkBerlin,
+				BerlinTransactionReceipt: test.receipt,
+			})
+			if hex.EncodeToString(encoded) != test.expected {
+				t.Fatalf("expected %s, found %x", test.expected, encoded)
+			}
+		})
+	}
+}
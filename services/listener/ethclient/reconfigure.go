@@ -0,0 +1,255 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// Configuration is the configuration of the listener that can be changed while it is running.
+type Configuration struct {
+	BlockTriggers     []*handlers.BlockTrigger
+	TxTriggers        []*handlers.TxTrigger
+	CompositeTriggers []*handlers.CompositeTrigger
+	EventTriggers     []*handlers.EventTrigger
+	WindowTriggers    []*handlers.WindowTrigger
+	// Interval is the interval between polls.  If zero the current interval is retained.
+	Interval time.Duration
+}
+
+// ConfigurationLoader loads the configuration of the listener, for example from a file.
+type ConfigurationLoader func(ctx context.Context) (*Configuration, error)
+
+// pollInterval returns the interval between polls.
+func (s *Service) pollInterval() time.Duration {
+	return time.Duration(s.interval.Load())
+}
+
+// triggers returns the current block, transaction and event triggers.
+func (s *Service) triggers() ([]*handlers.BlockTrigger, []*handlers.TxTrigger, []*handlers.EventTrigger) {
+	s.triggersMu.RLock()
+	defer s.triggersMu.RUnlock()
+
+	return s.blockTriggers, s.txTriggers, s.eventTriggers
+}
+
+// setInterval sets the interval between polls, along with the poll timeout and maximum backoff
+// if they were derived from the interval rather than supplied.
+func (s *Service) setInterval(interval time.Duration) {
+	s.interval.Store(int64(interval))
+	if s.pollTimeoutDefault {
+		s.pollTimeout.Store(int64(interval))
+	}
+	if s.maxBackoffDefault {
+		s.maxBackoff.Store(int64(defaultMaxBackoffIntervals * interval))
+	}
+}
+
+// Reload obtains the configuration from the configuration loader and applies it.
+func (s *Service) Reload(ctx context.Context) error {
+	if s.configurationLoader == nil {
+		return errors.New("no configuration loader specified")
+	}
+
+	config, err := s.configurationLoader(ctx)
+	if err != nil {
+		return errors.Join(errors.New("failed to load configuration"), err)
+	}
+
+	return s.Reconfigure(ctx, config)
+}
+
+// Reconfigure replaces the triggers and interval of the listener with those in the configuration,
+// between polls.  Triggers are identified by their type and qualified name, so triggers that remain
+// in the configuration keep their progress and state, and triggers that are removed keep their
+// progress in the metadata database should they be added again later.  Added triggers start as
+// they would if the listener were restarted with them.  Window triggers keep their window only if
+// the same trigger is supplied.
+// The change is recorded in the audit log against the actor in the context, if any.
+func (s *Service) Reconfigure(ctx context.Context, config *Configuration) error {
+	if config == nil {
+		return errors.New("no configuration supplied")
+	}
+
+	parameters := &parameters{
+		blockTriggers:     config.BlockTriggers,
+		txTriggers:        config.TxTriggers,
+		compositeTriggers: config.CompositeTriggers,
+		eventTriggers:     config.EventTriggers,
+		windowTriggers:    config.WindowTriggers,
+	}
+	if err := checkTriggerParameters(parameters); err != nil {
+		return err
+	}
+	if err := checkCompositeTriggerParameters(parameters); err != nil {
+		return err
+	}
+	if err := checkWindowTriggerParameters(parameters); err != nil {
+		return err
+	}
+	if err := checkTriggerDependencies(parameters); err != nil {
+		return err
+	}
+	if len(parameters.compositeTriggers) > 0 && s.receiptsProvider == nil {
+		return errors.New("composite triggers require transaction receipts, which were not enabled at startup")
+	}
+
+	interval := s.pollInterval()
+	switch {
+	case config.Interval < 0:
+		return errors.New("interval cannot be negative")
+	case config.Interval > 0 && interval == 0:
+		return errors.New("cannot set an interval for a listener that does not poll by itself")
+	case config.Interval > time.Duration(s.maxBackoff.Load()) && !s.maxBackoffDefault:
+		return errors.New("interval cannot be greater than max backoff")
+	case config.Interval > 0:
+		interval = config.Interval
+	}
+
+	eventDecoders, err := newEventDecoders(s.abis, parameters.eventTriggers)
+	if err != nil {
+		return errors.Join(errors.New("failed to create event decoders"), err)
+	}
+
+	// Wait for any running poll to complete, so that the poll sees a consistent set of triggers.
+	s.pollMu.Lock()
+	defer s.pollMu.Unlock()
+
+	parameters.txTriggers = append(append([]*handlers.TxTrigger{}, parameters.txTriggers...), compositeTxTriggers(s.log, s.receiptsProvider, parameters.compositeTriggers)...)
+	parameters.eventTriggers = append(append([]*handlers.EventTrigger{}, parameters.eventTriggers...), s.retainedWindowEventTriggers(parameters.windowTriggers)...)
+	blockTriggers, txTriggers, eventTriggers := sortByPriority(parameters)
+
+	previous := triggerKeys(s.blockTriggers, s.txTriggers, s.eventTriggers)
+	current := triggerKeys(blockTriggers, txTriggers, eventTriggers)
+
+	s.triggersMu.Lock()
+	previousBlockTriggers, previousTxTriggers, previousEventTriggers := s.blockTriggers, s.txTriggers, s.eventTriggers
+	s.blockTriggers, s.txTriggers, s.eventTriggers = blockTriggers, txTriggers, eventTriggers
+	if err := s.loadTriggerStates(); err != nil {
+		s.blockTriggers, s.txTriggers, s.eventTriggers = previousBlockTriggers, previousTxTriggers, previousEventTriggers
+		s.triggersMu.Unlock()
+
+		return errors.Join(errors.New("failed to load trigger states"), err)
+	}
+	s.txMatcher = newTxMatcher(txTriggers)
+	s.eventMatchers = newEventMatchers(eventTriggers)
+	s.eventDecoders = eventDecoders
+	s.triggersMu.Unlock()
+	// Ensure that the next poll runs even if the chain has not advanced, so that added triggers catch up.
+	s.lastPollSucceeded = false
+
+	details := make([]string, 0)
+	for key := range current {
+		if _, exists := previous[key]; !exists {
+			details = append(details, fmt.Sprintf("added %v trigger %s", key.triggerType, key.name))
+		}
+	}
+	for key := range previous {
+		if _, exists := current[key]; !exists {
+			details = append(details, fmt.Sprintf("removed %v trigger %s", key.triggerType, key.name))
+			s.forgetProgress(key)
+		}
+	}
+	sort.Strings(details)
+	if previousInterval := s.pollInterval(); interval != previousInterval {
+		details = append(details, fmt.Sprintf("interval %v -> %v", previousInterval, interval))
+		s.setInterval(interval)
+	}
+
+	s.recordTriggerLabels(parameters)
+
+	s.log.Info().Strs("changes", details).Str("actor", ActorFromContext(ctx)).Msg("Reconfigured listener")
+	s.audit(ctx, AuditActionReconfigure, "", details...)
+
+	return nil
+}
+
+// retainedWindowEventTriggers returns event triggers that carry out the windowed triggers,
+// retaining those already in use so that their windows are undisturbed.
+func (s *Service) retainedWindowEventTriggers(triggers []*handlers.WindowTrigger) []*handlers.EventTrigger {
+	existing := make(map[*handlers.WindowTrigger]*handlers.EventTrigger)
+	for _, trigger := range s.eventTriggers {
+		if handler, isHandler := trigger.Handler.(*windowEventHandler); isHandler {
+			existing[handler.trigger] = trigger
+		}
+	}
+
+	eventTriggers := make([]*handlers.EventTrigger, 0, len(triggers))
+	for _, trigger := range triggers {
+		if eventTrigger, exists := existing[trigger]; exists {
+			eventTriggers = append(eventTriggers, eventTrigger)
+
+			continue
+		}
+		eventTriggers = append(eventTriggers, windowEventTriggers([]*handlers.WindowTrigger{trigger})...)
+	}
+
+	return eventTriggers
+}
+
+// triggerKeys returns the keys identifying the given triggers.
+func triggerKeys(blockTriggers []*handlers.BlockTrigger,
+	txTriggers []*handlers.TxTrigger,
+	eventTriggers []*handlers.EventTrigger,
+) map[progressKey]struct{} {
+	keys := make(map[progressKey]struct{}, len(blockTriggers)+len(txTriggers)+len(eventTriggers))
+	for _, trigger := range blockTriggers {
+		keys[progressKey{triggerType: blockTriggerType, name: trigger.QualifiedName()}] = struct{}{}
+	}
+	for _, trigger := range txTriggers {
+		keys[progressKey{triggerType: txTriggerType, name: trigger.QualifiedName()}] = struct{}{}
+	}
+	for _, trigger := range eventTriggers {
+		keys[progressKey{triggerType: eventTriggerType, name: trigger.QualifiedName()}] = struct{}{}
+	}
+
+	return keys
+}
+
+// forgetProgress forgets the in-memory progress of a trigger that has been removed.
+func (s *Service) forgetProgress(key progressKey) {
+	s.progressMu.Lock()
+	delete(s.progress, key)
+	delete(s.progressSamples, key)
+	s.progressMu.Unlock()
+}
+
+// reloader reloads the configuration when the process receives SIGHUP.
+func (s *Service) reloader(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-hup:
+			s.log.Debug().Msg("Reloading configuration on SIGHUP")
+		case <-s.stopping:
+			return
+		}
+		if err := s.Reload(ctx); err != nil && ctx.Err() == nil {
+			// Keep the existing configuration in place.
+			s.log.Warn().Err(err).Msg("Failed to reload configuration; retaining previous configuration")
+		}
+	}
+}
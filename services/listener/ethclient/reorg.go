@@ -0,0 +1,72 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// reorg is a reorg awaiting notification to the reorg handler.
+type reorg struct {
+	oldHead *handlers.ChainHead
+	newHead *handlers.ChainHead
+}
+
+// queueReorg queues a reorg for notification to the reorg handler, if present.
+// This assumes that the canonical lock is held.
+func (s *Service) queueReorg(oldHead *handlers.ChainHead, newHead *handlers.ChainHead) {
+	if s.reorgHandler == nil {
+		return
+	}
+
+	s.reorgs = append(s.reorgs, &reorg{
+		oldHead: oldHead,
+		newHead: newHead,
+	})
+}
+
+// notifyReorgs passes reorgs awaiting notification to the reorg handler.
+func (s *Service) notifyReorgs(ctx context.Context) {
+	if s.reorgHandler == nil {
+		return
+	}
+
+	s.canonicalMu.Lock()
+	reorgs := s.reorgs
+	s.reorgs = nil
+	s.canonicalMu.Unlock()
+
+	for _, reorg := range reorgs {
+		s.log.Trace().Uint64("old_head", reorg.oldHead.Number).Uint64("new_head", reorg.newHead.Number).Msg("Notifying reorg")
+		s.reorgHandler.HandleReorg(ctx, reorg.oldHead, reorg.newHead)
+	}
+}
+
+// confirmPreviousHeader fetches the block processed by the previous poll again, so that a reorg
+// that replaced it is detected even if the blocks in between have not been fetched.
+func (s *Service) confirmPreviousHeader(ctx context.Context, height uint64) {
+	if s.reorgHandler == nil {
+		return
+	}
+	previous := s.latestHeader.Load()
+	if previous == nil || previous.Number >= height {
+		return
+	}
+
+	if _, err := s.block(ctx, previous.Number); err != nil {
+		s.log.Debug().Uint64("height", previous.Number).Err(err).Msg("Failed to confirm previously processed block")
+	}
+}
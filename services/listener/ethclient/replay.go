@@ -0,0 +1,149 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// EventIterator iterates over the events matched by a replay.
+// Events are fetched from the client in batches as the iterator advances.
+type EventIterator struct {
+	s       *Service
+	trigger *handlers.EventTrigger
+	matcher *eventMatcher
+	source  *types.Address
+	next    uint64
+	to      uint64
+	events  []*spec.BerlinTransactionEvent
+	event   *spec.BerlinTransactionEvent
+	ctx     context.Context
+	err     error
+}
+
+// ReplayEvents returns an iterator over the events between the given blocks inclusive that match
+// the trigger, applying the same filtering and enrichment as the listener.
+// The trigger does not need to be registered with the listener, its handler is not called and
+// no progress is recorded.  The range is limited to the chain head at the time of the call, and
+// the source of the trigger is resolved once at the time of the call.
+func (s *Service) ReplayEvents(ctx context.Context,
+	trigger *handlers.EventTrigger,
+	from uint64,
+	to uint64,
+) (
+	*EventIterator,
+	error,
+) {
+	if trigger == nil {
+		return nil, errors.New("no trigger specified")
+	}
+	if from > to {
+		return nil, errors.New("from block after to block")
+	}
+
+	chainHeight, err := s.chainHeight(ctx)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to get chain height"), err)
+	}
+	if to > chainHeight {
+		to = chainHeight
+	}
+
+	source, err := s.resolveSourceFromTrigger(ctx, trigger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EventIterator{
+		s:       s,
+		trigger: trigger,
+		matcher: s.eventMatcherFor(trigger),
+		source:  source,
+		next:    from,
+		to:      to,
+	}, nil
+}
+
+// Next advances the iterator to the next matching event, returning false when there are no
+// more events or an error has occurred.
+func (i *EventIterator) Next(ctx context.Context) bool {
+	if i.err != nil {
+		return false
+	}
+
+	for len(i.events) == 0 {
+		if i.next > i.to {
+			i.event = nil
+			i.ctx = nil
+
+			return false
+		}
+		if err := i.fetch(ctx); err != nil {
+			i.err = err
+
+			return false
+		}
+	}
+
+	i.event = i.events[0]
+	i.events = i.events[1:]
+	i.ctx = i.s.eventContext(ctx, i.event)
+	if decoded := i.s.decodeEvent(i.trigger, i.event); decoded != nil {
+		i.ctx = handlers.WithDecodedEvent(i.ctx, decoded)
+	}
+
+	return true
+}
+
+// fetch fetches the next batch of matching events.
+func (i *EventIterator) fetch(ctx context.Context) error {
+	toBlock := i.to
+	if toBlock+1-i.next > i.s.maxBlocksForEvents {
+		toBlock = i.next + i.s.maxBlocksForEvents - 1
+	}
+
+	events, err := i.s.events(ctx, i.trigger, i.source, i.next, toBlock)
+	if err != nil {
+		return errors.Join(errors.New("failed to obtain events"), i.s.historyError(err, i.next))
+	}
+	for _, event := range events {
+		if i.matcher.matches(event) {
+			i.events = append(i.events, event)
+		}
+	}
+	i.next = toBlock + 1
+
+	return nil
+}
+
+// Event returns the current event.
+func (i *EventIterator) Event() *spec.BerlinTransactionEvent {
+	return i.event
+}
+
+// Context returns the context for the current event, containing the enrichment that would be
+// passed to a handler such as address labels, token metadata, signatures and the decoded event.
+func (i *EventIterator) Context() context.Context {
+	return i.ctx
+}
+
+// Err returns the error that stopped the iterator, if any.
+func (i *EventIterator) Err() error {
+	return i.err
+}
@@ -0,0 +1,78 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// startRun updates the run information at the start of a poll.
+func (s *Service) startRun() {
+	s.pollID++
+	if s.pollID == 1 || s.lastPollSucceeded {
+		s.pollAttempt = 1
+	} else {
+		s.pollAttempt++
+	}
+}
+
+// runContext returns a copy of the context containing the run information for handlers
+// processing the given range of blocks in the current poll.
+func (s *Service) runContext(ctx context.Context, from uint64, to uint64) context.Context {
+	return handlers.WithRunInfo(ctx, &handlers.RunInfo{
+		PollID:     s.pollID,
+		Attempt:    s.pollAttempt,
+		From:       from,
+		To:         to,
+		CatchingUp: s.behind(from),
+	})
+}
+
+// behind returns true if the given block trails the chain head by more than the catch-up threshold.
+func (s *Service) behind(height uint64) bool {
+	chainHead := s.chainHead.Load()
+
+	return height < chainHead && chainHead-height > s.catchUpThreshold
+}
+
+// CatchingUp returns true if, as of the last poll, any trigger trailed the chain head by more
+// than the catch-up threshold.
+func (s *Service) CatchingUp() bool {
+	return s.catchingUp.Load()
+}
+
+// updateCatchingUp updates whether the listener is catching up, based on the progress of its triggers.
+func (s *Service) updateCatchingUp() {
+	catchingUp := false
+	s.progressMu.RLock()
+	for _, latest := range s.progress {
+		if s.behind(latest) {
+			catchingUp = true
+
+			break
+		}
+	}
+	s.progressMu.RUnlock()
+
+	if s.catchingUp.Swap(catchingUp) != catchingUp {
+		if catchingUp {
+			s.log.Info().Uint64("threshold", s.catchUpThreshold).Msg("Listener is catching up")
+		} else {
+			s.log.Info().Msg("Listener is live")
+		}
+	}
+	monitorCatchingUp(catchingUp)
+}
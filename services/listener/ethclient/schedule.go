@@ -0,0 +1,91 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// scheduler runs a schedule trigger until the listener is stopping.
+func (s *Service) scheduler(ctx context.Context, trigger *handlers.ScheduleTrigger) {
+	log := s.triggerLog(trigger.Name, trigger.Labels)
+
+	var schedule *cronSchedule
+	if trigger.Cron != "" {
+		// Already validated in parameters.
+		schedule, _ = parseCron(trigger.Cron)
+	}
+
+	next := time.Now()
+	for {
+		if schedule != nil {
+			next = schedule.next(next)
+			if next.IsZero() {
+				log.Error().Str("cron", trigger.Cron).Msg("Schedule never runs")
+
+				return
+			}
+		} else {
+			next = next.Add(trigger.Every)
+		}
+
+		select {
+		case <-time.After(time.Until(next)):
+		case <-s.stopping:
+			log.Debug().Msg("Stopping")
+
+			return
+		}
+
+		if err := s.runSchedule(ctx, trigger, next); err != nil && ctx.Err() == nil {
+			log.Warn().Err(err).Msg("Schedule trigger failed")
+			monitorFailure()
+		}
+
+		if schedule == nil && time.Since(next) > trigger.Every {
+			// The handler overran, so skip missed runs rather than running them back to back.
+			next = time.Now()
+		}
+	}
+}
+
+// runSchedule runs a schedule trigger with the current chain context.
+func (s *Service) runSchedule(ctx context.Context, trigger *handlers.ScheduleTrigger, scheduled time.Time) error {
+	chainHead, err := s.chainHeight(ctx)
+	if err != nil {
+		return errors.Join(errors.New("failed to obtain chain height"), err)
+	}
+	headBlock, err := s.blocksProvider.Block(ctx, fmt.Sprintf("%d", chainHead))
+	if err != nil {
+		return errors.Join(errors.New("failed to obtain head block"), err)
+	}
+
+	info := &handlers.ScheduleInfo{
+		Time:      scheduled,
+		ChainHead: chainHead,
+		HeadBlock: headBlock,
+	}
+	if err := trigger.Handler.HandleSchedule(s.handlerContext(ctx, chainHead), info, trigger); err != nil {
+		return errors.Join(errors.New("handler errored"), err)
+	}
+	log := s.triggerLog(trigger.Name, trigger.Labels)
+	log.Trace().Uint64("chain_head", chainHead).Msg("Schedule trigger succeeded")
+
+	return nil
+}
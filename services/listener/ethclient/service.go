@@ -16,34 +16,118 @@ package ethclient
 import (
 	"context"
 	"errors"
+	"net/http"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	execclient "github.com/attestantio/go-execution-client"
 	jsonrpcexecclient "github.com/attestantio/go-execution-client/jsonrpc"
+	"github.com/attestantio/go-execution-client/types"
 	"github.com/cockroachdb/pebble"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/abi"
 	"github.com/wealdtech/go-eth-listener/handlers"
+	"github.com/wealdtech/go-eth-listener/services/quorum"
+	"github.com/wealdtech/go-eth-listener/services/validation"
 )
 
 // Service is a listener that listens to an Ethereum client.
 type Service struct {
 	log                 zerolog.Logger
 	chainHeightProvider execclient.ChainHeightProvider
+	chainID             uint64
 	blocksProvider      execclient.BlocksProvider
 	eventsProvider      execclient.EventsProvider
+	receiptsProvider    execclient.TransactionReceiptsProvider
+	receiptProofs       bool
+	isolateTriggers     bool
+	maxEventsPerFetch   int
+	logSampler          *logSampler
+	maxBlocksForEvents  uint64
+	eventSpansMu        sync.Mutex
+	eventSpans          map[string]uint64
+	verifiedMu          sync.Mutex
+	earliestAvailable   atomic.Int64
+	clampToAvailable    bool
+	initialProgress     map[string]uint64
+	historyLogged       atomic.Bool
+	stopped             chan struct{}
+	stopping            <-chan struct{}
+	stop                context.CancelFunc
+	cancel              context.CancelFunc
+	workers             sync.WaitGroup
+	pollTimeout         atomic.Int64
+	pollTimeoutDefault  bool
+	maxBackoff          atomic.Int64
+	maxBackoffDefault   bool
+	blockCache          *blockCache
+	pollStats           *PollStats
+	pollStatsMu         sync.Mutex
+	pollID              uint64
+	pollAttempt         int
+	catchUpThreshold    uint64
+	catchingUp          atomic.Bool
+	pausedMu            sync.RWMutex
+	paused              map[string]bool
+	auditSequence       atomic.Uint32
+	eventIndex          bool
+	eventIndexBlocks    uint64
+	eventIndexPeriod    time.Duration
+	eventIndexPrune     time.Duration
+	lastPollStats       atomic.Pointer[PollStats]
+	latestHeader        atomic.Pointer[BlockHeader]
+	verified            map[uint64]*verifiedBlock
+	// triggersMu protects the triggers along with their matchers and decoders.  They are only
+	// replaced while holding both pollMu and triggersMu, so the poll can read them without
+	// triggersMu but anything else must hold it.
+	triggersMu          sync.RWMutex
 	blockTriggers       []*handlers.BlockTrigger
 	txTriggers          []*handlers.TxTrigger
+	txMatcher           *txMatcher
+	eventMatchers       map[*handlers.EventTrigger]*eventMatcher
+	eventDecoders       map[*handlers.EventTrigger]*abi.Registry
+	abis                [][]byte
 	eventTriggers       []*handlers.EventTrigger
-	interval            time.Duration
-	blockDelay          uint32
+	interval            atomic.Int64
+	configurationLoader ConfigurationLoader
+	blockDelay          uint64
 	blockSpecifier      string
-	earliestBlock       int32
+	earliestBlock       int64
 	metadataDB          *pebble.DB
 	metadataDBMu        sync.Mutex
 	metadataDBOpen      atomic.Bool
+	metadataCipher      ValueCipher
+	compressThreshold   int
+	backupStore         BackupStore
+	backupInterval      time.Duration
+	fastPathPriority    *int
+	chainHead           atomic.Uint64
+	pollTarget          atomic.Uint64
+	pollMu              sync.Mutex
+	lastPollTo          uint64
+	lastPollSucceeded   bool
+	lastPollAdvanced    atomic.Bool
+	lastPollOverran     atomic.Bool
+	prePollHooks        []PollHook
+	addressLabeler      handlers.AddressLabeler
+	tokenMetadata       handlers.TokenMetadataProvider
+	signatureLookup     handlers.SignatureLookup
+	reorgHandler        handlers.ReorgHandler
+	postPollHooks       []PollHook
+	contextDecorators   []HandlerContextDecorator
+	progressMu          sync.RWMutex
+	progress            map[progressKey]uint64
+	progressSamples     map[progressKey][]*progressSample
+	trackingTimeout     uint64
+	trackedMu           sync.Mutex
+	tracked             map[types.Hash]*trackedTx
+	trackedHead         uint64
+	canonicalMu         sync.Mutex
+	canonical           map[uint64]types.Hash
+	reorgs              []*reorg
+	triggerStates       map[triggerStateKind]map[string]*triggerState
 }
 
 // New creates a new service.
@@ -63,80 +147,266 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		return nil, err
 	}
 
-	chainHeightProvider, blocksProvider, eventsProvider, err := setupProviders(ctx, parameters)
+	eventDecoders, err := newEventDecoders(parameters.abis, parameters.eventTriggers)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to create event decoders"), err)
+	}
+
+	chainHeightProvider, blocksProvider, eventsProvider, receiptsProvider, err := setupProviders(ctx, log, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	chainID, err := obtainChainID(ctx, parameters.chainID, chainHeightProvider)
 	if err != nil {
 		return nil, err
 	}
 
-	metadataDB, err := pebble.Open(parameters.metadataDBPath, &pebble.Options{})
+	metadataDB, err := openMetadataDB(ctx, log, parameters)
 	if err != nil {
 		return nil, errors.Join(errors.New("failed to start metadata database"), err)
 	}
 
+	// Composite triggers are carried out as transaction triggers.
+	parameters.txTriggers = append(append([]*handlers.TxTrigger{}, parameters.txTriggers...), compositeTxTriggers(log, receiptsProvider, parameters.compositeTriggers)...)
+	// Windowed triggers are carried out as event triggers.
+	parameters.eventTriggers = append(append([]*handlers.EventTrigger{}, parameters.eventTriggers...), windowEventTriggers(parameters.windowTriggers)...)
+
+	blockTriggers, txTriggers, eventTriggers := sortByPriority(parameters)
+
 	s := &Service{
 		log:                 log,
 		metadataDB:          metadataDB,
+		metadataCipher:      parameters.metadataCipher,
+		compressThreshold:   parameters.compressionThreshold,
+		backupStore:         parameters.backupStore,
+		backupInterval:      parameters.backupInterval,
+		fastPathPriority:    parameters.fastPathPriority,
 		blocksProvider:      blocksProvider,
 		eventsProvider:      eventsProvider,
-		blockTriggers:       parameters.blockTriggers,
-		txTriggers:          parameters.txTriggers,
-		eventTriggers:       parameters.eventTriggers,
+		receiptsProvider:    receiptsProvider,
+		receiptProofs:       parameters.receiptProofs,
+		isolateTriggers:     parameters.isolateTriggers,
+		maxEventsPerFetch:   parameters.maxEventsPerFetch,
+		logSampler:          newLogSampler(parameters.errorLogSampling),
+		maxBlocksForEvents:  parameters.maxBlocksForEvents,
+		eventSpans:          make(map[string]uint64),
+		initialProgress:     parameters.initialProgress,
+		stopped:             make(chan struct{}),
+		pollTimeoutDefault:  parameters.pollTimeoutDefaulted,
+		maxBackoffDefault:   parameters.maxBackoffDefaulted,
+		catchUpThreshold:    parameters.catchUpThreshold,
+		eventIndex:          parameters.eventIndex,
+		eventIndexBlocks:    parameters.eventIndexBlocks,
+		eventIndexPeriod:    parameters.eventIndexPeriod,
+		eventIndexPrune:     parameters.eventIndexPrune,
+		blockCache:          newBlockCache(parameters.blockMemoryBudget),
+		verified:            make(map[uint64]*verifiedBlock),
+		blockTriggers:       blockTriggers,
+		txTriggers:          txTriggers,
+		txMatcher:           newTxMatcher(txTriggers),
+		eventTriggers:       eventTriggers,
+		eventMatchers:       newEventMatchers(eventTriggers),
+		eventDecoders:       eventDecoders,
+		abis:                parameters.abis,
 		blockDelay:          parameters.blockDelay,
 		blockSpecifier:      parameters.blockSpecifier,
 		earliestBlock:       parameters.earliestBlock,
 		chainHeightProvider: chainHeightProvider,
-		interval:            parameters.interval,
+		chainID:             chainID,
+		prePollHooks:        parameters.prePollHooks,
+		addressLabeler:      parameters.addressLabeler,
+		tokenMetadata:       parameters.tokenMetadata,
+		signatureLookup:     parameters.signatureLookup,
+		reorgHandler:        parameters.reorgHandler,
+		postPollHooks:       parameters.postPollHooks,
+		configurationLoader: parameters.configurationLoader,
+		contextDecorators:   parameters.contextDecorators,
+		trackingTimeout:     parameters.trackingTimeout,
+		tracked:             make(map[types.Hash]*trackedTx),
+		progress:            make(map[progressKey]uint64),
+		progressSamples:     make(map[progressKey][]*progressSample),
+		paused:              make(map[string]bool),
+		canonical:           make(map[uint64]types.Hash),
 	}
 
+	s.interval.Store(int64(parameters.interval))
+	s.pollTimeout.Store(int64(parameters.pollTimeout))
+	s.maxBackoff.Store(int64(parameters.maxBackoff))
+	s.recordTriggerLabels(parameters)
+
 	// Note that the metadata DB is open.
 	s.metadataDBOpen.Store(true)
 
-	// Close the database on context done.
-	go func(ctx context.Context, metadataDB *pebble.DB) {
-		<-ctx.Done()
-		s.metadataDBMu.Lock()
-		err := metadataDB.Close()
-		s.metadataDBOpen.Store(false)
-		s.metadataDBMu.Unlock()
+	if err := s.migrateMetadata(ctx); err != nil {
+		s.closeMetadataDB()
+
+		return nil, err
+	}
+
+	if err := s.loadCanonicalChain(); err != nil {
+		s.closeMetadataDB()
+
+		return nil, err
+	}
+
+	if err := s.loadTriggerStates(); err != nil {
+		s.closeMetadataDB()
+
+		return nil, err
+	}
+
+	// The earliest available block is not known until discovered.
+	s.earliestAvailable.Store(-1)
+	if parameters.discoverEarliestBlock {
+		earliest, err := s.discoverEarliestAvailableBlock(ctx)
 		if err != nil {
-			log.Warn().Err(err).Msg("Failed to close pebble")
+			// Discovery is advisory, so failure does not stop the listener.
+			log.Warn().Err(err).Msg("Failed to discover earliest available block")
+		} else {
+			s.applyEarliestAvailableBlock(earliest, parameters.clampEarliestBlock)
 		}
-	}(ctx, metadataDB)
+	}
+
+	for _, handler := range parameters.startupHandlers {
+		if err := handler(ctx); err != nil {
+			s.closeMetadataDB()
+
+			return nil, errors.Join(errors.New("startup handler failed"), err)
+		}
+	}
+
+	// Work is cancelled on context done or if a stop times out, whereas stopping only prevents
+	// further work from starting.
+	ctx, s.cancel = context.WithCancel(ctx)
+	var stopCtx context.Context
+	stopCtx, s.stop = context.WithCancel(ctx)
+	s.stopping = stopCtx.Done()
+
+	// Shut down when stopping.
+	go s.shutdown(ctx, parameters.shutdownHandlers)
+
+	// Kick off the listener, unless polling is driven externally.
+	if s.pollInterval() > 0 {
+		s.startWorker(func() { s.listener(ctx) })
+	}
+
+	if parameters.reloadOnHUP {
+		s.startWorker(func() { s.reloader(ctx) })
+	}
+
+	for _, trigger := range parameters.scheduleTriggers {
+		s.startWorker(func() { s.scheduler(ctx, trigger) })
+	}
+
+	if s.backupStore != nil {
+		s.startWorker(func() { s.backupper(ctx) })
+	}
 
-	// Kick off the listener.
-	go s.listener(ctx)
+	if s.eventIndex && (s.eventIndexBlocks > 0 || s.eventIndexPeriod > 0) {
+		s.startWorker(func() { s.eventIndexPruner(ctx) })
+	}
 
 	return s, nil
 }
 
 func setupProviders(ctx context.Context,
+	log zerolog.Logger,
 	parameters *parameters,
 ) (
 	execclient.ChainHeightProvider,
 	execclient.BlocksProvider,
 	execclient.EventsProvider,
+	execclient.TransactionReceiptsProvider,
 	error,
 ) {
+	var err error
+	provider := parameters.provider
+	switch {
+	case provider != nil:
+	case len(parameters.addresses) > 0:
+		provider, err = connectFailoverProvider(ctx, log, parameters)
+	default:
+		provider, err = connectProvider(ctx, parameters, parameters.address)
+	}
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	// Receipts are only required for verification, proofs and composite triggers, and are obtained from the primary client.
+	var receiptsProvider execclient.TransactionReceiptsProvider
+	if parameters.verifyReceipts || parameters.receiptProofs || len(parameters.compositeTriggers) > 0 {
+		var isProvider bool
+		receiptsProvider, isProvider = provider.(execclient.TransactionReceiptsProvider)
+		if !isProvider {
+			return nil, nil, nil, nil, errors.New("client does not provide transaction receipts")
+		}
+	}
+
+	if len(parameters.verificationAddresses) > 0 {
+		// Data is cross-verified against additional clients.
+		providers := []quorum.Provider{provider}
+		for _, address := range parameters.verificationAddresses {
+			provider, err := connectProvider(ctx, parameters, address)
+			if err != nil {
+				return nil, nil, nil, nil, err
+			}
+			providers = append(providers, provider)
+		}
+		provider, err = quorum.New(ctx,
+			quorum.WithLogLevel(parameters.logLevel),
+			quorum.WithProviders(providers),
+			quorum.WithQuorum(parameters.quorum),
+		)
+		if err != nil {
+			return nil, nil, nil, nil, errors.Join(errors.New("failed to create quorum provider"), err)
+		}
+	}
+
+	if parameters.validateResponses {
+		provider, err = validation.New(ctx,
+			validation.WithLogLevel(parameters.logLevel),
+			validation.WithMonitor(parameters.monitor),
+			validation.WithProvider(provider),
+		)
+		if err != nil {
+			return nil, nil, nil, nil, errors.Join(errors.New("failed to create validation provider"), err)
+		}
+	}
+
+	return provider, provider, provider, receiptsProvider, nil
+}
+
+// connectProvider connects to an Ethereum client.
+func connectProvider(ctx context.Context, parameters *parameters, address string) (quorum.Provider, error) {
 	client, err := jsonrpcexecclient.New(ctx,
 		jsonrpcexecclient.WithLogLevel(parameters.clientLogLevel),
-		jsonrpcexecclient.WithAddress(parameters.address),
+		jsonrpcexecclient.WithAddress(address),
 		jsonrpcexecclient.WithTimeout(parameters.timeout),
 	)
 	if err != nil {
-		return nil, nil, nil, errors.Join(errors.New("failed to connect to Ethereum client"), err)
+		return nil, errors.Join(errors.New("failed to connect to Ethereum client"), err)
 	}
-	chainHeightProvider, isProvider := client.(execclient.ChainHeightProvider)
-	if !isProvider {
-		return nil, nil, nil, errors.New("client does not provide chain height")
+	if service, isService := client.(*jsonrpcexecclient.Service); isService {
+		// Blocks are fetched directly, so that those the client library cannot decode can be identified.
+		client = &jsonrpcProvider{
+			Service: service,
+			address: address,
+			client: &http.Client{
+				Timeout: parameters.timeout,
+			},
+		}
 	}
-	blocksProvider, isProvider := client.(execclient.BlocksProvider)
-	if !isProvider {
-		return nil, nil, nil, errors.New("client does not provide blocks")
+	if _, isProvider := client.(execclient.ChainHeightProvider); !isProvider {
+		return nil, errors.New("client does not provide chain height")
+	}
+	if _, isProvider := client.(execclient.BlocksProvider); !isProvider {
+		return nil, errors.New("client does not provide blocks")
 	}
-	eventsProvider, isProvider := client.(execclient.EventsProvider)
+	provider, isProvider := client.(quorum.Provider)
 	if !isProvider {
-		return nil, nil, nil, errors.New("client does not provide events")
+		return nil, errors.New("client does not provide events")
 	}
 
-	return chainHeightProvider, blocksProvider, eventsProvider, nil
+	return provider, nil
 }
@@ -0,0 +1,207 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// setCodeTxType is the type of EIP-7702 set-code transactions.
+const setCodeTxType = "0x4"
+
+type setCodeAuthorizationJSON struct {
+	ChainID string `json:"chainId"`
+	Address string `json:"address"`
+	Nonce   string `json:"nonce"`
+	YParity string `json:"yParity"`
+	R       string `json:"r"`
+	S       string `json:"s"`
+}
+
+// setCodeBlock fetches a block that contains transactions the client library cannot decode.
+// The client library does not support set-code transactions, so they are decoded as the EIP-1559
+// transactions that they extend, and their authorization lists are returned separately; handlers
+// obtain their real type with handlers.TransactionTypeFromContext().
+// Transactions of other unsupported types are skipped with a warning.
+func (s *Service) setCodeBlock(ctx context.Context,
+	height uint64,
+) (
+	*spec.Block,
+	map[types.Hash][]*handlers.SetCodeAuthorization,
+	error,
+) {
+	provider, isProvider := s.blocksProvider.(RawBlocksProvider)
+	if !isProvider {
+		return nil, nil, errors.Join(errors.New("client cannot supply blocks with unsupported transactions"), ErrUnsupportedTransactionType)
+	}
+	data, err := provider.RawBlock(ctx, fmt.Sprintf("%d", height))
+	if err != nil {
+		return nil, nil, errors.Join(errors.New("failed to obtain raw block"), err)
+	}
+	if data == nil {
+		return nil, nil, fmt.Errorf("block %d not found", height)
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, nil, errors.Join(errors.New("failed to parse block"), err)
+	}
+	txs, err := rawTransactions(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	supported := make([]map[string]json.RawMessage, 0, len(txs))
+	authorizations := make(map[types.Hash][]*handlers.SetCodeAuthorization)
+	for _, tx := range txs {
+		var txType spec.TransactionType
+		if err := txType.UnmarshalJSON(tx["type"]); err == nil {
+			supported = append(supported, tx)
+
+			continue
+		}
+		var typeName string
+		if err := json.Unmarshal(tx["type"], &typeName); err != nil || !strings.EqualFold(typeName, setCodeTxType) {
+			s.log.Warn().Uint64("block", height).RawJSON("type", tx["type"]).RawJSON("hash", tx["hash"]).Msg("Skipping transaction of unsupported type")
+
+			continue
+		}
+		var hash string
+		if err := json.Unmarshal(tx["hash"], &hash); err != nil {
+			return nil, nil, errors.Join(errors.New("failed to parse set-code transaction hash"), err)
+		}
+		txHash, err := parseHash(hash)
+		if err != nil {
+			return nil, nil, errors.Join(errors.New("invalid set-code transaction hash"), err)
+		}
+		authorizations[txHash], err = parseSetCodeAuthorizations(tx["authorizationList"])
+		if err != nil {
+			return nil, nil, err
+		}
+		delete(tx, "authorizationList")
+		tx["type"] = json.RawMessage(`"0x2"`)
+		supported = append(supported, tx)
+	}
+	if fields["transactions"], err = json.Marshal(supported); err != nil {
+		return nil, nil, err
+	}
+
+	blockData, err := json.Marshal(fields)
+	if err != nil {
+		return nil, nil, err
+	}
+	block := &spec.Block{}
+	if err := json.Unmarshal(blockData, block); err != nil {
+		return nil, nil, errors.Join(errors.New("failed to parse block"), err)
+	}
+
+	return block, authorizations, nil
+}
+
+// parseSetCodeAuthorizations parses the authorization list of a set-code transaction.
+func parseSetCodeAuthorizations(data json.RawMessage) ([]*handlers.SetCodeAuthorization, error) {
+	var authorizationsJSON []*setCodeAuthorizationJSON
+	if err := json.Unmarshal(data, &authorizationsJSON); err != nil {
+		return nil, errors.Join(errors.New("failed to parse authorization list"), err)
+	}
+
+	authorizations := make([]*handlers.SetCodeAuthorization, 0, len(authorizationsJSON))
+	for _, authorizationJSON := range authorizationsJSON {
+		chainID, ok := new(big.Int).SetString(strings.TrimPrefix(authorizationJSON.ChainID, "0x"), 16)
+		if !ok {
+			return nil, errors.New("invalid authorization chain ID")
+		}
+		address, err := hex.DecodeString(strings.TrimPrefix(authorizationJSON.Address, "0x"))
+		if err != nil || len(address) != types.AddressLength {
+			return nil, errors.New("invalid authorization address")
+		}
+		nonce, err := strconv.ParseUint(strings.TrimPrefix(authorizationJSON.Nonce, "0x"), 16, 64)
+		if err != nil {
+			return nil, errors.Join(errors.New("invalid authorization nonce"), err)
+		}
+		yParity, err := strconv.ParseUint(strings.TrimPrefix(authorizationJSON.YParity, "0x"), 16, 8)
+		if err != nil {
+			return nil, errors.Join(errors.New("invalid authorization y parity"), err)
+		}
+		r, ok := new(big.Int).SetString(strings.TrimPrefix(authorizationJSON.R, "0x"), 16)
+		if !ok {
+			return nil, errors.New("invalid authorization r value")
+		}
+		s, ok := new(big.Int).SetString(strings.TrimPrefix(authorizationJSON.S, "0x"), 16)
+		if !ok {
+			return nil, errors.New("invalid authorization s value")
+		}
+		authorizations = append(authorizations, &handlers.SetCodeAuthorization{
+			ChainID: chainID,
+			Address: types.Address(address),
+			Nonce:   nonce,
+			YParity: uint8(yParity),
+			R:       r,
+			S:       s,
+		})
+	}
+
+	return authorizations, nil
+}
+
+// parseHash parses a hex string in to a hash.
+func parseHash(input string) (types.Hash, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return types.Hash{}, err
+	}
+	if len(data) != len(types.Hash{}) {
+		return types.Hash{}, fmt.Errorf("incorrect length %d", len(data))
+	}
+
+	return types.Hash(data), nil
+}
+
+// setCodeAuthorizations returns the authorization list of a transaction in a block fetched
+// during the current poll, or nil if it is not a set-code transaction.
+func (s *Service) setCodeAuthorizations(tx *spec.Transaction) []*handlers.SetCodeAuthorization {
+	return s.blockCache.authorizationsFor(tx.Hash())
+}
+
+// setCodeMatchesTrigger returns true if the transaction satisfies the set-code criteria of the trigger.
+func (s *Service) setCodeMatchesTrigger(trigger *handlers.TxTrigger, tx *spec.Transaction) bool {
+	if !trigger.SetCode && trigger.DelegatesTo == nil {
+		return true
+	}
+
+	authorizations := s.setCodeAuthorizations(tx)
+	if authorizations == nil {
+		return false
+	}
+	if trigger.DelegatesTo == nil {
+		return true
+	}
+	for _, authorization := range authorizations {
+		if trigger.DelegatesTo.Contains(authorization.Address) {
+			return true
+		}
+	}
+
+	return false
+}
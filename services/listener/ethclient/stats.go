@@ -0,0 +1,61 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"time"
+)
+
+// PollStats contains statistics about a poll.
+type PollStats struct {
+	// Blocks is the number of blocks processed for block triggers.
+	Blocks int
+	// Transactions is the number of transactions examined for transaction triggers.
+	Transactions int
+	// Events is the number of events examined for event triggers.
+	Events int
+	// BlocksDuration is the time taken to process blocks.
+	BlocksDuration time.Duration
+	// TransactionsDuration is the time taken to process transactions.
+	TransactionsDuration time.Duration
+	// EventsDuration is the time taken to process events.
+	EventsDuration time.Duration
+	// TrackingDuration is the time taken to update tracked transactions.
+	TrackingDuration time.Duration
+	// Duration is the total time taken by the poll.
+	Duration time.Duration
+}
+
+// LastPollStats returns the statistics for the last completed poll, or nil if no poll has completed.
+func (s *Service) LastPollStats() *PollStats {
+	stats := s.lastPollStats.Load()
+	if stats == nil {
+		return nil
+	}
+	res := *stats
+
+	return &res
+}
+
+// recordPollStats records the statistics for a completed poll.
+func (s *Service) recordPollStats(stats *PollStats) {
+	s.lastPollStats.Store(stats)
+	monitorPollStats(stats)
+	s.log.Trace().
+		Int("blocks", stats.Blocks).
+		Int("transactions", stats.Transactions).
+		Int("events", stats.Events).
+		Dur("duration", stats.Duration).
+		Msg("Poll statistics")
+}
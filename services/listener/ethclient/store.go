@@ -0,0 +1,92 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+)
+
+// metadataPrefix is the prefix of keys used by the listener in the metadata database.
+var metadataPrefix = []byte("listener.ethclient.")
+
+// Metadata returns the value stored under the given key in the metadata database, or nil if there is no value.
+// This allows other components, such as sibling listeners, to share the listener's metadata database.
+func (s *Service) Metadata(_ context.Context, key []byte) ([]byte, error) {
+	if bytes.HasPrefix(key, metadataPrefix) {
+		return nil, errors.New("key is reserved for the listener")
+	}
+
+	s.metadataDBMu.Lock()
+	defer s.metadataDBMu.Unlock()
+	if !s.metadataDBOpen.Load() {
+		return nil, errors.New("database closed")
+	}
+
+	started := time.Now()
+	data, closer, err := s.metadataDB.Get(key)
+	monitorMetadataDBOperation("read", time.Since(started))
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, nil
+		}
+
+		return nil, errors.Join(errors.New("failed to get metadata"), err)
+	}
+	defer closer.Close()
+
+	value := append([]byte{}, data...)
+	if s.metadataCipher != nil {
+		value, err = s.metadataCipher.Decrypt(value)
+		if err != nil {
+			return nil, errors.Join(errors.New("failed to decrypt metadata"), err)
+		}
+	}
+
+	return value, nil
+}
+
+// SetMetadata stores a value under the given key in the metadata database.
+func (s *Service) SetMetadata(_ context.Context, key []byte, value []byte) error {
+	if bytes.HasPrefix(key, metadataPrefix) {
+		return errors.New("key is reserved for the listener")
+	}
+
+	s.metadataDBMu.Lock()
+	defer s.metadataDBMu.Unlock()
+	if !s.metadataDBOpen.Load() {
+		return errors.New("database closed")
+	}
+
+	if s.metadataCipher != nil {
+		var err error
+		value, err = s.metadataCipher.Encrypt(value)
+		if err != nil {
+			return errors.Join(errors.New("failed to encrypt metadata"), err)
+		}
+	}
+
+	started := time.Now()
+	err := s.metadataDB.Set(key, value, pebble.Sync)
+	monitorMetadataDBOperation("write", time.Since(started))
+	if err != nil {
+		return errors.Join(errors.New("failed to set metadata"), err)
+	}
+
+	return nil
+}
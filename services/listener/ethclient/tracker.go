@@ -0,0 +1,277 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// maxBlocksForTracking is the maximum number of blocks to scan for tracked transactions in a single poll.
+const maxBlocksForTracking = uint64(100)
+
+// ConfirmationStatus is the status of a tracked transaction when its callback is invoked.
+type ConfirmationStatus int
+
+const (
+	// ConfirmationStatusConfirmed means that the transaction reached the requested confirmation depth.
+	ConfirmationStatusConfirmed ConfirmationStatus = iota
+	// ConfirmationStatusReorged means that the block containing the transaction is no longer canonical.
+	ConfirmationStatusReorged
+	// ConfirmationStatusDropped means that the transaction was not included within the tracking timeout.
+	ConfirmationStatusDropped
+)
+
+var confirmationStatusStrings = [...]string{
+	"confirmed",
+	"reorged",
+	"dropped",
+}
+
+// String returns a string representation of the status.
+func (c ConfirmationStatus) String() string {
+	if int(c) < 0 || int(c) >= len(confirmationStatusStrings) {
+		return "unknown"
+	}
+
+	return confirmationStatusStrings[c]
+}
+
+// Confirmation contains the result of tracking a transaction.
+type Confirmation struct {
+	// Hash is the hash of the transaction.
+	Hash types.Hash
+	// Status is the status of the transaction.
+	Status ConfirmationStatus
+	// BlockNumber is the number of the block in which the transaction was included.
+	// This is 0 if the transaction was dropped.
+	BlockNumber uint64
+	// BlockHash is the hash of the block in which the transaction was included.
+	BlockHash types.Hash
+	// Confirmations is the confirmation depth of the transaction, being the chain head minus the block number.
+	Confirmations uint32
+}
+
+// ConfirmationCallback is invoked once when a tracked transaction reaches its requested
+// confirmation depth, or is reorged or dropped.
+type ConfirmationCallback func(ctx context.Context, confirmation *Confirmation)
+
+type trackedTx struct {
+	hash          types.Hash
+	confirmations uint32
+	callback      ConfirmationCallback
+	// startBlock is the block from which the transaction could be included.
+	startBlock uint64
+	// fromBlock is the next block to scan for the transaction.
+	fromBlock uint64
+	found     bool
+	block     uint64
+	blockHash types.Hash
+}
+
+// TrackOption is an option for tracking a transaction.
+type TrackOption interface {
+	applyTrack(o *trackOptions)
+}
+
+type trackOptions struct {
+	submitted uint64
+}
+
+type trackOptionFunc func(*trackOptions)
+
+func (f trackOptionFunc) applyTrack(o *trackOptions) {
+	f(o)
+}
+
+// WithSubmittedBlock sets the block from which to scan for a tracked transaction, which should be
+// the chain head when the transaction was submitted, so that a transaction included before tracking
+// starts is found.
+// If not supplied, or 0, the scan starts at the chain head when tracking starts.
+func WithSubmittedBlock(height uint64) TrackOption {
+	return trackOptionFunc(func(o *trackOptions) {
+		o.submitted = height
+	})
+}
+
+// TrackTransaction tracks a transaction, invoking the callback when it reaches the requested
+// confirmation depth (chain head minus the block number in which it is included), or is reorged or dropped.
+func (s *Service) TrackTransaction(hash types.Hash,
+	confirmations uint32,
+	callback ConfirmationCallback,
+	opts ...TrackOption,
+) error {
+	if callback == nil {
+		return errors.New("no callback specified")
+	}
+	options := trackOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt.applyTrack(&options)
+		}
+	}
+	submitted := options.submitted
+
+	s.trackedMu.Lock()
+	defer s.trackedMu.Unlock()
+
+	if _, exists := s.tracked[hash]; exists {
+		return errors.New("transaction already tracked")
+	}
+	if submitted == 0 {
+		submitted = s.trackedHead
+	}
+	s.tracked[hash] = &trackedTx{
+		hash:          hash,
+		confirmations: confirmations,
+		callback:      callback,
+		startBlock:    submitted,
+		fromBlock:     submitted,
+	}
+
+	return nil
+}
+
+// pollTracked updates the state of tracked transactions.
+func (s *Service) pollTracked(ctx context.Context) error {
+	s.trackedMu.Lock()
+	if len(s.tracked) == 0 {
+		s.trackedMu.Unlock()
+		return nil
+	}
+	s.trackedMu.Unlock()
+
+	head, err := s.chainHeight(ctx)
+	if err != nil {
+		return errors.Join(errors.New("failed to obtain chain height for tracked transactions"), err)
+	}
+
+	s.trackedMu.Lock()
+	completed, err := s.updateTracked(ctx, head)
+	s.trackedMu.Unlock()
+
+	// Callbacks are invoked without the lock held, to allow them to track further transactions.
+	for _, item := range completed {
+		item.callback(ctx, item.confirmation)
+	}
+
+	return err
+}
+
+type completedTx struct {
+	callback     ConfirmationCallback
+	confirmation *Confirmation
+}
+
+// updateTracked scans new blocks for tracked transactions, and returns those that have completed.
+// Must be called with trackedMu held.
+func (s *Service) updateTracked(ctx context.Context, head uint64) ([]*completedTx, error) {
+	from := head + 1
+	for _, tracked := range s.tracked {
+		if tracked.startBlock == 0 {
+			// Tracked before we knew the chain head.
+			tracked.startBlock = head
+			tracked.fromBlock = head
+		}
+		if !tracked.found && tracked.fromBlock < from {
+			from = tracked.fromBlock
+		}
+	}
+	to := head
+	if from <= to && to+1-from > maxBlocksForTracking {
+		to = from + maxBlocksForTracking - 1
+	}
+
+	// Scan new blocks for tracked transactions.
+	blocks := make(map[uint64]*spec.Block)
+	for height := from; height <= to; height++ {
+		block, err := s.block(ctx, height)
+		if err != nil {
+			return nil, errors.Join(errors.New("failed to obtain block for tracked transactions"), err)
+		}
+		blocks[height] = block
+		for _, tx := range block.Transactions() {
+			tracked, exists := s.tracked[tx.Hash()]
+			if !exists || tracked.found {
+				continue
+			}
+			tracked.found = true
+			tracked.block = height
+			tracked.blockHash = block.Hash()
+			s.log.Trace().Stringer("tx", tracked.hash).Uint64("block", height).Msg("Tracked transaction included")
+		}
+	}
+	for _, tracked := range s.tracked {
+		if !tracked.found && tracked.fromBlock <= to+1 {
+			tracked.fromBlock = to + 1
+		}
+	}
+	s.trackedHead = head
+
+	return s.completeTracked(ctx, head, blocks)
+}
+
+// completeTracked removes and returns tracked transactions that have reached a final state.
+// Must be called with trackedMu held.
+func (s *Service) completeTracked(ctx context.Context,
+	head uint64,
+	blocks map[uint64]*spec.Block,
+) (
+	[]*completedTx,
+	error,
+) {
+	completed := make([]*completedTx, 0)
+	for hash, tracked := range s.tracked {
+		confirmation := &Confirmation{
+			Hash: hash,
+		}
+		switch {
+		case tracked.found && head >= tracked.block && head-tracked.block >= uint64(tracked.confirmations):
+			block, exists := blocks[tracked.block]
+			if !exists {
+				var err error
+				block, err = s.block(ctx, tracked.block)
+				if err != nil {
+					return completed, errors.Join(errors.New("failed to obtain block to confirm tracked transaction"), err)
+				}
+			}
+			confirmation.BlockNumber = tracked.block
+			confirmation.BlockHash = tracked.blockHash
+			confirmation.Confirmations = uint32(min(head-tracked.block, math.MaxUint32))
+			if block.Hash() == tracked.blockHash {
+				confirmation.Status = ConfirmationStatusConfirmed
+			} else {
+				confirmation.Status = ConfirmationStatusReorged
+			}
+		case !tracked.found && s.trackingTimeout > 0 && tracked.fromBlock > head &&
+			head >= tracked.startBlock && head-tracked.startBlock >= s.trackingTimeout:
+			confirmation.Status = ConfirmationStatusDropped
+		default:
+			continue
+		}
+
+		delete(s.tracked, hash)
+		s.log.Trace().Stringer("tx", hash).Stringer("status", confirmation.Status).Msg("Tracked transaction complete")
+		completed = append(completed, &completedTx{
+			callback:     tracked.callback,
+			confirmation: confirmation,
+		})
+	}
+
+	return completed, nil
+}
@@ -0,0 +1,236 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"bytes"
+	"sort"
+
+	executil "github.com/attestantio/go-execution-client/util"
+	"golang.org/x/crypto/sha3"
+)
+
+// trieEntry is a key/value pair in a Merkle Patricia trie, with the key expressed as nibbles.
+type trieEntry struct {
+	key   []byte
+	value []byte
+}
+
+// trieRoot calculates the root of the Merkle Patricia trie containing the given keys and values.
+func trieRoot(keys [][]byte, values [][]byte) [32]byte {
+	entries := make([]*trieEntry, len(keys))
+	for i := range keys {
+		entries[i] = &trieEntry{
+			key:   toNibbles(keys[i]),
+			value: values[i],
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+
+	return keccak256(encodeTrieNode(entries, 0, nil))
+}
+
+// trieProof returns the proof of inclusion of the given key in the Merkle Patricia trie
+// containing the given keys and values.  The proof is the list of encoded nodes on the
+// path from the root to the key, excluding nodes embedded in their parents.
+func trieProof(keys [][]byte, values [][]byte, key []byte) [][]byte {
+	entries := make([]*trieEntry, len(keys))
+	for i := range keys {
+		entries[i] = &trieEntry{
+			key:   toNibbles(keys[i]),
+			value: values[i],
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+
+	prover := &trieProver{
+		target: toNibbles(key),
+	}
+	encodeTrieNode(entries, 0, prover)
+
+	// Nodes are recorded from the leaf upwards.
+	proof := make([][]byte, len(prover.nodes))
+	for i := range prover.nodes {
+		proof[i] = prover.nodes[len(prover.nodes)-1-i]
+	}
+
+	return proof
+}
+
+// trieProver records the nodes on the path to a target key.
+type trieProver struct {
+	target []byte
+	nodes  [][]byte
+}
+
+// record records the node if it is on the path to the target key and is not embedded in its parent.
+func (p *trieProver) record(entries []*trieEntry, depth int, encoded []byte) {
+	if p == nil || len(entries) == 0 {
+		return
+	}
+	if len(p.target) < depth || !bytes.Equal(entries[0].key[:depth], p.target[:depth]) {
+		return
+	}
+	if depth > 0 && len(encoded) < 32 {
+		return
+	}
+	p.nodes = append(p.nodes, encoded)
+}
+
+func toNibbles(key []byte) []byte {
+	nibbles := make([]byte, 0, len(key)*2)
+	for _, b := range key {
+		nibbles = append(nibbles, b>>4, b&0x0f)
+	}
+
+	return nibbles
+}
+
+// encodeTrieNode returns the RLP encoding of the node containing the given entries,
+// all of which share the first depth nibbles of their keys.
+// If a prover is supplied it records the nodes on the path to its target.
+func encodeTrieNode(entries []*trieEntry, depth int, prover *trieProver) []byte {
+	encoded := encodeTrieNodeEntries(entries, depth, prover)
+	prover.record(entries, depth, encoded)
+
+	return encoded
+}
+
+func encodeTrieNodeEntries(entries []*trieEntry, depth int, prover *trieProver) []byte {
+	buf := new(bytes.Buffer)
+	switch len(entries) {
+	case 0:
+		executil.RLPNil(buf)
+
+		return buf.Bytes()
+	case 1:
+		// Leaf node.
+		items := new(bytes.Buffer)
+		executil.RLPBytes(items, hexPrefix(entries[0].key[depth:], true))
+		executil.RLPBytes(items, entries[0].value)
+		executil.RLPList(buf, items.Bytes())
+
+		return buf.Bytes()
+	}
+
+	if prefix := commonPrefixLength(entries, depth); prefix > 0 {
+		// Extension node.
+		items := new(bytes.Buffer)
+		executil.RLPBytes(items, hexPrefix(entries[0].key[depth:depth+prefix], false))
+		items.Write(trieNodeReference(encodeTrieNode(entries, depth+prefix, prover)))
+		executil.RLPList(buf, items.Bytes())
+
+		return buf.Bytes()
+	}
+
+	// Branch node.
+	items := new(bytes.Buffer)
+	var value []byte
+	start := 0
+	if len(entries[0].key) == depth {
+		// Sorting places the entry that terminates at this node first.
+		value = entries[0].value
+		start = 1
+	}
+	for nibble := byte(0); nibble < 16; nibble++ {
+		end := start
+		for end < len(entries) && entries[end].key[depth] == nibble {
+			end++
+		}
+		if end == start {
+			executil.RLPNil(items)
+
+			continue
+		}
+		items.Write(trieNodeReference(encodeTrieNode(entries[start:end], depth+1, prover)))
+		start = end
+	}
+	if value == nil {
+		executil.RLPNil(items)
+	} else {
+		executil.RLPBytes(items, value)
+	}
+	executil.RLPList(buf, items.Bytes())
+
+	return buf.Bytes()
+}
+
+// commonPrefixLength returns the length of the key prefix shared by all entries beyond depth.
+func commonPrefixLength(entries []*trieEntry, depth int) int {
+	first := entries[0].key[depth:]
+	length := len(first)
+	for _, entry := range entries[1:] {
+		key := entry.key[depth:]
+		if len(key) < length {
+			length = len(key)
+		}
+		for i := 0; i < length; i++ {
+			if key[i] != first[i] {
+				length = i
+
+				break
+			}
+		}
+	}
+
+	return length
+}
+
+// trieNodeReference returns the reference to a node from its parent, which is the
+// node itself if its encoding is short, otherwise the encoding of its hash.
+func trieNodeReference(encoded []byte) []byte {
+	if len(encoded) < 32 {
+		return encoded
+	}
+	hash := keccak256(encoded)
+	buf := new(bytes.Buffer)
+	executil.RLPBytes(buf, hash[:])
+
+	return buf.Bytes()
+}
+
+// hexPrefix returns the hex-prefix encoding of nibbles.
+func hexPrefix(nibbles []byte, leaf bool) []byte {
+	flag := byte(0)
+	if leaf {
+		flag = 2
+	}
+
+	res := make([]byte, 0, len(nibbles)/2+1)
+	if len(nibbles)%2 == 1 {
+		res = append(res, (flag+1)<<4|nibbles[0])
+		nibbles = nibbles[1:]
+	} else {
+		res = append(res, flag<<4)
+	}
+	for i := 0; i < len(nibbles); i += 2 {
+		res = append(res, nibbles[i]<<4|nibbles[i+1])
+	}
+
+	return res
+}
+
+// keccak256 returns the Keccak-256 hash of the data.
+func keccak256(data []byte) [32]byte {
+	var res [32]byte
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(data)
+	copy(res[:], hash.Sum(nil))
+
+	return res
+}
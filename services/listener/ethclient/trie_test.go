@@ -0,0 +1,79 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestTrie(t *testing.T) {
+	tests := []struct {
+		name   string
+		keys   []string
+		values []string
+		root   string
+	}{
+		{
+			name: "Empty",
+			root: "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+		},
+		{
+			name:   "Dogs",
+			keys:   []string{"doe", "dog", "dogglesworth"},
+			values: []string{"reindeer", "puppy", "cat"},
+			root:   "0x8aad789dff2f538bca5d8ea56e8abe10f4c7ba3a5dea95fea4cd6e7c3a1168d3",
+		},
+		{
+			// Keys are supplied out of order.
+			name:   "Puppy",
+			keys:   []string{"do", "horse", "doge", "dog"},
+			values: []string{"verb", "stallion", "coin", "puppy"},
+			root:   "0x5991bb8c6514148a29db676a14ac506cd2cd5775ace63c30a4fe457715e9ac84",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keys := make([][]byte, len(test.keys))
+			values := make([][]byte, len(test.values))
+			for i := range test.keys {
+				keys[i] = []byte(test.keys[i])
+				values[i] = []byte(test.values[i])
+			}
+
+			root := trieRoot(keys, values)
+			if fmt.Sprintf("%#x", root) != test.root {
+				t.Fatalf("expected root %s, found %#x", test.root, root)
+			}
+
+			for _, key := range keys {
+				proof := trieProof(keys, values, key)
+				if len(proof) == 0 {
+					t.Fatalf("no proof for key %s", key)
+				}
+				if hash := keccak256(proof[0]); hash != root {
+					t.Fatalf("proof for key %s does not start at the root", key)
+				}
+				for i := 1; i < len(proof); i++ {
+					hash := keccak256(proof[i])
+					if !bytes.Contains(proof[i-1], hash[:]) {
+						t.Fatalf("proof node %d for key %s is not referenced by its parent", i, key)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+)
+
+// triggerStatePrefix is the prefix of keys for handler state in the metadata database.
+var triggerStatePrefix = []byte("listener.ethclient.state.")
+
+// triggerStateKind is the kind of trigger that holds state, as state is committed with the
+// progress of triggers of the same kind.
+type triggerStateKind string
+
+const (
+	blockTriggerState triggerStateKind = "blocks"
+	txTriggerState    triggerStateKind = "transactions"
+	eventTriggerState triggerStateKind = "events"
+)
+
+// triggerState is the handler state held for a trigger.
+type triggerState struct {
+	mu         sync.Mutex
+	key        []byte
+	state      []byte
+	pending    []byte
+	hasPending bool
+	// version is incremented each time state is accepted, and written is the version last written.
+	version uint64
+	written uint64
+}
+
+// State returns the state of the trigger, or nil if it has none.
+func (t *triggerState) State() []byte {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.hasPending {
+		return append([]byte(nil), t.pending...)
+	}
+
+	return append([]byte(nil), t.state...)
+}
+
+// SetState sets the state of the trigger, pending acceptance once the item being handled succeeds.
+func (t *triggerState) SetState(state []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pending = append([]byte(nil), state...)
+	t.hasPending = true
+}
+
+// accept accepts pending state, to be committed with the trigger's progress.
+func (t *triggerState) accept() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.hasPending {
+		return
+	}
+	t.state = t.pending
+	t.pending = nil
+	t.hasPending = false
+	t.version++
+}
+
+// discard discards pending state.
+func (t *triggerState) discard() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pending = nil
+	t.hasPending = false
+}
+
+// triggerStateFor returns the state held for the given trigger.
+func (s *Service) triggerStateFor(kind triggerStateKind, name string) *triggerState {
+	return s.triggerStates[kind][name]
+}
+
+// loadTriggerStates loads the state held for each trigger from the metadata database.
+// State already held for a trigger is retained, as it may not yet have been committed.
+func (s *Service) loadTriggerStates() error {
+	names := map[triggerStateKind][]string{}
+	for _, trigger := range s.blockTriggers {
+		names[blockTriggerState] = append(names[blockTriggerState], trigger.QualifiedName())
+	}
+	for _, trigger := range s.txTriggers {
+		names[txTriggerState] = append(names[txTriggerState], trigger.QualifiedName())
+	}
+	for _, trigger := range s.eventTriggers {
+		names[eventTriggerState] = append(names[eventTriggerState], trigger.QualifiedName())
+	}
+
+	s.metadataDBMu.Lock()
+	defer s.metadataDBMu.Unlock()
+	if !s.metadataDBOpen.Load() {
+		return errors.New("database closed")
+	}
+
+	triggerStates := make(map[triggerStateKind]map[string]*triggerState, len(names))
+	for kind, kindNames := range names {
+		triggerStates[kind] = make(map[string]*triggerState, len(kindNames))
+		for _, name := range kindNames {
+			if state, exists := s.triggerStates[kind][name]; exists {
+				triggerStates[kind][name] = state
+
+				continue
+			}
+			state := &triggerState{
+				key: append(append(append([]byte{}, triggerStatePrefix...), kind+"."...), name...),
+			}
+			started := time.Now()
+			data, closer, err := s.metadataDB.Get(state.key)
+			monitorMetadataDBOperation("read", time.Since(started))
+			switch {
+			case errors.Is(err, pebble.ErrNotFound):
+			case err != nil:
+				return errors.Join(errors.New("failed to get trigger state"), err)
+			default:
+				err = s.unmarshalValue(data, &state.state)
+				closer.Close()
+				if err != nil {
+					return errors.Join(errors.New("failed to unmarshal trigger state"), err)
+				}
+			}
+			triggerStates[kind][name] = state
+		}
+	}
+	s.triggerStates = triggerStates
+
+	return nil
+}
+
+// commitMetadata commits progress metadata along with the accepted state of triggers of
+// the same kind, atomically.
+// This assumes that the metadata database lock is held.
+func (s *Service) commitMetadata(key []byte, data []byte, kind triggerStateKind) error {
+	batch := s.metadataDB.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(key, data, nil); err != nil {
+		return err
+	}
+
+	versions := make(map[*triggerState]uint64)
+	for _, state := range s.triggerStates[kind] {
+		state.mu.Lock()
+		if state.version != state.written {
+			value, err := s.marshalValue(state.state)
+			if err != nil {
+				state.mu.Unlock()
+
+				return errors.Join(errors.New("failed to marshal trigger state"), err)
+			}
+			if err := batch.Set(state.key, value, nil); err != nil {
+				state.mu.Unlock()
+
+				return err
+			}
+			versions[state] = state.version
+		}
+		state.mu.Unlock()
+	}
+
+	started := time.Now()
+	err := batch.Commit(pebble.Sync)
+	monitorMetadataDBOperation("write", time.Since(started))
+	if err != nil {
+		return err
+	}
+
+	for state, version := range versions {
+		state.mu.Lock()
+		state.written = version
+		state.mu.Unlock()
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// txMatcher indexes transaction triggers by their static addresses, so that
+// each transaction is only checked against the triggers that could match it
+// rather than scanning every trigger.
+type txMatcher struct {
+	triggers []*handlers.TxTrigger
+	// byFrom indexes triggers with a static from address.
+	byFrom map[types.Address][]int
+	// byTo indexes triggers without a static from address but with a static to address.
+	byTo map[types.Address][]int
+	// unindexed contains triggers without static addresses, which must be checked for every transaction.
+	unindexed []int
+}
+
+// newTxMatcher creates a matcher for the given triggers.
+func newTxMatcher(triggers []*handlers.TxTrigger) *txMatcher {
+	m := &txMatcher{
+		triggers:  triggers,
+		byFrom:    make(map[types.Address][]int),
+		byTo:      make(map[types.Address][]int),
+		unindexed: make([]int, 0),
+	}
+
+	for i, trigger := range triggers {
+		switch {
+		case trigger.From != nil:
+			m.byFrom[*trigger.From] = append(m.byFrom[*trigger.From], i)
+		case trigger.To != nil:
+			m.byTo[*trigger.To] = append(m.byTo[*trigger.To], i)
+		default:
+			m.unindexed = append(m.unindexed, i)
+		}
+	}
+
+	return m
+}
+
+// candidates returns the triggers that could match the transaction, in trigger order.
+func (m *txMatcher) candidates(tx *spec.Transaction) []*handlers.TxTrigger {
+	indices := make([]int, 0, len(m.unindexed))
+	indices = append(indices, m.unindexed...)
+	indices = append(indices, m.byFrom[tx.From()]...)
+	if txTo := tx.To(); txTo != nil {
+		indices = append(indices, m.byTo[*txTo]...)
+	}
+	if len(indices) == 0 {
+		return nil
+	}
+	sort.Ints(indices)
+
+	res := make([]*handlers.TxTrigger, len(indices))
+	for i, index := range indices {
+		res[i] = m.triggers[index]
+	}
+
+	return res
+}
+
+// txMatchesTrigger returns true if the transaction matches all of the trigger's conditions.
+func txMatchesTrigger(trigger *handlers.TxTrigger, tx *spec.Transaction) bool {
+	txFrom := tx.From()
+	if trigger.From != nil && *trigger.From != txFrom {
+		return false
+	}
+	if trigger.FromSet != nil && !trigger.FromSet.Contains(txFrom) {
+		return false
+	}
+
+	txTo := tx.To()
+	if trigger.To != nil && (txTo == nil || *trigger.To != *txTo) {
+		return false
+	}
+	if trigger.ToSet != nil && (txTo == nil || !trigger.ToSet.Contains(*txTo)) {
+		return false
+	}
+
+	if trigger.InputPrefix != nil && !bytes.HasPrefix(tx.Input(), trigger.InputPrefix) {
+		return false
+	}
+	if trigger.InputMatcher != nil && !trigger.InputMatcher.Match(tx.Input()) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,192 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// benchAddress returns a distinct address for the given index.
+func benchAddress(index int) types.Address {
+	return types.Address{0xaa, byte(index >> 16), byte(index >> 8), byte(index)}
+}
+
+// benchTxs returns transactions to addresses, half of which are the subject of the given number of triggers.
+func benchTxs(triggers int) []*spec.Transaction {
+	txs := make([]*spec.Transaction, 1024)
+	for i := range txs {
+		to := benchAddress(i * 2 % (triggers * 2))
+		txs[i] = &spec.Transaction{
+			Type: spec.TransactionType0,
+			Type0Transaction: &spec.Type0Transaction{
+				From: types.Address{0xbb},
+				To:   &to,
+			},
+		}
+	}
+
+	return txs
+}
+
+// benchTxTriggers returns the given number of transaction triggers, each for a single to address.
+func benchTxTriggers(count int) []*handlers.TxTrigger {
+	triggers := make([]*handlers.TxTrigger, count)
+	for i := range triggers {
+		to := benchAddress(i)
+		triggers[i] = &handlers.TxTrigger{
+			Name: fmt.Sprintf("tx-%d", i),
+			To:   &to,
+		}
+	}
+
+	return triggers
+}
+
+// matcherSet is a fixed set of addresses.
+type matcherSet map[types.Address]struct{}
+
+func newMatcherSet(addresses ...types.Address) matcherSet {
+	s := make(matcherSet, len(addresses))
+	for _, address := range addresses {
+		s[address] = struct{}{}
+	}
+
+	return s
+}
+
+func (s matcherSet) Contains(address types.Address) bool {
+	_, exists := s[address]
+
+	return exists
+}
+
+func (s matcherSet) Addresses() []types.Address {
+	res := make([]types.Address, 0, len(s))
+	for address := range s {
+		res = append(res, address)
+	}
+
+	return res
+}
+
+func TestTxMatcherMatchesScan(t *testing.T) {
+	a := types.Address{0x0a}
+	b := types.Address{0x0b}
+	c := types.Address{0x0c}
+
+	triggers := []*handlers.TxTrigger{
+		{Name: "from-a", From: &a},
+		{Name: "to-a", To: &a},
+		{Name: "from-a-to-b", From: &a, To: &b},
+		{Name: "from-b-to-a", From: &b, To: &a},
+		{Name: "from-set", FromSet: newMatcherSet(b, c)},
+		{Name: "to-set", ToSet: newMatcherSet(a, c)},
+		{Name: "from-a-to-set", From: &a, ToSet: newMatcherSet(c)},
+		{Name: "from-set-to-b", FromSet: newMatcherSet(a), To: &b},
+		{Name: "delegates-to", DelegatesTo: newMatcherSet(c)},
+		{Name: "set-code", SetCode: true},
+		{Name: "input-prefix", InputPrefix: []byte{0x01}},
+		{Name: "any"},
+		{Name: "from-a-again", From: &a},
+	}
+	matcher := newTxMatcher(triggers)
+
+	txs := make([]*spec.Transaction, 0)
+	for _, from := range []types.Address{a, b, c} {
+		for _, to := range []*types.Address{&a, &b, &c, nil} {
+			for _, input := range [][]byte{nil, {0x01, 0x02}} {
+				txs = append(txs, &spec.Transaction{
+					Type: spec.TransactionType0,
+					Type0Transaction: &spec.Type0Transaction{
+						From:  from,
+						To:    to,
+						Input: input,
+					},
+				})
+			}
+		}
+	}
+
+	for _, tx := range txs {
+		scanned := make([]string, 0)
+		for _, trigger := range triggers {
+			if txMatchesTrigger(trigger, tx) {
+				scanned = append(scanned, trigger.Name)
+			}
+		}
+		indexed := make([]string, 0)
+		for _, trigger := range matcher.candidates(tx) {
+			if txMatchesTrigger(trigger, tx) {
+				indexed = append(indexed, trigger.Name)
+			}
+		}
+
+		to := "none"
+		if tx.To() != nil {
+			to = tx.To().String()
+		}
+		if fmt.Sprint(indexed) != fmt.Sprint(scanned) {
+			t.Fatalf("transaction from %s to %s with input %#x: expected %v, found %v", tx.From(), to, tx.Input(), scanned, indexed)
+		}
+	}
+}
+
+// BenchmarkTxMatcher benchmarks matching transactions against triggers by address, with the
+// indexed matcher and with a scan of every trigger for comparison.
+func BenchmarkTxMatcher(b *testing.B) {
+	for _, count := range []int{100, 1000, 10000, 50000} {
+		triggers := benchTxTriggers(count)
+		txs := benchTxs(count)
+
+		b.Run(fmt.Sprintf("indexed/triggers=%d", count), func(b *testing.B) {
+			matcher := newTxMatcher(triggers)
+			b.ReportAllocs()
+			b.ResetTimer()
+			matches := 0
+			for i := range b.N {
+				tx := txs[i%len(txs)]
+				for _, trigger := range matcher.candidates(tx) {
+					if txMatchesTrigger(trigger, tx) {
+						matches++
+					}
+				}
+			}
+			if matches == 0 {
+				b.Fatal("no transactions matched")
+			}
+		})
+
+		b.Run(fmt.Sprintf("scan/triggers=%d", count), func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+			matches := 0
+			for i := range b.N {
+				tx := txs[i%len(txs)]
+				for _, trigger := range triggers {
+					if txMatchesTrigger(trigger, tx) {
+						matches++
+					}
+				}
+			}
+			if matches == 0 {
+				b.Fatal("no transactions matched")
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	execclient "github.com/attestantio/go-execution-client"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+	"github.com/wealdtech/go-eth-listener/services/quorum"
+)
+
+// Validate checks the listener configuration in the parameters against the Ethereum clients
+// without opening the metadata database or starting the listener, for example to check the
+// configuration of a deployment as part of a build pipeline.  As well as the checks carried out
+// by New, it checks that:
+//   - each client can be reached and is on the configured chain, or on the same chain as the
+//     primary client if no chain ID is configured;
+//   - the sources of event triggers resolve; and
+//   - the earliest blocks of triggers are available from each client, unless they are clamped.
+//
+// Clients are referred to by their position, with the primary client first, followed by
+// failover and then verification clients.  All problems found are returned together.
+func Validate(ctx context.Context, params ...Parameter) error {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return err
+	}
+
+	log := zerologger.With().Str("service", "listener").Str("impl", "ethclient").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	problems := validateSources(ctx, parameters)
+
+	providers, errs := validationProviders(ctx, parameters)
+	problems = append(problems, errs...)
+
+	chainID := parameters.chainID
+	for i, provider := range providers {
+		if provider == nil {
+			continue
+		}
+		s := &Service{
+			log:                 log.With().Int("client", i).Logger(),
+			chainHeightProvider: provider,
+			blocksProvider:      provider,
+		}
+		if err := s.validateClient(ctx, provider, parameters, &chainID); err != nil {
+			problems = append(problems, errors.Join(fmt.Errorf("client %d failed validation", i), err))
+		}
+	}
+
+	return errors.Join(problems...)
+}
+
+// validationProviders connects to each of the clients in the parameters.
+// Clients that cannot be connected to have a nil provider.
+func validationProviders(ctx context.Context, parameters *parameters) ([]quorum.Provider, []error) {
+	if parameters.provider != nil {
+		return []quorum.Provider{parameters.provider}, nil
+	}
+
+	addresses := append(append([]string{parameters.address}, parameters.addresses...), parameters.verificationAddresses...)
+	providers := make([]quorum.Provider, len(addresses))
+	problems := make([]error, 0)
+	for i, address := range addresses {
+		provider, err := connectProvider(ctx, parameters, address)
+		if err != nil {
+			problems = append(problems, errors.Join(fmt.Errorf("failed to connect to client %d", i), err))
+
+			continue
+		}
+		providers[i] = provider
+	}
+
+	return providers, problems
+}
+
+// validateSources checks that the sources of event triggers resolve.
+func validateSources(ctx context.Context, parameters *parameters) []error {
+	resolvers := make(map[string]handlers.SourceResolver)
+	for _, trigger := range parameters.eventTriggers {
+		if trigger.SourceResolver != nil {
+			resolvers["event trigger "+trigger.QualifiedName()] = trigger.SourceResolver
+		}
+	}
+	for _, trigger := range parameters.windowTriggers {
+		if trigger.Event.SourceResolver != nil {
+			resolvers["window trigger "+trigger.QualifiedName()] = trigger.Event.SourceResolver
+		}
+	}
+
+	names := make([]string, 0, len(resolvers))
+	for name := range resolvers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	problems := make([]error, 0)
+	for _, name := range names {
+		source, err := resolvers[name].Resolve(ctx)
+		switch {
+		case err != nil:
+			problems = append(problems, errors.Join(fmt.Errorf("failed to resolve source of %s", name), err))
+		case source == nil:
+			problems = append(problems, fmt.Errorf("source of %s did not resolve to an address", name))
+		}
+	}
+
+	return problems
+}
+
+// validateClient checks the client against the parameters.  If the chain ID is not yet known
+// then it is set to that of the client.
+func (s *Service) validateClient(ctx context.Context,
+	provider quorum.Provider,
+	parameters *parameters,
+	chainID *uint64,
+) error {
+	problems := make([]error, 0)
+
+	clientChainID, err := obtainChainID(ctx, 0, provider)
+	switch {
+	case err != nil:
+		problems = append(problems, err)
+	case *chainID == 0:
+		*chainID = clientChainID
+	case clientChainID != *chainID:
+		problems = append(problems, fmt.Errorf("client is on chain %d but chain %d is expected", clientChainID, *chainID))
+	}
+
+	if parameters.verifyReceipts || parameters.receiptProofs || len(parameters.compositeTriggers) > 0 {
+		if _, isProvider := provider.(execclient.TransactionReceiptsProvider); !isProvider {
+			problems = append(problems, errors.New("client does not provide transaction receipts"))
+		}
+	}
+
+	if !parameters.clampEarliestBlock {
+		if err := s.validateEarliestBlocks(ctx, parameters); err != nil {
+			problems = append(problems, err)
+		}
+	}
+
+	return errors.Join(problems...)
+}
+
+// validateEarliestBlocks checks that the earliest blocks of triggers are available from the client.
+// Earliest blocks beyond the chain head are not yet available, but are not a problem.
+func (s *Service) validateEarliestBlocks(ctx context.Context, parameters *parameters) error {
+	head, err := s.chainHeight(ctx)
+	if err != nil {
+		return errors.Join(errors.New("failed to obtain chain height"), err)
+	}
+
+	earliestBlocks := triggerEarliestBlocks(parameters)
+	blocks := make([]uint64, 0, len(earliestBlocks))
+	for block := range earliestBlocks {
+		if block <= head {
+			blocks = append(blocks, block)
+		}
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i] < blocks[j] })
+
+	problems := make([]error, 0)
+	for _, block := range blocks {
+		available, err := s.blockAvailable(ctx, block)
+		if err != nil {
+			problems = append(problems, errors.Join(fmt.Errorf("failed to check availability of block %d", block), err))
+
+			break
+		}
+		if !available {
+			for _, name := range earliestBlocks[block] {
+				problems = append(problems, fmt.Errorf("earliest block %d of %s is not available", block, name))
+			}
+		}
+	}
+
+	return errors.Join(problems...)
+}
+
+// triggerEarliestBlocks returns the descriptions of the triggers that start at each block.
+func triggerEarliestBlocks(parameters *parameters) map[uint64][]string {
+	earliestBlocks := make(map[uint64][]string)
+	if parameters.earliestBlock > -1 {
+		earliestBlocks[uint64(parameters.earliestBlock)] = append(earliestBlocks[uint64(parameters.earliestBlock)], "listener")
+	}
+	for _, trigger := range parameters.blockTriggers {
+		earliestBlocks[trigger.EarliestBlock] = append(earliestBlocks[trigger.EarliestBlock], "block trigger "+trigger.QualifiedName())
+	}
+	for _, trigger := range parameters.txTriggers {
+		earliestBlocks[trigger.EarliestBlock] = append(earliestBlocks[trigger.EarliestBlock], "transaction trigger "+trigger.QualifiedName())
+	}
+	for _, trigger := range parameters.compositeTriggers {
+		earliestBlocks[trigger.EarliestBlock] = append(earliestBlocks[trigger.EarliestBlock], "composite trigger "+trigger.QualifiedName())
+	}
+	for _, trigger := range parameters.eventTriggers {
+		earliestBlocks[trigger.EarliestBlock] = append(earliestBlocks[trigger.EarliestBlock], "event trigger "+trigger.QualifiedName())
+	}
+	for _, trigger := range parameters.windowTriggers {
+		earliestBlocks[trigger.EarliestBlock] = append(earliestBlocks[trigger.EarliestBlock], "window trigger "+trigger.QualifiedName())
+	}
+	for name, initial := range parameters.initialProgress {
+		earliestBlocks[initial] = append(earliestBlocks[initial], "initial progress of trigger "+name)
+	}
+
+	return earliestBlocks
+}
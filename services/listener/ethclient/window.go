@@ -0,0 +1,195 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+// windowEventHandler handles events for a windowed trigger, maintaining the sliding window.
+type windowEventHandler struct {
+	mu      sync.Mutex
+	trigger *handlers.WindowTrigger
+	events  []*spec.BerlinTransactionEvent
+	// latestBlock and latestIndex are the position of the latest event added to the window, to ignore repeated events.
+	latestBlock uint64
+	latestIndex int64
+}
+
+// HandleEvent adds the event to the window, and calls the handler if the threshold is reached.
+func (h *windowEventHandler) HandleEvent(ctx context.Context, event *spec.BerlinTransactionEvent, _ *handlers.EventTrigger) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	height := uint64(event.BlockNumber)
+	if height < h.latestBlock || (height == h.latestBlock && int64(event.Index) <= h.latestIndex) {
+		// Already in the window.
+		return nil
+	}
+
+	// Drop events that have slid out of the window.
+	from := uint64(0)
+	if blocks := uint64(h.trigger.Blocks); height >= blocks {
+		from = height - blocks + 1
+	}
+	retained := 0
+	for retained < len(h.events) && uint64(h.events[retained].BlockNumber) < from {
+		retained++
+	}
+	events := append(append([]*spec.BerlinTransactionEvent{}, h.events[retained:]...), event)
+
+	aggregates, err := aggregateWindow(h.trigger, events)
+	if err != nil {
+		return err
+	}
+
+	if windowReachedThreshold(h.trigger, events, aggregates) {
+		window := &handlers.Window{
+			From:       from,
+			To:         height,
+			Events:     events,
+			Aggregates: aggregates,
+		}
+		if err := h.trigger.Handler.HandleWindow(ctx, window, h.trigger); err != nil {
+			// Leave the window as it was, so that the event is added again when retried.
+			return err
+		}
+		events = nil
+	}
+
+	h.events = events
+	h.latestBlock = height
+	h.latestIndex = int64(event.Index)
+
+	return nil
+}
+
+// aggregateWindow calculates the aggregates of the trigger over the events in the window.
+func aggregateWindow(trigger *handlers.WindowTrigger, events []*spec.BerlinTransactionEvent) (map[string]*big.Int, error) {
+	if len(trigger.Aggregates) == 0 {
+		return nil, nil
+	}
+
+	aggregates := make(map[string]*big.Int, len(trigger.Aggregates))
+	for name, aggregator := range trigger.Aggregates {
+		value, err := aggregator.Aggregate(events)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to calculate aggregate %s", name), err)
+		}
+		aggregates[name] = value
+	}
+
+	return aggregates, nil
+}
+
+// windowReachedThreshold returns true if the window has reached any of the thresholds of the trigger.
+func windowReachedThreshold(trigger *handlers.WindowTrigger,
+	events []*spec.BerlinTransactionEvent,
+	aggregates map[string]*big.Int,
+) bool {
+	if trigger.Threshold > 0 && len(events) >= trigger.Threshold {
+		return true
+	}
+	for name, threshold := range trigger.AggregateThresholds {
+		if value, exists := aggregates[name]; exists && value.Cmp(threshold) >= 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// windowEventTriggers returns event triggers that carry out the windowed triggers.
+func windowEventTriggers(triggers []*handlers.WindowTrigger) []*handlers.EventTrigger {
+	eventTriggers := make([]*handlers.EventTrigger, 0, len(triggers))
+	for _, trigger := range triggers {
+		eventTriggers = append(eventTriggers, &handlers.EventTrigger{
+			Name:           trigger.Name,
+			Namespace:      trigger.Namespace,
+			Source:         trigger.Event.Source,
+			SourceResolver: trigger.Event.SourceResolver,
+			SourceSet:      trigger.Event.SourceSet,
+			Topics:         trigger.Event.Topics,
+			TopicSets:      trigger.Event.TopicSets,
+			EarliestBlock:  trigger.EarliestBlock,
+			Handler: &windowEventHandler{
+				trigger:     trigger,
+				latestIndex: -1,
+			},
+			Labels:    trigger.Labels,
+			Priority:  trigger.Priority,
+			DependsOn: trigger.DependsOn,
+		})
+	}
+
+	return eventTriggers
+}
+
+// checkWindowTriggerParameters checks the parameters of windowed triggers.
+func checkWindowTriggerParameters(parameters *parameters) error {
+	for _, trigger := range parameters.windowTriggers {
+		if trigger.Name == "" {
+			return errors.New("no window trigger name specified")
+		}
+		if strings.Contains(trigger.Namespace, handlers.NamespaceSeparator) {
+			return fmt.Errorf("window trigger namespace cannot contain %q", handlers.NamespaceSeparator)
+		}
+		if trigger.Handler == nil {
+			return errors.New("no window trigger handler specified")
+		}
+		if trigger.Event == nil {
+			return errors.New("no window trigger event filters specified")
+		}
+		if err := checkEventTopics(trigger.Event); err != nil {
+			return errors.Join(fmt.Errorf("invalid topics for window trigger %s", trigger.QualifiedName()), err)
+		}
+		if trigger.Blocks == 0 {
+			return errors.New("no window trigger blocks specified")
+		}
+		if trigger.Threshold < 0 {
+			return errors.New("window trigger threshold cannot be negative")
+		}
+		if trigger.Threshold == 0 && len(trigger.AggregateThresholds) == 0 {
+			return errors.New("no window trigger threshold specified")
+		}
+		for name, aggregator := range trigger.Aggregates {
+			if aggregator == nil {
+				return fmt.Errorf("window trigger aggregate %s has no aggregator", name)
+			}
+		}
+		for name, threshold := range trigger.AggregateThresholds {
+			if _, exists := trigger.Aggregates[name]; !exists {
+				return fmt.Errorf("window trigger threshold for unknown aggregate %s", name)
+			}
+			if threshold == nil {
+				return fmt.Errorf("window trigger threshold for aggregate %s has no value", name)
+			}
+		}
+		for _, eventTrigger := range parameters.eventTriggers {
+			if eventTrigger.QualifiedName() == trigger.QualifiedName() {
+				return fmt.Errorf("window trigger %s has the same name as an event trigger", trigger.QualifiedName())
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prices
+
+import (
+	"errors"
+
+	execclient "github.com/attestantio/go-execution-client"
+	"github.com/rs/zerolog"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+type parameters struct {
+	logLevel       zerolog.Level
+	blocksProvider execclient.BlocksProvider
+	source         handlers.PriceSource
+	cacheSize      int
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithBlocksProvider sets the provider used to obtain block timestamps.
+func WithBlocksProvider(provider execclient.BlocksProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.blocksProvider = provider
+	})
+}
+
+// WithSource sets the source of prices.
+func WithSource(source handlers.PriceSource) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.source = source
+	})
+}
+
+// WithCacheSize sets the maximum number of prices and block timestamps to cache.
+func WithCacheSize(size int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.cacheSize = size
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:  zerolog.GlobalLevel(),
+		cacheSize: 1024,
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.blocksProvider == nil {
+		return nil, errors.New("no blocks provider specified")
+	}
+	if parameters.source == nil {
+		return nil, errors.New("no price source specified")
+	}
+	if parameters.cacheSize < 1 {
+		return nil, errors.New("cache size must be at least 1")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,143 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package prices provides prices of assets at the time of blocks, from a pluggable price source.
+package prices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	execclient "github.com/attestantio/go-execution-client"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+type priceKey struct {
+	asset    types.Address
+	currency string
+	height   uint64
+}
+
+// Service provides prices at the time of blocks.
+// It implements the handlers.PriceEnricher interface.
+type Service struct {
+	log            zerolog.Logger
+	blocksProvider execclient.BlocksProvider
+	source         handlers.PriceSource
+	cacheSize      int
+	cacheMu        sync.Mutex
+	timestamps     map[uint64]time.Time
+	prices         map[priceKey]*handlers.Price
+}
+
+// New creates a new price service.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "prices").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	return &Service{
+		log:            log,
+		blocksProvider: parameters.blocksProvider,
+		source:         parameters.source,
+		cacheSize:      parameters.cacheSize,
+		timestamps:     make(map[uint64]time.Time),
+		prices:         make(map[priceKey]*handlers.Price),
+	}, nil
+}
+
+// PriceAtBlock returns the price of an asset in a currency at the timestamp of the given block.
+func (s *Service) PriceAtBlock(ctx context.Context,
+	asset types.Address,
+	currency string,
+	height uint64,
+) (
+	*handlers.Price,
+	error,
+) {
+	key := priceKey{
+		asset:    asset,
+		currency: currency,
+		height:   height,
+	}
+	s.cacheMu.Lock()
+	price, exists := s.prices[key]
+	s.cacheMu.Unlock()
+	if exists {
+		return price, nil
+	}
+
+	timestamp, err := s.blockTimestamp(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err = s.source.Price(ctx, asset, currency, timestamp)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to obtain price"), err)
+	}
+	if price == nil {
+		return nil, errors.New("no price returned")
+	}
+
+	s.cacheMu.Lock()
+	if len(s.prices) >= s.cacheSize {
+		// Prices for historical blocks do not change, but the cache is bounded so clear it when full.
+		s.prices = make(map[priceKey]*handlers.Price)
+	}
+	s.prices[key] = price
+	s.cacheMu.Unlock()
+
+	return price, nil
+}
+
+// blockTimestamp returns the timestamp of the given block.
+func (s *Service) blockTimestamp(ctx context.Context, height uint64) (time.Time, error) {
+	s.cacheMu.Lock()
+	timestamp, exists := s.timestamps[height]
+	s.cacheMu.Unlock()
+	if exists {
+		return timestamp, nil
+	}
+
+	block, err := s.blocksProvider.Block(ctx, fmt.Sprintf("%d", height))
+	if err != nil {
+		return time.Time{}, errors.Join(errors.New("failed to obtain block"), err)
+	}
+	if block == nil {
+		return time.Time{}, fmt.Errorf("block %d not found", height)
+	}
+	timestamp = block.Timestamp()
+
+	s.cacheMu.Lock()
+	if len(s.timestamps) >= s.cacheSize {
+		s.timestamps = make(map[uint64]time.Time)
+	}
+	s.timestamps[height] = timestamp
+	s.cacheMu.Unlock()
+
+	return timestamp, nil
+}
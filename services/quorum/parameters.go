@@ -0,0 +1,91 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quorum
+
+import (
+	"errors"
+
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel  zerolog.Level
+	providers []Provider
+	quorum    int
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithProviders sets the providers that are cross-verified.
+func WithProviders(providers []Provider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.providers = providers
+	})
+}
+
+// WithQuorum sets the number of providers that must agree for data to be returned.
+// If not supplied a majority of providers is required.
+func WithQuorum(quorum int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.quorum = quorum
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if len(parameters.providers) < 2 {
+		return nil, errors.New("at least two providers must be specified")
+	}
+	for _, provider := range parameters.providers {
+		if provider == nil {
+			return nil, errors.New("nil provider specified")
+		}
+	}
+	if parameters.quorum == 0 {
+		parameters.quorum = len(parameters.providers)/2 + 1
+	}
+	if parameters.quorum < 2 {
+		return nil, errors.New("quorum must be at least 2")
+	}
+	if parameters.quorum > len(parameters.providers) {
+		return nil, errors.New("quorum cannot be larger than the number of providers")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,413 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package quorum provides chain data that is cross-verified across multiple execution clients,
+// and only returned when a quorum of them agree.
+package quorum
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	execclient "github.com/attestantio/go-execution-client"
+	"github.com/attestantio/go-execution-client/api"
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Provider is the interface for the providers that are cross-verified.
+type Provider interface {
+	execclient.ChainHeightProvider
+	execclient.BlocksProvider
+	execclient.EventsProvider
+}
+
+// rawBlocksProvider is the interface for providers that supply blocks as returned by the client,
+// for blocks that the client library cannot decode.
+type rawBlocksProvider interface {
+	RawBlock(ctx context.Context, blockID string) (json.RawMessage, error)
+}
+
+// Service cross-verifies chain data across multiple providers.
+// It implements the chain height, chain ID, blocks and events provider interfaces.
+type Service struct {
+	log       zerolog.Logger
+	providers []Provider
+	quorum    int
+}
+
+// New creates a new quorum service.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "quorum").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	return &Service{
+		log:       log,
+		providers: parameters.providers,
+		quorum:    parameters.quorum,
+	}, nil
+}
+
+// result is the result of a request to a single provider.
+type result[T any] struct {
+	value T
+	key   string
+	err   error
+}
+
+// query runs a request against all providers concurrently.
+func query[T any](ctx context.Context,
+	providers []Provider,
+	request func(ctx context.Context, provider Provider) (T, string, error),
+) []*result[T] {
+	results := make([]*result[T], len(providers))
+	var wg sync.WaitGroup
+	for i, provider := range providers {
+		wg.Add(1)
+		go func(i int, provider Provider) {
+			defer wg.Done()
+			value, key, err := request(ctx, provider)
+			results[i] = &result[T]{value: value, key: key, err: err}
+		}(i, provider)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// agree returns the value for which at least quorum results agree.
+func agree[T any](results []*result[T], quorum int) (T, error) {
+	counts := make(map[string]int)
+	errs := make([]error, 0)
+	for _, res := range results {
+		if res.err != nil {
+			errs = append(errs, res.err)
+
+			continue
+		}
+		counts[res.key]++
+		if counts[res.key] >= quorum {
+			return res.value, nil
+		}
+	}
+
+	var empty T
+	if len(counts) > 1 {
+		return empty, errors.Join(append([]error{fmt.Errorf("providers disagree; %d distinct responses without quorum of %d", len(counts), quorum)}, errs...)...)
+	}
+
+	return empty, errors.Join(append([]error{fmt.Errorf("insufficient responses for quorum of %d", quorum)}, errs...)...)
+}
+
+// ChainHeight returns the highest block that at least a quorum of providers have reached.
+func (s *Service) ChainHeight(ctx context.Context) (uint32, error) {
+	results := query(ctx, s.providers, func(ctx context.Context, provider Provider) (uint32, string, error) {
+		height, err := provider.ChainHeight(ctx)
+
+		return height, "", err
+	})
+
+	heights := make([]uint32, 0, len(results))
+	for _, res := range results {
+		if res.err != nil {
+			s.log.Debug().Err(res.err).Msg("Provider failed to return chain height")
+
+			continue
+		}
+		heights = append(heights, res.value)
+	}
+	if len(heights) < s.quorum {
+		return 0, fmt.Errorf("insufficient chain height responses for quorum of %d", s.quorum)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] > heights[j] })
+
+	return heights[s.quorum-1], nil
+}
+
+// ChainID returns the chain ID, if a quorum of providers agree on it.
+// Providers that cannot supply the chain ID are treated as having failed.
+func (s *Service) ChainID(ctx context.Context) (uint64, error) {
+	results := query(ctx, s.providers, func(ctx context.Context, provider Provider) (uint64, string, error) {
+		chainIDProvider, isProvider := provider.(execclient.ChainIDProvider)
+		if !isProvider {
+			return 0, "", errors.New("provider does not provide chain ID")
+		}
+		chainID, err := chainIDProvider.ChainID(ctx)
+
+		return chainID, strconv.FormatUint(chainID, 10), err
+	})
+
+	return agree(results, s.quorum)
+}
+
+// Block returns the block with the given ID, if a quorum of providers agree on its hash and transactions.
+func (s *Service) Block(ctx context.Context, blockID string) (*spec.Block, error) {
+	if isNamedBlock(blockID) {
+		// Named blocks can legitimately differ between providers, so settle on the
+		// lowest of the blocks returned and verify that by number.
+		number, err := s.lowestNamedBlock(ctx, blockID)
+		if err != nil {
+			return nil, err
+		}
+		blockID = fmt.Sprintf("%d", number)
+	}
+
+	results := query(ctx, s.providers, func(ctx context.Context, provider Provider) (*spec.Block, string, error) {
+		block, err := provider.Block(ctx, blockID)
+		if err != nil {
+			return nil, "", err
+		}
+		if block == nil {
+			return nil, "", errors.New("block not found")
+		}
+
+		return block, blockKey(block), nil
+	})
+
+	block, err := agree(results, s.quorum)
+	if err != nil {
+		s.log.Warn().Str("block", blockID).Err(err).Msg("No quorum for block")
+
+		return nil, errors.Join(fmt.Errorf("no quorum for block %s", blockID), err)
+	}
+
+	return block, nil
+}
+
+// RawBlock returns the JSON of the block with the given ID, if a quorum of providers agree on its hash
+// and transactions.
+// Providers that cannot supply raw blocks are treated as having failed.
+func (s *Service) RawBlock(ctx context.Context, blockID string) (json.RawMessage, error) {
+	if isNamedBlock(blockID) {
+		number, err := s.lowestNamedBlock(ctx, blockID)
+		if err != nil {
+			return nil, err
+		}
+		blockID = fmt.Sprintf("%d", number)
+	}
+
+	results := query(ctx, s.providers, func(ctx context.Context, provider Provider) (json.RawMessage, string, error) {
+		rawBlocksProvider, isProvider := provider.(rawBlocksProvider)
+		if !isProvider {
+			return nil, "", errors.New("provider does not provide raw blocks")
+		}
+		data, err := rawBlocksProvider.RawBlock(ctx, blockID)
+		if err != nil {
+			return nil, "", err
+		}
+		if data == nil {
+			return nil, "", errors.New("block not found")
+		}
+		key, err := rawBlockKey(data)
+		if err != nil {
+			return nil, "", errors.Join(errors.New("failed to parse block"), err)
+		}
+
+		return data, key, nil
+	})
+
+	data, err := agree(results, s.quorum)
+	if err != nil {
+		s.log.Warn().Str("block", blockID).Err(err).Msg("No quorum for block")
+
+		return nil, errors.Join(fmt.Errorf("no quorum for block %s", blockID), err)
+	}
+
+	return data, nil
+}
+
+// Events returns the events matching the filter, if a quorum of providers agree on them.
+func (s *Service) Events(ctx context.Context, filter *api.EventsFilter) ([]*spec.BerlinTransactionEvent, error) {
+	results := query(ctx, s.providers, func(ctx context.Context, provider Provider) ([]*spec.BerlinTransactionEvent, string, error) {
+		events, err := provider.Events(ctx, filter)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return events, eventsKey(events), nil
+	})
+
+	events, err := agree(results, s.quorum)
+	if err != nil {
+		s.log.Warn().Str("from", filter.FromBlock).Str("to", filter.ToBlock).Err(err).Msg("No quorum for events")
+
+		return nil, errors.Join(errors.New("no quorum for events"), err)
+	}
+
+	return events, nil
+}
+
+// lowestNamedBlock returns the lowest block number returned by providers for a named block.
+func (s *Service) lowestNamedBlock(ctx context.Context, blockID string) (uint32, error) {
+	results := query(ctx, s.providers, func(ctx context.Context, provider Provider) (uint32, string, error) {
+		block, err := provider.Block(ctx, blockID)
+		if err != nil {
+			return 0, "", err
+		}
+		if block == nil {
+			return 0, "", errors.New("block not found")
+		}
+
+		return block.Number(), "", nil
+	})
+
+	numbers := make([]uint32, 0, len(results))
+	for _, res := range results {
+		if res.err == nil {
+			numbers = append(numbers, res.value)
+		}
+	}
+	if len(numbers) < s.quorum {
+		return 0, fmt.Errorf("insufficient responses for %s block for quorum of %d", blockID, s.quorum)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+
+	return numbers[0], nil
+}
+
+// isNamedBlock returns true if the block ID is a name such as "latest" rather than a number or hash.
+func isNamedBlock(blockID string) bool {
+	if strings.HasPrefix(blockID, "0x") {
+		return false
+	}
+	_, err := strconv.ParseUint(blockID, 10, 64)
+
+	return err != nil
+}
+
+// blockKey returns a key that identifies the contents of a block.
+// The hash alone is not sufficient, as it is supplied by the provider and does not tie the
+// transactions returned alongside it to the block.
+func blockKey(block *spec.Block) string {
+	hash := sha256.New()
+	buf := make([]byte, 8)
+	blockHash := block.Hash()
+	hash.Write(blockHash[:])
+	transactionsRoot := block.TransactionsRoot()
+	hash.Write(transactionsRoot[:])
+	transactions := block.Transactions()
+	for _, tx := range transactions {
+		txHash := tx.Hash()
+		hash.Write(txHash[:])
+		from := tx.From()
+		hash.Write(from[:])
+		if to := tx.To(); to != nil {
+			hash.Write([]byte{1})
+			hash.Write(to[:])
+		} else {
+			hash.Write([]byte{0})
+		}
+		binary.BigEndian.PutUint64(buf, tx.Nonce())
+		hash.Write(buf)
+		var value []byte
+		if tx.Value() != nil {
+			value = tx.Value().Bytes()
+		}
+		binary.BigEndian.PutUint64(buf, uint64(len(value)))
+		hash.Write(buf)
+		hash.Write(value)
+		input := tx.Input()
+		binary.BigEndian.PutUint64(buf, uint64(len(input)))
+		hash.Write(buf)
+		hash.Write(input)
+	}
+
+	return fmt.Sprintf("%d:%x", len(transactions), hash.Sum(nil))
+}
+
+// rawBlockKey returns a key that identifies the contents of a block supplied as JSON.
+// Values are compared as returned by the providers, which are canonical hex for JSON-RPC.
+func rawBlockKey(data json.RawMessage) (string, error) {
+	var block struct {
+		Hash             string `json:"hash"`
+		TransactionsRoot string `json:"transactionsRoot"`
+		Transactions     []struct {
+			Hash  string  `json:"hash"`
+			From  string  `json:"from"`
+			To    *string `json:"to"`
+			Nonce string  `json:"nonce"`
+			Value string  `json:"value"`
+			Input string  `json:"input"`
+		} `json:"transactions"`
+	}
+	if err := json.Unmarshal(data, &block); err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	write := func(value string) {
+		hash.Write([]byte(strings.ToLower(value)))
+		hash.Write([]byte{0})
+	}
+	write(block.Hash)
+	write(block.TransactionsRoot)
+	for _, tx := range block.Transactions {
+		write(tx.Hash)
+		write(tx.From)
+		if tx.To != nil {
+			write(*tx.To)
+		} else {
+			write("-")
+		}
+		write(tx.Nonce)
+		write(tx.Value)
+		write(tx.Input)
+	}
+
+	return fmt.Sprintf("%d:%x", len(block.Transactions), hash.Sum(nil)), nil
+}
+
+// eventsKey returns a key that identifies the contents of a set of events.
+func eventsKey(events []*spec.BerlinTransactionEvent) string {
+	hash := sha256.New()
+	buf := make([]byte, 4)
+	for _, event := range events {
+		hash.Write(event.BlockHash[:])
+		hash.Write(event.TransactionHash[:])
+		binary.BigEndian.PutUint32(buf, event.Index)
+		hash.Write(buf)
+		hash.Write(event.Address[:])
+		binary.BigEndian.PutUint32(buf, uint32(len(event.Topics)))
+		hash.Write(buf)
+		for _, topic := range event.Topics {
+			hash.Write(topic[:])
+		}
+		binary.BigEndian.PutUint32(buf, uint32(len(event.Data)))
+		hash.Write(buf)
+		hash.Write(event.Data)
+		if event.Removed {
+			hash.Write([]byte{1})
+		} else {
+			hash.Write([]byte{0})
+		}
+	}
+
+	return fmt.Sprintf("%d:%x", len(events), hash.Sum(nil))
+}
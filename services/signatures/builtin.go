@@ -0,0 +1,56 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures
+
+import (
+	"golang.org/x/crypto/sha3"
+)
+
+// builtin are the signatures of common token standards, available without a signature directory.
+var builtin = []string{
+	// ERC-20.
+	"transfer(address,uint256)",
+	"transferFrom(address,address,uint256)",
+	"approve(address,uint256)",
+	"Transfer(address,address,uint256)",
+	"Approval(address,address,uint256)",
+	// WETH.
+	"deposit()",
+	"withdraw(uint256)",
+	"Deposit(address,uint256)",
+	"Withdrawal(address,uint256)",
+	// ERC-721.
+	"safeTransferFrom(address,address,uint256)",
+	"safeTransferFrom(address,address,uint256,bytes)",
+	"setApprovalForAll(address,bool)",
+	"ApprovalForAll(address,address,bool)",
+	// ERC-1155.
+	"safeTransferFrom(address,address,uint256,uint256,bytes)",
+	"safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)",
+	"TransferSingle(address,address,address,uint256,uint256)",
+	"TransferBatch(address,address,address,uint256[],uint256[])",
+	// Ownable.
+	"transferOwnership(address)",
+	"OwnershipTransferred(address,address)",
+}
+
+// hashSignature returns the Keccak-256 hash of a signature.
+func hashSignature(signature string) [32]byte {
+	var res [32]byte
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(signature))
+	copy(res[:], hash.Sum(nil))
+
+	return res
+}
@@ -0,0 +1,206 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// maxResponseSize is the maximum size of a signature directory fetched over HTTP.
+const maxResponseSize = 256 * 1024 * 1024
+
+const (
+	cacheFile     = "signatures.json"
+	cacheETagFile = "signatures.etag"
+)
+
+// directoryJSON is the JSON representation of a signature directory.
+// Each map is keyed by the hex-encoded selector or topic.
+type directoryJSON struct {
+	Functions map[string][]string `json:"functions"`
+	Events    map[string][]string `json:"events"`
+}
+
+// directory is a parsed signature directory.
+type directory struct {
+	functions map[[4]byte][]string
+	events    map[types.Hash][]string
+}
+
+func newDirectory() *directory {
+	return &directory{
+		functions: make(map[[4]byte][]string),
+		events:    make(map[types.Hash][]string),
+	}
+}
+
+// addSignature adds a signature, calculating its selector or topic.
+// Signatures whose names start with an upper-case letter are considered to be events.
+func (d *directory) addSignature(signature string) {
+	hash := hashSignature(signature)
+	if signature != "" && signature[0] >= 'A' && signature[0] <= 'Z' {
+		d.events[hash] = appendUnique(d.events[hash], signature)
+
+		return
+	}
+	var selector [4]byte
+	copy(selector[:], hash[:4])
+	d.functions[selector] = appendUnique(d.functions[selector], signature)
+}
+
+// merge merges another directory in to this one.
+func (d *directory) merge(other *directory) {
+	for selector, signatures := range other.functions {
+		for _, signature := range signatures {
+			d.functions[selector] = appendUnique(d.functions[selector], signature)
+		}
+	}
+	for topic, signatures := range other.events {
+		for _, signature := range signatures {
+			d.events[topic] = appendUnique(d.events[topic], signature)
+		}
+	}
+}
+
+func appendUnique(signatures []string, signature string) []string {
+	for _, existing := range signatures {
+		if existing == signature {
+			return signatures
+		}
+	}
+
+	return append(signatures, signature)
+}
+
+// parseDirectory parses the JSON representation of a signature directory.
+func parseDirectory(data []byte) (*directory, error) {
+	var raw directoryJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Join(errors.New("failed to parse signature directory"), err)
+	}
+
+	dir := newDirectory()
+	for key, signatures := range raw.Functions {
+		val, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+		if err != nil || len(val) != 4 {
+			return nil, fmt.Errorf("invalid function selector %q", key)
+		}
+		var selector [4]byte
+		copy(selector[:], val)
+		for _, signature := range signatures {
+			dir.functions[selector] = appendUnique(dir.functions[selector], signature)
+		}
+	}
+	for key, signatures := range raw.Events {
+		val, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+		if err != nil || len(val) != len(types.Hash{}) {
+			return nil, fmt.Errorf("invalid event topic %q", key)
+		}
+		topic := types.Hash(val)
+		for _, signature := range signatures {
+			dir.events[topic] = appendUnique(dir.events[topic], signature)
+		}
+	}
+
+	return dir, nil
+}
+
+// readCache reads the cached remote directory and its ETag, if present.
+func (s *Service) readCache() ([]byte, string) {
+	if s.cacheDir == "" {
+		return nil, ""
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.cacheDir, cacheFile))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			s.log.Warn().Err(err).Msg("Failed to read cached signature directory")
+		}
+
+		return nil, ""
+	}
+	etag, err := os.ReadFile(filepath.Join(s.cacheDir, cacheETagFile))
+	if err != nil {
+		// Without an ETag the data can still be used, but will be fetched in full.
+		return data, ""
+	}
+
+	return data, strings.TrimSpace(string(etag))
+}
+
+// writeCache writes the remote directory and its ETag to the cache.
+func (s *Service) writeCache(data []byte, etag string) error {
+	if s.cacheDir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(s.cacheDir, 0o700); err != nil {
+		return errors.Join(errors.New("failed to create signature cache directory"), err)
+	}
+	tmpPath := filepath.Join(s.cacheDir, cacheFile+".tmp")
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return errors.Join(errors.New("failed to write signature cache"), err)
+	}
+	if err := os.Rename(tmpPath, filepath.Join(s.cacheDir, cacheFile)); err != nil {
+		return errors.Join(errors.New("failed to replace signature cache"), err)
+	}
+	if err := os.WriteFile(filepath.Join(s.cacheDir, cacheETagFile), []byte(etag), 0o600); err != nil {
+		return errors.Join(errors.New("failed to write signature cache ETag"), err)
+	}
+
+	return nil
+}
+
+// fetchRemote fetches the remote directory.
+// It returns nil data if the directory has not changed since the given ETag.
+func (s *Service) fetchRemote(ctx context.Context, etag string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, "", errors.Join(errors.New("failed to create signature directory request"), err)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, "", errors.Join(errors.New("failed to fetch signature directory"), err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil, etag, nil
+	default:
+		return nil, "", fmt.Errorf("signature directory request returned status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, "", errors.Join(errors.New("failed to read signature directory response"), err)
+	}
+
+	return data, resp.Header.Get("ETag"), nil
+}
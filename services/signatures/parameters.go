@@ -0,0 +1,116 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatures
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel    zerolog.Level
+	path        string
+	url         string
+	cacheDir    string
+	timeout     time.Duration
+	interval    time.Duration
+	withBuiltin bool
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithPath sets the path of a local signature directory file.
+func WithPath(path string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.path = path
+	})
+}
+
+// WithURL sets the URL of a remote signature directory file.
+func WithURL(url string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.url = url
+	})
+}
+
+// WithCacheDir sets the directory in which the remote signature directory is cached,
+// allowing it to be used offline and refreshed with conditional requests.
+func WithCacheDir(dir string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.cacheDir = dir
+	})
+}
+
+// WithTimeout sets the timeout for requests made to the signature directory URL.
+func WithTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.timeout = timeout
+	})
+}
+
+// WithInterval sets the interval between refreshes of the remote signature directory.
+// If not supplied the remote directory is only fetched at startup.
+func WithInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.interval = interval
+	})
+}
+
+// WithBuiltin sets whether the built-in signatures for common token standards are included.
+func WithBuiltin(builtin bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.withBuiltin = builtin
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:    zerolog.GlobalLevel(),
+		timeout:     30 * time.Second,
+		withBuiltin: true,
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.url != "" && parameters.timeout == 0 {
+		return nil, errors.New("no timeout specified")
+	}
+	if parameters.cacheDir != "" && parameters.url == "" {
+		return nil, errors.New("cache directory specified without URL")
+	}
+
+	return &parameters, nil
+}
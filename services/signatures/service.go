@@ -0,0 +1,182 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package signatures provides human-readable signatures for function selectors and event topics,
+// from built-in, local and remote signature directories.
+package signatures
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service looks up signatures.
+// It implements the handlers.SignatureLookup interface.
+type Service struct {
+	log        zerolog.Logger
+	url        string
+	cacheDir   string
+	client     *http.Client
+	base       *directory
+	remoteETag string
+	mu         sync.RWMutex
+	dir        *directory
+}
+
+// New creates a new signature lookup service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "signatures").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		log:      log,
+		url:      parameters.url,
+		cacheDir: parameters.cacheDir,
+		client: &http.Client{
+			Timeout: parameters.timeout,
+		},
+		base: newDirectory(),
+	}
+
+	// The base directory contains the signatures that do not change.
+	if parameters.withBuiltin {
+		for _, signature := range builtin {
+			s.base.addSignature(signature)
+		}
+	}
+	if parameters.path != "" {
+		data, err := os.ReadFile(parameters.path)
+		if err != nil {
+			return nil, errors.Join(errors.New("failed to read signature directory"), err)
+		}
+		dir, err := parseDirectory(data)
+		if err != nil {
+			return nil, err
+		}
+		s.base.merge(dir)
+	}
+	s.dir = s.base
+
+	if s.url != "" {
+		// Start with the cached copy of the remote directory if there is one, so that
+		// signatures are available even if the remote directory cannot be reached.
+		if data, etag := s.readCache(); data != nil {
+			if err := s.apply(data); err != nil {
+				s.log.Warn().Err(err).Msg("Cached signature directory is invalid; ignoring")
+			} else {
+				s.remoteETag = etag
+			}
+		}
+		if err := s.refresh(ctx); err != nil {
+			s.log.Warn().Err(err).Msg("Failed to refresh signature directory; continuing with available signatures")
+		}
+		if parameters.interval > 0 {
+			go s.refresher(ctx, parameters.interval)
+		}
+	}
+
+	return s, nil
+}
+
+// FunctionSignatures returns the probable signatures for a function selector.
+func (s *Service) FunctionSignatures(selector [4]byte) []string {
+	s.mu.RLock()
+	signatures := s.dir.functions[selector]
+	s.mu.RUnlock()
+
+	return signatures
+}
+
+// EventSignatures returns the probable signatures for an event topic.
+func (s *Service) EventSignatures(topic types.Hash) []string {
+	s.mu.RLock()
+	signatures := s.dir.events[topic]
+	s.mu.RUnlock()
+
+	return signatures
+}
+
+func (s *Service) refresher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.refresh(ctx); err != nil && ctx.Err() == nil {
+				s.log.Warn().Err(err).Msg("Failed to refresh signature directory; retaining previous signatures")
+			}
+		case <-ctx.Done():
+			s.log.Debug().Msg("Context done")
+			return
+		}
+	}
+}
+
+// refresh fetches the remote directory if it has changed.
+func (s *Service) refresh(ctx context.Context) error {
+	data, etag, err := s.fetchRemote(ctx, s.remoteETag)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		s.log.Trace().Msg("Signature directory not modified")
+
+		return nil
+	}
+
+	if err := s.apply(data); err != nil {
+		return err
+	}
+	s.remoteETag = etag
+	if err := s.writeCache(data, etag); err != nil {
+		s.log.Warn().Err(err).Msg("Failed to cache signature directory")
+	}
+
+	return nil
+}
+
+// apply parses remote directory data and merges it with the base directory.
+func (s *Service) apply(data []byte) error {
+	remote, err := parseDirectory(data)
+	if err != nil {
+		return err
+	}
+
+	dir := newDirectory()
+	dir.merge(s.base)
+	dir.merge(remote)
+
+	s.mu.Lock()
+	s.dir = dir
+	s.mu.Unlock()
+	s.log.Trace().Int("functions", len(dir.functions)).Int("events", len(dir.events)).Msg("Loaded signature directory")
+
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"bytes"
+	"math/big"
+	"unicode/utf8"
+)
+
+const wordLength = 32
+
+// decodeDecimals decodes the result of decimals().
+func decodeDecimals(data []byte) (uint8, bool) {
+	if len(data) != wordLength {
+		return 0, false
+	}
+	val := new(big.Int).SetBytes(data)
+	if !val.IsUint64() || val.Uint64() > 255 {
+		return 0, false
+	}
+
+	return uint8(val.Uint64()), true
+}
+
+// decodeString decodes the result of name() or symbol().
+// This handles both the standard ABI-encoded string and the bytes32 value
+// returned by some early tokens.
+func decodeString(data []byte) string {
+	switch {
+	case len(data) == wordLength:
+		// Fixed bytes32 value.
+		return validString(bytes.TrimRight(data, "\x00"))
+	case len(data) >= 2*wordLength:
+		offset := new(big.Int).SetBytes(data[:wordLength])
+		if !offset.IsUint64() || offset.Uint64() > uint64(len(data)-wordLength) {
+			return ""
+		}
+		start := int(offset.Uint64()) + wordLength
+		length := new(big.Int).SetBytes(data[start-wordLength : start])
+		if !length.IsUint64() || length.Uint64() > uint64(len(data)-start) {
+			return ""
+		}
+
+		return validString(data[start : start+int(length.Uint64())])
+	default:
+		return ""
+	}
+}
+
+// validString returns the data as a string if it is valid UTF-8, otherwise an empty string.
+func validString(data []byte) string {
+	if !utf8.Valid(data) {
+		return ""
+	}
+
+	return string(data)
+}
@@ -0,0 +1,80 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"errors"
+	"time"
+
+	execclient "github.com/attestantio/go-execution-client"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel     zerolog.Level
+	callProvider execclient.CallProvider
+	failureTTL   time.Duration
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithCallProvider sets the provider used to call token contracts.
+func WithCallProvider(provider execclient.CallProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.callProvider = provider
+	})
+}
+
+// WithFailureTTL sets the time for which an address that could not be resolved as a token
+// is remembered before it is tried again.
+func WithFailureTTL(ttl time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.failureTTL = ttl
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:   zerolog.GlobalLevel(),
+		failureTTL: time.Hour,
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.callProvider == nil {
+		return nil, errors.New("no call provider specified")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,161 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tokens resolves and caches ERC-20 token metadata.
+package tokens
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	execclient "github.com/attestantio/go-execution-client"
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/handlers"
+)
+
+var (
+	// nameSelector is the selector for name().
+	nameSelector = []byte{0x06, 0xfd, 0xde, 0x03}
+	// symbolSelector is the selector for symbol().
+	symbolSelector = []byte{0x95, 0xd8, 0x9b, 0x41}
+	// decimalsSelector is the selector for decimals().
+	decimalsSelector = []byte{0x31, 0x3c, 0xe5, 0x67}
+)
+
+type cacheEntry struct {
+	metadata *handlers.TokenMetadata
+	// expires is used for addresses that are not tokens, as they may become so later
+	// (e.g. the contract had not been deployed at the time of the lookup).
+	expires time.Time
+}
+
+// Service resolves and caches token metadata.
+// It implements the handlers.TokenMetadataProvider interface.
+type Service struct {
+	log          zerolog.Logger
+	callProvider execclient.CallProvider
+	failureTTL   time.Duration
+	cacheMu      sync.RWMutex
+	cache        map[types.Address]*cacheEntry
+}
+
+// New creates a new token metadata service.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "tokens").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	return &Service{
+		log:          log,
+		callProvider: parameters.callProvider,
+		failureTTL:   parameters.failureTTL,
+		cache:        make(map[types.Address]*cacheEntry),
+	}, nil
+}
+
+// TokenMetadata returns the metadata for the token at the given address.
+// It returns nil if the address does not provide ERC-20 metadata.
+func (s *Service) TokenMetadata(ctx context.Context, address types.Address) (*handlers.TokenMetadata, error) {
+	s.cacheMu.RLock()
+	entry, exists := s.cache[address]
+	s.cacheMu.RUnlock()
+	if exists && (entry.metadata != nil || time.Now().Before(entry.expires)) {
+		return entry.metadata, nil
+	}
+
+	metadata, err := s.resolve(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	entry = &cacheEntry{
+		metadata: metadata,
+	}
+	if metadata == nil {
+		entry.expires = time.Now().Add(s.failureTTL)
+	}
+	s.cacheMu.Lock()
+	s.cache[address] = entry
+	s.cacheMu.Unlock()
+
+	return metadata, nil
+}
+
+// resolve obtains token metadata from the chain.
+func (s *Service) resolve(ctx context.Context, address types.Address) (*handlers.TokenMetadata, error) {
+	// Decimals is the only item required to normalize amounts, so if it is not present
+	// the address is not considered to be a token.
+	res, err := s.call(ctx, address, decimalsSelector)
+	if err != nil {
+		return nil, err
+	}
+	decimals, isDecimals := decodeDecimals(res)
+	if !isDecimals {
+		s.log.Trace().Stringer("address", address).Msg("Address does not provide decimals; not a token")
+
+		return nil, nil
+	}
+
+	metadata := &handlers.TokenMetadata{
+		Address:  address,
+		Decimals: decimals,
+	}
+
+	// Name and symbol are optional in ERC-20, so failure to decode them is not an error.
+	res, err = s.call(ctx, address, symbolSelector)
+	if err != nil {
+		return nil, err
+	}
+	metadata.Symbol = decodeString(res)
+
+	res, err = s.call(ctx, address, nameSelector)
+	if err != nil {
+		return nil, err
+	}
+	metadata.Name = decodeString(res)
+
+	s.log.Trace().Stringer("address", address).Str("symbol", metadata.Symbol).Uint8("decimals", metadata.Decimals).Msg("Resolved token")
+
+	return metadata, nil
+}
+
+// call calls a method on the contract at the given address.
+// It returns nil data if the call reverts, as that is taken to mean the method is not supported.
+func (s *Service) call(ctx context.Context, address types.Address, data []byte) ([]byte, error) {
+	res, err := s.callProvider.Call(ctx, &execclient.CallOpts{
+		To:    &address,
+		Data:  data,
+		Block: "latest",
+	})
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, errors.Join(errors.New("failed to call token contract"), err)
+		}
+		s.log.Trace().Stringer("address", address).Err(err).Msg("Call failed; treating as unsupported")
+
+		return nil, nil
+	}
+
+	return res, nil
+}
@@ -0,0 +1,144 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-execution-client/api"
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// checkBlock checks that a block is consistent with the ID by which it was requested, and with its transactions.
+// A missing block is not an anomaly, as the provider may not yet have it.
+func checkBlock(blockID string, block *spec.Block) error {
+	if block == nil {
+		return nil
+	}
+
+	if number, isNumber := blockNumber(blockID); isNumber && uint64(block.Number()) != number {
+		return anomaly("block_number", "requested block %d but received block %d", number, block.Number())
+	}
+	if strings.HasPrefix(blockID, "0x") && len(blockID) == 2+2*len(types.Hash{}) &&
+		!strings.EqualFold(blockID, block.Hash().String()) {
+		return anomaly("block_hash", "requested block %s but received block %s", blockID, block.Hash().String())
+	}
+
+	hash := block.Hash()
+	for i, tx := range block.Transactions() {
+		if blockHash := tx.BlockHash(); blockHash != nil && *blockHash != hash {
+			return anomaly("transaction_block", "transaction %s has block hash %s in block %s", tx.Hash().String(), blockHash.String(), hash.String())
+		}
+		if index := tx.TransactionIndex(); index != nil && int(*index) != i {
+			return anomaly("transaction_index", "transaction %s has index %d at position %d", tx.Hash().String(), *index, i)
+		}
+	}
+
+	return nil
+}
+
+// rawBlockJSON holds the fields of a raw block that are checked.
+type rawBlockJSON struct {
+	Number       string `json:"number"`
+	Hash         string `json:"hash"`
+	Transactions []struct {
+		Hash             string `json:"hash"`
+		BlockHash        string `json:"blockHash"`
+		TransactionIndex string `json:"transactionIndex"`
+	} `json:"transactions"`
+}
+
+// checkRawBlock checks that the JSON of a block is consistent with the ID by which it was requested,
+// and with its transactions.
+// A missing block is not an anomaly, as the provider may not yet have it.
+func checkRawBlock(blockID string, data json.RawMessage) error {
+	if data == nil {
+		return nil
+	}
+
+	block := &rawBlockJSON{}
+	if err := json.Unmarshal(data, block); err != nil {
+		return anomaly("block_format", "invalid block: %v", err)
+	}
+	if number, isNumber := blockNumber(blockID); isNumber {
+		if blockNumber, err := strconv.ParseUint(block.Number, 0, 64); err != nil || blockNumber != number {
+			return anomaly("block_number", "requested block %d but received block %s", number, block.Number)
+		}
+	}
+	if strings.HasPrefix(blockID, "0x") && len(blockID) == 2+2*len(types.Hash{}) &&
+		!strings.EqualFold(blockID, block.Hash) {
+		return anomaly("block_hash", "requested block %s but received block %s", blockID, block.Hash)
+	}
+
+	for i, tx := range block.Transactions {
+		if tx.BlockHash != "" && !strings.EqualFold(tx.BlockHash, block.Hash) {
+			return anomaly("transaction_block", "transaction %s has block hash %s in block %s", tx.Hash, tx.BlockHash, block.Hash)
+		}
+		if index, err := strconv.ParseUint(tx.TransactionIndex, 0, 64); err == nil && index != uint64(i) {
+			return anomaly("transaction_index", "transaction %s has index %d at position %d", tx.Hash, index, i)
+		}
+	}
+
+	return nil
+}
+
+// checkEvents checks that events are within the range of the filter, in order, and match the filter.
+func checkEvents(filter *api.EventsFilter, events []*spec.BerlinTransactionEvent) error {
+	fromBlock, hasFrom := blockNumber(filter.FromBlock)
+	toBlock, hasTo := blockNumber(filter.ToBlock)
+
+	blockHashes := make(map[uint32]types.Hash)
+	for i, event := range events {
+		if (hasFrom && uint64(event.BlockNumber) < fromBlock) || (hasTo && uint64(event.BlockNumber) > toBlock) {
+			return anomaly("event_range", "event in block %d outside of requested range %s-%s", event.BlockNumber, filter.FromBlock, filter.ToBlock)
+		}
+		if i > 0 {
+			prev := events[i-1]
+			if event.BlockNumber < prev.BlockNumber || (event.BlockNumber == prev.BlockNumber && event.Index <= prev.Index) {
+				return anomaly("event_order", "event %d in block %d follows event %d in block %d", event.Index, event.BlockNumber, prev.Index, prev.BlockNumber)
+			}
+		}
+		if blockHash, exists := blockHashes[event.BlockNumber]; exists && blockHash != event.BlockHash {
+			return anomaly("event_block_hash", "events in block %d have differing block hashes", event.BlockNumber)
+		}
+		blockHashes[event.BlockNumber] = event.BlockHash
+		if filter.Address != nil && event.Address != *filter.Address {
+			return anomaly("event_address", "event from %s does not match requested address %s", event.Address.String(), filter.Address.String())
+		}
+		for j, topic := range filter.Topics {
+			if j >= len(event.Topics) || event.Topics[j] != topic {
+				return anomaly("event_topics", "event %d in block %d does not match requested topics", event.Index, event.BlockNumber)
+			}
+		}
+	}
+
+	return nil
+}
+
+// blockNumber returns the number of a block ID, if it is a decimal or hex number rather than a name or hash.
+func blockNumber(blockID string) (uint64, bool) {
+	if strings.HasPrefix(blockID, "0x") && len(blockID) > 2+16 {
+		// Too long to be a number, so a hash.
+		return 0, false
+	}
+	number, err := strconv.ParseUint(blockID, 0, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return number, true
+}
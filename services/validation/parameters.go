@@ -0,0 +1,113 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/wealdtech/go-eth-listener/services/metrics"
+	nullmetrics "github.com/wealdtech/go-eth-listener/services/metrics/null"
+)
+
+type parameters struct {
+	logLevel      zerolog.Level
+	monitor       metrics.Service
+	provider      Provider
+	maxReorgDepth uint32
+	maxRetries    int
+	retryDelay    time.Duration
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the metrics monitor.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithProvider sets the provider whose responses are validated.
+func WithProvider(provider Provider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.provider = provider
+	})
+}
+
+// WithMaxReorgDepth sets the largest decrease in chain height that is accepted as a reorg.
+func WithMaxReorgDepth(depth uint32) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxReorgDepth = depth
+	})
+}
+
+// WithMaxRetries sets the maximum number of retries for a request with an anomalous response.
+func WithMaxRetries(maxRetries int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxRetries = maxRetries
+	})
+}
+
+// WithRetryDelay sets the initial delay between retries, which doubles with each retry.
+func WithRetryDelay(retryDelay time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.retryDelay = retryDelay
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:      zerolog.GlobalLevel(),
+		monitor:       nullmetrics.New(),
+		maxReorgDepth: 64,
+		maxRetries:    3,
+		retryDelay:    500 * time.Millisecond,
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.provider == nil {
+		return nil, errors.New("no provider specified")
+	}
+	if parameters.maxRetries < 0 {
+		return nil, errors.New("max retries cannot be negative")
+	}
+
+	return &parameters, nil
+}
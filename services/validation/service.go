@@ -0,0 +1,217 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package validation provides chain data from a provider after sanity-checking its responses, so that
+// anomalous responses are retried rather than passed to handlers.
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	execclient "github.com/attestantio/go-execution-client"
+	"github.com/attestantio/go-execution-client/api"
+	"github.com/attestantio/go-execution-client/spec"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Provider is the interface for the provider whose responses are validated.
+type Provider interface {
+	execclient.ChainHeightProvider
+	execclient.BlocksProvider
+	execclient.EventsProvider
+}
+
+// rawBlocksProvider is the interface for providers that supply blocks as returned by the client,
+// for blocks that the client library cannot decode.
+type rawBlocksProvider interface {
+	RawBlock(ctx context.Context, blockID string) (json.RawMessage, error)
+}
+
+// anomalyError is an error for a response that failed a check.
+type anomalyError struct {
+	check string
+	err   error
+}
+
+func (e *anomalyError) Error() string {
+	return fmt.Sprintf("%s check failed: %v", e.check, e.err)
+}
+
+func (e *anomalyError) Unwrap() error {
+	return e.err
+}
+
+func anomaly(check string, format string, args ...any) error {
+	return &anomalyError{check: check, err: fmt.Errorf(format, args...)}
+}
+
+// Service validates the responses of a provider.
+// It implements the chain height, chain ID, blocks and events provider interfaces.
+//
+// Responses that fail a check are retried with backoff; if they still fail then an error is
+// returned, so the listener retries the poll rather than passing anomalous data to handlers.
+// Errors from the provider itself are returned unchanged.
+type Service struct {
+	log           zerolog.Logger
+	provider      Provider
+	maxReorgDepth uint32
+	maxRetries    int
+	retryDelay    time.Duration
+	mu            sync.Mutex
+	highestHeight uint32
+}
+
+// New creates a new validation service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "validation").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.Join(errors.New("failed to register metrics"), err)
+	}
+
+	return &Service{
+		log:           log,
+		provider:      parameters.provider,
+		maxReorgDepth: parameters.maxReorgDepth,
+		maxRetries:    parameters.maxRetries,
+		retryDelay:    parameters.retryDelay,
+	}, nil
+}
+
+// ChainHeight returns the chain height, if it has not decreased by more than the maximum reorg depth.
+func (s *Service) ChainHeight(ctx context.Context) (uint32, error) {
+	height, err := validated(ctx, s, "chain height",
+		s.provider.ChainHeight,
+		func(height uint32) error {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if height+s.maxReorgDepth < s.highestHeight {
+				return anomaly("chain_height", "height %d more than %d below previous height %d", height, s.maxReorgDepth, s.highestHeight)
+			}
+
+			return nil
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	if height > s.highestHeight {
+		s.highestHeight = height
+	}
+	s.mu.Unlock()
+
+	return height, nil
+}
+
+// ChainID returns the chain ID.
+func (s *Service) ChainID(ctx context.Context) (uint64, error) {
+	chainIDProvider, isProvider := s.provider.(execclient.ChainIDProvider)
+	if !isProvider {
+		return 0, errors.New("provider does not provide chain ID")
+	}
+
+	return chainIDProvider.ChainID(ctx)
+}
+
+// Block returns the block with the given ID, if it is consistent with the ID and its transactions.
+func (s *Service) Block(ctx context.Context, blockID string) (*spec.Block, error) {
+	return validated(ctx, s, fmt.Sprintf("block %s", blockID),
+		func(ctx context.Context) (*spec.Block, error) {
+			return s.provider.Block(ctx, blockID)
+		},
+		func(block *spec.Block) error {
+			return checkBlock(blockID, block)
+		})
+}
+
+// RawBlock returns the JSON of the block with the given ID, if it is consistent with the ID and its transactions.
+func (s *Service) RawBlock(ctx context.Context, blockID string) (json.RawMessage, error) {
+	provider, isProvider := s.provider.(rawBlocksProvider)
+	if !isProvider {
+		return nil, errors.New("provider does not provide raw blocks")
+	}
+
+	return validated(ctx, s, fmt.Sprintf("block %s", blockID),
+		func(ctx context.Context) (json.RawMessage, error) {
+			return provider.RawBlock(ctx, blockID)
+		},
+		func(data json.RawMessage) error {
+			return checkRawBlock(blockID, data)
+		})
+}
+
+// Events returns the events matching the filter, if they are within its range, in order and match it.
+func (s *Service) Events(ctx context.Context, filter *api.EventsFilter) ([]*spec.BerlinTransactionEvent, error) {
+	return validated(ctx, s, fmt.Sprintf("events %s-%s", filter.FromBlock, filter.ToBlock),
+		func(ctx context.Context) ([]*spec.BerlinTransactionEvent, error) {
+			return s.provider.Events(ctx, filter)
+		},
+		func(events []*spec.BerlinTransactionEvent) error {
+			return checkEvents(filter, events)
+		})
+}
+
+// validated runs a request until its response passes the check, or retries are exhausted.
+func validated[T any](ctx context.Context,
+	s *Service,
+	description string,
+	request func(ctx context.Context) (T, error),
+	check func(T) error,
+) (T, error) {
+	var empty T
+	delay := s.retryDelay
+	for attempt := 0; ; attempt++ {
+		res, err := request(ctx)
+		if err != nil {
+			return empty, err
+		}
+		err = check(res)
+		if err == nil {
+			return res, nil
+		}
+
+		var anomalyErr *anomalyError
+		if errors.As(err, &anomalyErr) {
+			monitorAnomaly(anomalyErr.check)
+		}
+		if attempt >= s.maxRetries {
+			s.log.Warn().Str("request", description).Err(err).Msg("Provider response failed validation; rejecting")
+
+			return empty, errors.Join(fmt.Errorf("invalid response for %s", description), err)
+		}
+		s.log.Debug().Str("request", description).Int("attempt", attempt+1).Err(err).Msg("Provider response failed validation; retrying")
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return empty, ctx.Err()
+		}
+		delay *= 2
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watchlist
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/attestantio/go-execution-client/types"
+)
+
+// maxResponseSize is the maximum size of a watchlist fetched over HTTP.
+const maxResponseSize = 64 * 1024 * 1024
+
+// watchlistJSON is the structured JSON representation of a watchlist.
+type watchlistJSON struct {
+	Addresses []string `json:"addresses"`
+	Topics    []string `json:"topics"`
+}
+
+// fetch fetches the raw watchlist data from its URL.
+func (s *Service) fetch(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to create watchlist request"), err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to fetch watchlist"), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("watchlist request returned status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to read watchlist response"), err)
+	}
+
+	return data, nil
+}
+
+// parse parses the raw watchlist data in to addresses and topics, returning the addresses and
+// retaining the topics.
+// Entries are classified by their length.
+func (s *Service) parse(data []byte) ([]types.Address, error) {
+	var entries []string
+	switch s.format {
+	case "csv":
+		var err error
+		entries, err = parseCSV(data)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		var err error
+		entries, err = parseJSON(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	addresses := make([]types.Address, 0, len(entries))
+	topics := make(map[types.Hash]struct{})
+	for _, entry := range entries {
+		val, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(entry), "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid watchlist entry %q", entry)
+		}
+		switch len(val) {
+		case types.AddressLength:
+			addresses = append(addresses, types.Address(val))
+		case len(types.Hash{}):
+			topics[types.Hash(val)] = struct{}{}
+		default:
+			return nil, fmt.Errorf("watchlist entry %q is neither an address nor a topic", entry)
+		}
+	}
+
+	// The address set replaces its addresses once they are returned.
+	s.mu.Lock()
+	s.topics = topics
+	s.mu.Unlock()
+	s.log.Trace().Int("addresses", len(addresses)).Int("topics", len(topics)).Msg("Parsed watchlist")
+
+	return addresses, nil
+}
+
+// parseJSON parses either a JSON array of entries, or an object with separate addresses and topics.
+func parseJSON(data []byte) ([]string, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("[")) {
+		var entries []string
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, errors.Join(errors.New("failed to parse watchlist"), err)
+		}
+
+		return entries, nil
+	}
+
+	var structured watchlistJSON
+	if err := json.Unmarshal(data, &structured); err != nil {
+		return nil, errors.Join(errors.New("failed to parse watchlist"), err)
+	}
+
+	return append(structured.Addresses, structured.Topics...), nil
+}
+
+// parseCSV parses CSV data, taking the first field of each record as an entry.
+// A header row is skipped if present.
+func parseCSV(data []byte) ([]string, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to parse watchlist"), err)
+	}
+
+	entries := make([]string, 0, len(records))
+	for i, record := range records {
+		if len(record) == 0 || record[0] == "" {
+			continue
+		}
+		if i == 0 && !strings.HasPrefix(strings.ToLower(record[0]), "0x") {
+			// Header.
+			continue
+		}
+		entries = append(entries, record[0])
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,138 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watchlist
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel    zerolog.Level
+	path        string
+	url         string
+	format      string
+	timeout     time.Duration
+	interval    time.Duration
+	reloadOnHUP bool
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithPath sets the path of the file containing the watchlist.
+func WithPath(path string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.path = path
+	})
+}
+
+// WithURL sets the URL of the HTTP endpoint serving the watchlist.
+func WithURL(url string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.url = url
+	})
+}
+
+// WithFormat sets the format of the watchlist, either "json" or "csv".
+// If not supplied the format is inferred from the extension of the path or URL.
+func WithFormat(format string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.format = format
+	})
+}
+
+// WithTimeout sets the timeout for requests made to the watchlist URL.
+func WithTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.timeout = timeout
+	})
+}
+
+// WithInterval sets the interval between reloads of the watchlist.
+// If not supplied the watchlist is not reloaded periodically.
+func WithInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.interval = interval
+	})
+}
+
+// WithReloadOnHUP reloads the watchlist when the process receives SIGHUP.
+func WithReloadOnHUP(reload bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.reloadOnHUP = reload
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+		timeout:  30 * time.Second,
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.path == "" && parameters.url == "" {
+		return nil, errors.New("no path or URL specified")
+	}
+	if parameters.path != "" && parameters.url != "" {
+		return nil, errors.New("only one of path and URL can be specified")
+	}
+	if parameters.timeout == 0 {
+		return nil, errors.New("no timeout specified")
+	}
+
+	if parameters.format == "" {
+		source := parameters.path
+		if source == "" {
+			source = strings.SplitN(parameters.url, "?", 2)[0]
+		}
+		if strings.HasSuffix(strings.ToLower(source), ".csv") {
+			parameters.format = "csv"
+		} else {
+			parameters.format = "json"
+		}
+	}
+	parameters.format = strings.ToLower(parameters.format)
+	switch parameters.format {
+	case "json", "csv":
+	default:
+		return nil, fmt.Errorf("unsupported format %s", parameters.format)
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,116 @@
+// Copyright © 2026 Weald Technology Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package watchlist provides a hot-reloadable list of addresses and topics, sourced from a file or HTTP endpoint.
+package watchlist
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/attestantio/go-execution-client/types"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"github.com/wealdtech/go-eth-listener/services/addressset/file"
+)
+
+// Service is a hot-reloadable watchlist.
+// It can be used as both an address set and a topic set for triggers, and
+// updates to the watchlist are seen by triggers without restart.
+// Loading and reloading are carried out by a file-backed address set, with the
+// watchlist adding its formats, HTTP source and topics.
+type Service struct {
+	log       zerolog.Logger
+	url       string
+	format    string
+	client    *http.Client
+	addresses *file.Service
+	mu        sync.RWMutex
+	topics    map[types.Hash]struct{}
+}
+
+// New creates a new watchlist.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Join(errors.New("problem with parameters"), err)
+	}
+
+	// Set logging.
+	log := zerologger.With().Str("service", "watchlist").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		log:    log,
+		url:    parameters.url,
+		format: parameters.format,
+		client: &http.Client{
+			Timeout: parameters.timeout,
+		},
+	}
+
+	fileParams := []file.Parameter{
+		file.WithLogLevel(parameters.logLevel),
+		file.WithParser(s.parse),
+		file.WithInterval(parameters.interval),
+		file.WithReloadOnHUP(parameters.reloadOnHUP),
+	}
+	if parameters.path != "" {
+		fileParams = append(fileParams, file.WithPath(parameters.path))
+	} else {
+		fileParams = append(fileParams, file.WithReader(s.fetch))
+	}
+	// Initial load must succeed.
+	s.addresses, err = file.New(ctx, fileParams...)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to load watchlist"), err)
+	}
+
+	return s, nil
+}
+
+// Contains returns true if the address is a member of the watchlist.
+func (s *Service) Contains(address types.Address) bool {
+	return s.addresses.Contains(address)
+}
+
+// Addresses returns the addresses that are members of the watchlist.
+func (s *Service) Addresses() []types.Address {
+	return s.addresses.Addresses()
+}
+
+// ContainsTopic returns true if the topic is a member of the watchlist.
+// A topic is a member if it is listed explicitly, or if it is an address in
+// the watchlist padded to 32 bytes, as found in indexed event parameters.
+func (s *Service) ContainsTopic(topic types.Hash) bool {
+	s.mu.RLock()
+	_, exists := s.topics[topic]
+	s.mu.RUnlock()
+	if exists {
+		return true
+	}
+
+	for i := 0; i < len(topic)-types.AddressLength; i++ {
+		if topic[i] != 0 {
+			return false
+		}
+	}
+	var address types.Address
+	copy(address[:], topic[len(topic)-types.AddressLength:])
+
+	return s.addresses.Contains(address)
+}